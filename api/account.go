@@ -0,0 +1,193 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type accountResponse struct {
+	ID           int64     `json:"id"`
+	OwnerID      string    `json:"owner_id"`
+	BalanceCents int64     `json:"balance_cents"`
+	Currency     string    `json:"currency"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"created_at,omitzero"`
+	// OverdraftLimitCents is how far below zero the balance may go and HeldCents
+	// is reserved by active holds; AvailableCents is what can still be withdrawn or sent.
+	OverdraftLimitCents int64 `json:"overdraft_limit_cents"`
+	HeldCents           int64 `json:"held_cents"`
+	AvailableCents      int64 `json:"available_cents"`
+}
+
+func newAccountResponse(account db.Account) accountResponse {
+	return accountResponse{
+		ID:                  account.ID,
+		OwnerID:             account.OwnerID.String(),
+		BalanceCents:        account.BalanceCents,
+		Currency:            string(account.Currency),
+		Status:              string(account.Status),
+		CreatedAt:           account.CreatedAt.Time,
+		OverdraftLimitCents: account.OverdraftLimitCents,
+		HeldCents:           account.HeldCents,
+		AvailableCents:      account.AvailableCents(),
+	}
+}
+
+type createAccountRequest struct {
+	Currency string `json:"currency" binding:"required,currency"`
+}
+
+func (server *Server) createAccount(ctx *gin.Context) {
+	var req createAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// Accounts are always opened for the authenticated caller and start empty;
+	// money only enters through a deposit.
+	account, err := server.store.CreateAccount(ctx, db.CreateAccountParams{
+		OwnerID:      pgtype.UUID{Bytes: authPayload(ctx).UserID, Valid: true},
+		BalanceCents: 0,
+		Currency:     db.Currency(req.Currency),
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, newAccountResponse(account))
+}
+
+type accountURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getAccount(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, ok := server.authorizedAccount(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
+}
+
+func (server *Server) listAccounts(ctx *gin.Context) {
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	accounts, err := server.store.ListAccountsByOwnerPage(ctx, db.ListAccountsByOwnerPageParams{
+		OwnerID: pgtype.UUID{Bytes: authPayload(ctx).UserID, Valid: true},
+		Cursor:  req.Cursor,
+		Limit:   req.PageSize,
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPageResponse(accounts, newAccountResponse))
+}
+
+type moneyRequest struct {
+	AmountCents int64  `json:"amount_cents" binding:"required,gt=0"`
+	Description string `json:"description" binding:"max=140"`
+}
+
+type accountTransactionResponse struct {
+	Transaction transactionResponse `json:"transaction"`
+	Account     accountResponse     `json:"account"`
+}
+
+func (server *Server) depositMoney(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req moneyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	key, ok := idempotencyKey(ctx)
+	if !ok {
+		return
+	}
+
+	if _, ok := server.authorizedAccount(ctx, uri.ID); !ok {
+		return
+	}
+
+	result, err := server.store.DepositMoneyTx(ctx, db.AccountTransactionParams{
+		AccountID:      uri.ID,
+		Amount:         req.AmountCents,
+		IdempotencyKey: key,
+		Description:    optionalText(req.Description),
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	markReplayed(ctx, result.Replayed)
+	ctx.JSON(http.StatusCreated, accountTransactionResponse{
+		Transaction: newTransactionResponse(result.Transaction),
+		Account:     newAccountResponse(result.Account),
+	})
+}
+
+func (server *Server) withdrawMoney(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req moneyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	key, ok := idempotencyKey(ctx)
+	if !ok {
+		return
+	}
+
+	if _, ok := server.authorizedAccount(ctx, uri.ID); !ok {
+		return
+	}
+
+	result, err := server.store.WithdrawMoneyTx(ctx, db.AccountTransactionParams{
+		AccountID:      uri.ID,
+		Amount:         req.AmountCents,
+		IdempotencyKey: key,
+		Description:    optionalText(req.Description),
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	markReplayed(ctx, result.Replayed)
+	ctx.JSON(http.StatusCreated, accountTransactionResponse{
+		Transaction: newTransactionResponse(result.Transaction),
+		Account:     newAccountResponse(result.Account),
+	})
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type changeAccountStatusRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+type changeAccountStatusFunc func(ctx context.Context, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error)
+
+func (server *Server) freezeAccount(ctx *gin.Context) {
+	server.changeAccountStatus(ctx, server.store.FreezeAccountTx)
+}
+
+// unfreezeAccount lets an owner lift only a freeze they made themselves; freezes made by
+// the system, such as reconciliation, stay until an operator lifts them.
+func (server *Server) unfreezeAccount(ctx *gin.Context) {
+	server.changeAccountStatus(ctx, func(c context.Context, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error) {
+		arg.FrozenBy = arg.Actor
+		return server.store.UnfreezeAccountTx(c, arg)
+	})
+}
+
+func (server *Server) closeAccount(ctx *gin.Context) {
+	server.changeAccountStatus(ctx, server.store.CloseAccountTx)
+}
+
+// changeAccountStatus lets an owner freeze, unfreeze or close their own account.
+// The change is recorded with the caller as its actor.
+func (server *Server) changeAccountStatus(ctx *gin.Context, change changeAccountStatusFunc) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req changeAccountStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.authorizedAccount(ctx, uri.ID); !ok {
+		return
+	}
+
+	result, err := change(ctx, db.ChangeAccountStatusParams{
+		AccountID: uri.ID,
+		Reason:    req.Reason,
+		Actor:     userActor(authPayload(ctx).UserID),
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountResponse(result.Account))
+}
+
+// userActor names an authenticated user in audit records.
+func userActor(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
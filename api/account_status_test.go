@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeAccountStatusAPI(t *testing.T) {
+	account := randomAccount(t)
+
+	testCases := []struct {
+		name       string
+		action     string
+		caller     pgtype.UUID
+		body       map[string]any
+		err        error
+		wantStatus int
+		wantNext   db.AccountStatus
+	}{
+		{
+			name:       "Freeze",
+			action:     "freeze",
+			caller:     account.OwnerID,
+			body:       map[string]any{"reason": "card lost"},
+			wantStatus: http.StatusOK,
+			wantNext:   db.AccountStatusFrozen,
+		},
+		{
+			name:       "Unfreeze",
+			action:     "unfreeze",
+			caller:     account.OwnerID,
+			body:       map[string]any{"reason": "card found"},
+			wantStatus: http.StatusOK,
+			wantNext:   db.AccountStatusActive,
+		},
+		{
+			name:       "Close",
+			action:     "close",
+			caller:     account.OwnerID,
+			body:       map[string]any{"reason": "moving banks"},
+			wantStatus: http.StatusOK,
+			wantNext:   db.AccountStatusClosed,
+		},
+		{
+			name:       "CloseWithBalance",
+			action:     "close",
+			caller:     account.OwnerID,
+			body:       map[string]any{"reason": "moving banks"},
+			err:        db.ErrAccountBalanceNotZero,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "CloseWithActiveHolds",
+			action:     "close",
+			caller:     account.OwnerID,
+			body:       map[string]any{"reason": "moving banks"},
+			err:        db.ErrAccountHasActiveHolds,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "InvalidTransition",
+			action:     "freeze",
+			caller:     account.OwnerID,
+			body:       map[string]any{"reason": "again"},
+			err:        db.ErrInvalidAccountTransition,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "AlreadyClosed",
+			action:     "unfreeze",
+			caller:     account.OwnerID,
+			body:       map[string]any{"reason": "reopen"},
+			err:        &db.AccountStatusError{AccountID: account.ID, Status: db.AccountStatusClosed},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "UnfreezeSystemFreeze",
+			action:     "unfreeze",
+			caller:     account.OwnerID,
+			body:       map[string]any{"reason": "looks fine to me"},
+			err:        db.ErrFreezeNotOwned,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "MissingReason",
+			action:     "freeze",
+			caller:     account.OwnerID,
+			body:       map[string]any{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "NotOwner",
+			action:     "freeze",
+			caller:     randomUUID(t),
+			body:       map[string]any{"reason": "card lost"},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				getAccount: accountLookup(account),
+				changeAccountStatus: func(next db.AccountStatus, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error) {
+					require.Equal(t, account.ID, arg.AccountID)
+					require.Equal(t, tc.body["reason"], arg.Reason)
+					require.Equal(t, "user:"+account.OwnerID.String(), arg.Actor)
+					if next == db.AccountStatusActive {
+						require.Equal(t, arg.Actor, arg.FrozenBy)
+					} else {
+						require.Empty(t, arg.FrozenBy)
+					}
+					if tc.err != nil {
+						return db.ChangeAccountStatusResult{}, tc.err
+					}
+					updated := account
+					updated.Status = next
+					return db.ChangeAccountStatusResult{Account: updated}, nil
+				},
+			})
+
+			url := fmt.Sprintf("/accounts/%d/%s", account.ID, tc.action)
+			recorder := serveAs(t, server, tc.caller, http.MethodPost, url, tc.body)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code == http.StatusOK {
+				require.Equal(t, string(tc.wantNext), decodeBody[accountResponse](t, recorder).Status)
+			} else {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
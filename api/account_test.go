@@ -0,0 +1,324 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/RakibRahman/fincore-api/db/dberr"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func randomUUID(t *testing.T) pgtype.UUID {
+	var id pgtype.UUID
+	id.Valid = true
+	for i := range id.Bytes {
+		id.Bytes[i] = byte(utils.RandomInt(0, 255))
+	}
+	// mark as a version 4, RFC 4122 variant UUID so validators accept it
+	id.Bytes[6] = (id.Bytes[6] & 0x0f) | 0x40
+	id.Bytes[8] = (id.Bytes[8] & 0x3f) | 0x80
+	return id
+}
+
+func randomAccount(t *testing.T) db.Account {
+	return db.Account{
+		ID:           utils.RandomInt(1, 1000),
+		OwnerID:      randomUUID(t),
+		BalanceCents: utils.RandomInt(1000, 10000),
+		Currency:     db.CurrencyUSD,
+		Status:       db.AccountStatusActive,
+	}
+}
+
+// accountLookup stubs GetAccount over a fixed set of accounts.
+func accountLookup(accounts ...db.Account) func(id int64) (db.Account, error) {
+	return func(id int64) (db.Account, error) {
+		for _, account := range accounts {
+			if account.ID == id {
+				return account, nil
+			}
+		}
+		return db.Account{}, pgx.ErrNoRows
+	}
+}
+
+func TestGetAccountAPI(t *testing.T) {
+	account := randomAccount(t)
+
+	testCases := []struct {
+		name          string
+		accountID     int64
+		caller        pgtype.UUID
+		getAccount    func(id int64) (db.Account, error)
+		checkResponse func(t *testing.T, status int, body accountResponse)
+	}{
+		{
+			name:       "OK",
+			accountID:  account.ID,
+			caller:     account.OwnerID,
+			getAccount: accountLookup(account),
+			checkResponse: func(t *testing.T, status int, body accountResponse) {
+				require.Equal(t, http.StatusOK, status)
+				require.Equal(t, newAccountResponse(account), body)
+			},
+		},
+		{
+			name:      "Overdrawn",
+			accountID: account.ID,
+			caller:    account.OwnerID,
+			getAccount: func(id int64) (db.Account, error) {
+				overdrawn := account
+				overdrawn.BalanceCents = -300
+				overdrawn.OverdraftLimitCents = 1000
+				return overdrawn, nil
+			},
+			checkResponse: func(t *testing.T, status int, body accountResponse) {
+				require.Equal(t, http.StatusOK, status)
+				require.Equal(t, int64(-300), body.BalanceCents)
+				require.Equal(t, int64(1000), body.OverdraftLimitCents)
+				require.Equal(t, int64(700), body.AvailableCents)
+			},
+		},
+		{
+			name:      "WithHolds",
+			accountID: account.ID,
+			caller:    account.OwnerID,
+			getAccount: func(id int64) (db.Account, error) {
+				held := account
+				held.BalanceCents = 1000
+				held.HeldCents = 400
+				return held, nil
+			},
+			checkResponse: func(t *testing.T, status int, body accountResponse) {
+				require.Equal(t, http.StatusOK, status)
+				require.Equal(t, int64(1000), body.BalanceCents)
+				require.Equal(t, int64(400), body.HeldCents)
+				require.Equal(t, int64(600), body.AvailableCents)
+			},
+		},
+		{
+			name:       "UnauthorizedUser",
+			accountID:  account.ID,
+			caller:     randomUUID(t),
+			getAccount: accountLookup(account),
+			checkResponse: func(t *testing.T, status int, body accountResponse) {
+				require.Equal(t, http.StatusForbidden, status)
+			},
+		},
+		{
+			name:       "NotFound",
+			accountID:  account.ID,
+			caller:     account.OwnerID,
+			getAccount: accountLookup(),
+			checkResponse: func(t *testing.T, status int, body accountResponse) {
+				require.Equal(t, http.StatusNotFound, status)
+			},
+		},
+		{
+			name:      "InternalError",
+			accountID: account.ID,
+			caller:    account.OwnerID,
+			getAccount: func(id int64) (db.Account, error) {
+				return db.Account{}, pgx.ErrTxClosed
+			},
+			checkResponse: func(t *testing.T, status int, body accountResponse) {
+				require.Equal(t, http.StatusInternalServerError, status)
+			},
+		},
+		{
+			name:      "InvalidID",
+			accountID: 0,
+			caller:    account.OwnerID,
+			checkResponse: func(t *testing.T, status int, body accountResponse) {
+				require.Equal(t, http.StatusBadRequest, status)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{getAccount: tc.getAccount})
+			recorder := serveAs(t, server, tc.caller, http.MethodGet, fmt.Sprintf("/accounts/%d", tc.accountID), nil)
+
+			var body accountResponse
+			if recorder.Code == http.StatusOK {
+				body = decodeBody[accountResponse](t, recorder)
+			} else {
+				requireErrorBody(t, recorder)
+			}
+			tc.checkResponse(t, recorder.Code, body)
+		})
+	}
+}
+
+func TestGetAccountAPINoAuthorization(t *testing.T) {
+	account := randomAccount(t)
+	server := newTestServer(t, &fakeStore{getAccount: accountLookup(account)})
+
+	recorder := serve(t, server, http.MethodGet, fmt.Sprintf("/accounts/%d", account.ID), nil)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	requireErrorBody(t, recorder)
+}
+
+func TestCreateAccountAPI(t *testing.T) {
+	account := randomAccount(t)
+	account.BalanceCents = 0
+
+	testCases := []struct {
+		name          string
+		body          map[string]any
+		createAccount func(arg db.CreateAccountParams) (db.Account, error)
+		wantStatus    int
+	}{
+		{
+			name: "OK",
+			body: map[string]any{"currency": "USD"},
+			createAccount: func(arg db.CreateAccountParams) (db.Account, error) {
+				require.Equal(t, account.OwnerID, arg.OwnerID)
+				require.Equal(t, db.CurrencyUSD, arg.Currency)
+				require.Zero(t, arg.BalanceCents)
+				return account, nil
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "InvalidCurrency",
+			body:       map[string]any{"currency": "XYZ"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "OwnerNotFound",
+			body: map[string]any{"currency": "EUR"},
+			createAccount: func(arg db.CreateAccountParams) (db.Account, error) {
+				return db.Account{}, dberr.Translate(&pgconn.PgError{Code: "23503", ConstraintName: "accounts_owner_id_fkey"})
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{createAccount: tc.createAccount})
+			recorder := serveAs(t, server, account.OwnerID, http.MethodPost, "/accounts", tc.body)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code == http.StatusCreated {
+				require.Equal(t, newAccountResponse(account), decodeBody[accountResponse](t, recorder))
+			} else {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
+
+func TestListAccountsAPI(t *testing.T) {
+	owner := randomUUID(t)
+	accounts := []db.Account{randomAccount(t), randomAccount(t)}
+
+	server := newTestServer(t, &fakeStore{
+		listAccountsByOwnerPage: func(arg db.ListAccountsByOwnerPageParams) (db.Page[db.Account], error) {
+			require.Equal(t, owner, arg.OwnerID)
+			require.Equal(t, int32(2), arg.Limit)
+			if arg.Cursor != "next" {
+				return db.Page[db.Account]{}, db.ErrInvalidCursor
+			}
+			return db.Page[db.Account]{Items: accounts, NextCursor: "after", PrevCursor: "before"}, nil
+		},
+	})
+
+	recorder := serveAs(t, server, owner, http.MethodGet, "/accounts?cursor=next&page_size=2", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body := decodeBody[pageResponse[accountResponse]](t, recorder)
+	require.Len(t, body.Items, len(accounts))
+	require.Equal(t, "after", body.NextCursor)
+	require.Equal(t, "before", body.PrevCursor)
+
+	recorder = serveAs(t, server, owner, http.MethodGet, "/accounts?cursor=stale&page_size=2", nil)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	requireErrorBody(t, recorder)
+
+	recorder = serveAs(t, server, owner, http.MethodGet, "/accounts?cursor=next", nil)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	requireErrorBody(t, recorder)
+}
+
+func TestDepositMoneyAPI(t *testing.T) {
+	account := randomAccount(t)
+	amount := int64(500)
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(account),
+		depositMoneyTx: func(arg db.AccountTransactionParams) (db.AccountTransactionResult, error) {
+			require.Equal(t, account.ID, arg.AccountID)
+			require.Equal(t, amount, arg.Amount)
+			require.Equal(t, pgtype.Text{String: "Salary", Valid: true}, arg.Description)
+			updated := account
+			updated.BalanceCents += amount
+			return db.AccountTransactionResult{
+				Account: updated,
+				Transaction: db.Transaction{
+					AccountID:         account.ID,
+					Type:              db.TransactionTypeDeposit,
+					AmountCents:       amount,
+					BalanceAfterCents: updated.BalanceCents,
+				},
+			}, nil
+		},
+	})
+
+	url := fmt.Sprintf("/accounts/%d/deposits", account.ID)
+	recorder := serveAs(t, server, account.OwnerID, http.MethodPost, url, map[string]any{"amount_cents": amount, "description": "Salary"})
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	body := decodeBody[accountTransactionResponse](t, recorder)
+	require.Equal(t, account.BalanceCents+amount, body.Account.BalanceCents)
+	require.Equal(t, string(db.TransactionTypeDeposit), body.Transaction.Type)
+
+	recorder = serveAs(t, server, account.OwnerID, http.MethodPost, url, map[string]any{"amount_cents": -amount})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	requireErrorBody(t, recorder)
+
+	recorder = serveAs(t, server, randomUUID(t), http.MethodPost, url, map[string]any{"amount_cents": amount})
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	requireErrorBody(t, recorder)
+}
+
+func TestWithdrawMoneyAPI(t *testing.T) {
+	account := randomAccount(t)
+
+	testCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "OK", wantStatus: http.StatusCreated},
+		{name: "InsufficientBalance", err: db.ErrInsufficientBalance, wantStatus: http.StatusUnprocessableEntity},
+		{name: "AccountFrozen", err: &db.AccountStatusError{AccountID: account.ID, Status: db.AccountStatusFrozen, Debit: true}, wantStatus: http.StatusUnprocessableEntity},
+		{name: "InternalError", err: pgx.ErrTxClosed, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				getAccount: accountLookup(account),
+				withdrawMoneyTx: func(arg db.AccountTransactionParams) (db.AccountTransactionResult, error) {
+					if tc.err != nil {
+						return db.AccountTransactionResult{}, tc.err
+					}
+					return db.AccountTransactionResult{Account: account}, nil
+				},
+			})
+
+			url := fmt.Sprintf("/accounts/%d/withdrawals", account.ID)
+			recorder := serveAs(t, server, account.OwnerID, http.MethodPost, url, map[string]any{"amount_cents": 100})
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if tc.err != nil {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
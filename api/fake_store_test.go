@@ -0,0 +1,282 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var errNotStubbed = errors.New("fake store: method not stubbed")
+
+// fakeStore implements Store with per-method stubs. A method whose stub is nil
+// fails the request, so a handler touching the store unexpectedly surfaces as a 500.
+type fakeStore struct {
+	createUser     func(arg db.CreateUserParams) (db.User, error)
+	getUser        func(id pgtype.UUID) (db.User, error)
+	getUserByEmail func(email string) (db.User, error)
+	updateUser     func(arg db.UpdateUserParams) (db.UpdateUserRow, error)
+	changePassword func(arg db.ChangePasswordTxParams) (db.User, error)
+
+	createAccount           func(arg db.CreateAccountParams) (db.Account, error)
+	getAccount              func(id int64) (db.Account, error)
+	listAccountsByOwnerPage func(arg db.ListAccountsByOwnerPageParams) (db.Page[db.Account], error)
+	changeAccountStatus     func(next db.AccountStatus, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error)
+
+	getTransaction           func(id pgtype.UUID) (db.Transaction, error)
+	listTransactionsPage     func(arg db.ListTransactionsPageParams) (db.Page[db.Transaction], error)
+	searchTransactionsPage   func(arg db.SearchTransactionsPageParams) (db.Page[db.Transaction], error)
+	getLastTransactionBefore func(arg db.GetLastTransactionBeforeParams) (db.Transaction, error)
+	listTransactionsInPeriod func(arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error)
+
+	getTransfer                func(id pgtype.UUID) (db.Transfer, error)
+	listTransfersByAccountPage func(arg db.ListTransfersByAccountPageParams) (db.Page[db.Transfer], error)
+
+	scheduleTransfer                   func(arg db.ScheduleTransferParams) (db.TransferSchedule, error)
+	getTransferSchedule                func(id pgtype.UUID) (db.TransferSchedule, error)
+	listTransferSchedulesByAccountPage func(arg db.ListTransferSchedulesByAccountPageParams) (db.Page[db.TransferSchedule], error)
+	listTransferScheduleRunsPage       func(arg db.ListTransferScheduleRunsPageParams) (db.Page[db.TransferScheduleRun], error)
+	cancelTransferSchedule             func(id pgtype.UUID) (db.TransferSchedule, error)
+
+	createWebhookEndpoint               func(arg db.CreateWebhookEndpointParams) (db.WebhookEndpoint, error)
+	getWebhookEndpoint                  func(id pgtype.UUID) (db.WebhookEndpoint, error)
+	listWebhookEndpointsByOwnerPage     func(arg db.ListWebhookEndpointsByOwnerPageParams) (db.Page[db.WebhookEndpoint], error)
+	deactivateWebhookEndpoint           func(id pgtype.UUID) (db.WebhookEndpoint, error)
+	getWebhookDelivery                  func(id int64) (db.WebhookDelivery, error)
+	listWebhookDeliveriesByEndpointPage func(arg db.ListWebhookDeliveriesByEndpointPageParams) (db.Page[db.WebhookDelivery], error)
+	redeliverWebhookDelivery            func(id int64) (db.WebhookDelivery, error)
+
+	transferMoneyTx func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error)
+	depositMoneyTx  func(arg db.AccountTransactionParams) (db.AccountTransactionResult, error)
+	withdrawMoneyTx func(arg db.AccountTransactionParams) (db.AccountTransactionResult, error)
+}
+
+func (s *fakeStore) CreateUser(_ context.Context, arg db.CreateUserParams) (db.User, error) {
+	if s.createUser == nil {
+		return db.User{}, errNotStubbed
+	}
+	return s.createUser(arg)
+}
+
+func (s *fakeStore) GetUser(_ context.Context, id pgtype.UUID) (db.User, error) {
+	if s.getUser == nil {
+		return db.User{}, errNotStubbed
+	}
+	return s.getUser(id)
+}
+
+func (s *fakeStore) GetUserByEmail(_ context.Context, email string) (db.User, error) {
+	if s.getUserByEmail == nil {
+		return db.User{}, errNotStubbed
+	}
+	return s.getUserByEmail(email)
+}
+
+func (s *fakeStore) UpdateUser(_ context.Context, arg db.UpdateUserParams) (db.UpdateUserRow, error) {
+	if s.updateUser == nil {
+		return db.UpdateUserRow{}, errNotStubbed
+	}
+	return s.updateUser(arg)
+}
+
+func (s *fakeStore) ChangePasswordTx(_ context.Context, arg db.ChangePasswordTxParams) (db.User, error) {
+	if s.changePassword == nil {
+		return db.User{}, errNotStubbed
+	}
+	return s.changePassword(arg)
+}
+
+func (s *fakeStore) CreateAccount(_ context.Context, arg db.CreateAccountParams) (db.Account, error) {
+	if s.createAccount == nil {
+		return db.Account{}, errNotStubbed
+	}
+	return s.createAccount(arg)
+}
+
+func (s *fakeStore) GetAccount(_ context.Context, id int64) (db.Account, error) {
+	if s.getAccount == nil {
+		return db.Account{}, errNotStubbed
+	}
+	return s.getAccount(id)
+}
+
+func (s *fakeStore) ListAccountsByOwnerPage(_ context.Context, arg db.ListAccountsByOwnerPageParams) (db.Page[db.Account], error) {
+	if s.listAccountsByOwnerPage == nil {
+		return db.Page[db.Account]{}, errNotStubbed
+	}
+	return s.listAccountsByOwnerPage(arg)
+}
+
+func (s *fakeStore) FreezeAccountTx(_ context.Context, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error) {
+	return s.changeStatus(db.AccountStatusFrozen, arg)
+}
+
+func (s *fakeStore) UnfreezeAccountTx(_ context.Context, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error) {
+	return s.changeStatus(db.AccountStatusActive, arg)
+}
+
+func (s *fakeStore) CloseAccountTx(_ context.Context, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error) {
+	return s.changeStatus(db.AccountStatusClosed, arg)
+}
+
+func (s *fakeStore) changeStatus(next db.AccountStatus, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error) {
+	if s.changeAccountStatus == nil {
+		return db.ChangeAccountStatusResult{}, errNotStubbed
+	}
+	return s.changeAccountStatus(next, arg)
+}
+
+func (s *fakeStore) GetTransaction(_ context.Context, id pgtype.UUID) (db.Transaction, error) {
+	if s.getTransaction == nil {
+		return db.Transaction{}, errNotStubbed
+	}
+	return s.getTransaction(id)
+}
+
+func (s *fakeStore) ListTransactionsPage(_ context.Context, arg db.ListTransactionsPageParams) (db.Page[db.Transaction], error) {
+	if s.listTransactionsPage == nil {
+		return db.Page[db.Transaction]{}, errNotStubbed
+	}
+	return s.listTransactionsPage(arg)
+}
+
+func (s *fakeStore) SearchTransactionsPage(_ context.Context, arg db.SearchTransactionsPageParams) (db.Page[db.Transaction], error) {
+	if s.searchTransactionsPage == nil {
+		return db.Page[db.Transaction]{}, errNotStubbed
+	}
+	return s.searchTransactionsPage(arg)
+}
+
+func (s *fakeStore) GetLastTransactionBefore(_ context.Context, arg db.GetLastTransactionBeforeParams) (db.Transaction, error) {
+	if s.getLastTransactionBefore == nil {
+		return db.Transaction{}, errNotStubbed
+	}
+	return s.getLastTransactionBefore(arg)
+}
+
+func (s *fakeStore) ListTransactionsInPeriod(_ context.Context, arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error) {
+	if s.listTransactionsInPeriod == nil {
+		return nil, errNotStubbed
+	}
+	return s.listTransactionsInPeriod(arg)
+}
+
+func (s *fakeStore) GetTransfer(_ context.Context, id pgtype.UUID) (db.Transfer, error) {
+	if s.getTransfer == nil {
+		return db.Transfer{}, errNotStubbed
+	}
+	return s.getTransfer(id)
+}
+
+func (s *fakeStore) ListTransfersByAccountPage(_ context.Context, arg db.ListTransfersByAccountPageParams) (db.Page[db.Transfer], error) {
+	if s.listTransfersByAccountPage == nil {
+		return db.Page[db.Transfer]{}, errNotStubbed
+	}
+	return s.listTransfersByAccountPage(arg)
+}
+
+func (s *fakeStore) ScheduleTransfer(_ context.Context, arg db.ScheduleTransferParams) (db.TransferSchedule, error) {
+	if s.scheduleTransfer == nil {
+		return db.TransferSchedule{}, errNotStubbed
+	}
+	return s.scheduleTransfer(arg)
+}
+
+func (s *fakeStore) GetTransferSchedule(_ context.Context, id pgtype.UUID) (db.TransferSchedule, error) {
+	if s.getTransferSchedule == nil {
+		return db.TransferSchedule{}, errNotStubbed
+	}
+	return s.getTransferSchedule(id)
+}
+
+func (s *fakeStore) ListTransferSchedulesByAccountPage(_ context.Context, arg db.ListTransferSchedulesByAccountPageParams) (db.Page[db.TransferSchedule], error) {
+	if s.listTransferSchedulesByAccountPage == nil {
+		return db.Page[db.TransferSchedule]{}, errNotStubbed
+	}
+	return s.listTransferSchedulesByAccountPage(arg)
+}
+
+func (s *fakeStore) ListTransferScheduleRunsPage(_ context.Context, arg db.ListTransferScheduleRunsPageParams) (db.Page[db.TransferScheduleRun], error) {
+	if s.listTransferScheduleRunsPage == nil {
+		return db.Page[db.TransferScheduleRun]{}, errNotStubbed
+	}
+	return s.listTransferScheduleRunsPage(arg)
+}
+
+func (s *fakeStore) CancelTransferSchedule(_ context.Context, id pgtype.UUID) (db.TransferSchedule, error) {
+	if s.cancelTransferSchedule == nil {
+		return db.TransferSchedule{}, errNotStubbed
+	}
+	return s.cancelTransferSchedule(id)
+}
+
+func (s *fakeStore) CreateWebhookEndpoint(_ context.Context, arg db.CreateWebhookEndpointParams) (db.WebhookEndpoint, error) {
+	if s.createWebhookEndpoint == nil {
+		return db.WebhookEndpoint{}, errNotStubbed
+	}
+	return s.createWebhookEndpoint(arg)
+}
+
+func (s *fakeStore) GetWebhookEndpoint(_ context.Context, id pgtype.UUID) (db.WebhookEndpoint, error) {
+	if s.getWebhookEndpoint == nil {
+		return db.WebhookEndpoint{}, errNotStubbed
+	}
+	return s.getWebhookEndpoint(id)
+}
+
+func (s *fakeStore) ListWebhookEndpointsByOwnerPage(_ context.Context, arg db.ListWebhookEndpointsByOwnerPageParams) (db.Page[db.WebhookEndpoint], error) {
+	if s.listWebhookEndpointsByOwnerPage == nil {
+		return db.Page[db.WebhookEndpoint]{}, errNotStubbed
+	}
+	return s.listWebhookEndpointsByOwnerPage(arg)
+}
+
+func (s *fakeStore) DeactivateWebhookEndpoint(_ context.Context, id pgtype.UUID) (db.WebhookEndpoint, error) {
+	if s.deactivateWebhookEndpoint == nil {
+		return db.WebhookEndpoint{}, errNotStubbed
+	}
+	return s.deactivateWebhookEndpoint(id)
+}
+
+func (s *fakeStore) GetWebhookDelivery(_ context.Context, id int64) (db.WebhookDelivery, error) {
+	if s.getWebhookDelivery == nil {
+		return db.WebhookDelivery{}, errNotStubbed
+	}
+	return s.getWebhookDelivery(id)
+}
+
+func (s *fakeStore) ListWebhookDeliveriesByEndpointPage(_ context.Context, arg db.ListWebhookDeliveriesByEndpointPageParams) (db.Page[db.WebhookDelivery], error) {
+	if s.listWebhookDeliveriesByEndpointPage == nil {
+		return db.Page[db.WebhookDelivery]{}, errNotStubbed
+	}
+	return s.listWebhookDeliveriesByEndpointPage(arg)
+}
+
+func (s *fakeStore) RedeliverWebhookDelivery(_ context.Context, id int64) (db.WebhookDelivery, error) {
+	if s.redeliverWebhookDelivery == nil {
+		return db.WebhookDelivery{}, errNotStubbed
+	}
+	return s.redeliverWebhookDelivery(id)
+}
+
+func (s *fakeStore) TransferMoneyTx(_ context.Context, arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+	if s.transferMoneyTx == nil {
+		return db.TransferMoneyResult{}, errNotStubbed
+	}
+	return s.transferMoneyTx(arg)
+}
+
+func (s *fakeStore) DepositMoneyTx(_ context.Context, arg db.AccountTransactionParams) (db.AccountTransactionResult, error) {
+	if s.depositMoneyTx == nil {
+		return db.AccountTransactionResult{}, errNotStubbed
+	}
+	return s.depositMoneyTx(arg)
+}
+
+func (s *fakeStore) WithdrawMoneyTx(_ context.Context, arg db.AccountTransactionParams) (db.AccountTransactionResult, error) {
+	if s.withdrawMoneyTx == nil {
+		return db.AccountTransactionResult{}, errNotStubbed
+	}
+	return s.withdrawMoneyTx(arg)
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// idempotencyKey reads the optional Idempotency-Key header of a money-moving request.
+// On failure the error response is already written and ok is false.
+func idempotencyKey(ctx *gin.Context) (key pgtype.Text, ok bool) {
+	value := ctx.GetHeader(idempotencyKeyHeader)
+	if value == "" {
+		return key, true
+	}
+
+	if len(value) > maxIdempotencyKeyLength {
+		err := fmt.Errorf("%s header must be at most %d characters", idempotencyKeyHeader, maxIdempotencyKeyLength)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return key, false
+	}
+	if err := db.CheckIdempotencyKey(value); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return key, false
+	}
+	return pgtype.Text{String: value, Valid: true}, true
+}
+
+// markReplayed tells the client the response describes an earlier request with the same key.
+func markReplayed(ctx *gin.Context, replayed bool) {
+	if replayed {
+		ctx.Header(idempotentReplayedHeader, "true")
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// serveWithKey sends an authenticated request carrying the given Idempotency-Key header.
+func serveWithKey(t *testing.T, server *Server, userID pgtype.UUID, key, method, url string, body any) *httptest.ResponseRecorder {
+	request := newTestRequest(t, method, url, body)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, userID.Bytes, time.Minute)
+	request.Header.Set(idempotencyKeyHeader, key)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestDepositMoneyAPIIdempotencyKey(t *testing.T) {
+	account := randomAccount(t)
+	key := utils.RandomString(16)
+
+	testCases := []struct {
+		name         string
+		key          string
+		replayed     bool
+		err          error
+		wantStatus   int
+		wantReplayed bool
+	}{
+		{name: "FirstRequest", key: key, wantStatus: http.StatusCreated},
+		{name: "Replay", key: key, replayed: true, wantStatus: http.StatusCreated, wantReplayed: true},
+		{name: "KeyReused", key: key, err: db.ErrIdempotencyKeyReused, wantStatus: http.StatusUnprocessableEntity},
+		{name: "KeyTooLong", key: strings.Repeat("k", maxIdempotencyKeyLength+1), wantStatus: http.StatusBadRequest},
+		{name: "ReservedPrefix", key: "overdraft:" + key, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				getAccount: accountLookup(account),
+				depositMoneyTx: func(arg db.AccountTransactionParams) (db.AccountTransactionResult, error) {
+					require.Equal(t, pgtype.Text{String: tc.key, Valid: true}, arg.IdempotencyKey)
+					if tc.err != nil {
+						return db.AccountTransactionResult{}, tc.err
+					}
+					return db.AccountTransactionResult{Account: account, Replayed: tc.replayed}, nil
+				},
+			})
+
+			url := fmt.Sprintf("/accounts/%d/deposits", account.ID)
+			recorder := serveWithKey(t, server, account.OwnerID, tc.key, http.MethodPost, url, map[string]any{"amount_cents": 100})
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if tc.wantReplayed {
+				require.Equal(t, "true", recorder.Header().Get(idempotentReplayedHeader))
+			} else {
+				require.Empty(t, recorder.Header().Get(idempotentReplayedHeader))
+			}
+			if recorder.Code != http.StatusCreated {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
+
+func TestCreateTransferAPIIdempotencyKey(t *testing.T) {
+	fromAccount := randomAccount(t)
+	toAccount := randomAccount(t)
+	toAccount.ID = fromAccount.ID + 1
+	key := utils.RandomString(16)
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(fromAccount, toAccount),
+		transferMoneyTx: func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+			require.Equal(t, pgtype.Text{String: key, Valid: true}, arg.IdempotencyKey)
+			return db.TransferMoneyResult{
+				Transfer:    db.Transfer{FromAccountID: arg.FromAccountID, ToAccountID: arg.ToAccountID, AmountCents: arg.AmountCents, Reference: arg.IdempotencyKey},
+				FromAccount: fromAccount,
+				ToAccount:   toAccount,
+				Replayed:    true,
+			}, nil
+		},
+	})
+
+	body := map[string]any{"from_account_id": fromAccount.ID, "to_account_id": toAccount.ID, "amount_cents": 100}
+	recorder := serveWithKey(t, server, fromAccount.OwnerID, key, http.MethodPost, "/transfers", body)
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	require.Equal(t, "true", recorder.Header().Get(idempotentReplayedHeader))
+
+	rsp := decodeBody[transferMoneyResponse](t, recorder)
+	require.NotNil(t, rsp.Transfer.Reference)
+	require.Equal(t, key, *rsp.Transfer.Reference)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/password"
+	"github.com/RakibRahman/fincore-api/token"
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func newTestServer(t *testing.T, store Store) *Server {
+	tokenMaker, err := token.NewPasetoMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	hasher, err := password.NewBcryptHasher(bcrypt.MinCost)
+	require.NoError(t, err)
+
+	server := NewServer(store, tokenMaker, hasher, time.Minute)
+	require.NotNil(t, server)
+	server.resolver = testResolver{
+		"example.com": {netip.MustParseAddr("93.184.216.34")},
+		"localhost":   {netip.MustParseAddr("127.0.0.1")},
+	}
+	return server
+}
+
+// testResolver resolves host names from a fixed table, so that tests don't need DNS.
+type testResolver map[string][]netip.Addr
+
+func (r testResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	if addrs, ok := r[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func newTestRequest(t *testing.T, method, url string, body any) *http.Request {
+	var reqBody bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+
+	request, err := http.NewRequest(method, url, &reqBody)
+	require.NoError(t, err)
+	return request
+}
+
+// serve sends an unauthenticated request through the server router and returns the recorded response.
+func serve(t *testing.T, server *Server, method, url string, body any) *httptest.ResponseRecorder {
+	request := newTestRequest(t, method, url, body)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// serveAs sends a request carrying a valid bearer token for userID.
+func serveAs(t *testing.T, server *Server, userID pgtype.UUID, method, url string, body any) *httptest.ResponseRecorder {
+	request := newTestRequest(t, method, url, body)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, userID.Bytes, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func addAuthorization(
+	t *testing.T,
+	request *http.Request,
+	tokenMaker token.Maker,
+	authorizationType string,
+	userID uuid.UUID,
+	duration time.Duration,
+) {
+	accessToken, payload, err := tokenMaker.CreateToken(userID, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	authorizationHeader := fmt.Sprintf("%s %s", authorizationType, accessToken)
+	request.Header.Set(authorizationHeaderKey, authorizationHeader)
+}
+
+func decodeBody[T any](t *testing.T, recorder *httptest.ResponseRecorder) T {
+	var out T
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &out))
+	return out
+}
+
+func requireErrorBody(t *testing.T, recorder *httptest.ResponseRecorder) {
+	body := decodeBody[map[string]string](t, recorder)
+	require.NotEmpty(t, body["error"])
+}
@@ -0,0 +1,28 @@
+package api
+
+import db "github.com/RakibRahman/fincore-api/db/sqlc"
+
+// pageRequest is the query string of every list endpoint. Cursor is empty for the first
+// page, otherwise the next_cursor or prev_cursor of a page already read.
+type pageRequest struct {
+	Cursor   string `form:"cursor"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=100"`
+}
+
+type pageResponse[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+func newPageResponse[S, T any](page db.Page[S], convert func(S) T) pageResponse[T] {
+	rsp := pageResponse[T]{
+		Items:      make([]T, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+	for _, item := range page.Items {
+		rsp.Items = append(rsp.Items, convert(item))
+	}
+	return rsp
+}
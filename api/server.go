@@ -0,0 +1,187 @@
+package api
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/db/dberr"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/password"
+	"github.com/RakibRahman/fincore-api/token"
+	"github.com/RakibRahman/fincore-api/webhook"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+)
+
+// Server serves HTTP requests for the banking service.
+type Server struct {
+	store               Store
+	tokenMaker          token.Maker
+	hasher              password.Hasher
+	passwordPolicy      password.Policy
+	accessTokenDuration time.Duration
+	// resolver looks up the hosts of webhook URLs, which must be public
+	resolver webhook.Resolver
+	router   *gin.Engine
+}
+
+// NewServer creates a new HTTP server and sets up routing.
+func NewServer(store Store, tokenMaker token.Maker, hasher password.Hasher, accessTokenDuration time.Duration) *Server {
+	server := &Server{
+		store:               store,
+		tokenMaker:          tokenMaker,
+		hasher:              hasher,
+		passwordPolicy:      password.DefaultPolicy,
+		accessTokenDuration: accessTokenDuration,
+		resolver:            net.DefaultResolver,
+	}
+
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterValidation("currency", validCurrency)
+	}
+
+	server.setupRouter()
+	return server
+}
+
+func (server *Server) setupRouter() {
+	router := gin.Default()
+
+	router.POST("/users", server.createUser)
+	router.POST("/users/login", server.loginUser)
+
+	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+
+	authRoutes.GET("/users/:id", server.getUser)
+	authRoutes.PUT("/users/:id", server.updateUser)
+	authRoutes.PUT("/users/:id/password", server.changePassword)
+
+	authRoutes.POST("/accounts", server.createAccount)
+	authRoutes.GET("/accounts", server.listAccounts)
+	authRoutes.GET("/accounts/:id", server.getAccount)
+	authRoutes.POST("/accounts/:id/freeze", server.freezeAccount)
+	authRoutes.POST("/accounts/:id/unfreeze", server.unfreezeAccount)
+	authRoutes.POST("/accounts/:id/close", server.closeAccount)
+	authRoutes.POST("/accounts/:id/deposits", server.depositMoney)
+	authRoutes.POST("/accounts/:id/withdrawals", server.withdrawMoney)
+	authRoutes.GET("/accounts/:id/transactions", server.listTransactions)
+	authRoutes.GET("/accounts/:id/transactions/search", server.searchTransactions)
+	authRoutes.GET("/accounts/:id/statement", server.getStatement)
+	authRoutes.GET("/accounts/:id/transfers", server.listTransfers)
+	authRoutes.GET("/accounts/:id/transfer-schedules", server.listTransferSchedules)
+
+	authRoutes.GET("/transactions/:id", server.getTransaction)
+
+	authRoutes.POST("/transfers", server.createTransfer)
+	authRoutes.GET("/transfers/:id", server.getTransfer)
+
+	authRoutes.POST("/transfer-schedules", server.createTransferSchedule)
+	authRoutes.GET("/transfer-schedules/:id", server.getTransferSchedule)
+	authRoutes.GET("/transfer-schedules/:id/runs", server.listTransferScheduleRuns)
+	authRoutes.POST("/transfer-schedules/:id/cancel", server.cancelTransferSchedule)
+
+	authRoutes.POST("/webhooks", server.createWebhookEndpoint)
+	authRoutes.GET("/webhooks", server.listWebhookEndpoints)
+	authRoutes.GET("/webhooks/:id", server.getWebhookEndpoint)
+	authRoutes.DELETE("/webhooks/:id", server.deactivateWebhookEndpoint)
+	authRoutes.GET("/webhooks/:id/deliveries", server.listWebhookDeliveries)
+	authRoutes.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", server.redeliverWebhookDelivery)
+
+	server.router = router
+}
+
+// Timeouts bound how long the HTTP server spends on a connection.
+type Timeouts struct {
+	// Read bounds reading a request, body included
+	Read time.Duration
+	// Write bounds handling a request and writing its response
+	Write time.Duration
+	// Idle is how long a keep-alive connection waits for the next request
+	Idle time.Duration
+}
+
+// Start runs the HTTP server on a specific address.
+func (server *Server) Start(address string, timeouts Timeouts) error {
+	httpServer := &http.Server{
+		Addr:              address,
+		Handler:           server.router,
+		ReadHeaderTimeout: timeouts.Read,
+		ReadTimeout:       timeouts.Read,
+		WriteTimeout:      timeouts.Write,
+		IdleTimeout:       timeouts.Idle,
+	}
+	return httpServer.ListenAndServe()
+}
+
+// errorResponse is the single JSON shape every failed request returns.
+func errorResponse(err error) gin.H {
+	return gin.H{"error": err.Error()}
+}
+
+var (
+	errInternal        = errors.New("internal server error")
+	errAccountNotOwned = errors.New("account doesn't belong to the authenticated user")
+	errUserNotSelf     = errors.New("cannot access another user's profile")
+)
+
+// authorizedAccount loads the account and verifies that the authenticated caller owns it.
+// On failure the error response is already written and ok is false.
+func (server *Server) authorizedAccount(ctx *gin.Context, accountID int64) (account db.Account, ok bool) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return account, false
+	}
+
+	if account.OwnerID.Bytes != authPayload(ctx).UserID {
+		ctx.JSON(http.StatusForbidden, errorResponse(errAccountNotOwned))
+		return account, false
+	}
+	return account, true
+}
+
+// handleStoreError translates store and database errors into an HTTP status
+// and writes the JSON error body. Unknown errors are reported as 500 without
+// leaking driver details to the client.
+func handleStoreError(ctx *gin.Context, err error) {
+	ctx.JSON(storeErrorResponse(err))
+}
+
+// storeErrorResponse is the HTTP status and JSON error body handleStoreError writes for err.
+func storeErrorResponse(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows), errors.Is(err, dberr.ErrNotFound):
+		return http.StatusNotFound, errorResponse(dberr.Missing(err))
+	case errors.Is(err, db.ErrInsufficientBalance):
+		return http.StatusUnprocessableEntity, errorResponse(err)
+	case errors.Is(err, db.ErrInvalidAmount):
+		return http.StatusBadRequest, errorResponse(err)
+	case errors.Is(err, db.ErrIdempotencyKeyReused):
+		return http.StatusUnprocessableEntity, errorResponse(err)
+	case errors.Is(err, db.ErrFreezeNotOwned):
+		return http.StatusForbidden, errorResponse(err)
+	case errors.Is(err, db.ErrInvalidTransferTransition), errors.Is(err, db.ErrInvalidAccountTransition):
+		return http.StatusConflict, errorResponse(err)
+	case errors.Is(err, db.ErrAccountFrozen), errors.Is(err, db.ErrAccountClosed), errors.Is(err, db.ErrAccountBalanceNotZero),
+		errors.Is(err, db.ErrAccountHasActiveHolds):
+		return http.StatusUnprocessableEntity, errorResponse(err)
+	case errors.Is(err, db.ErrCurrencyMismatch), errors.Is(err, db.ErrExchangeRateNotFound), errors.Is(err, db.ErrConvertedAmountZero):
+		return http.StatusUnprocessableEntity, errorResponse(err)
+	case errors.Is(err, db.ErrInvalidSchedule), errors.Is(err, db.ErrInvalidCursor), errors.Is(err, db.ErrInvalidTransactionSort):
+		return http.StatusBadRequest, errorResponse(err)
+	case errors.Is(err, dberr.ErrAlreadyExists), errors.Is(err, dberr.ErrStillReferenced):
+		return http.StatusConflict, errorResponse(err)
+	case errors.Is(err, dberr.ErrReferenceNotFound):
+		return http.StatusBadRequest, errorResponse(err)
+	case errors.Is(err, dberr.ErrConstraintViolated):
+		return http.StatusUnprocessableEntity, errorResponse(err)
+	case dberr.Retryable(err):
+		return http.StatusConflict, errorResponse(err)
+	}
+
+	return http.StatusInternalServerError, errorResponse(errInternal)
+}
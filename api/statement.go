@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/statement"
+	"github.com/gin-gonic/gin"
+)
+
+var errStatementPeriod = errors.New("give either month or both from and to")
+
+// getStatementRequest selects the period either as a calendar month (YYYY-MM) or as
+// an inclusive range of days (YYYY-MM-DD). Periods are in UTC.
+type getStatementRequest struct {
+	Month  string `form:"month" binding:"omitempty,datetime=2006-01"`
+	From   string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To     string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	Format string `form:"format" binding:"omitempty,oneof=json csv html camt053 mt940 ofx"`
+}
+
+func (req getStatementRequest) period() (statement.Period, error) {
+	switch {
+	case req.Month != "" && req.From == "" && req.To == "":
+		month, _ := time.Parse("2006-01", req.Month)
+		return statement.Month(month.Year(), month.Month(), time.UTC), nil
+	case req.Month == "" && req.From != "" && req.To != "":
+		from, _ := time.Parse(time.DateOnly, req.From)
+		to, _ := time.Parse(time.DateOnly, req.To)
+		if to.Before(from) {
+			return statement.Period{}, statement.ErrInvalidPeriod
+		}
+		return statement.Period{From: from, To: to.AddDate(0, 0, 1)}, nil
+	}
+	return statement.Period{}, errStatementPeriod
+}
+
+func (server *Server) getStatement(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req getStatementRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	period, err := req.period()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	format := statement.FormatJSON
+	if req.Format != "" {
+		format = statement.Format(req.Format)
+	}
+
+	if _, ok := server.authorizedAccount(ctx, uri.ID); !ok {
+		return
+	}
+
+	st, err := statement.Generate(ctx, server.store, uri.ID, period)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	// Render before writing any header, so that a failure still gets an error response
+	var body bytes.Buffer
+	if err := statement.Write(&body, st, format); err != nil {
+		if errors.Is(err, statement.ErrUnsupportedCurrency) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		handleStoreError(ctx, err)
+		return
+	}
+
+	if extension := format.FileExtension(); extension != "" {
+		filename := fmt.Sprintf("statement-%d-%s.%s", uri.ID, period.From.Format(time.DateOnly), extension)
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	}
+	ctx.Data(http.StatusOK, format.ContentType(), body.Bytes())
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/statement"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStatementAPI(t *testing.T) {
+	account := randomAccount(t)
+	transaction := randomTransaction(t, account)
+	transaction.CreatedAt = pgtype.Timestamptz{Time: time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC), Valid: true}
+
+	february := statement.Month(2026, time.February, time.UTC)
+
+	testCases := []struct {
+		name       string
+		query      string
+		caller     pgtype.UUID
+		wantPeriod statement.Period
+		wantStatus int
+		wantType   string
+	}{
+		{
+			name:       "Month",
+			query:      "month=2026-02",
+			caller:     account.OwnerID,
+			wantPeriod: february,
+			wantStatus: http.StatusOK,
+			wantType:   "application/json",
+		},
+		{
+			name:       "RangeIncludesLastDay",
+			query:      "from=2026-02-01&to=2026-02-28&format=csv",
+			caller:     account.OwnerID,
+			wantPeriod: february,
+			wantStatus: http.StatusOK,
+			wantType:   "text/csv; charset=utf-8",
+		},
+		{
+			name:       "HTML",
+			query:      "month=2026-02&format=html",
+			caller:     account.OwnerID,
+			wantPeriod: february,
+			wantStatus: http.StatusOK,
+			wantType:   "text/html; charset=utf-8",
+		},
+		{
+			name:       "CAMT053",
+			query:      "month=2026-02&format=camt053",
+			caller:     account.OwnerID,
+			wantPeriod: february,
+			wantStatus: http.StatusOK,
+			wantType:   "application/xml",
+		},
+		{name: "NoPeriod", query: "", caller: account.OwnerID, wantStatus: http.StatusBadRequest},
+		{name: "MonthAndRange", query: "month=2026-02&from=2026-02-01&to=2026-02-28", caller: account.OwnerID, wantStatus: http.StatusBadRequest},
+		{name: "RangeBackwards", query: "from=2026-02-28&to=2026-02-01", caller: account.OwnerID, wantStatus: http.StatusBadRequest},
+		{name: "BadMonth", query: "month=2026-13", caller: account.OwnerID, wantStatus: http.StatusBadRequest},
+		{name: "UnknownFormat", query: "month=2026-02&format=pdf", caller: account.OwnerID, wantStatus: http.StatusBadRequest},
+		{name: "NotOwner", query: "month=2026-02", caller: randomUUID(t), wantStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				getAccount: accountLookup(account),
+				listTransactionsInPeriod: func(arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error) {
+					require.Equal(t, account.ID, arg.AccountID)
+					require.True(t, tc.wantPeriod.From.Equal(arg.FromTime.Time))
+					require.True(t, tc.wantPeriod.To.Equal(arg.ToTime.Time))
+					if arg.AfterSeq != 0 {
+						return nil, nil
+					}
+					return []db.Transaction{transaction}, nil
+				},
+			})
+
+			url := fmt.Sprintf("/accounts/%d/statement?%s", account.ID, tc.query)
+			recorder := serveAs(t, server, tc.caller, http.MethodGet, url, nil)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code != http.StatusOK {
+				requireErrorBody(t, recorder)
+				return
+			}
+			require.Equal(t, tc.wantType, recorder.Header().Get("Content-Type"))
+			require.Contains(t, recorder.Body.String(), "2026-02-10")
+		})
+	}
+}
+
+func TestGetStatementAPIEmptyPeriod(t *testing.T) {
+	account := randomAccount(t)
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(account),
+		listTransactionsInPeriod: func(arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error) {
+			return nil, nil
+		},
+		getLastTransactionBefore: func(arg db.GetLastTransactionBeforeParams) (db.Transaction, error) {
+			return db.Transaction{}, pgx.ErrNoRows
+		},
+	})
+
+	url := fmt.Sprintf("/accounts/%d/statement?month=2026-02&format=csv", account.ID)
+	recorder := serveAs(t, server, account.OwnerID, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, fmt.Sprintf(`attachment; filename="statement-%d-2026-02-01.csv"`, account.ID), recorder.Header().Get("Content-Disposition"))
+
+	rows := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	require.Len(t, rows, 3)
+	require.Contains(t, rows[1], "Opening balance,,,0.00")
+	require.Contains(t, rows[2], "Closing balance,,,0.00")
+}
+
+func TestGetStatementAPIUnsupportedCurrency(t *testing.T) {
+	account := randomAccount(t)
+	account.Currency = db.Currency("JPY")
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(account),
+		listTransactionsInPeriod: func(arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error) {
+			return nil, nil
+		},
+		getLastTransactionBefore: func(arg db.GetLastTransactionBeforeParams) (db.Transaction, error) {
+			return db.Transaction{}, pgx.ErrNoRows
+		},
+	})
+
+	// the error replaces the file rather than arriving after its headers
+	url := fmt.Sprintf("/accounts/%d/statement?month=2026-02&format=mt940", account.ID)
+	recorder := serveAs(t, server, account.OwnerID, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	require.Empty(t, recorder.Header().Get("Content-Disposition"))
+	requireErrorBody(t, recorder)
+}
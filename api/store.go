@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Store is the subset of db.Store the HTTP handlers depend on.
+// Keeping it narrow lets handler tests run against a fake instead of Postgres.
+type Store interface {
+	CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error)
+	GetUser(ctx context.Context, id pgtype.UUID) (db.User, error)
+	GetUserByEmail(ctx context.Context, email string) (db.User, error)
+	UpdateUser(ctx context.Context, arg db.UpdateUserParams) (db.UpdateUserRow, error)
+	ChangePasswordTx(ctx context.Context, arg db.ChangePasswordTxParams) (db.User, error)
+
+	CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error)
+	GetAccount(ctx context.Context, id int64) (db.Account, error)
+	ListAccountsByOwnerPage(ctx context.Context, arg db.ListAccountsByOwnerPageParams) (db.Page[db.Account], error)
+	FreezeAccountTx(ctx context.Context, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error)
+	UnfreezeAccountTx(ctx context.Context, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error)
+	CloseAccountTx(ctx context.Context, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error)
+
+	GetTransaction(ctx context.Context, id pgtype.UUID) (db.Transaction, error)
+	ListTransactionsPage(ctx context.Context, arg db.ListTransactionsPageParams) (db.Page[db.Transaction], error)
+	SearchTransactionsPage(ctx context.Context, arg db.SearchTransactionsPageParams) (db.Page[db.Transaction], error)
+	GetLastTransactionBefore(ctx context.Context, arg db.GetLastTransactionBeforeParams) (db.Transaction, error)
+	ListTransactionsInPeriod(ctx context.Context, arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error)
+
+	GetTransfer(ctx context.Context, id pgtype.UUID) (db.Transfer, error)
+	ListTransfersByAccountPage(ctx context.Context, arg db.ListTransfersByAccountPageParams) (db.Page[db.Transfer], error)
+
+	ScheduleTransfer(ctx context.Context, arg db.ScheduleTransferParams) (db.TransferSchedule, error)
+	GetTransferSchedule(ctx context.Context, id pgtype.UUID) (db.TransferSchedule, error)
+	ListTransferSchedulesByAccountPage(ctx context.Context, arg db.ListTransferSchedulesByAccountPageParams) (db.Page[db.TransferSchedule], error)
+	ListTransferScheduleRunsPage(ctx context.Context, arg db.ListTransferScheduleRunsPageParams) (db.Page[db.TransferScheduleRun], error)
+	CancelTransferSchedule(ctx context.Context, id pgtype.UUID) (db.TransferSchedule, error)
+
+	CreateWebhookEndpoint(ctx context.Context, arg db.CreateWebhookEndpointParams) (db.WebhookEndpoint, error)
+	GetWebhookEndpoint(ctx context.Context, id pgtype.UUID) (db.WebhookEndpoint, error)
+	ListWebhookEndpointsByOwnerPage(ctx context.Context, arg db.ListWebhookEndpointsByOwnerPageParams) (db.Page[db.WebhookEndpoint], error)
+	DeactivateWebhookEndpoint(ctx context.Context, id pgtype.UUID) (db.WebhookEndpoint, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (db.WebhookDelivery, error)
+	ListWebhookDeliveriesByEndpointPage(ctx context.Context, arg db.ListWebhookDeliveriesByEndpointPageParams) (db.Page[db.WebhookDelivery], error)
+	RedeliverWebhookDelivery(ctx context.Context, id int64) (db.WebhookDelivery, error)
+
+	TransferMoneyTx(ctx context.Context, arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error)
+	DepositMoneyTx(ctx context.Context, arg db.AccountTransactionParams) (db.AccountTransactionResult, error)
+	WithdrawMoneyTx(ctx context.Context, arg db.AccountTransactionParams) (db.AccountTransactionResult, error)
+}
+
+// compile-time check that the Postgres-backed store satisfies Store
+var _ Store = (db.Store)(nil)
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type transactionResponse struct {
+	ID                string    `json:"id"`
+	AccountID         int64     `json:"account_id"`
+	Type              string    `json:"type"`
+	AmountCents       int64     `json:"amount_cents"`
+	BalanceAfterCents int64     `json:"balance_after_cents"`
+	RelatedAccountID  *int64    `json:"related_account_id,omitempty"`
+	Reference         *string   `json:"reference,omitempty"`
+	Description       *string   `json:"description,omitempty"`
+	CreatedAt         time.Time `json:"created_at,omitzero"`
+}
+
+func newTransactionResponse(transaction db.Transaction) transactionResponse {
+	rsp := transactionResponse{
+		ID:                transaction.ID.String(),
+		AccountID:         transaction.AccountID,
+		Type:              string(transaction.Type),
+		AmountCents:       transaction.AmountCents,
+		BalanceAfterCents: transaction.BalanceAfterCents,
+		CreatedAt:         transaction.CreatedAt.Time,
+	}
+	if transaction.RelatedAccountID.Valid {
+		rsp.RelatedAccountID = &transaction.RelatedAccountID.Int64
+	}
+	if transaction.Reference.Valid {
+		rsp.Reference = &transaction.Reference.String
+	}
+	if transaction.Description.Valid {
+		rsp.Description = &transaction.Description.String
+	}
+	return rsp
+}
+
+// optionalText stores an empty string as NULL.
+func optionalText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+type transactionURI struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+func (server *Server) getTransaction(ctx *gin.Context) {
+	var uri transactionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	_ = id.Scan(uri.ID)
+
+	transaction, err := server.store.GetTransaction(ctx, id)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	if _, ok := server.authorizedAccount(ctx, transaction.AccountID); !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTransactionResponse(transaction))
+}
+
+func (server *Server) listTransactions(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.authorizedAccount(ctx, uri.ID); !ok {
+		return
+	}
+
+	transactions, err := server.store.ListTransactionsPage(ctx, db.ListTransactionsPageParams{
+		AccountID: uri.ID,
+		Cursor:    req.Cursor,
+		Limit:     req.PageSize,
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPageResponse(transactions, newTransactionResponse))
+}
+
+type searchTransactionsRequest struct {
+	pageRequest
+	From  string   `form:"from" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To    string   `form:"to" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Types []string `form:"type" binding:"dive,oneof=deposit withdrawal transfer_in transfer_out reversal overdraft_interest overdraft_fee"`
+	// MinAmountCents and MaxAmountCents bound the absolute amount.
+	MinAmountCents        int64  `form:"min_amount_cents" binding:"min=0"`
+	MaxAmountCents        int64  `form:"max_amount_cents" binding:"omitempty,gtefield=MinAmountCents"`
+	CounterpartyAccountID int64  `form:"counterparty_account_id" binding:"min=0"`
+	Reference             string `form:"reference" binding:"max=255"`
+	Query                 string `form:"q" binding:"max=140"`
+	Sort                  string `form:"sort" binding:"omitempty,oneof=newest oldest largest smallest"`
+}
+
+// searchTransactions filters an account's transactions. Every filter is optional and
+// they combine with AND; repeating type matches any of the given types.
+func (server *Server) searchTransactions(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req searchTransactionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.authorizedAccount(ctx, uri.ID); !ok {
+		return
+	}
+
+	arg := db.SearchTransactionsPageParams{
+		AccountID:           uri.ID,
+		CreatedFrom:         searchTime(req.From),
+		CreatedTo:           searchTime(req.To),
+		MinAmountCents:      req.MinAmountCents,
+		MaxAmountCents:      req.MaxAmountCents,
+		RelatedAccountID:    pgtype.Int8{Int64: req.CounterpartyAccountID, Valid: req.CounterpartyAccountID != 0},
+		Reference:           optionalText(req.Reference),
+		DescriptionContains: req.Query,
+		Sort:                db.TransactionSort(req.Sort),
+		Cursor:              req.Cursor,
+		Limit:               req.PageSize,
+	}
+	slices.Sort(req.Types)
+	for _, t := range slices.Compact(req.Types) {
+		arg.Types = append(arg.Types, db.TransactionType(t))
+	}
+
+	transactions, err := server.store.SearchTransactionsPage(ctx, arg)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPageResponse(transactions, newTransactionResponse))
+}
+
+// searchTime parses a from or to bound already checked by the binding; empty leaves it open.
+func searchTime(value string) pgtype.Timestamptz {
+	t, err := time.Parse(time.RFC3339, value)
+	return pgtype.Timestamptz{Time: t, Valid: err == nil}
+}
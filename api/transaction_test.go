@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func randomTransaction(t *testing.T, account db.Account) db.Transaction {
+	return db.Transaction{
+		ID:                randomUUID(t),
+		AccountID:         account.ID,
+		Type:              db.TransactionTypeDeposit,
+		AmountCents:       100,
+		BalanceAfterCents: account.BalanceCents + 100,
+	}
+}
+
+func TestGetTransactionAPI(t *testing.T) {
+	account := randomAccount(t)
+	transaction := randomTransaction(t, account)
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(account),
+		getTransaction: func(id pgtype.UUID) (db.Transaction, error) {
+			if id == transaction.ID {
+				return transaction, nil
+			}
+			return db.Transaction{}, pgx.ErrNoRows
+		},
+	})
+
+	recorder := serveAs(t, server, account.OwnerID, http.MethodGet, "/transactions/"+transaction.ID.String(), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, newTransactionResponse(transaction), decodeBody[transactionResponse](t, recorder))
+
+	recorder = serveAs(t, server, randomUUID(t), http.MethodGet, "/transactions/"+transaction.ID.String(), nil)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	requireErrorBody(t, recorder)
+
+	recorder = serveAs(t, server, account.OwnerID, http.MethodGet, "/transactions/"+randomUUID(t).String(), nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	requireErrorBody(t, recorder)
+}
+
+func TestListTransactionsAPI(t *testing.T) {
+	account := randomAccount(t)
+	transactions := []db.Transaction{randomTransaction(t, account), randomTransaction(t, account)}
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(account),
+		listTransactionsPage: func(arg db.ListTransactionsPageParams) (db.Page[db.Transaction], error) {
+			require.Equal(t, account.ID, arg.AccountID)
+			require.Equal(t, int32(10), arg.Limit)
+			require.Empty(t, arg.Cursor)
+			return db.Page[db.Transaction]{Items: transactions, NextCursor: "next"}, nil
+		},
+	})
+
+	url := fmt.Sprintf("/accounts/%d/transactions?page_size=10", account.ID)
+	recorder := serveAs(t, server, account.OwnerID, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body := decodeBody[map[string]json.RawMessage](t, recorder)
+	require.JSONEq(t, `"next"`, string(body["next_cursor"]))
+	require.NotContains(t, body, "prev_cursor")
+	require.Len(t, decodeBody[pageResponse[transactionResponse]](t, recorder).Items, len(transactions))
+
+	url = fmt.Sprintf("/accounts/%d/transactions?page_size=1000", account.ID)
+	recorder = serveAs(t, server, account.OwnerID, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestSearchTransactionsAPI(t *testing.T) {
+	account := randomAccount(t)
+	transactions := []db.Transaction{randomTransaction(t, account)}
+	transactions[0].Description = pgtype.Text{String: "Rent for March", Valid: true}
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(account),
+		searchTransactionsPage: func(arg db.SearchTransactionsPageParams) (db.Page[db.Transaction], error) {
+			require.Equal(t, account.ID, arg.AccountID)
+			require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), arg.CreatedFrom.Time.UTC())
+			require.False(t, arg.CreatedTo.Valid)
+			require.Equal(t, []db.TransactionType{db.TransactionTypeTransferIn, db.TransactionTypeTransferOut}, arg.Types)
+			require.Equal(t, int64(1000), arg.MinAmountCents)
+			require.Equal(t, int64(0), arg.MaxAmountCents)
+			require.Equal(t, pgtype.Int8{Int64: 42, Valid: true}, arg.RelatedAccountID)
+			require.False(t, arg.Reference.Valid)
+			require.Equal(t, "rent", arg.DescriptionContains)
+			require.Equal(t, db.TransactionSortLargest, arg.Sort)
+			require.Equal(t, int32(5), arg.Limit)
+			return db.Page[db.Transaction]{Items: transactions}, nil
+		},
+	})
+
+	url := fmt.Sprintf("/accounts/%d/transactions/search?page_size=5&from=2026-03-01T00:00:00Z"+
+		"&type=transfer_out&type=transfer_in&type=transfer_out&min_amount_cents=1000"+
+		"&counterparty_account_id=42&q=rent&sort=largest", account.ID)
+	recorder := serveAs(t, server, account.OwnerID, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body := decodeBody[pageResponse[transactionResponse]](t, recorder)
+	require.Len(t, body.Items, 1)
+	require.Equal(t, "Rent for March", *body.Items[0].Description)
+
+	for _, query := range []string{
+		"page_size=5&sort=alphabetical",
+		"page_size=5&type=fee",
+		"page_size=5&from=yesterday",
+		"page_size=5&min_amount_cents=500&max_amount_cents=100",
+	} {
+		url := fmt.Sprintf("/accounts/%d/transactions/search?%s", account.ID, query)
+		recorder := serveAs(t, server, account.OwnerID, http.MethodGet, url, nil)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, query)
+		requireErrorBody(t, recorder)
+	}
+
+	url = fmt.Sprintf("/accounts/%d/transactions/search?page_size=5", account.ID)
+	recorder = serveAs(t, server, randomUUID(t), http.MethodGet, url, nil)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
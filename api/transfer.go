@@ -0,0 +1,190 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type transferResponse struct {
+	ID            string     `json:"id"`
+	FromAccountID int64      `json:"from_account_id"`
+	ToAccountID   int64      `json:"to_account_id"`
+	AmountCents   int64      `json:"amount_cents"`
+	Status        string     `json:"status"`
+	CreatedAt     time.Time  `json:"created_at,omitzero"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+	Reference     *string    `json:"reference,omitempty"`
+	FailureReason *string    `json:"failure_reason,omitempty"`
+	// ToAmountCents and ExchangeRate are set on converted cross-currency transfers.
+	ToAmountCents *int64  `json:"to_amount_cents,omitempty"`
+	ExchangeRate  *string `json:"exchange_rate,omitempty"`
+}
+
+func newTransferResponse(transfer db.Transfer) transferResponse {
+	rsp := transferResponse{
+		ID:            transfer.ID.String(),
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		AmountCents:   transfer.AmountCents,
+		Status:        string(transfer.Status),
+		CreatedAt:     transfer.CreatedAt.Time,
+	}
+	if transfer.ProcessedAt.Valid {
+		rsp.ProcessedAt = &transfer.ProcessedAt.Time
+	}
+	if transfer.Reference.Valid {
+		rsp.Reference = &transfer.Reference.String
+	}
+	if transfer.FailureReason.Valid {
+		rsp.FailureReason = &transfer.FailureReason.String
+	}
+	if transfer.ToAmountCents.Valid {
+		rsp.ToAmountCents = &transfer.ToAmountCents.Int64
+	}
+	if rate, err := transfer.ExchangeRate.Value(); err == nil && rate != nil {
+		// kept as a string so clients don't lose precision parsing it as a float
+		s := rate.(string)
+		rsp.ExchangeRate = &s
+	}
+	return rsp
+}
+
+type createTransferRequest struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64 `json:"to_account_id" binding:"required,min=1,nefield=FromAccountID"`
+	AmountCents   int64 `json:"amount_cents" binding:"required,gt=0"`
+	// ConvertCurrency allows a transfer between accounts in different currencies,
+	// converted at the latest stored exchange rate.
+	ConvertCurrency bool   `json:"convert_currency"`
+	Description     string `json:"description" binding:"max=140"`
+}
+
+type transferMoneyResponse struct {
+	Transfer    transferResponse    `json:"transfer"`
+	FromAccount accountResponse     `json:"from_account"`
+	ToAccount   accountResponse     `json:"to_account"`
+	FromTx      transactionResponse `json:"from_transaction"`
+	ToTx        transactionResponse `json:"to_transaction"`
+}
+
+func (server *Server) createTransfer(ctx *gin.Context) {
+	var req createTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	key, ok := idempotencyKey(ctx)
+	if !ok {
+		return
+	}
+
+	// Only the owner of the source account may move money out of it;
+	// the destination may belong to anyone.
+	if _, ok := server.authorizedAccount(ctx, req.FromAccountID); !ok {
+		return
+	}
+
+	result, err := server.store.TransferMoneyTx(ctx, db.TransferMoneyTxParams{
+		FromAccountID:   req.FromAccountID,
+		ToAccountID:     req.ToAccountID,
+		AmountCents:     req.AmountCents,
+		IdempotencyKey:  key,
+		ConvertCurrency: req.ConvertCurrency,
+		Description:     optionalText(req.Description),
+	})
+	if err != nil {
+		code, body := storeErrorResponse(err)
+		// A refused transfer is committed as failed; hand back which one, so the client
+		// can find it again
+		if result.Transfer.Status == db.TransferStatusFailed {
+			markReplayed(ctx, result.Replayed)
+			body["transfer"] = newTransferResponse(result.Transfer)
+		}
+		ctx.JSON(code, body)
+		return
+	}
+
+	markReplayed(ctx, result.Replayed)
+	ctx.JSON(http.StatusCreated, transferMoneyResponse{
+		Transfer:    newTransferResponse(result.Transfer),
+		FromAccount: newAccountResponse(result.FromAccount),
+		ToAccount:   newAccountResponse(result.ToAccount),
+		FromTx:      newTransactionResponse(result.FromTx),
+		ToTx:        newTransactionResponse(result.ToTx),
+	})
+}
+
+type transferURI struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+func (server *Server) getTransfer(ctx *gin.Context) {
+	var uri transferURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	_ = id.Scan(uri.ID)
+
+	transfer, err := server.store.GetTransfer(ctx, id)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	if !server.ownsTransferSide(ctx, transfer) {
+		ctx.JSON(http.StatusForbidden, errorResponse(errAccountNotOwned))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTransferResponse(transfer))
+}
+
+// ownsTransferSide reports whether the caller owns either account of the transfer.
+func (server *Server) ownsTransferSide(ctx *gin.Context, transfer db.Transfer) bool {
+	userID := authPayload(ctx).UserID
+	for _, accountID := range []int64{transfer.FromAccountID, transfer.ToAccountID} {
+		account, err := server.store.GetAccount(ctx, accountID)
+		if err == nil && account.OwnerID.Bytes == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (server *Server) listTransfers(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.authorizedAccount(ctx, uri.ID); !ok {
+		return
+	}
+
+	transfers, err := server.store.ListTransfersByAccountPage(ctx, db.ListTransfersByAccountPageParams{
+		AccountID: uri.ID,
+		Cursor:    req.Cursor,
+		Limit:     req.PageSize,
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPageResponse(transfers, newTransferResponse))
+}
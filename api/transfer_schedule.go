@@ -0,0 +1,238 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var errScheduleNotActive = errors.New("transfer schedule is no longer active")
+
+type transferScheduleResponse struct {
+	ID                      string     `json:"id"`
+	FromAccountID           int64      `json:"from_account_id"`
+	ToAccountID             int64      `json:"to_account_id"`
+	AmountCents             int64      `json:"amount_cents"`
+	Frequency               string     `json:"frequency"`
+	DayOfMonth              *int32     `json:"day_of_month,omitempty"`
+	Status                  string     `json:"status"`
+	InsufficientFundsPolicy string     `json:"insufficient_funds_policy"`
+	MaxAttempts             int32      `json:"max_attempts"`
+	NextRunAt               time.Time  `json:"next_run_at"`
+	EndsAt                  *time.Time `json:"ends_at,omitempty"`
+	CreatedAt               time.Time  `json:"created_at,omitzero"`
+}
+
+func newTransferScheduleResponse(schedule db.TransferSchedule) transferScheduleResponse {
+	rsp := transferScheduleResponse{
+		ID:                      schedule.ID.String(),
+		FromAccountID:           schedule.FromAccountID,
+		ToAccountID:             schedule.ToAccountID,
+		AmountCents:             schedule.AmountCents,
+		Frequency:               string(schedule.Frequency),
+		Status:                  string(schedule.Status),
+		InsufficientFundsPolicy: string(schedule.InsufficientFundsPolicy),
+		MaxAttempts:             schedule.MaxAttempts,
+		NextRunAt:               schedule.NextRunAt.Time,
+		CreatedAt:               schedule.CreatedAt.Time,
+	}
+	if schedule.DayOfMonth.Valid {
+		rsp.DayOfMonth = &schedule.DayOfMonth.Int32
+	}
+	if schedule.EndsAt.Valid {
+		rsp.EndsAt = &schedule.EndsAt.Time
+	}
+	return rsp
+}
+
+type transferScheduleRunResponse struct {
+	ID            int64     `json:"id"`
+	OccurrenceAt  time.Time `json:"occurrence_at"`
+	Attempt       int32     `json:"attempt"`
+	Status        string    `json:"status"`
+	TransferID    *string   `json:"transfer_id,omitempty"`
+	FailureReason *string   `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at,omitzero"`
+}
+
+func newTransferScheduleRunResponse(run db.TransferScheduleRun) transferScheduleRunResponse {
+	rsp := transferScheduleRunResponse{
+		ID:           run.ID,
+		OccurrenceAt: run.OccurrenceAt.Time,
+		Attempt:      run.Attempt,
+		Status:       string(run.Status),
+		CreatedAt:    run.CreatedAt.Time,
+	}
+	if run.TransferID.Valid {
+		id := run.TransferID.String()
+		rsp.TransferID = &id
+	}
+	if run.FailureReason.Valid {
+		rsp.FailureReason = &run.FailureReason.String
+	}
+	return rsp
+}
+
+type createTransferScheduleRequest struct {
+	FromAccountID int64     `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64     `json:"to_account_id" binding:"required,min=1,nefield=FromAccountID"`
+	AmountCents   int64     `json:"amount_cents" binding:"required,gt=0"`
+	Frequency     string    `json:"frequency" binding:"required,oneof=once daily weekly monthly"`
+	StartsAt      time.Time `json:"starts_at" binding:"required"`
+	// DayOfMonth picks the day of monthly transfers; it defaults to the day of StartsAt.
+	DayOfMonth int32      `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+	EndsAt     *time.Time `json:"ends_at"`
+	// InsufficientFundsPolicy is "skip" (the default) or "retry".
+	InsufficientFundsPolicy string `json:"insufficient_funds_policy" binding:"omitempty,oneof=retry skip"`
+	MaxAttempts             int32  `json:"max_attempts" binding:"omitempty,min=1,max=10"`
+}
+
+func (server *Server) createTransferSchedule(ctx *gin.Context) {
+	var req createTransferScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// Like a transfer, only the owner of the source account may schedule one
+	if _, ok := server.authorizedAccount(ctx, req.FromAccountID); !ok {
+		return
+	}
+
+	arg := db.ScheduleTransferParams{
+		FromAccountID:           req.FromAccountID,
+		ToAccountID:             req.ToAccountID,
+		AmountCents:             req.AmountCents,
+		Frequency:               db.ScheduleFrequency(req.Frequency),
+		StartsAt:                req.StartsAt,
+		DayOfMonth:              req.DayOfMonth,
+		InsufficientFundsPolicy: db.InsufficientFundsPolicy(req.InsufficientFundsPolicy),
+		MaxAttempts:             req.MaxAttempts,
+	}
+	if req.EndsAt != nil {
+		arg.EndsAt = *req.EndsAt
+	}
+
+	schedule, err := server.store.ScheduleTransfer(ctx, arg)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, newTransferScheduleResponse(schedule))
+}
+
+type transferScheduleURI struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// authorizedTransferSchedule loads the schedule named in the URI and verifies that the
+// caller owns its source account. On failure the error response is already written.
+func (server *Server) authorizedTransferSchedule(ctx *gin.Context) (db.TransferSchedule, bool) {
+	var uri transferScheduleURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.TransferSchedule{}, false
+	}
+
+	var id pgtype.UUID
+	_ = id.Scan(uri.ID)
+
+	schedule, err := server.store.GetTransferSchedule(ctx, id)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return schedule, false
+	}
+
+	if _, ok := server.authorizedAccount(ctx, schedule.FromAccountID); !ok {
+		return schedule, false
+	}
+	return schedule, true
+}
+
+func (server *Server) getTransferSchedule(ctx *gin.Context) {
+	schedule, ok := server.authorizedTransferSchedule(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTransferScheduleResponse(schedule))
+}
+
+func (server *Server) cancelTransferSchedule(ctx *gin.Context) {
+	schedule, ok := server.authorizedTransferSchedule(ctx)
+	if !ok {
+		return
+	}
+	if schedule.Status != db.ScheduleStatusActive {
+		ctx.JSON(http.StatusConflict, errorResponse(errScheduleNotActive))
+		return
+	}
+
+	schedule, err := server.store.CancelTransferSchedule(ctx, schedule.ID)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTransferScheduleResponse(schedule))
+}
+
+func (server *Server) listTransferSchedules(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.authorizedAccount(ctx, uri.ID); !ok {
+		return
+	}
+
+	schedules, err := server.store.ListTransferSchedulesByAccountPage(ctx, db.ListTransferSchedulesByAccountPageParams{
+		FromAccountID: uri.ID,
+		Cursor:        req.Cursor,
+		Limit:         req.PageSize,
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPageResponse(schedules, newTransferScheduleResponse))
+}
+
+func (server *Server) listTransferScheduleRuns(ctx *gin.Context) {
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	schedule, ok := server.authorizedTransferSchedule(ctx)
+	if !ok {
+		return
+	}
+
+	runs, err := server.store.ListTransferScheduleRunsPage(ctx, db.ListTransferScheduleRunsPageParams{
+		ScheduleID: schedule.ID,
+		Cursor:     req.Cursor,
+		Limit:      req.PageSize,
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPageResponse(runs, newTransferScheduleRunResponse))
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func randomTransferSchedule(t *testing.T, fromAccount, toAccount db.Account) db.TransferSchedule {
+	return db.TransferSchedule{
+		ID:                      randomUUID(t),
+		FromAccountID:           fromAccount.ID,
+		ToAccountID:             toAccount.ID,
+		AmountCents:             500,
+		Frequency:               db.ScheduleFrequencyMonthly,
+		DayOfMonth:              pgtype.Int4{Int32: 15, Valid: true},
+		Status:                  db.ScheduleStatusActive,
+		InsufficientFundsPolicy: db.InsufficientFundsPolicySkip,
+		MaxAttempts:             3,
+		NextRunAt:               pgtype.Timestamptz{Time: time.Now().Add(24 * time.Hour).UTC(), Valid: true},
+	}
+}
+
+// scheduleLookup stubs GetTransferSchedule over a fixed set of schedules.
+func scheduleLookup(schedules ...db.TransferSchedule) func(id pgtype.UUID) (db.TransferSchedule, error) {
+	return func(id pgtype.UUID) (db.TransferSchedule, error) {
+		for _, schedule := range schedules {
+			if schedule.ID == id {
+				return schedule, nil
+			}
+		}
+		return db.TransferSchedule{}, pgx.ErrNoRows
+	}
+}
+
+func TestCreateTransferScheduleAPI(t *testing.T) {
+	fromAccount := randomAccount(t)
+	toAccount := randomAccount(t)
+	toAccount.ID = fromAccount.ID + 1
+	startsAt := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+
+	body := func(fields map[string]any) map[string]any {
+		request := map[string]any{
+			"from_account_id": fromAccount.ID,
+			"to_account_id":   toAccount.ID,
+			"amount_cents":    500,
+			"frequency":       "monthly",
+			"starts_at":       startsAt,
+		}
+		for key, value := range fields {
+			request[key] = value
+		}
+		return request
+	}
+
+	testCases := []struct {
+		name             string
+		body             map[string]any
+		caller           pgtype.UUID
+		scheduleTransfer func(arg db.ScheduleTransferParams) (db.TransferSchedule, error)
+		wantStatus       int
+	}{
+		{
+			name:   "OK",
+			body:   body(map[string]any{"day_of_month": 31, "insufficient_funds_policy": "retry", "max_attempts": 5}),
+			caller: fromAccount.OwnerID,
+			scheduleTransfer: func(arg db.ScheduleTransferParams) (db.TransferSchedule, error) {
+				require.Equal(t, fromAccount.ID, arg.FromAccountID)
+				require.Equal(t, toAccount.ID, arg.ToAccountID)
+				require.Equal(t, db.ScheduleFrequencyMonthly, arg.Frequency)
+				require.True(t, startsAt.Equal(arg.StartsAt))
+				require.Equal(t, int32(31), arg.DayOfMonth)
+				require.True(t, arg.EndsAt.IsZero())
+				require.Equal(t, db.InsufficientFundsPolicyRetry, arg.InsufficientFundsPolicy)
+				require.Equal(t, int32(5), arg.MaxAttempts)
+				return randomTransferSchedule(t, fromAccount, toAccount), nil
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "NotSourceOwner",
+			body:       body(nil),
+			caller:     toAccount.OwnerID,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "UnknownFrequency",
+			body:       body(map[string]any{"frequency": "hourly"}),
+			caller:     fromAccount.OwnerID,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "DayOfMonthOutOfRange",
+			body:       body(map[string]any{"day_of_month": 32}),
+			caller:     fromAccount.OwnerID,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "InvalidSchedule",
+			body:   body(map[string]any{"ends_at": startsAt.Add(-time.Hour)}),
+			caller: fromAccount.OwnerID,
+			scheduleTransfer: func(arg db.ScheduleTransferParams) (db.TransferSchedule, error) {
+				require.True(t, startsAt.Add(-time.Hour).Equal(arg.EndsAt))
+				return db.TransferSchedule{}, fmt.Errorf("%w: schedule ends before its first transfer", db.ErrInvalidSchedule)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "CurrencyMismatch",
+			body:   body(nil),
+			caller: fromAccount.OwnerID,
+			scheduleTransfer: func(arg db.ScheduleTransferParams) (db.TransferSchedule, error) {
+				return db.TransferSchedule{}, db.ErrCurrencyMismatch
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				getAccount:       accountLookup(fromAccount, toAccount),
+				scheduleTransfer: tc.scheduleTransfer,
+			})
+			recorder := serveAs(t, server, tc.caller, http.MethodPost, "/transfer-schedules", tc.body)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code == http.StatusCreated {
+				body := decodeBody[transferScheduleResponse](t, recorder)
+				require.Equal(t, fromAccount.ID, body.FromAccountID)
+				require.Equal(t, "monthly", body.Frequency)
+			} else {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
+
+func TestCancelTransferScheduleAPI(t *testing.T) {
+	fromAccount := randomAccount(t)
+	toAccount := randomAccount(t)
+	toAccount.ID = fromAccount.ID + 1
+
+	active := randomTransferSchedule(t, fromAccount, toAccount)
+	completed := randomTransferSchedule(t, fromAccount, toAccount)
+	completed.Status = db.ScheduleStatusCompleted
+
+	testCases := []struct {
+		name       string
+		scheduleID pgtype.UUID
+		caller     pgtype.UUID
+		wantStatus int
+	}{
+		{name: "OK", scheduleID: active.ID, caller: fromAccount.OwnerID, wantStatus: http.StatusOK},
+		{name: "NotSourceOwner", scheduleID: active.ID, caller: toAccount.OwnerID, wantStatus: http.StatusForbidden},
+		{name: "NotActive", scheduleID: completed.ID, caller: fromAccount.OwnerID, wantStatus: http.StatusConflict},
+		{name: "NotFound", scheduleID: randomUUID(t), caller: fromAccount.OwnerID, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				getAccount:          accountLookup(fromAccount, toAccount),
+				getTransferSchedule: scheduleLookup(active, completed),
+				cancelTransferSchedule: func(id pgtype.UUID) (db.TransferSchedule, error) {
+					require.Equal(t, active.ID, id)
+					cancelled := active
+					cancelled.Status = db.ScheduleStatusCancelled
+					return cancelled, nil
+				},
+			})
+			url := fmt.Sprintf("/transfer-schedules/%s/cancel", tc.scheduleID)
+			recorder := serveAs(t, server, tc.caller, http.MethodPost, url, nil)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code == http.StatusOK {
+				body := decodeBody[transferScheduleResponse](t, recorder)
+				require.Equal(t, "cancelled", body.Status)
+			} else {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
+
+func TestListTransferScheduleRunsAPI(t *testing.T) {
+	fromAccount := randomAccount(t)
+	toAccount := randomAccount(t)
+	toAccount.ID = fromAccount.ID + 1
+	schedule := randomTransferSchedule(t, fromAccount, toAccount)
+
+	runs := []db.TransferScheduleRun{
+		{ID: 2, ScheduleID: schedule.ID, Attempt: 2, Status: db.ScheduleRunStatusSucceeded, TransferID: randomUUID(t)},
+		{
+			ID:            1,
+			ScheduleID:    schedule.ID,
+			Attempt:       1,
+			Status:        db.ScheduleRunStatusRetrying,
+			TransferID:    randomUUID(t),
+			FailureReason: pgtype.Text{String: db.ErrInsufficientBalance.Error(), Valid: true},
+		},
+	}
+
+	server := newTestServer(t, &fakeStore{
+		getAccount:          accountLookup(fromAccount, toAccount),
+		getTransferSchedule: scheduleLookup(schedule),
+		listTransferScheduleRunsPage: func(arg db.ListTransferScheduleRunsPageParams) (db.Page[db.TransferScheduleRun], error) {
+			require.Equal(t, schedule.ID, arg.ScheduleID)
+			require.Equal(t, int32(5), arg.Limit)
+			require.Equal(t, "next", arg.Cursor)
+			return db.Page[db.TransferScheduleRun]{Items: runs}, nil
+		},
+	})
+
+	url := fmt.Sprintf("/transfer-schedules/%s/runs?cursor=next&page_size=5", schedule.ID)
+	recorder := serveAs(t, server, fromAccount.OwnerID, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	body := decodeBody[pageResponse[transferScheduleRunResponse]](t, recorder).Items
+	require.Len(t, body, 2)
+	require.Equal(t, "succeeded", body[0].Status)
+	require.Nil(t, body[0].FailureReason)
+	require.Equal(t, "retrying", body[1].Status)
+	require.Equal(t, db.ErrInsufficientBalance.Error(), *body[1].FailureReason)
+
+	recorder = serveAs(t, server, toAccount.OwnerID, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
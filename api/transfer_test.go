@@ -0,0 +1,256 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/RakibRahman/fincore-api/db/dberr"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransferAPI(t *testing.T) {
+	fromAccount := randomAccount(t)
+	toAccount := randomAccount(t)
+	toAccount.ID = fromAccount.ID + 1
+	amount := int64(250)
+
+	testCases := []struct {
+		name          string
+		body          map[string]any
+		caller        pgtype.UUID
+		transferMoney func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error)
+		wantStatus    int
+	}{
+		{
+			name:   "OK",
+			body:   map[string]any{"from_account_id": fromAccount.ID, "to_account_id": toAccount.ID, "amount_cents": amount},
+			caller: fromAccount.OwnerID,
+			transferMoney: func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+				require.Equal(t, fromAccount.ID, arg.FromAccountID)
+				require.Equal(t, toAccount.ID, arg.ToAccountID)
+				require.Equal(t, amount, arg.AmountCents)
+				return db.TransferMoneyResult{
+					Transfer:    db.Transfer{FromAccountID: arg.FromAccountID, ToAccountID: arg.ToAccountID, AmountCents: arg.AmountCents},
+					FromAccount: fromAccount,
+					ToAccount:   toAccount,
+				}, nil
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "NotSourceOwner",
+			body:       map[string]any{"from_account_id": fromAccount.ID, "to_account_id": toAccount.ID, "amount_cents": amount},
+			caller:     toAccount.OwnerID,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "SameAccount",
+			body:       map[string]any{"from_account_id": fromAccount.ID, "to_account_id": fromAccount.ID, "amount_cents": amount},
+			caller:     fromAccount.OwnerID,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "NonPositiveAmount",
+			body:       map[string]any{"from_account_id": fromAccount.ID, "to_account_id": toAccount.ID, "amount_cents": 0},
+			caller:     fromAccount.OwnerID,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "InsufficientBalance",
+			body:   map[string]any{"from_account_id": fromAccount.ID, "to_account_id": toAccount.ID, "amount_cents": amount},
+			caller: fromAccount.OwnerID,
+			transferMoney: func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+				return db.TransferMoneyResult{}, db.ErrInsufficientBalance
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "DestinationNotFound",
+			body:   map[string]any{"from_account_id": fromAccount.ID, "to_account_id": toAccount.ID + 100, "amount_cents": amount},
+			caller: fromAccount.OwnerID,
+			transferMoney: func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+				return db.TransferMoneyResult{}, pgx.ErrNoRows
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "CurrencyMismatch",
+			body:   map[string]any{"from_account_id": fromAccount.ID, "to_account_id": toAccount.ID, "amount_cents": amount},
+			caller: fromAccount.OwnerID,
+			transferMoney: func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+				require.False(t, arg.ConvertCurrency)
+				return db.TransferMoneyResult{}, db.ErrCurrencyMismatch
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "NoExchangeRate",
+			body:   map[string]any{"from_account_id": fromAccount.ID, "to_account_id": toAccount.ID, "amount_cents": amount, "convert_currency": true},
+			caller: fromAccount.OwnerID,
+			transferMoney: func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+				return db.TransferMoneyResult{}, db.ErrExchangeRateNotFound
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				getAccount:      accountLookup(fromAccount, toAccount),
+				transferMoneyTx: tc.transferMoney,
+			})
+			recorder := serveAs(t, server, tc.caller, http.MethodPost, "/transfers", tc.body)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code == http.StatusCreated {
+				body := decodeBody[transferMoneyResponse](t, recorder)
+				require.Equal(t, amount, body.Transfer.AmountCents)
+				require.Equal(t, fromAccount.ID, body.FromAccount.ID)
+			} else {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
+
+func TestCreateTransferAPIConvertCurrency(t *testing.T) {
+	fromAccount := randomAccount(t)
+	toAccount := randomAccount(t)
+	toAccount.ID = fromAccount.ID + 1
+	toAccount.Currency = db.CurrencyEUR
+
+	var rate pgtype.Numeric
+	require.NoError(t, rate.Scan("0.9215000000"))
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(fromAccount, toAccount),
+		transferMoneyTx: func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+			require.True(t, arg.ConvertCurrency)
+			return db.TransferMoneyResult{
+				Transfer: db.Transfer{
+					FromAccountID: arg.FromAccountID,
+					ToAccountID:   arg.ToAccountID,
+					AmountCents:   arg.AmountCents,
+					ToAmountCents: pgtype.Int8{Int64: 9215, Valid: true},
+					ExchangeRate:  rate,
+				},
+				FromAccount: fromAccount,
+				ToAccount:   toAccount,
+			}, nil
+		},
+	})
+
+	body := map[string]any{"from_account_id": fromAccount.ID, "to_account_id": toAccount.ID, "amount_cents": 10000, "convert_currency": true}
+	recorder := serveAs(t, server, fromAccount.OwnerID, http.MethodPost, "/transfers", body)
+	require.Equal(t, http.StatusCreated, recorder.Code)
+
+	transfer := decodeBody[transferMoneyResponse](t, recorder).Transfer
+	require.Equal(t, int64(10000), transfer.AmountCents)
+	require.Equal(t, int64(9215), *transfer.ToAmountCents)
+	require.Equal(t, "0.9215000000", *transfer.ExchangeRate)
+}
+
+func TestCreateTransferAPIFailedTransfer(t *testing.T) {
+	fromAccount := randomAccount(t)
+	toAccount := randomAccount(t)
+	toAccount.ID = fromAccount.ID + 1
+	transferID := randomUUID(t)
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(fromAccount, toAccount),
+		transferMoneyTx: func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+			// A refused transfer is committed as failed and returned with the rule's error
+			return db.TransferMoneyResult{
+				Transfer: db.Transfer{
+					ID:            transferID,
+					FromAccountID: arg.FromAccountID,
+					ToAccountID:   arg.ToAccountID,
+					AmountCents:   arg.AmountCents,
+					Status:        db.TransferStatusFailed,
+					FailureReason: pgtype.Text{String: db.TransferFailureInsufficientBalance, Valid: true},
+				},
+				FromAccount: fromAccount,
+				ToAccount:   toAccount,
+			}, db.ErrInsufficientBalance
+		},
+	})
+
+	body := map[string]any{"from_account_id": fromAccount.ID, "to_account_id": toAccount.ID, "amount_cents": 250}
+	recorder := serveAs(t, server, fromAccount.OwnerID, http.MethodPost, "/transfers", body)
+	require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+
+	rsp := decodeBody[struct {
+		Error    string           `json:"error"`
+		Transfer transferResponse `json:"transfer"`
+	}](t, recorder)
+	require.Equal(t, db.ErrInsufficientBalance.Error(), rsp.Error)
+	require.Equal(t, transferID.String(), rsp.Transfer.ID)
+	require.Equal(t, string(db.TransferStatusFailed), rsp.Transfer.Status)
+	require.Equal(t, db.TransferFailureInsufficientBalance, *rsp.Transfer.FailureReason)
+}
+
+func TestGetTransferAPI(t *testing.T) {
+	fromAccount := randomAccount(t)
+	toAccount := randomAccount(t)
+	toAccount.ID = fromAccount.ID + 1
+	transfer := db.Transfer{
+		ID:            randomUUID(t),
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		AmountCents:   100,
+		Status:        db.TransferStatusPending,
+	}
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(fromAccount, toAccount),
+		getTransfer: func(id pgtype.UUID) (db.Transfer, error) {
+			if id == transfer.ID {
+				return transfer, nil
+			}
+			return db.Transfer{}, dberr.TranslateQuery("GetTransfer", pgx.ErrNoRows)
+		},
+	})
+
+	// both sides of the transfer may read it
+	for _, caller := range []pgtype.UUID{fromAccount.OwnerID, toAccount.OwnerID} {
+		recorder := serveAs(t, server, caller, http.MethodGet, "/transfers/"+transfer.ID.String(), nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, newTransferResponse(transfer), decodeBody[transferResponse](t, recorder))
+	}
+
+	recorder := serveAs(t, server, randomUUID(t), http.MethodGet, "/transfers/"+transfer.ID.String(), nil)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = serveAs(t, server, fromAccount.OwnerID, http.MethodGet, "/transfers/"+randomUUID(t).String(), nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	require.Equal(t, dberr.ErrTransferNotFound.Error(), decodeBody[map[string]string](t, recorder)["error"])
+
+	recorder = serveAs(t, server, fromAccount.OwnerID, http.MethodGet, "/transfers/123", nil)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestListTransfersAPI(t *testing.T) {
+	account := randomAccount(t)
+
+	server := newTestServer(t, &fakeStore{
+		getAccount: accountLookup(account),
+		listTransfersByAccountPage: func(arg db.ListTransfersByAccountPageParams) (db.Page[db.Transfer], error) {
+			require.Equal(t, account.ID, arg.AccountID)
+			return db.Page[db.Transfer]{
+				Items: []db.Transfer{{ID: randomUUID(t), FromAccountID: account.ID, ToAccountID: account.ID + 1}},
+			}, nil
+		},
+	})
+
+	url := fmt.Sprintf("/accounts/%d/transfers?page_size=5", account.ID)
+	recorder := serveAs(t, server, account.OwnerID, http.MethodGet, url, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Len(t, decodeBody[pageResponse[transferResponse]](t, recorder).Items, 1)
+
+	recorder = serveAs(t, server, randomUUID(t), http.MethodGet, url, nil)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
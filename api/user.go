@@ -0,0 +1,297 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/password"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// userResponse is the public view of a user; it never carries the password hash.
+type userResponse struct {
+	ID        string    `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+}
+
+func newUserResponse(user db.User) userResponse {
+	return userResponse{
+		ID:        user.ID.String(),
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt.Time,
+	}
+}
+
+type createUserRequest struct {
+	FirstName string `json:"first_name" binding:"required"`
+	LastName  string `json:"last_name" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required"`
+}
+
+func (server *Server) createUser(ctx *gin.Context) {
+	var req createUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := server.passwordPolicy.Validate(req.Password); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hashedPassword, err := server.hasher.Hash(req.Password)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(errInternal))
+		return
+	}
+
+	user, err := server.store.CreateUser(ctx, db.CreateUserParams{
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		Email:        req.Email,
+		PasswordHash: hashedPassword,
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, newUserResponse(user))
+}
+
+type userURI struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+func (uri userURI) uuid() pgtype.UUID {
+	var id pgtype.UUID
+	// binding already guarantees a well-formed UUID
+	_ = id.Scan(uri.ID)
+	return id
+}
+
+// requireSelf rejects requests for a user profile other than the caller's own.
+func requireSelf(ctx *gin.Context, uri userURI) bool {
+	if uri.uuid().Bytes != authPayload(ctx).UserID {
+		ctx.JSON(http.StatusForbidden, errorResponse(errUserNotSelf))
+		return false
+	}
+	return true
+}
+
+func (server *Server) getUser(ctx *gin.Context) {
+	var uri userURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !requireSelf(ctx, uri) {
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, uri.uuid())
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
+
+type updateUserRequest struct {
+	FirstName string `json:"first_name" binding:"required"`
+	LastName  string `json:"last_name" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+}
+
+func (server *Server) updateUser(ctx *gin.Context) {
+	var uri userURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !requireSelf(ctx, uri) {
+		return
+	}
+
+	var req updateUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.UpdateUser(ctx, db.UpdateUserParams{
+		ID:        uri.uuid(),
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Email:     req.Email,
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, userResponse{
+		ID:        user.ID.String(),
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+	})
+}
+
+type loginUserRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type loginUserResponse struct {
+	AccessToken          string       `json:"access_token"`
+	AccessTokenExpiresAt time.Time    `json:"access_token_expires_at"`
+	User                 userResponse `json:"user"`
+}
+
+var errInvalidCredentials = errors.New("invalid email or password")
+
+func (server *Server) loginUser(ctx *gin.Context) {
+	var req loginUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// Unknown email and wrong password return the same response so the
+	// endpoint cannot be used to discover registered addresses.
+	user, err := server.store.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+			return
+		}
+		handleStoreError(ctx, err)
+		return
+	}
+
+	needsRehash, err := server.hasher.Verify(req.Password, user.PasswordHash)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+		return
+	}
+
+	if needsRehash {
+		server.upgradePasswordHash(ctx, user, req.Password)
+	}
+
+	accessToken, payload, err := server.tokenMaker.CreateToken(user.ID.Bytes, server.accessTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(errInternal))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, loginUserResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: payload.ExpiredAt,
+		User:                 newUserResponse(user),
+	})
+}
+
+var errPasswordChangedConcurrently = errors.New("password changed concurrently")
+
+// upgradePasswordHash re-hashes a verified password with the current hasher configuration.
+// It is best effort: a failure is logged and never fails the login itself.
+func (server *Server) upgradePasswordHash(ctx *gin.Context, user db.User, plainPassword string) {
+	newHash, err := server.hasher.Hash(plainPassword)
+	if err != nil {
+		log.Printf("cannot rehash password for user %s: %v", user.ID.String(), err)
+		return
+	}
+
+	_, err = server.store.ChangePasswordTx(ctx, db.ChangePasswordTxParams{
+		UserID:          user.ID,
+		NewPasswordHash: newHash,
+		VerifyCurrent: func(currentHash string) error {
+			if currentHash != user.PasswordHash {
+				return errPasswordChangedConcurrently
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		log.Printf("cannot store upgraded password hash for user %s: %v", user.ID.String(), err)
+	}
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+var errWrongCurrentPassword = errors.New("current password is incorrect")
+
+func (server *Server) changePassword(ctx *gin.Context) {
+	var uri userURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !requireSelf(ctx, uri) {
+		return
+	}
+
+	var req changePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.NewPassword == req.CurrentPassword {
+		ctx.JSON(http.StatusBadRequest, errorResponse(password.ErrSameAsPrevious))
+		return
+	}
+
+	if err := server.passwordPolicy.Validate(req.NewPassword); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	newHash, err := server.hasher.Hash(req.NewPassword)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(errInternal))
+		return
+	}
+
+	user, err := server.store.ChangePasswordTx(ctx, db.ChangePasswordTxParams{
+		UserID:          uri.uuid(),
+		NewPasswordHash: newHash,
+		VerifyCurrent: func(currentHash string) error {
+			if _, err := server.hasher.Verify(req.CurrentPassword, currentHash); err != nil {
+				return errWrongCurrentPassword
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		if errors.Is(err, errWrongCurrentPassword) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
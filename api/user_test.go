@@ -0,0 +1,295 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/RakibRahman/fincore-api/db/dberr"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	passwordpkg "github.com/RakibRahman/fincore-api/password"
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func randomUser(t *testing.T) db.User {
+	return db.User{
+		ID:           randomUUID(t),
+		FirstName:    utils.RandomString(6),
+		LastName:     utils.RandomString(4),
+		Email:        utils.RandomEmail(),
+		PasswordHash: utils.RandomString(20),
+	}
+}
+
+// randomPassword returns a password that satisfies password.DefaultPolicy.
+func randomPassword() string {
+	return "Aa1" + utils.RandomString(10)
+}
+
+func TestCreateUserAPI(t *testing.T) {
+	user := randomUser(t)
+	password := randomPassword()
+
+	testCases := []struct {
+		name       string
+		body       map[string]any
+		createUser func(arg db.CreateUserParams) (db.User, error)
+		wantStatus int
+	}{
+		{
+			name: "OK",
+			body: map[string]any{"first_name": user.FirstName, "last_name": user.LastName, "email": user.Email, "password": password},
+			createUser: func(arg db.CreateUserParams) (db.User, error) {
+				require.Equal(t, user.Email, arg.Email)
+				require.NotEqual(t, password, arg.PasswordHash)
+				require.NotEmpty(t, arg.PasswordHash)
+				return user, nil
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "DuplicateEmail",
+			body: map[string]any{"first_name": user.FirstName, "last_name": user.LastName, "email": user.Email, "password": password},
+			createUser: func(arg db.CreateUserParams) (db.User, error) {
+				return db.User{}, dberr.Translate(&pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"})
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "InvalidEmail",
+			body:       map[string]any{"first_name": user.FirstName, "last_name": user.LastName, "email": "invalid", "password": password},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "ShortPassword",
+			body:       map[string]any{"first_name": user.FirstName, "last_name": user.LastName, "email": user.Email, "password": "Sh0rt"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "WeakPassword",
+			body:       map[string]any{"first_name": user.FirstName, "last_name": user.LastName, "email": user.Email, "password": utils.RandomString(12)},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{createUser: tc.createUser})
+			recorder := serve(t, server, http.MethodPost, "/users", tc.body)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code == http.StatusCreated {
+				require.Equal(t, newUserResponse(user), decodeBody[userResponse](t, recorder))
+				require.NotContains(t, recorder.Body.String(), user.PasswordHash)
+			} else {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
+
+func TestUpdateUserAPI(t *testing.T) {
+	user := randomUser(t)
+	newEmail := utils.RandomEmail()
+
+	server := newTestServer(t, &fakeStore{
+		updateUser: func(arg db.UpdateUserParams) (db.UpdateUserRow, error) {
+			require.Equal(t, user.ID, arg.ID)
+			return db.UpdateUserRow{ID: arg.ID, FirstName: arg.FirstName, LastName: arg.LastName, Email: arg.Email}, nil
+		},
+	})
+
+	body := map[string]any{"first_name": user.FirstName, "last_name": user.LastName, "email": newEmail}
+	recorder := serveAs(t, server, user.ID, http.MethodPut, "/users/"+user.ID.String(), body)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, newEmail, decodeBody[userResponse](t, recorder).Email)
+
+	recorder = serveAs(t, server, randomUUID(t), http.MethodPut, "/users/"+user.ID.String(), body)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	requireErrorBody(t, recorder)
+
+	recorder = serveAs(t, server, user.ID, http.MethodPut, "/users/not-a-uuid", body)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	requireErrorBody(t, recorder)
+}
+
+func TestLoginUserAPI(t *testing.T) {
+	password := randomPassword()
+	hasher, err := passwordpkg.NewBcryptHasher(bcrypt.MinCost)
+	require.NoError(t, err)
+	hashedPassword, err := hasher.Hash(password)
+	require.NoError(t, err)
+
+	user := randomUser(t)
+	user.PasswordHash = hashedPassword
+
+	getUserByEmail := func(email string) (db.User, error) {
+		if email == user.Email {
+			return user, nil
+		}
+		return db.User{}, pgx.ErrNoRows
+	}
+
+	testCases := []struct {
+		name       string
+		body       map[string]any
+		wantStatus int
+	}{
+		{
+			name:       "OK",
+			body:       map[string]any{"email": user.Email, "password": password},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "WrongPassword",
+			body:       map[string]any{"email": user.Email, "password": "wrong-password"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "UnknownEmail",
+			body:       map[string]any{"email": utils.RandomEmail(), "password": password},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "InvalidEmail",
+			body:       map[string]any{"email": "invalid", "password": password},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				getUserByEmail: getUserByEmail,
+				changePassword: func(arg db.ChangePasswordTxParams) (db.User, error) {
+					t.Fatal("hash with current parameters must not be upgraded")
+					return db.User{}, nil
+				},
+			})
+			recorder := serve(t, server, http.MethodPost, "/users/login", tc.body)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code != http.StatusOK {
+				requireErrorBody(t, recorder)
+				return
+			}
+
+			body := decodeBody[loginUserResponse](t, recorder)
+			require.Equal(t, newUserResponse(user), body.User)
+
+			payload, err := server.tokenMaker.VerifyToken(body.AccessToken)
+			require.NoError(t, err)
+			require.Equal(t, user.ID.Bytes, [16]byte(payload.UserID))
+		})
+	}
+}
+
+func TestLoginUserAPIUpgradesHash(t *testing.T) {
+	password := randomPassword()
+	// hashed with a cost the server is not configured for
+	legacyHasher, err := passwordpkg.NewBcryptHasher(bcrypt.MinCost + 1)
+	require.NoError(t, err)
+	legacyHash, err := legacyHasher.Hash(password)
+	require.NoError(t, err)
+
+	user := randomUser(t)
+	user.PasswordHash = legacyHash
+
+	var upgraded bool
+	server := newTestServer(t, &fakeStore{
+		getUserByEmail: func(email string) (db.User, error) {
+			return user, nil
+		},
+		changePassword: func(arg db.ChangePasswordTxParams) (db.User, error) {
+			require.Equal(t, user.ID, arg.UserID)
+			require.NotEqual(t, legacyHash, arg.NewPasswordHash)
+			require.NoError(t, arg.VerifyCurrent(legacyHash))
+			require.Error(t, arg.VerifyCurrent("$2a$changed"))
+
+			needsRehash, err := legacyHasher.Verify(password, arg.NewPasswordHash)
+			require.NoError(t, err)
+			require.True(t, needsRehash)
+
+			upgraded = true
+			return user, nil
+		},
+	})
+
+	recorder := serve(t, server, http.MethodPost, "/users/login", map[string]any{"email": user.Email, "password": password})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.True(t, upgraded)
+}
+
+func TestChangePasswordAPI(t *testing.T) {
+	currentPassword := randomPassword()
+	hasher, err := passwordpkg.NewBcryptHasher(bcrypt.MinCost)
+	require.NoError(t, err)
+	currentHash, err := hasher.Hash(currentPassword)
+	require.NoError(t, err)
+
+	user := randomUser(t)
+	user.PasswordHash = currentHash
+	newPassword := randomPassword()
+
+	testCases := []struct {
+		name       string
+		caller     pgtype.UUID
+		body       map[string]any
+		wantStatus int
+	}{
+		{
+			name:       "OK",
+			caller:     user.ID,
+			body:       map[string]any{"current_password": currentPassword, "new_password": newPassword},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "WrongCurrentPassword",
+			caller:     user.ID,
+			body:       map[string]any{"current_password": randomPassword(), "new_password": newPassword},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "WeakNewPassword",
+			caller:     user.ID,
+			body:       map[string]any{"current_password": currentPassword, "new_password": "weak"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "SamePassword",
+			caller:     user.ID,
+			body:       map[string]any{"current_password": currentPassword, "new_password": currentPassword},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "OtherUser",
+			caller:     randomUUID(t),
+			body:       map[string]any{"current_password": currentPassword, "new_password": newPassword},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				changePassword: func(arg db.ChangePasswordTxParams) (db.User, error) {
+					require.Equal(t, user.ID, arg.UserID)
+					if err := arg.VerifyCurrent(user.PasswordHash); err != nil {
+						return db.User{}, err
+					}
+					_, err := hasher.Verify(newPassword, arg.NewPasswordHash)
+					require.NoError(t, err)
+					return user, nil
+				},
+			})
+
+			recorder := serveAs(t, server, tc.caller, http.MethodPut, "/users/"+user.ID.String()+"/password", tc.body)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code != http.StatusOK {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/go-playground/validator/v10"
+)
+
+var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if currency, ok := fieldLevel.Field().Interface().(string); ok {
+		return isSupportedCurrency(currency)
+	}
+	return false
+}
+
+func isSupportedCurrency(currency string) bool {
+	switch db.Currency(currency) {
+	case db.CurrencyUSD, db.CurrencyEUR, db.CurrencyGBP, db.CurrencyBDT, db.CurrencyINR:
+		return true
+	}
+	return false
+}
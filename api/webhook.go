@@ -0,0 +1,279 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/webhook"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	errWebhookNotOwned      = errors.New("webhook endpoint doesn't belong to the authenticated user")
+	errWebhookNotActive     = errors.New("webhook endpoint is deactivated")
+	errDeliveryWrongWebhook = errors.New("webhook delivery doesn't belong to this endpoint")
+	errDeliveryPending      = errors.New("webhook delivery is still pending")
+)
+
+type webhookEndpointResponse struct {
+	ID         string    `json:"id"`
+	Url        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at,omitzero"`
+	// Secret is only returned when the endpoint is created.
+	Secret string `json:"secret,omitempty"`
+}
+
+func newWebhookEndpointResponse(endpoint db.WebhookEndpoint) webhookEndpointResponse {
+	eventTypes := endpoint.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return webhookEndpointResponse{
+		ID:         endpoint.ID.String(),
+		Url:        endpoint.Url,
+		EventTypes: eventTypes,
+		Active:     endpoint.Active,
+		CreatedAt:  endpoint.CreatedAt.Time,
+	}
+}
+
+type webhookDeliveryResponse struct {
+	ID             int64      `json:"id"`
+	EventID        int64      `json:"event_id"`
+	Status         string     `json:"status"`
+	Attempts       int32      `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastStatusCode *int32     `json:"last_status_code,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at,omitzero"`
+}
+
+func newWebhookDeliveryResponse(delivery db.WebhookDelivery) webhookDeliveryResponse {
+	rsp := webhookDeliveryResponse{
+		ID:            delivery.ID,
+		EventID:       delivery.EventID,
+		Status:        string(delivery.Status),
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt.Time,
+		CreatedAt:     delivery.CreatedAt.Time,
+	}
+	if delivery.LastStatusCode.Valid {
+		rsp.LastStatusCode = &delivery.LastStatusCode.Int32
+	}
+	if delivery.LastError.Valid {
+		rsp.LastError = &delivery.LastError.String
+	}
+	if delivery.DeliveredAt.Valid {
+		rsp.DeliveredAt = &delivery.DeliveredAt.Time
+	}
+	return rsp
+}
+
+type createWebhookEndpointRequest struct {
+	// Url must be https and resolve only to public addresses, so that deliveries can't
+	// be aimed at internal services
+	Url string `json:"url" binding:"required,http_url"`
+	// EventTypes limits the endpoint to some event types; empty subscribes it to all of them.
+	EventTypes []string `json:"event_types" binding:"omitempty,unique,dive,oneof=deposit.completed withdrawal.completed transfer.completed transfer.failed transfer.reversed overdraft.charged"`
+}
+
+func (server *Server) createWebhookEndpoint(ctx *gin.Context) {
+	var req createWebhookEndpointRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := webhook.CheckURL(ctx, req.Url, server.resolver); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(errInternal))
+		return
+	}
+
+	eventTypes := req.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	endpoint, err := server.store.CreateWebhookEndpoint(ctx, db.CreateWebhookEndpointParams{
+		OwnerID:    pgtype.UUID{Bytes: authPayload(ctx).UserID, Valid: true},
+		Url:        req.Url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	// The secret is never shown again; receivers need it to verify signatures
+	rsp := newWebhookEndpointResponse(endpoint)
+	rsp.Secret = endpoint.Secret
+	ctx.JSON(http.StatusCreated, rsp)
+}
+
+func (server *Server) listWebhookEndpoints(ctx *gin.Context) {
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	endpoints, err := server.store.ListWebhookEndpointsByOwnerPage(ctx, db.ListWebhookEndpointsByOwnerPageParams{
+		OwnerID: pgtype.UUID{Bytes: authPayload(ctx).UserID, Valid: true},
+		Cursor:  req.Cursor,
+		Limit:   req.PageSize,
+	})
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPageResponse(endpoints, newWebhookEndpointResponse))
+}
+
+type webhookEndpointURI struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// authorizedWebhookEndpoint loads the endpoint named in the URI and verifies that the
+// caller owns it. On failure the error response is already written.
+func (server *Server) authorizedWebhookEndpoint(ctx *gin.Context) (db.WebhookEndpoint, bool) {
+	var uri webhookEndpointURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.WebhookEndpoint{}, false
+	}
+
+	var id pgtype.UUID
+	_ = id.Scan(uri.ID)
+
+	endpoint, err := server.store.GetWebhookEndpoint(ctx, id)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return endpoint, false
+	}
+
+	if endpoint.OwnerID.Bytes != authPayload(ctx).UserID {
+		ctx.JSON(http.StatusForbidden, errorResponse(errWebhookNotOwned))
+		return endpoint, false
+	}
+	return endpoint, true
+}
+
+func (server *Server) getWebhookEndpoint(ctx *gin.Context) {
+	endpoint, ok := server.authorizedWebhookEndpoint(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newWebhookEndpointResponse(endpoint))
+}
+
+// deactivateWebhookEndpoint stops deliveries to the endpoint. Pending ones are
+// dead-lettered by the dispatcher on their next attempt.
+func (server *Server) deactivateWebhookEndpoint(ctx *gin.Context) {
+	endpoint, ok := server.authorizedWebhookEndpoint(ctx)
+	if !ok {
+		return
+	}
+
+	endpoint, err := server.store.DeactivateWebhookEndpoint(ctx, endpoint.ID)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newWebhookEndpointResponse(endpoint))
+}
+
+type listWebhookDeliveriesRequest struct {
+	pageRequest
+	Status string `form:"status" binding:"omitempty,oneof=pending delivered dead"`
+}
+
+func (server *Server) listWebhookDeliveries(ctx *gin.Context) {
+	var req listWebhookDeliveriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	endpoint, ok := server.authorizedWebhookEndpoint(ctx)
+	if !ok {
+		return
+	}
+
+	arg := db.ListWebhookDeliveriesByEndpointPageParams{
+		EndpointID: endpoint.ID,
+		Cursor:     req.Cursor,
+		Limit:      req.PageSize,
+	}
+	if req.Status != "" {
+		arg.Status = db.NullWebhookDeliveryStatus{WebhookDeliveryStatus: db.WebhookDeliveryStatus(req.Status), Valid: true}
+	}
+
+	deliveries, err := server.store.ListWebhookDeliveriesByEndpointPage(ctx, arg)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPageResponse(deliveries, newWebhookDeliveryResponse))
+}
+
+type webhookDeliveryURI struct {
+	DeliveryID int64 `uri:"delivery_id" binding:"required,min=1"`
+}
+
+// redeliverWebhookDelivery queues a delivered or dead delivery to be sent again with a
+// fresh set of attempts.
+func (server *Server) redeliverWebhookDelivery(ctx *gin.Context) {
+	var uri webhookDeliveryURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	endpoint, ok := server.authorizedWebhookEndpoint(ctx)
+	if !ok {
+		return
+	}
+	if !endpoint.Active {
+		ctx.JSON(http.StatusConflict, errorResponse(errWebhookNotActive))
+		return
+	}
+
+	delivery, err := server.store.GetWebhookDelivery(ctx, uri.DeliveryID)
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+	if delivery.EndpointID != endpoint.ID {
+		ctx.JSON(http.StatusNotFound, errorResponse(errDeliveryWrongWebhook))
+		return
+	}
+
+	delivery, err = server.store.RedeliverWebhookDelivery(ctx, delivery.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		ctx.JSON(http.StatusConflict, errorResponse(errDeliveryPending))
+		return
+	}
+	if err != nil {
+		handleStoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newWebhookDeliveryResponse(delivery))
+}
@@ -0,0 +1,287 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func randomWebhookEndpoint(t *testing.T) db.WebhookEndpoint {
+	return db.WebhookEndpoint{
+		ID:         randomUUID(t),
+		OwnerID:    randomUUID(t),
+		Url:        "https://example.com/hooks",
+		Secret:     "whsec_test",
+		EventTypes: []string{},
+		Active:     true,
+	}
+}
+
+// webhookEndpointLookup stubs GetWebhookEndpoint over a fixed set of endpoints.
+func webhookEndpointLookup(endpoints ...db.WebhookEndpoint) func(id pgtype.UUID) (db.WebhookEndpoint, error) {
+	return func(id pgtype.UUID) (db.WebhookEndpoint, error) {
+		for _, endpoint := range endpoints {
+			if endpoint.ID == id {
+				return endpoint, nil
+			}
+		}
+		return db.WebhookEndpoint{}, pgx.ErrNoRows
+	}
+}
+
+func TestCreateWebhookEndpointAPI(t *testing.T) {
+	caller := randomUUID(t)
+
+	testCases := []struct {
+		name           string
+		body           map[string]any
+		wantEventTypes []string
+		wantStatus     int
+	}{
+		{
+			name:           "AllEvents",
+			body:           map[string]any{"url": "https://example.com/hooks"},
+			wantEventTypes: []string{},
+			wantStatus:     http.StatusCreated,
+		},
+		{
+			name:           "SomeEvents",
+			body:           map[string]any{"url": "https://example.com/hooks", "event_types": []string{"transfer.completed", "transfer.failed"}},
+			wantEventTypes: []string{"transfer.completed", "transfer.failed"},
+			wantStatus:     http.StatusCreated,
+		},
+		{
+			name:       "InvalidURL",
+			body:       map[string]any{"url": "example.com"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "PlainHTTP",
+			body:       map[string]any{"url": "http://example.com/hooks"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "MetadataAddress",
+			body:       map[string]any{"url": "https://169.254.169.254/latest/meta-data"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Loopback",
+			body:       map[string]any{"url": "https://localhost:5433"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "UnknownEventType",
+			body:       map[string]any{"url": "https://example.com/hooks", "event_types": []string{"account.opened"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "DuplicateEventType",
+			body:       map[string]any{"url": "https://example.com/hooks", "event_types": []string{"transfer.failed", "transfer.failed"}},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				createWebhookEndpoint: func(arg db.CreateWebhookEndpointParams) (db.WebhookEndpoint, error) {
+					require.Equal(t, caller, arg.OwnerID)
+					require.Equal(t, "https://example.com/hooks", arg.Url)
+					require.Equal(t, tc.wantEventTypes, arg.EventTypes)
+					require.True(t, strings.HasPrefix(arg.Secret, "whsec_"))
+
+					endpoint := randomWebhookEndpoint(t)
+					endpoint.OwnerID = arg.OwnerID
+					endpoint.Secret = arg.Secret
+					endpoint.EventTypes = arg.EventTypes
+					return endpoint, nil
+				},
+			})
+			recorder := serveAs(t, server, caller, http.MethodPost, "/webhooks", tc.body)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code == http.StatusCreated {
+				body := decodeBody[webhookEndpointResponse](t, recorder)
+				require.Equal(t, tc.wantEventTypes, body.EventTypes)
+				require.True(t, strings.HasPrefix(body.Secret, "whsec_"))
+			} else {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
+
+func TestGetWebhookEndpointAPI(t *testing.T) {
+	endpoint := randomWebhookEndpoint(t)
+
+	testCases := []struct {
+		name       string
+		endpointID pgtype.UUID
+		caller     pgtype.UUID
+		wantStatus int
+	}{
+		{name: "OK", endpointID: endpoint.ID, caller: endpoint.OwnerID, wantStatus: http.StatusOK},
+		{name: "NotOwner", endpointID: endpoint.ID, caller: randomUUID(t), wantStatus: http.StatusForbidden},
+		{name: "NotFound", endpointID: randomUUID(t), caller: endpoint.OwnerID, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{getWebhookEndpoint: webhookEndpointLookup(endpoint)})
+			recorder := serveAs(t, server, tc.caller, http.MethodGet, fmt.Sprintf("/webhooks/%s", tc.endpointID), nil)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code == http.StatusOK {
+				body := decodeBody[webhookEndpointResponse](t, recorder)
+				require.Equal(t, endpoint.Url, body.Url)
+				// The secret is only shown on creation
+				require.Empty(t, body.Secret)
+			} else {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
+
+func TestDeactivateWebhookEndpointAPI(t *testing.T) {
+	endpoint := randomWebhookEndpoint(t)
+
+	server := newTestServer(t, &fakeStore{
+		getWebhookEndpoint: webhookEndpointLookup(endpoint),
+		deactivateWebhookEndpoint: func(id pgtype.UUID) (db.WebhookEndpoint, error) {
+			require.Equal(t, endpoint.ID, id)
+			deactivated := endpoint
+			deactivated.Active = false
+			return deactivated, nil
+		},
+	})
+
+	recorder := serveAs(t, server, randomUUID(t), http.MethodDelete, fmt.Sprintf("/webhooks/%s", endpoint.ID), nil)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = serveAs(t, server, endpoint.OwnerID, http.MethodDelete, fmt.Sprintf("/webhooks/%s", endpoint.ID), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.False(t, decodeBody[webhookEndpointResponse](t, recorder).Active)
+}
+
+func TestListWebhookDeliveriesAPI(t *testing.T) {
+	endpoint := randomWebhookEndpoint(t)
+	dead := db.WebhookDelivery{
+		ID:             3,
+		EventID:        9,
+		EndpointID:     endpoint.ID,
+		Status:         db.WebhookDeliveryStatusDead,
+		Attempts:       8,
+		LastStatusCode: pgtype.Int4{Int32: 500, Valid: true},
+		LastError:      pgtype.Text{String: "unexpected response status 500", Valid: true},
+	}
+
+	testCases := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantFilter db.NullWebhookDeliveryStatus
+	}{
+		{name: "All", query: "page_size=5", wantStatus: http.StatusOK},
+		{
+			name:       "Dead",
+			query:      "cursor=next&page_size=5&status=dead",
+			wantStatus: http.StatusOK,
+			wantFilter: db.NullWebhookDeliveryStatus{WebhookDeliveryStatus: db.WebhookDeliveryStatusDead, Valid: true},
+		},
+		{name: "UnknownStatus", query: "page_size=5&status=lost", wantStatus: http.StatusBadRequest},
+		{name: "MissingPageSize", query: "cursor=next", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				getWebhookEndpoint: webhookEndpointLookup(endpoint),
+				listWebhookDeliveriesByEndpointPage: func(arg db.ListWebhookDeliveriesByEndpointPageParams) (db.Page[db.WebhookDelivery], error) {
+					require.Equal(t, endpoint.ID, arg.EndpointID)
+					require.Equal(t, tc.wantFilter, arg.Status)
+					require.Equal(t, int32(5), arg.Limit)
+					return db.Page[db.WebhookDelivery]{Items: []db.WebhookDelivery{dead}}, nil
+				},
+			})
+			url := fmt.Sprintf("/webhooks/%s/deliveries?%s", endpoint.ID, tc.query)
+			recorder := serveAs(t, server, endpoint.OwnerID, http.MethodGet, url, nil)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code == http.StatusOK {
+				body := decodeBody[pageResponse[webhookDeliveryResponse]](t, recorder).Items
+				require.Len(t, body, 1)
+				require.Equal(t, "dead", body[0].Status)
+				require.Equal(t, int32(500), *body[0].LastStatusCode)
+			} else {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
+
+func TestRedeliverWebhookDeliveryAPI(t *testing.T) {
+	endpoint := randomWebhookEndpoint(t)
+	inactive := randomWebhookEndpoint(t)
+	inactive.OwnerID = endpoint.OwnerID
+	inactive.Active = false
+
+	deliveries := map[int64]db.WebhookDelivery{
+		1: {ID: 1, EndpointID: endpoint.ID, Status: db.WebhookDeliveryStatusDead, Attempts: 8},
+		2: {ID: 2, EndpointID: endpoint.ID, Status: db.WebhookDeliveryStatusPending, Attempts: 1},
+		3: {ID: 3, EndpointID: randomUUID(t), Status: db.WebhookDeliveryStatusDead, Attempts: 8},
+	}
+
+	testCases := []struct {
+		name       string
+		endpointID pgtype.UUID
+		deliveryID int64
+		caller     pgtype.UUID
+		wantStatus int
+	}{
+		{name: "OK", endpointID: endpoint.ID, deliveryID: 1, caller: endpoint.OwnerID, wantStatus: http.StatusOK},
+		{name: "NotOwner", endpointID: endpoint.ID, deliveryID: 1, caller: randomUUID(t), wantStatus: http.StatusForbidden},
+		{name: "StillPending", endpointID: endpoint.ID, deliveryID: 2, caller: endpoint.OwnerID, wantStatus: http.StatusConflict},
+		{name: "OtherEndpoint", endpointID: endpoint.ID, deliveryID: 3, caller: endpoint.OwnerID, wantStatus: http.StatusNotFound},
+		{name: "UnknownDelivery", endpointID: endpoint.ID, deliveryID: 4, caller: endpoint.OwnerID, wantStatus: http.StatusNotFound},
+		{name: "EndpointDeactivated", endpointID: inactive.ID, deliveryID: 1, caller: endpoint.OwnerID, wantStatus: http.StatusConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fakeStore{
+				getWebhookEndpoint: webhookEndpointLookup(endpoint, inactive),
+				getWebhookDelivery: func(id int64) (db.WebhookDelivery, error) {
+					delivery, ok := deliveries[id]
+					if !ok {
+						return db.WebhookDelivery{}, pgx.ErrNoRows
+					}
+					return delivery, nil
+				},
+				redeliverWebhookDelivery: func(id int64) (db.WebhookDelivery, error) {
+					delivery := deliveries[id]
+					if delivery.Status == db.WebhookDeliveryStatusPending {
+						return db.WebhookDelivery{}, pgx.ErrNoRows
+					}
+					delivery.Status = db.WebhookDeliveryStatusPending
+					delivery.Attempts = 0
+					return delivery, nil
+				},
+			})
+			url := fmt.Sprintf("/webhooks/%s/deliveries/%d/redeliver", tc.endpointID, tc.deliveryID)
+			recorder := serveAs(t, server, tc.caller, http.MethodPost, url, nil)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if recorder.Code == http.StatusOK {
+				body := decodeBody[webhookDeliveryResponse](t, recorder)
+				require.Equal(t, "pending", body.Status)
+				require.Zero(t, body.Attempts)
+			} else {
+				requireErrorBody(t, recorder)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Command reconcile checks account balances against transaction history and the ledger,
+// and prints the report as JSON. It exits with status 2 when drift is found.
+//
+// Usage:
+//
+//	reconcile [-config file] [-accounts 1,2,3] [-batch 500] [-open-incidents] [-freeze]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/RakibRahman/fincore-api/config"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/reconcile"
+)
+
+// parseAccountIDs parses a comma-separated list of account IDs.
+func parseAccountIDs(value string) ([]int64, error) {
+	var ids []int64
+	for field := range strings.SplitSeq(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil || id < 1 {
+			return nil, fmt.Errorf("invalid account id %q", field)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func main() {
+	accounts := flag.String("accounts", "", "comma-separated account IDs to check; all accounts when empty")
+	batchSize := flag.Int("batch", 500, "accounts read per query")
+	openIncidents := flag.Bool("open-incidents", false, "record each drift as a reconciliation incident")
+	freeze := flag.Bool("freeze", false, "freeze accounts with drift")
+	configFile := flag.String("config", "", "file of KEY=VALUE settings; the environment overrides it (default $CONFIG_FILE)")
+	flag.Parse()
+
+	accountIDs, err := parseAccountIDs(*accounts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal("invalid config: ", err)
+	}
+
+	pool, err := cfg.NewPool(context.Background())
+	if err != nil {
+		log.Fatal("cannot connect to db:", err)
+	}
+	defer pool.Close()
+
+	report, err := reconcile.Run(context.Background(), db.NewStore(pool), reconcile.Options{
+		AccountIDs:     accountIDs,
+		BatchSize:      *batchSize,
+		OpenIncidents:  *openIncidents,
+		FreezeAccounts: *freeze,
+	})
+	if err != nil {
+		log.Fatal("reconciliation failed:", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatal(err)
+	}
+
+	if !report.Clean() {
+		pool.Close()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,81 @@
+// Command scheduler makes the transfers of due transfer schedules and releases expired
+// holds. Any number of instances may run against the same database; each schedule is
+// claimed by one of them at a time.
+//
+// Usage:
+//
+//	scheduler [-config file] [-interval 1m] [-once] [-batch 100] [-lease 5m] [-retry-delay 1h]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/config"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/scheduler"
+)
+
+func main() {
+	interval := flag.Duration("interval", time.Minute, "time between passes over the due schedules")
+	once := flag.Bool("once", false, "make a single pass, print its report as JSON and exit")
+	batchSize := flag.Int("batch", 100, "schedules claimed per query")
+	lease := flag.Duration("lease", 5*time.Minute, "how long a claimed schedule stays with this instance")
+	retryDelay := flag.Duration("retry-delay", time.Hour, "wait before retrying a transfer refused for insufficient funds")
+	configFile := flag.String("config", "", "file of KEY=VALUE settings; the environment overrides it (default $CONFIG_FILE)")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal("invalid config: ", err)
+	}
+
+	pool, err := cfg.NewPool(ctx)
+	if err != nil {
+		log.Fatal("cannot connect to db:", err)
+	}
+	defer pool.Close()
+
+	store := db.NewStore(pool)
+	opts := scheduler.Options{
+		BatchSize:  *batchSize,
+		Lease:      *lease,
+		RetryDelay: *retryDelay,
+	}
+
+	if *once {
+		report, err := scheduler.RunDue(ctx, store, time.Now(), opts)
+		if err != nil {
+			log.Fatal("scheduler pass failed:", err)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	err = scheduler.Run(ctx, store, *interval, opts, func(report scheduler.Report, err error) {
+		if err != nil {
+			log.Println("scheduler pass failed:", err)
+			return
+		}
+		if report != (scheduler.Report{}) {
+			log.Printf("scheduler pass: %+v", report)
+		}
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
+}
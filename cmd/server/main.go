@@ -0,0 +1,89 @@
+// Command server serves the HTTP and gRPC APIs. With the migrate subcommand it applies
+// the embedded schema migrations instead.
+//
+// Usage:
+//
+//	server [-config file]
+//	server [-config file] migrate up | down [N] | goto VERSION | force VERSION | status
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/RakibRahman/fincore-api/api"
+	"github.com/RakibRahman/fincore-api/config"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/gapi"
+	"github.com/RakibRahman/fincore-api/password"
+	"github.com/RakibRahman/fincore-api/token"
+)
+
+// newTokenMaker picks the token implementation named by TOKEN_TYPE.
+func newTokenMaker(tokenType, symmetricKey string) (token.Maker, error) {
+	if tokenType == "jwt" {
+		return token.NewJWTMaker(symmetricKey)
+	}
+	return token.NewPasetoMaker(symmetricKey)
+}
+
+func main() {
+	configFile := flag.String("config", "", "file of KEY=VALUE settings; the environment overrides it (default $CONFIG_FILE)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal("invalid config: ", err)
+	}
+	log.Printf("config: %v", cfg)
+
+	pool, err := cfg.NewPool(context.Background())
+	if err != nil {
+		log.Fatal("cannot connect to db:", err)
+	}
+	defer pool.Close()
+
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(context.Background(), pool, flag.Args()[1:]); err != nil {
+			log.Fatal("migrate: ", err)
+		}
+		return
+	}
+
+	tokenMaker, err := newTokenMaker(cfg.TokenType, cfg.TokenSymmetricKey)
+	if err != nil {
+		log.Fatal("cannot create token maker:", err)
+	}
+
+	// Existing hashes made with other parameters keep verifying and are upgraded on the
+	// user's next login
+	hasher, err := password.NewArgon2idHasher(cfg.PasswordParams())
+	if err != nil {
+		log.Fatal("cannot create password hasher:", err)
+	}
+
+	// TX_ISOLATION=serializable runs every store transaction under SERIALIZABLE,
+	// retrying the ones that lose a conflict
+	txPolicy := db.DefaultTxPolicy
+	if cfg.TxIsolation == "serializable" {
+		txPolicy = db.SerializableTxPolicy
+	}
+
+	store := db.NewStore(pool, db.AllowFrozenCredits(cfg.AllowFrozenAccountCredits), db.WithTxPolicy(txPolicy))
+	server := api.NewServer(store, tokenMaker, hasher, cfg.AccessTokenDuration)
+	grpcServer := gapi.NewServer(store, tokenMaker, hasher, cfg.AccessTokenDuration)
+
+	go func() {
+		log.Printf("starting gRPC server on %s", cfg.GRPCServerAddress)
+		if err := grpcServer.Start(cfg.GRPCServerAddress); err != nil {
+			log.Fatal("cannot start gRPC server:", err)
+		}
+	}()
+
+	log.Printf("starting HTTP server on %s", cfg.ServerAddress)
+	timeouts := api.Timeouts{Read: cfg.HTTPReadTimeout, Write: cfg.HTTPWriteTimeout, Idle: cfg.HTTPIdleTimeout}
+	if err := server.Start(cfg.ServerAddress, timeouts); err != nil {
+		log.Fatal("cannot start server:", err)
+	}
+}
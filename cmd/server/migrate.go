@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/RakibRahman/fincore-api/db/migration"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const migrateUsage = "usage: server migrate up | down [N] | goto VERSION | force VERSION | status"
+
+// runMigrate runs the migrate subcommand on the database of pool.
+func runMigrate(ctx context.Context, pool *pgxpool.Pool, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	migrator := migration.New(pool)
+	migrator.Logf = log.Printf
+
+	switch command, args := args[0], args[1:]; {
+	case command == "up" && len(args) == 0:
+		return migrator.Up(ctx)
+	case command == "down" && len(args) <= 1:
+		steps := 1
+		if len(args) == 1 {
+			var err error
+			if steps, err = strconv.Atoi(args[0]); err != nil {
+				return fmt.Errorf("invalid number of migrations %q", args[0])
+			}
+		}
+		return migrator.Down(ctx, steps)
+	case command == "goto" && len(args) == 1:
+		version, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		return migrator.Goto(ctx, uint(version))
+	case command == "force" && len(args) == 1:
+		version, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		return migrator.Force(ctx, uint(version))
+	case command == "status" && len(args) == 0:
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		dirty := ""
+		if status.Dirty {
+			dirty = " (dirty)"
+		}
+		fmt.Printf("version %d%s, latest %d\n", status.Version, dirty, status.Latest)
+		for _, pending := range status.Pending {
+			fmt.Printf("pending %d_%s\n", pending.Version, pending.Name)
+		}
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
@@ -0,0 +1,85 @@
+// Command webhooks delivers ledger events from the outbox to registered webhook endpoints.
+// Any number of instances may run against the same database; each event and delivery is
+// claimed by one of them at a time.
+//
+// Usage:
+//
+//	webhooks [-config file] [-interval 5s] [-once] [-batch 20] [-timeout 10s] [-max-attempts 8] [-backoff 30s] [-max-backoff 6h]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/config"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/webhook"
+)
+
+func main() {
+	interval := flag.Duration("interval", 5*time.Second, "time between passes over the outbox")
+	once := flag.Bool("once", false, "make a single pass, print its report as JSON and exit")
+	batchSize := flag.Int("batch", 20, "events and deliveries claimed per query")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of each webhook request")
+	maxAttempts := flag.Int("max-attempts", 8, "failed attempts before a delivery is dead-lettered")
+	baseBackoff := flag.Duration("backoff", 30*time.Second, "wait after the first failed attempt, doubled after each further one")
+	maxBackoff := flag.Duration("max-backoff", 6*time.Hour, "longest wait between attempts")
+	configFile := flag.String("config", "", "file of KEY=VALUE settings; the environment overrides it (default $CONFIG_FILE)")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal("invalid config: ", err)
+	}
+
+	pool, err := cfg.NewPool(ctx)
+	if err != nil {
+		log.Fatal("cannot connect to db:", err)
+	}
+	defer pool.Close()
+
+	store := db.NewStore(pool)
+	opts := webhook.Options{
+		BatchSize:   *batchSize,
+		Timeout:     *timeout,
+		MaxAttempts: *maxAttempts,
+		BaseBackoff: *baseBackoff,
+		MaxBackoff:  *maxBackoff,
+	}
+
+	if *once {
+		report, err := webhook.RunDue(ctx, store, time.Now(), opts)
+		if err != nil {
+			log.Fatal("webhook pass failed:", err)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	err = webhook.Run(ctx, store, *interval, opts, func(report webhook.Report, err error) {
+		if err != nil {
+			log.Println("webhook pass failed:", err)
+			return
+		}
+		if report != (webhook.Report{}) {
+			log.Printf("webhook pass: %+v", report)
+		}
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,214 @@
+// Package dberr translates PostgreSQL errors into domain errors, so callers can branch
+// on what went wrong instead of on SQLSTATE codes and constraint names.
+//
+// A translated error matches, with errors.Is, its domain error (ErrEmailTaken), the
+// broader kind that error belongs to (ErrAlreadyExists), and the driver error it came
+// from, so errors.As still finds the *pgconn.PgError. Its message is only the domain
+// error's, which is safe to show to clients.
+package dberr
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Kinds of database errors. Every translated error matches exactly one of them.
+var (
+	ErrNotFound           = errors.New("resource not found")
+	ErrAlreadyExists      = errors.New("resource already exists")
+	ErrReferenceNotFound  = errors.New("referenced resource does not exist")
+	ErrStillReferenced    = errors.New("resource is still referenced")
+	ErrConstraintViolated = errors.New("value violates a data constraint")
+	// ErrSerializationFailure and ErrDeadlock abort a transaction that conflicted with
+	// a concurrent one. Running it again may succeed; see Retryable.
+	ErrSerializationFailure = errors.New("transaction conflicted with a concurrent update")
+	ErrDeadlock             = errors.New("transaction deadlocked with a concurrent one")
+)
+
+// Domain errors for the constraints callers need to tell apart.
+var (
+	ErrEmailTaken       = errors.New("email already registered")
+	ErrOwnerNotFound    = errors.New("owner does not exist")
+	ErrAccountNotFound  = errors.New("account does not exist")
+	ErrTransferNotFound = errors.New("transfer does not exist")
+)
+
+// SQLSTATE codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	foreignKeyViolation  = "23503"
+	uniqueViolation      = "23505"
+	checkViolation       = "23514"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// uniqueConstraints maps unique constraints to the error for a duplicate value.
+// Postgres names inline UNIQUE constraints <table>_<column>_key.
+var uniqueConstraints = map[string]error{
+	"users_email_key": ErrEmailTaken,
+}
+
+// foreignKeys maps foreign keys to the error for a missing referenced row. Postgres
+// names unnamed foreign keys <table>_<columns>_fkey.
+var foreignKeys = map[string]error{
+	"accounts_owner_id_fkey":          ErrOwnerNotFound,
+	"webhook_endpoints_owner_id_fkey": ErrOwnerNotFound,
+
+	"transactions_account_id_fkey":             ErrAccountNotFound,
+	"transactions_related_account_id_fkey":     ErrAccountNotFound,
+	"transfers_from_account_id_fkey":           ErrAccountNotFound,
+	"transfers_to_account_id_fkey":             ErrAccountNotFound,
+	"account_status_events_account_id_fkey":    ErrAccountNotFound,
+	"holds_account_id_fkey":                    ErrAccountNotFound,
+	"overdraft_accruals_account_id_fkey":       ErrAccountNotFound,
+	"postings_account_id_currency_fkey":        ErrAccountNotFound,
+	"reconciliation_incidents_account_id_fkey": ErrAccountNotFound,
+	"transfer_schedules_from_account_id_fkey":  ErrAccountNotFound,
+	"transfer_schedules_to_account_id_fkey":    ErrAccountNotFound,
+
+	"transfer_reversals_transfer_id_fkey":     ErrTransferNotFound,
+	"journal_entries_transfer_id_fkey":        ErrTransferNotFound,
+	"holds_capture_transfer_id_fkey":          ErrTransferNotFound,
+	"transfer_schedule_runs_transfer_id_fkey": ErrTransferNotFound,
+}
+
+// notFoundQueries maps the sqlc queries that look up a row by its ID to the error for a
+// missing row, named like constraints are above. Lookups by anything else are left
+// generic, since their callers expect to miss.
+var notFoundQueries = map[string]error{
+	"GetAccount":                  ErrAccountNotFound,
+	"GetAccountForUpdate":         ErrAccountNotFound,
+	"UpdateAccountBalance":        ErrAccountNotFound,
+	"UpdateAccountHeld":           ErrAccountNotFound,
+	"UpdateAccountOverdraftLimit": ErrAccountNotFound,
+	"UpdateAccountStatus":         ErrAccountNotFound,
+
+	"GetTransfer":          ErrTransferNotFound,
+	"GetTransferForUpdate": ErrTransferNotFound,
+}
+
+// translated is a driver error together with the domain error it stands for.
+type translated struct {
+	domain error
+	kind   error
+	cause  error
+}
+
+func (e *translated) Error() string {
+	return e.domain.Error()
+}
+
+func (e *translated) Unwrap() []error {
+	if e.domain == e.kind {
+		return []error{e.domain, e.cause}
+	}
+	return []error{e.domain, e.kind, e.cause}
+}
+
+// Translate returns the domain error for a PostgreSQL or pgx error. Errors it has no
+// translation for, nil included, are returned unchanged, as are errors it already
+// translated.
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	var already *translated
+	if errors.As(err, &already) {
+		return err
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &translated{domain: ErrNotFound, kind: ErrNotFound, cause: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	kind := kindOf(pgErr)
+	if kind == nil {
+		return err
+	}
+	domain := kind
+	switch kind {
+	case ErrAlreadyExists:
+		if named, ok := uniqueConstraints[pgErr.ConstraintName]; ok {
+			domain = named
+		}
+	case ErrReferenceNotFound:
+		if named, ok := foreignKeys[pgErr.ConstraintName]; ok {
+			domain = named
+		}
+	}
+	return &translated{domain: domain, kind: kind, cause: err}
+}
+
+// TranslateQuery is Translate for an error from the sqlc query named query. A lookup
+// that found no row returns the not-found error of what it looked up, such as
+// ErrAccountNotFound, which matches ErrNotFound as well.
+func TranslateQuery(query string, err error) error {
+	named, ok := notFoundQueries[query]
+	if !ok || !errors.Is(err, pgx.ErrNoRows) {
+		return Translate(err)
+	}
+	var already *translated
+	if errors.As(err, &already) {
+		if already.domain != ErrNotFound {
+			return err
+		}
+		err = already.cause
+	}
+	return &translated{domain: named, kind: ErrNotFound, cause: err}
+}
+
+// Missing returns the not-found error naming what err found missing, such as
+// ErrAccountNotFound, or ErrNotFound when err does not say. Unlike err itself, its
+// message carries nothing a caller wrapped it with.
+func Missing(err error) error {
+	var tr *translated
+	if errors.As(err, &tr) && tr.kind == ErrNotFound {
+		return tr.domain
+	}
+	return ErrNotFound
+}
+
+// queryName returns the name sqlc gives a query in the "-- name: GetAccount :one"
+// comment its SQL starts with, or "" for SQL without one.
+func queryName(sql string) string {
+	rest, ok := strings.CutPrefix(sql, "-- name: ")
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	return name
+}
+
+func kindOf(pgErr *pgconn.PgError) error {
+	switch pgErr.Code {
+	case uniqueViolation:
+		return ErrAlreadyExists
+	case foreignKeyViolation:
+		// Both sides of a foreign key report the same code and constraint; only the
+		// message tells a delete of a still referenced row from a dangling reference.
+		if strings.HasPrefix(pgErr.Message, "update or delete on table") {
+			return ErrStillReferenced
+		}
+		return ErrReferenceNotFound
+	case checkViolation:
+		return ErrConstraintViolated
+	case serializationFailure:
+		return ErrSerializationFailure
+	case deadlockDetected:
+		return ErrDeadlock
+	}
+	return nil
+}
+
+// Retryable reports whether err aborted a transaction only because of a concurrent
+// one, so that running the whole transaction again may succeed.
+func Retryable(err error) bool {
+	err = Translate(err)
+	return errors.Is(err, ErrSerializationFailure) || errors.Is(err, ErrDeadlock)
+}
@@ -0,0 +1,210 @@
+package dberr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		domain error
+		kind   error
+	}{
+		{
+			name:   "NoRows",
+			err:    fmt.Errorf("get account: %w", pgx.ErrNoRows),
+			domain: ErrNotFound,
+			kind:   ErrNotFound,
+		},
+		{
+			name:   "EmailTaken",
+			err:    &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"},
+			domain: ErrEmailTaken,
+			kind:   ErrAlreadyExists,
+		},
+		{
+			name:   "OtherUniqueViolation",
+			err:    &pgconn.PgError{Code: "23505", ConstraintName: "holds_reference_key"},
+			domain: ErrAlreadyExists,
+			kind:   ErrAlreadyExists,
+		},
+		{
+			name: "OwnerNotFound",
+			err: &pgconn.PgError{
+				Code:           "23503",
+				Message:        `insert or update on table "accounts" violates foreign key constraint "accounts_owner_id_fkey"`,
+				ConstraintName: "accounts_owner_id_fkey",
+			},
+			domain: ErrOwnerNotFound,
+			kind:   ErrReferenceNotFound,
+		},
+		{
+			name:   "AccountNotFound",
+			err:    &pgconn.PgError{Code: "23503", ConstraintName: "transfers_to_account_id_fkey"},
+			domain: ErrAccountNotFound,
+			kind:   ErrReferenceNotFound,
+		},
+		{
+			name: "StillReferenced",
+			err: &pgconn.PgError{
+				Code:           "23503",
+				Message:        `update or delete on table "accounts" violates foreign key constraint "transactions_account_id_fkey" on table "transactions"`,
+				ConstraintName: "transactions_account_id_fkey",
+			},
+			domain: ErrStillReferenced,
+			kind:   ErrStillReferenced,
+		},
+		{
+			name:   "CheckViolation",
+			err:    &pgconn.PgError{Code: "23514", ConstraintName: "postings_entry_balanced"},
+			domain: ErrConstraintViolated,
+			kind:   ErrConstraintViolated,
+		},
+		{
+			name:   "SerializationFailure",
+			err:    &pgconn.PgError{Code: "40001"},
+			domain: ErrSerializationFailure,
+			kind:   ErrSerializationFailure,
+		},
+		{
+			name:   "Deadlock",
+			err:    &pgconn.PgError{Code: "40P01"},
+			domain: ErrDeadlock,
+			kind:   ErrDeadlock,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Translate(tc.err)
+			require.ErrorIs(t, err, tc.domain)
+			require.ErrorIs(t, err, tc.kind)
+			require.ErrorIs(t, err, tc.err)
+			require.EqualError(t, err, tc.domain.Error())
+			require.Same(t, err, Translate(err))
+
+			var pgErr *pgconn.PgError
+			require.Equal(t, errors.As(tc.err, &pgErr), errors.As(err, &pgErr))
+		})
+	}
+}
+
+func TestTranslateUnknown(t *testing.T) {
+	require.NoError(t, Translate(nil))
+
+	for _, err := range []error{
+		errors.New("connection reset"),
+		&pgconn.PgError{Code: "22001"}, // string_data_right_truncation
+	} {
+		require.Equal(t, err, Translate(err))
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	require.True(t, Retryable(&pgconn.PgError{Code: "40001"}))
+	require.True(t, Retryable(fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40P01"})))
+	require.False(t, Retryable(&pgconn.PgError{Code: "23505"}))
+	require.False(t, Retryable(pgx.ErrNoRows))
+	require.False(t, Retryable(nil))
+}
+
+func TestTranslateQuery(t *testing.T) {
+	testCases := []struct {
+		name   string
+		query  string
+		err    error
+		domain error
+	}{
+		{
+			name:   "AccountNotFound",
+			query:  "GetAccount",
+			err:    fmt.Errorf("get account: %w", pgx.ErrNoRows),
+			domain: ErrAccountNotFound,
+		},
+		{
+			name:   "AccountNotFoundForUpdate",
+			query:  "UpdateAccountStatus",
+			err:    pgx.ErrNoRows,
+			domain: ErrAccountNotFound,
+		},
+		{
+			name:   "TransferNotFound",
+			query:  "GetTransferForUpdate",
+			err:    pgx.ErrNoRows,
+			domain: ErrTransferNotFound,
+		},
+		{
+			name:   "AlreadyTranslated",
+			query:  "GetTransfer",
+			err:    Translate(pgx.ErrNoRows),
+			domain: ErrTransferNotFound,
+		},
+		{
+			name:   "UnnamedQuery",
+			query:  "GetHold",
+			err:    pgx.ErrNoRows,
+			domain: ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := TranslateQuery(tc.query, tc.err)
+			require.ErrorIs(t, err, tc.domain)
+			require.ErrorIs(t, err, ErrNotFound)
+			require.ErrorIs(t, err, pgx.ErrNoRows)
+			require.EqualError(t, err, tc.domain.Error())
+			require.Same(t, err, TranslateQuery(tc.query, err))
+			require.Same(t, err, Translate(err))
+		})
+	}
+
+	// Errors other than a missing row translate as they would without the query
+	constraint := &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}
+	require.ErrorIs(t, TranslateQuery("GetAccount", constraint), ErrEmailTaken)
+	require.NoError(t, TranslateQuery("GetAccount", nil))
+}
+
+// fakeRow is a pgx.Row whose Scan fails with err.
+type fakeRow struct {
+	err error
+}
+
+func (r fakeRow) Scan(...any) error {
+	return r.err
+}
+
+func TestTranslatingRow(t *testing.T) {
+	require.Equal(t, "GetTransfer", queryName("-- name: GetTransfer :one\nSELECT 1"))
+	require.Equal(t, "", queryName("SELECT 1"))
+
+	row := translatingRow{row: fakeRow{err: pgx.ErrNoRows}, query: queryName("-- name: GetAccount :one\nSELECT 1")}
+	err := row.Scan()
+	require.ErrorIs(t, err, ErrAccountNotFound)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	row = translatingRow{row: fakeRow{err: pgx.ErrNoRows}, query: queryName("SELECT 1")}
+	err = row.Scan()
+	require.ErrorIs(t, err, ErrNotFound)
+	require.EqualError(t, err, ErrNotFound.Error())
+}
+
+func TestMissing(t *testing.T) {
+	err := fmt.Errorf("get account 7: %w", TranslateQuery("GetAccount", pgx.ErrNoRows))
+	require.Equal(t, ErrAccountNotFound, Missing(err))
+
+	require.Equal(t, ErrTransferNotFound, Missing(TranslateQuery("GetTransfer", pgx.ErrNoRows)))
+	require.Equal(t, ErrNotFound, Missing(pgx.ErrNoRows))
+	require.Equal(t, ErrNotFound, Missing(Translate(pgx.ErrNoRows)))
+
+	// A dangling reference names an account too, but is not a lookup that missed
+	fkErr := Translate(&pgconn.PgError{Code: "23503", ConstraintName: "transfers_to_account_id_fkey"})
+	require.Equal(t, ErrNotFound, Missing(fkErr))
+}
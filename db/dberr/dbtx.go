@@ -0,0 +1,68 @@
+package dberr
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DBTX is the query interface sqlc's Queries run on, satisfied by pools, connections
+// and transactions.
+type DBTX interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+}
+
+// Wrap returns a DBTX whose errors, including those surfacing from Scan and Rows.Err,
+// have gone through Translate, or TranslateQuery for single rows. Queries built on it
+// return domain errors from every generated method.
+func Wrap(db DBTX) DBTX {
+	return translatingDB{db: db}
+}
+
+type translatingDB struct {
+	db DBTX
+}
+
+func (t translatingDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	tag, err := t.db.Exec(ctx, sql, args...)
+	return tag, Translate(err)
+}
+
+func (t translatingDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	rows, err := t.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, Translate(err)
+	}
+	return translatingRows{Rows: rows}, nil
+}
+
+func (t translatingDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return translatingRow{row: t.db.QueryRow(ctx, sql, args...), query: queryName(sql)}
+}
+
+// translatingRows translates the errors a query reports while its rows are read.
+type translatingRows struct {
+	pgx.Rows
+}
+
+func (r translatingRows) Err() error {
+	return Translate(r.Rows.Err())
+}
+
+func (r translatingRows) Scan(dest ...any) error {
+	return Translate(r.Rows.Scan(dest...))
+}
+
+// translatingRow translates the error of a single row query, naming what was missing
+// when the query is one of notFoundQueries.
+type translatingRow struct {
+	row   pgx.Row
+	query string
+}
+
+func (r translatingRow) Scan(dest ...any) error {
+	return TranslateQuery(r.query, r.row.Scan(dest...))
+}
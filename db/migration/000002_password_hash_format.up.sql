@@ -0,0 +1,4 @@
+-- Reject plaintext passwords: only bcrypt and argon2id encoded hashes may be stored.
+-- NOT VALID skips existing rows so the migration cannot fail on legacy data.
+ALTER TABLE "users" ADD CONSTRAINT "users_password_hash_format"
+  CHECK ("password_hash" ~ '^\$(2[aby]|argon2id)\$') NOT VALID;
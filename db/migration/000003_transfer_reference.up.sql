@@ -0,0 +1,5 @@
+-- Transfers carry their own idempotency key so a replayed request can find the
+-- original transfer; the two transaction legs reuse it with a ":out"/":in" suffix.
+ALTER TABLE "transfers" ADD COLUMN "reference" varchar UNIQUE;
+
+COMMENT ON COLUMN "transfers"."reference" IS 'Idempotency key / external reference';
@@ -0,0 +1,5 @@
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfers_failure_reason_status";
+
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfers_processed_at_status";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "failure_reason";
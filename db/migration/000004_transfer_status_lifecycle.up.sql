@@ -0,0 +1,11 @@
+ALTER TABLE "transfers" ADD COLUMN "failure_reason" varchar;
+
+COMMENT ON COLUMN "transfers"."failure_reason" IS 'Why the transfer failed, e.g. insufficient_balance; set only on failed transfers';
+
+-- A transfer is processed exactly when it leaves pending, and only failed transfers carry a reason.
+-- NOT VALID keeps the migration safe for rows written before the lifecycle existed.
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_processed_at_status"
+  CHECK (("status" = 'pending') = ("processed_at" IS NULL)) NOT VALID;
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_failure_reason_status"
+  CHECK (("status" = 'failed') = ("failure_reason" IS NOT NULL)) NOT VALID;
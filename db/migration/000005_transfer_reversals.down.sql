@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "transfer_reversals";
+
+DROP TYPE IF EXISTS "ReversalReason";
+
+-- Postgres can't drop a single enum value; 'reversal' stays in "TransactionType".
@@ -0,0 +1,31 @@
+ALTER TYPE "TransactionType" ADD VALUE IF NOT EXISTS 'reversal';
+
+CREATE TYPE "ReversalReason" AS ENUM (
+  'customer_request',
+  'duplicate',
+  'fraud',
+  'processing_error'
+);
+
+CREATE TABLE "transfer_reversals" (
+  "id" uuid PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "transfer_id" uuid UNIQUE NOT NULL,
+  "amount_cents" bigint NOT NULL,
+  "reason" "ReversalReason" NOT NULL,
+  "debit_transaction_id" uuid NOT NULL,
+  "credit_transaction_id" uuid NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "transfer_reversals_amount_positive" CHECK ("amount_cents" > 0)
+);
+
+COMMENT ON TABLE "transfer_reversals" IS 'Compensation of a completed transfer. At most one per transfer, for all or part of its amount.';
+
+COMMENT ON COLUMN "transfer_reversals"."debit_transaction_id" IS 'Takes the money back from the original recipient';
+
+COMMENT ON COLUMN "transfer_reversals"."credit_transaction_id" IS 'Returns the money to the original sender';
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("debit_transaction_id") REFERENCES "transactions" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("credit_transaction_id") REFERENCES "transactions" ("id");
@@ -0,0 +1,17 @@
+CREATE TABLE "account_status_events" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "from_status" "AccountStatus" NOT NULL,
+  "to_status" "AccountStatus" NOT NULL,
+  "reason" varchar NOT NULL,
+  "actor" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "account_status_events" ("account_id", "created_at");
+
+COMMENT ON TABLE "account_status_events" IS 'Audit trail of account freezes, unfreezes and closures.';
+
+COMMENT ON COLUMN "account_status_events"."actor" IS 'Who made the change, e.g. user:<uuid> or the name of a system job';
+
+ALTER TABLE "account_status_events" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
@@ -0,0 +1,7 @@
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfers_conversion_complete";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "exchange_rate";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "to_amount_cents";
+
+DROP TABLE IF EXISTS "exchange_rates";
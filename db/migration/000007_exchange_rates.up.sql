@@ -0,0 +1,27 @@
+CREATE TABLE "exchange_rates" (
+  "id" bigserial PRIMARY KEY,
+  "base_currency" "Currency" NOT NULL,
+  "quote_currency" "Currency" NOT NULL,
+  "rate" numeric(20,10) NOT NULL,
+  "effective_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "exchange_rates_rate_positive" CHECK ("rate" > 0),
+  CONSTRAINT "exchange_rates_distinct_currencies" CHECK ("base_currency" <> "quote_currency")
+);
+
+CREATE INDEX ON "exchange_rates" ("base_currency", "quote_currency", "effective_at");
+
+COMMENT ON TABLE "exchange_rates" IS 'Conversion rates for cross-currency transfers. The newest rate already in effect wins.';
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'Units of quote_currency per one unit of base_currency';
+
+ALTER TABLE "transfers" ADD COLUMN "to_amount_cents" bigint;
+
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" numeric(20,10);
+
+COMMENT ON COLUMN "transfers"."to_amount_cents" IS 'Amount credited in the destination currency; set only on cross-currency transfers';
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'Rate applied to amount_cents to get to_amount_cents';
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_conversion_complete"
+  CHECK (("to_amount_cents" IS NULL) = ("exchange_rate" IS NULL));
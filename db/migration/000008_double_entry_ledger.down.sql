@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS "postings";
+
+DROP FUNCTION IF EXISTS "postings_check_entry_balanced"();
+
+DROP FUNCTION IF EXISTS "postings_reject_change"();
+
+DROP TABLE IF EXISTS "journal_entries";
+
+DROP TABLE IF EXISTS "system_accounts";
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_id_currency_key";
+
+DROP TYPE IF EXISTS "SystemAccountKind";
+
+DROP TYPE IF EXISTS "JournalEntryType";
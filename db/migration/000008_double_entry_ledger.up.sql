@@ -0,0 +1,126 @@
+CREATE TYPE "JournalEntryType" AS ENUM (
+  'opening_balance',
+  'deposit',
+  'withdrawal',
+  'transfer',
+  'reversal'
+);
+
+CREATE TYPE "SystemAccountKind" AS ENUM (
+  'cash_in',
+  'cash_out',
+  'fx_clearing'
+);
+
+CREATE TABLE "system_accounts" (
+  "id" bigserial PRIMARY KEY,
+  "kind" "SystemAccountKind" NOT NULL,
+  "currency" "Currency" NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "system_accounts_kind_currency_key" UNIQUE ("kind", "currency"),
+  CONSTRAINT "system_accounts_id_currency_key" UNIQUE ("id", "currency")
+);
+
+COMMENT ON TABLE "system_accounts" IS 'Internal ledger accounts on the other side of customer postings; one per kind and currency.';
+
+INSERT INTO "system_accounts" ("kind", "currency")
+SELECT "kind", "currency"
+FROM unnest(enum_range(NULL::"SystemAccountKind")) AS "kind"
+CROSS JOIN unnest(enum_range(NULL::"Currency")) AS "currency";
+
+CREATE TABLE "journal_entries" (
+  "id" uuid PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "type" "JournalEntryType" NOT NULL,
+  "transfer_id" uuid,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "journal_entries" ("transfer_id");
+
+COMMENT ON TABLE "journal_entries" IS 'One balanced business event in the double-entry ledger; its postings sum to zero per currency.';
+
+ALTER TABLE "journal_entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_id_currency_key" UNIQUE ("id", "currency");
+
+CREATE TABLE "postings" (
+  "id" bigserial PRIMARY KEY,
+  "entry_id" uuid NOT NULL,
+  "account_id" bigint,
+  "system_account_id" bigint,
+  "transaction_id" uuid,
+  "currency" "Currency" NOT NULL,
+  "amount_cents" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "postings_single_account" CHECK (num_nonnulls("account_id", "system_account_id") = 1),
+  CONSTRAINT "postings_amount_nonzero" CHECK ("amount_cents" <> 0)
+);
+
+CREATE INDEX ON "postings" ("entry_id");
+
+CREATE INDEX ON "postings" ("account_id");
+
+CREATE INDEX ON "postings" ("system_account_id");
+
+COMMENT ON TABLE "postings" IS 'Append-only legs of journal entries. Each moves amount_cents into (positive) or out of (negative) one ledger account.';
+
+COMMENT ON COLUMN "postings"."transaction_id" IS 'The customer-facing transaction this leg produced, for account postings';
+
+-- The composite keys also pin each posting to its account's currency
+ALTER TABLE "postings" ADD FOREIGN KEY ("entry_id") REFERENCES "journal_entries" ("id");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("account_id", "currency") REFERENCES "accounts" ("id", "currency");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("system_account_id", "currency") REFERENCES "system_accounts" ("id", "currency");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("transaction_id") REFERENCES "transactions" ("id");
+
+CREATE FUNCTION "postings_check_entry_balanced"() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM "postings"
+    WHERE "entry_id" = NEW."entry_id"
+    GROUP BY "currency"
+    HAVING sum("amount_cents") <> 0
+  ) THEN
+    RAISE EXCEPTION 'journal entry % does not balance', NEW."entry_id"
+      USING ERRCODE = 'check_violation', CONSTRAINT = 'postings_entry_balanced';
+  END IF;
+  RETURN NULL;
+END;
+$$;
+
+-- Checked at commit, once every leg of the entry has been written
+CREATE CONSTRAINT TRIGGER "postings_entry_balanced"
+  AFTER INSERT ON "postings"
+  DEFERRABLE INITIALLY DEFERRED
+  FOR EACH ROW EXECUTE FUNCTION "postings_check_entry_balanced"();
+
+CREATE FUNCTION "postings_reject_change"() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+  RAISE EXCEPTION 'postings are append-only; post a correcting entry instead'
+    USING ERRCODE = 'restrict_violation';
+END;
+$$;
+
+CREATE TRIGGER "postings_append_only"
+  BEFORE UPDATE OR DELETE ON "postings"
+  FOR EACH ROW EXECUTE FUNCTION "postings_reject_change"();
+
+-- Carry existing balances into the ledger as opening entries funded from cash_in
+WITH "opening" AS (
+  SELECT "id", "currency", "balance_cents", gen_random_uuid() AS "entry_id"
+  FROM "accounts"
+  WHERE "balance_cents" <> 0
+), "entries" AS (
+  INSERT INTO "journal_entries" ("id", "type")
+  SELECT "entry_id", 'opening_balance' FROM "opening"
+)
+INSERT INTO "postings" ("entry_id", "account_id", "system_account_id", "currency", "amount_cents")
+SELECT "entry_id", "id", NULL, "currency", "balance_cents" FROM "opening"
+UNION ALL
+SELECT o."entry_id", NULL, s."id", o."currency", -o."balance_cents"
+FROM "opening" o
+JOIN "system_accounts" s ON s."kind" = 'cash_in' AND s."currency" = o."currency";
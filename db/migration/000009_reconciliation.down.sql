@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "reconciliation_incidents";
+
+DROP TYPE IF EXISTS "DriftKind";
+
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "seq";
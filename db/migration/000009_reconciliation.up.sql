@@ -0,0 +1,53 @@
+-- Rows of one account are inserted while its row lock is held, so a sequence orders
+-- them as they were applied. created_at can't: it is the start time of the transaction.
+CREATE SEQUENCE "transactions_seq_seq";
+
+ALTER TABLE "transactions" ADD COLUMN "seq" bigint;
+
+UPDATE "transactions" t
+SET "seq" = o."seq"
+FROM (
+  SELECT "id", row_number() OVER (ORDER BY "created_at", "id") AS "seq"
+  FROM "transactions"
+) o
+WHERE t."id" = o."id";
+
+SELECT setval('transactions_seq_seq', COALESCE(max("seq"), 0) + 1, false) FROM "transactions";
+
+ALTER TABLE "transactions"
+  ALTER COLUMN "seq" SET DEFAULT nextval('transactions_seq_seq'),
+  ALTER COLUMN "seq" SET NOT NULL;
+
+ALTER SEQUENCE "transactions_seq_seq" OWNED BY "transactions"."seq";
+
+CREATE UNIQUE INDEX ON "transactions" ("account_id", "seq");
+
+COMMENT ON COLUMN "transactions"."seq" IS 'Order in which rows were applied to their account; balance_after_cents chains along it';
+
+CREATE TYPE "DriftKind" AS ENUM (
+  'transactions_total',
+  'ledger_total',
+  'balance_chain'
+);
+
+CREATE TABLE "reconciliation_incidents" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "kind" "DriftKind" NOT NULL,
+  "expected_cents" bigint NOT NULL,
+  "actual_cents" bigint NOT NULL,
+  "transaction_id" uuid,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "resolved_at" timestamptz
+);
+
+CREATE UNIQUE INDEX "reconciliation_incidents_open_key" ON "reconciliation_incidents" ("account_id", "kind")
+  WHERE "resolved_at" IS NULL;
+
+COMMENT ON TABLE "reconciliation_incidents" IS 'Drift found by reconciliation. At most one open incident per account and kind.';
+
+COMMENT ON COLUMN "reconciliation_incidents"."transaction_id" IS 'First transaction that breaks the balance chain, for balance_chain drift';
+
+ALTER TABLE "reconciliation_incidents" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "reconciliation_incidents" ADD FOREIGN KEY ("transaction_id") REFERENCES "transactions" ("id");
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "accounts_overdrawn_idx";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "overdraft_limit_cents";
+
+COMMENT ON COLUMN "accounts"."balance_cents" IS 'Balance stored in cents; never negative unless overdraft allowed';
+
+-- Postgres can't drop a single enum value; the overdraft values stay in "TransactionType",
+-- "JournalEntryType" and "SystemAccountKind".
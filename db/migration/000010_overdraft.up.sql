@@ -0,0 +1,18 @@
+ALTER TABLE "accounts" ADD COLUMN "overdraft_limit_cents" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_overdraft_limit_nonnegative"
+  CHECK ("overdraft_limit_cents" >= 0);
+
+COMMENT ON COLUMN "accounts"."overdraft_limit_cents" IS 'How far below zero customer debits may take the balance';
+
+COMMENT ON COLUMN "accounts"."balance_cents" IS 'Balance stored in cents; negative while the account is in overdraft';
+
+CREATE INDEX "accounts_overdrawn_idx" ON "accounts" ("id") WHERE "balance_cents" < 0;
+
+ALTER TYPE "TransactionType" ADD VALUE IF NOT EXISTS 'overdraft_interest';
+
+ALTER TYPE "TransactionType" ADD VALUE IF NOT EXISTS 'overdraft_fee';
+
+ALTER TYPE "JournalEntryType" ADD VALUE IF NOT EXISTS 'overdraft_charge';
+
+ALTER TYPE "SystemAccountKind" ADD VALUE IF NOT EXISTS 'fee_income';
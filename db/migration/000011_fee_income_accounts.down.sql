@@ -0,0 +1,4 @@
+-- fee_income accounts that carry postings can't be removed without losing ledger history
+DELETE FROM "system_accounts" s
+WHERE s."kind" = 'fee_income'
+  AND NOT EXISTS (SELECT 1 FROM "postings" p WHERE p."system_account_id" = s."id");
@@ -0,0 +1,5 @@
+-- Separate from 000010: a new enum value can't be used in the transaction that adds it
+INSERT INTO "system_accounts" ("kind", "currency")
+SELECT 'fee_income', "currency"
+FROM unnest(enum_range(NULL::"Currency")) AS "currency"
+ON CONFLICT ("kind", "currency") DO NOTHING;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "holds";
+
+DROP TYPE IF EXISTS "HoldStatus";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "held_cents";
@@ -0,0 +1,50 @@
+ALTER TABLE "accounts" ADD COLUMN "held_cents" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_held_nonnegative" CHECK ("held_cents" >= 0);
+
+COMMENT ON COLUMN "accounts"."held_cents" IS 'Sum of active holds: reserved out of the available balance but not posted';
+
+CREATE TYPE "HoldStatus" AS ENUM (
+  'active',
+  'captured',
+  'voided',
+  'expired'
+);
+
+CREATE TABLE "holds" (
+  "id" uuid PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "account_id" bigint NOT NULL,
+  "amount_cents" bigint NOT NULL,
+  "status" "HoldStatus" NOT NULL DEFAULT 'active',
+  "captured_cents" bigint,
+  "capture_transaction_id" uuid,
+  "capture_transfer_id" uuid,
+  "reference" varchar UNIQUE,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "resolved_at" timestamptz,
+  CONSTRAINT "holds_amount_positive" CHECK ("amount_cents" > 0),
+  CONSTRAINT "holds_captured_within_amount" CHECK ("captured_cents" > 0 AND "captured_cents" <= "amount_cents"),
+  CONSTRAINT "holds_captured_status" CHECK (("status" = 'captured') = ("captured_cents" IS NOT NULL)),
+  CONSTRAINT "holds_resolved_status" CHECK (("status" = 'active') = ("resolved_at" IS NULL))
+);
+
+CREATE INDEX ON "holds" ("account_id", "created_at");
+
+CREATE INDEX "holds_active_expiry_idx" ON "holds" ("expires_at") WHERE "status" = 'active';
+
+COMMENT ON TABLE "holds" IS 'Card-style authorizations: funds reserved on an account until captured, voided or expired.';
+
+COMMENT ON COLUMN "holds"."captured_cents" IS 'Amount actually taken on capture; the rest of the hold is released';
+
+COMMENT ON COLUMN "holds"."capture_transaction_id" IS 'Debit that captured the hold, for a withdrawal or the outgoing leg of a transfer';
+
+COMMENT ON COLUMN "holds"."capture_transfer_id" IS 'Transfer that captured the hold, when captured into a transfer';
+
+COMMENT ON COLUMN "holds"."reference" IS 'Idempotency key / external reference';
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("capture_transaction_id") REFERENCES "transactions" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("capture_transfer_id") REFERENCES "transfers" ("id");
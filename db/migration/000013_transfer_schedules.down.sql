@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "transfer_schedule_runs";
+
+DROP TABLE IF EXISTS "transfer_schedules";
+
+DROP TYPE IF EXISTS "ScheduleRunStatus";
+
+DROP TYPE IF EXISTS "InsufficientFundsPolicy";
+
+DROP TYPE IF EXISTS "ScheduleStatus";
+
+DROP TYPE IF EXISTS "ScheduleFrequency";
@@ -0,0 +1,88 @@
+CREATE TYPE "ScheduleFrequency" AS ENUM (
+  'once',
+  'daily',
+  'weekly',
+  'monthly'
+);
+
+CREATE TYPE "ScheduleStatus" AS ENUM (
+  'active',
+  'completed',
+  'cancelled'
+);
+
+CREATE TYPE "InsufficientFundsPolicy" AS ENUM (
+  'retry',
+  'skip'
+);
+
+CREATE TYPE "ScheduleRunStatus" AS ENUM (
+  'succeeded',
+  'retrying',
+  'skipped',
+  'failed'
+);
+
+CREATE TABLE "transfer_schedules" (
+  "id" uuid PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount_cents" bigint NOT NULL,
+  "frequency" "ScheduleFrequency" NOT NULL,
+  "day_of_month" integer,
+  "status" "ScheduleStatus" NOT NULL DEFAULT 'active',
+  "insufficient_funds_policy" "InsufficientFundsPolicy" NOT NULL DEFAULT 'skip',
+  "max_attempts" integer NOT NULL DEFAULT 3,
+  "failed_attempts" integer NOT NULL DEFAULT 0,
+  "next_occurrence_at" timestamptz NOT NULL,
+  "next_run_at" timestamptz NOT NULL,
+  "ends_at" timestamptz,
+  "locked_until" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "transfer_schedules_amount_positive" CHECK ("amount_cents" > 0),
+  CONSTRAINT "transfer_schedules_different_accounts" CHECK ("from_account_id" <> "to_account_id"),
+  CONSTRAINT "transfer_schedules_day_of_month" CHECK (("frequency" = 'monthly') = ("day_of_month" IS NOT NULL) AND "day_of_month" BETWEEN 1 AND 31),
+  CONSTRAINT "transfer_schedules_max_attempts" CHECK ("max_attempts" >= 1),
+  CONSTRAINT "transfer_schedules_failed_attempts" CHECK ("failed_attempts" >= 0 AND "failed_attempts" < "max_attempts")
+);
+
+CREATE INDEX ON "transfer_schedules" ("from_account_id", "created_at");
+
+CREATE INDEX "transfer_schedules_due_idx" ON "transfer_schedules" ("next_run_at") WHERE "status" = 'active';
+
+COMMENT ON TABLE "transfer_schedules" IS 'Future-dated and recurring transfers (standing orders)';
+
+COMMENT ON COLUMN "transfer_schedules"."day_of_month" IS 'Monthly schedules only; clamped to the last day of shorter months';
+
+COMMENT ON COLUMN "transfer_schedules"."failed_attempts" IS 'Attempts of the current occurrence refused for insufficient funds';
+
+COMMENT ON COLUMN "transfer_schedules"."next_occurrence_at" IS 'When the current occurrence was due';
+
+COMMENT ON COLUMN "transfer_schedules"."next_run_at" IS 'When the current occurrence is next attempted; later than next_occurrence_at while retrying';
+
+COMMENT ON COLUMN "transfer_schedules"."locked_until" IS 'Lease held by the worker running the schedule';
+
+ALTER TABLE "transfer_schedules" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_schedules" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+CREATE TABLE "transfer_schedule_runs" (
+  "id" bigserial PRIMARY KEY,
+  "schedule_id" uuid NOT NULL,
+  "occurrence_at" timestamptz NOT NULL,
+  "attempt" integer NOT NULL,
+  "status" "ScheduleRunStatus" NOT NULL,
+  "transfer_id" uuid,
+  "failure_reason" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "transfer_schedule_runs_attempt_key" UNIQUE ("schedule_id", "occurrence_at", "attempt")
+);
+
+CREATE INDEX ON "transfer_schedule_runs" ("schedule_id", "id");
+
+COMMENT ON TABLE "transfer_schedule_runs" IS 'One row per attempt of a scheduled transfer';
+
+ALTER TABLE "transfer_schedule_runs" ADD FOREIGN KEY ("schedule_id") REFERENCES "transfer_schedules" ("id");
+
+ALTER TABLE "transfer_schedule_runs" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "webhook_deliveries";
+
+DROP TABLE IF EXISTS "webhook_endpoints";
+
+DROP TABLE IF EXISTS "outbox_events";
+
+DROP TYPE IF EXISTS "WebhookDeliveryStatus";
+
+DROP TYPE IF EXISTS "EventType";
@@ -0,0 +1,81 @@
+CREATE TYPE "EventType" AS ENUM (
+  'deposit.completed',
+  'withdrawal.completed',
+  'transfer.completed',
+  'transfer.failed',
+  'transfer.reversed',
+  'overdraft.charged'
+);
+
+CREATE TYPE "WebhookDeliveryStatus" AS ENUM (
+  'pending',
+  'delivered',
+  'dead'
+);
+
+CREATE TABLE "outbox_events" (
+  "id" bigserial PRIMARY KEY,
+  "type" "EventType" NOT NULL,
+  "account_ids" bigint[] NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "dispatched_at" timestamptz
+);
+
+CREATE INDEX "outbox_events_undispatched_idx" ON "outbox_events" ("id") WHERE "dispatched_at" IS NULL;
+
+COMMENT ON TABLE "outbox_events" IS 'Ledger events, written in the same database transaction as the money movement they describe';
+
+COMMENT ON COLUMN "outbox_events"."account_ids" IS 'Customer accounts the event concerns; their owners'' webhook endpoints receive it';
+
+COMMENT ON COLUMN "outbox_events"."dispatched_at" IS 'When a delivery was queued for every subscribed endpoint';
+
+CREATE TABLE "webhook_endpoints" (
+  "id" uuid PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "owner_id" uuid NOT NULL,
+  "url" text NOT NULL,
+  "secret" text NOT NULL,
+  "event_types" text[] NOT NULL DEFAULT '{}',
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "webhook_endpoints" ("owner_id");
+
+COMMENT ON TABLE "webhook_endpoints" IS 'URLs that receive a user''s ledger events';
+
+COMMENT ON COLUMN "webhook_endpoints"."secret" IS 'HMAC-SHA256 key deliveries are signed with';
+
+COMMENT ON COLUMN "webhook_endpoints"."event_types" IS 'Event types delivered to the endpoint; empty means all of them';
+
+ALTER TABLE "webhook_endpoints" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "event_id" bigint NOT NULL,
+  "endpoint_id" uuid NOT NULL,
+  "status" "WebhookDeliveryStatus" NOT NULL DEFAULT 'pending',
+  "attempts" integer NOT NULL DEFAULT 0,
+  "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+  "locked_until" timestamptz,
+  "last_status_code" integer,
+  "last_error" text,
+  "delivered_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "webhook_deliveries_event_endpoint_key" UNIQUE ("event_id", "endpoint_id")
+);
+
+CREATE INDEX "webhook_deliveries_due_idx" ON "webhook_deliveries" ("next_attempt_at") WHERE "status" = 'pending';
+
+CREATE INDEX ON "webhook_deliveries" ("endpoint_id", "id");
+
+COMMENT ON TABLE "webhook_deliveries" IS 'One event on its way to one webhook endpoint';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'dead once every attempt has failed; redelivery puts it back to pending';
+
+COMMENT ON COLUMN "webhook_deliveries"."locked_until" IS 'Lease held by the dispatcher sending the delivery';
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("event_id") REFERENCES "outbox_events" ("id");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id");
@@ -0,0 +1,27 @@
+DROP INDEX IF EXISTS "webhook_endpoints_owner_keyset_idx";
+
+CREATE INDEX ON "webhook_endpoints" ("owner_id");
+
+DROP INDEX IF EXISTS "transfer_schedules_from_account_keyset_idx";
+
+CREATE INDEX ON "transfer_schedules" ("from_account_id", "created_at");
+
+DROP INDEX IF EXISTS "holds_account_keyset_idx";
+
+CREATE INDEX ON "holds" ("account_id", "created_at");
+
+DROP INDEX IF EXISTS "account_status_events_account_keyset_idx";
+
+CREATE INDEX ON "account_status_events" ("account_id", "created_at");
+
+DROP INDEX IF EXISTS "accounts_owner_keyset_idx";
+
+DROP INDEX IF EXISTS "transfers_to_account_keyset_idx";
+
+DROP INDEX IF EXISTS "transfers_from_account_keyset_idx";
+
+DROP INDEX IF EXISTS "transfers_keyset_idx";
+
+DROP INDEX IF EXISTS "transactions_account_keyset_idx";
+
+CREATE INDEX ON "transactions" ("account_id", "created_at");
@@ -0,0 +1,29 @@
+-- List queries page on (created_at, id) or id instead of OFFSET. The id tie-breaker makes
+-- the order total, so the (…, created_at) indexes it extends are dropped.
+DROP INDEX IF EXISTS "transactions_account_id_created_at_idx";
+
+CREATE INDEX "transactions_account_keyset_idx" ON "transactions" ("account_id", "created_at", "id");
+
+CREATE INDEX "transfers_keyset_idx" ON "transfers" ("created_at", "id");
+
+CREATE INDEX "transfers_from_account_keyset_idx" ON "transfers" ("from_account_id", "created_at", "id");
+
+CREATE INDEX "transfers_to_account_keyset_idx" ON "transfers" ("to_account_id", "created_at", "id");
+
+CREATE INDEX "accounts_owner_keyset_idx" ON "accounts" ("owner_id", "id");
+
+DROP INDEX IF EXISTS "account_status_events_account_id_created_at_idx";
+
+CREATE INDEX "account_status_events_account_keyset_idx" ON "account_status_events" ("account_id", "created_at", "id");
+
+DROP INDEX IF EXISTS "holds_account_id_created_at_idx";
+
+CREATE INDEX "holds_account_keyset_idx" ON "holds" ("account_id", "created_at", "id");
+
+DROP INDEX IF EXISTS "transfer_schedules_from_account_id_created_at_idx";
+
+CREATE INDEX "transfer_schedules_from_account_keyset_idx" ON "transfer_schedules" ("from_account_id", "created_at", "id");
+
+DROP INDEX IF EXISTS "webhook_endpoints_owner_id_idx";
+
+CREATE INDEX "webhook_endpoints_owner_keyset_idx" ON "webhook_endpoints" ("owner_id", "created_at", "id");
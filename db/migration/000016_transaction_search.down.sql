@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "transactions_description_trgm_idx";
+
+DROP INDEX IF EXISTS "transactions_account_related_keyset_idx";
+
+DROP INDEX IF EXISTS "transactions_account_amount_keyset_idx";
+
+DROP INDEX IF EXISTS "transactions_account_type_keyset_idx";
+
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "description";
+
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,15 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE "transactions" ADD COLUMN "description" varchar;
+
+COMMENT ON COLUMN "transactions"."description" IS 'Free-text memo given with the deposit, withdrawal or transfer';
+
+-- Transfer legs written before this migration keep a NULL related_account_id
+CREATE INDEX "transactions_account_type_keyset_idx" ON "transactions" ("account_id", "type", "created_at", "id");
+
+CREATE INDEX "transactions_account_amount_keyset_idx" ON "transactions" ("account_id", abs("amount_cents"), "id");
+
+CREATE INDEX "transactions_account_related_keyset_idx" ON "transactions" ("account_id", "related_account_id", "created_at", "id")
+WHERE "related_account_id" IS NOT NULL;
+
+CREATE INDEX "transactions_description_trgm_idx" ON "transactions" USING gin ("description" gin_trgm_ops);
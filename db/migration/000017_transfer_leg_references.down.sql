@@ -0,0 +1,8 @@
+COMMENT ON COLUMN "transactions"."reference" IS 'Idempotency key / external reference';
+
+UPDATE "transactions" t
+SET "reference" = tr."reference" || CASE t."type" WHEN 'transfer_out' THEN ':out' ELSE ':in' END
+FROM "postings" p
+JOIN "journal_entries" e ON e."id" = p."entry_id" AND e."type" = 'transfer'
+JOIN "transfers" tr ON tr."id" = e."transfer_id"
+WHERE p."transaction_id" = t."id" AND tr."reference" IS NOT NULL;
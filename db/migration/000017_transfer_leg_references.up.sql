@@ -0,0 +1,7 @@
+-- Transfer legs no longer copy the transfer's idempotency key, which shared the
+-- unique reference space with the keys of deposits and withdrawals. A replayed
+-- transfer finds its legs through the postings of its journal entry instead.
+UPDATE "transactions" SET "reference" = NULL
+WHERE "type" IN ('transfer_in', 'transfer_out') AND "reference" IS NOT NULL;
+
+COMMENT ON COLUMN "transactions"."reference" IS 'Idempotency key of a deposit or withdrawal; NULL on transfer legs';
@@ -0,0 +1,12 @@
+-- Fails if two accounts have used the same key since the upgrade
+ALTER TABLE "holds" DROP CONSTRAINT "holds_account_reference_key";
+
+ALTER TABLE "holds" ADD CONSTRAINT "holds_reference_key" UNIQUE ("reference");
+
+ALTER TABLE "transfers" DROP CONSTRAINT "transfers_from_account_reference_key";
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_reference_key" UNIQUE ("reference");
+
+ALTER TABLE "transactions" DROP CONSTRAINT "transactions_account_reference_key";
+
+ALTER TABLE "transactions" ADD CONSTRAINT "transactions_reference_key" UNIQUE ("reference");
@@ -0,0 +1,14 @@
+-- Idempotency keys are chosen by callers, so they are unique per account rather than
+-- across every tenant: the account a deposit, withdrawal or hold is made on, and the
+-- account a transfer is sent from.
+ALTER TABLE "transactions" DROP CONSTRAINT "transactions_reference_key";
+
+ALTER TABLE "transactions" ADD CONSTRAINT "transactions_account_reference_key" UNIQUE ("account_id", "reference");
+
+ALTER TABLE "transfers" DROP CONSTRAINT "transfers_reference_key";
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_from_account_reference_key" UNIQUE ("from_account_id", "reference");
+
+ALTER TABLE "holds" DROP CONSTRAINT "holds_reference_key";
+
+ALTER TABLE "holds" ADD CONSTRAINT "holds_account_reference_key" UNIQUE ("account_id", "reference");
@@ -0,0 +1,6 @@
+UPDATE "transactions" t
+SET "reference" = 'overdraft:' || a."account_id" || ':' || to_char(a."day", 'YYYY-MM-DD') || ':' || a."type"
+FROM "overdraft_accruals" a
+WHERE a."transaction_id" = t."id";
+
+DROP TABLE IF EXISTS "overdraft_accruals";
@@ -0,0 +1,24 @@
+CREATE TABLE "overdraft_accruals" (
+  "account_id" bigint NOT NULL,
+  "day" date NOT NULL,
+  "type" "TransactionType" NOT NULL,
+  "transaction_id" uuid UNIQUE NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "day", "type")
+);
+
+COMMENT ON TABLE "overdraft_accruals" IS 'Overdraft charges booked per account, day and charge type; keeps each from being booked twice';
+
+ALTER TABLE "overdraft_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "overdraft_accruals" ADD FOREIGN KEY ("transaction_id") REFERENCES "transactions" ("id");
+
+-- Charges used to be deduplicated on a transaction reference, which callers could
+-- claim first with an idempotency key of their own
+INSERT INTO "overdraft_accruals" ("account_id", "day", "type", "transaction_id", "created_at")
+SELECT "account_id", split_part("reference", ':', 3)::date, "type", "id", "created_at"
+FROM "transactions"
+WHERE "type" IN ('overdraft_interest', 'overdraft_fee') AND "reference" LIKE 'overdraft:%';
+
+UPDATE "transactions" SET "reference" = NULL
+WHERE "type" IN ('overdraft_interest', 'overdraft_fee') AND "reference" LIKE 'overdraft:%';
@@ -0,0 +1,7 @@
+UPDATE "transfers" t SET "reference" = 'hold:' || h."id"
+FROM "holds" h
+WHERE h."capture_transfer_id" = t."id";
+
+UPDATE "transactions" t SET "reference" = 'hold:' || h."id"
+FROM "holds" h
+WHERE h."capture_transaction_id" = t."id" AND h."capture_transfer_id" IS NULL;
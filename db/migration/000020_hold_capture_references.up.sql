@@ -0,0 +1,10 @@
+-- Captures no longer key their withdrawal or transfer on the hold: a caller could
+-- claim that key first and leave the hold impossible to capture. The hold's own
+-- capture columns and status are what tie a capture to it.
+UPDATE "transactions" SET "reference" = NULL
+WHERE "id" IN (SELECT "capture_transaction_id" FROM "holds" WHERE "capture_transaction_id" IS NOT NULL)
+  AND "reference" LIKE 'hold:%';
+
+UPDATE "transfers" SET "reference" = NULL
+WHERE "id" IN (SELECT "capture_transfer_id" FROM "holds" WHERE "capture_transfer_id" IS NOT NULL)
+  AND "reference" LIKE 'hold:%';
@@ -0,0 +1,4 @@
+UPDATE "transfers" t
+SET "reference" = 'schedule:' || r."schedule_id" || ':' || extract(epoch FROM r."occurrence_at")::bigint || ':' || r."attempt"
+FROM "transfer_schedule_runs" r
+WHERE r."transfer_id" = t."id";
@@ -0,0 +1,6 @@
+-- Scheduled transfers no longer key their transfer on the schedule, occurrence and
+-- attempt: the owner could claim that key first and block the standing order. Each run
+-- commits together with its transfer_schedule_runs row, which ties the two together.
+UPDATE "transfers" SET "reference" = NULL
+WHERE "id" IN (SELECT "transfer_id" FROM "transfer_schedule_runs" WHERE "transfer_id" IS NOT NULL)
+  AND "reference" LIKE 'schedule:%';
@@ -0,0 +1,335 @@
+// Package migration embeds the schema migrations in this directory and applies them.
+//
+// It keeps its state in the schema_migrations table of golang-migrate, so a database
+// migrated with the migrate CLI carries on where the CLI stopped. Each migration file
+// runs as one implicit transaction, as the CLI runs it. A failed migration leaves the
+// database dirty at its version until Force records the version it is really at.
+package migration
+
+import (
+	"cmp"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed *.up.sql *.down.sql
+var files embed.FS
+
+// lockKey identifies the advisory lock that serializes migrators on a database.
+const lockKey int64 = 0x66696e636f7265 // "fincore"
+
+var (
+	ErrDirty          = errors.New("database is dirty: fix the failed migration and force its version")
+	ErrUnknownVersion = errors.New("no migration has this version")
+	ErrNoChange       = errors.New("no migration to roll back")
+)
+
+// Migration is one embedded migration.
+type Migration struct {
+	Version uint
+	Name    string
+	up      string
+	down    string
+}
+
+// Status is where a database stands.
+type Status struct {
+	// Version is the last migration applied, zero when none is
+	Version uint
+	// Dirty is set when the migration to Version failed part way
+	Dirty bool
+	// Latest is the version of the last embedded migration
+	Latest uint
+	// Pending are the embedded migrations after Version, oldest first
+	Pending []Migration
+}
+
+var migrations = mustParse(files)
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// mustParse reads the migrations of fsys in version order. Each must have an up and a
+// down file.
+func mustParse(fsys fs.FS) []Migration {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		panic(err)
+	}
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			panic(fmt.Sprintf("migration: malformed file name %q", entry.Name()))
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			panic(fmt.Sprintf("migration: invalid version in %q", entry.Name()))
+		}
+		sql, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			panic(err)
+		}
+
+		m := byVersion[uint(version)]
+		if m == nil {
+			m = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = m
+		}
+		if match[3] == "up" {
+			m.up = string(sql)
+		} else {
+			m.down = string(sql)
+		}
+	}
+
+	var result []Migration
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			panic(fmt.Sprintf("migration: %d_%s needs an up and a down file", m.Version, m.Name))
+		}
+		result = append(result, *m)
+	}
+	slices.SortFunc(result, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return result
+}
+
+// Migrations returns the embedded migrations, oldest first.
+func Migrations() []Migration {
+	return slices.Clone(migrations)
+}
+
+// Migrator applies the embedded migrations to a database. Migrators on any number of
+// processes may run at once: each holds an advisory lock on the database while it works.
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+
+	// Logf reports each migration as it runs; nil discards the reports.
+	Logf func(format string, args ...any)
+}
+
+// New returns a Migrator for the database of pool.
+func New(pool *pgxpool.Pool) *Migrator {
+	return &Migrator{pool: pool, migrations: migrations}
+}
+
+// Up applies the embedded migrations to pool's database, bootstrapping its schema when
+// it is empty.
+func Up(ctx context.Context, pool *pgxpool.Pool) error {
+	return New(pool).Up(ctx)
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(conn *pgx.Conn) error {
+		return m.migrate(ctx, conn, m.migrations[len(m.migrations)-1].Version)
+	})
+}
+
+// Down rolls back the last steps migrations, or all of them when fewer are applied.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("cannot roll back %d migrations", steps)
+	}
+	return m.locked(ctx, func(conn *pgx.Conn) error {
+		current, _, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if current == 0 {
+			return ErrNoChange
+		}
+		i := m.index(current)
+		if i < 0 {
+			return fmt.Errorf("database is at version %d: %w", current, ErrUnknownVersion)
+		}
+		var target uint
+		if i-steps >= 0 {
+			target = m.migrations[i-steps].Version
+		}
+		return m.migrate(ctx, conn, target)
+	})
+}
+
+// Goto migrates up or down to version; zero rolls back every migration.
+func (m *Migrator) Goto(ctx context.Context, version uint) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("cannot migrate to %d: %w", version, ErrUnknownVersion)
+	}
+	return m.locked(ctx, func(conn *pgx.Conn) error {
+		return m.migrate(ctx, conn, version)
+	})
+}
+
+// Force records version as applied and clears the dirty flag without running any
+// migration, once a failed migration has been repaired by hand.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("cannot force %d: %w", version, ErrUnknownVersion)
+	}
+	return m.locked(ctx, func(conn *pgx.Conn) error {
+		return writeVersion(ctx, conn, version, false)
+	})
+}
+
+// Status reports the version of the database and the migrations still to apply.
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	var status Status
+	err := m.locked(ctx, func(conn *pgx.Conn) error {
+		var err error
+		status.Version, status.Dirty, err = readVersion(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return Status{}, err
+	}
+	status.Latest = m.migrations[len(m.migrations)-1].Version
+	for _, migration := range m.migrations {
+		if migration.Version > status.Version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+// index returns the position of version among the migrations, or -1.
+func (m *Migrator) index(version uint) int {
+	return slices.IndexFunc(m.migrations, func(migration Migration) bool {
+		return migration.Version == version
+	})
+}
+
+// migrate runs the migrations between the database's version and target, in the order
+// that gets there.
+func (m *Migrator) migrate(ctx context.Context, conn *pgx.Conn, target uint) error {
+	current, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("version %d: %w", current, ErrDirty)
+	}
+	i := -1
+	if current != 0 {
+		if i = m.index(current); i < 0 {
+			return fmt.Errorf("database is at version %d: %w", current, ErrUnknownVersion)
+		}
+	}
+
+	// Each migration marks the database dirty at the version it leads to until it
+	// succeeds, as the migrate CLI does
+	for ; i+1 < len(m.migrations) && m.migrations[i+1].Version <= target; i++ {
+		next := m.migrations[i+1]
+		m.logf("applying %d_%s", next.Version, next.Name)
+		if err := run(ctx, conn, next.Version, next.up); err != nil {
+			return fmt.Errorf("migration %d_%s up: %w", next.Version, next.Name, err)
+		}
+	}
+	for ; i >= 0 && m.migrations[i].Version > target; i-- {
+		var prev uint
+		if i > 0 {
+			prev = m.migrations[i-1].Version
+		}
+		migration := m.migrations[i]
+		m.logf("rolling back %d_%s", migration.Version, migration.Name)
+		if err := run(ctx, conn, prev, migration.down); err != nil {
+			return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) logf(format string, args ...any) {
+	if m.Logf != nil {
+		m.Logf(format, args...)
+	}
+}
+
+// run executes the statements of a migration file, which leads to version.
+func run(ctx context.Context, conn *pgx.Conn, version uint, sql string) error {
+	if err := writeVersion(ctx, conn, version, true); err != nil {
+		return err
+	}
+	// Without arguments the file goes out as one simple query, which Postgres runs
+	// as a single transaction
+	if _, err := conn.Exec(ctx, sql); err != nil {
+		return err
+	}
+	return writeVersion(ctx, conn, version, false)
+}
+
+// locked runs fn on a connection holding the advisory lock, once the version table
+// exists.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgx.Conn) error) (err error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("cannot lock database: %w", err)
+	}
+	defer func() {
+		if _, unlockErr := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey); unlockErr != nil {
+			// The lock goes with the session, so the connection must not return to the pool
+			conn.Conn().Close(context.Background())
+			err = errors.Join(err, fmt.Errorf("cannot unlock database: %w", unlockErr))
+		}
+	}()
+
+	_, err = conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`)
+	if err != nil {
+		return fmt.Errorf("cannot create schema_migrations: %w", err)
+	}
+	return fn(conn.Conn())
+}
+
+// readVersion returns the version recorded in schema_migrations. The migrate CLI
+// records a failed first migration as version -1, which reads as zero.
+func readVersion(ctx context.Context, conn *pgx.Conn) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("cannot read schema version: %w", err)
+	}
+	return uint(max(version, 0)), dirty, nil
+}
+
+// writeVersion replaces the version recorded in schema_migrations. No version is
+// recorded as an empty table, or as -1 while dirty, as the migrate CLI does.
+func writeVersion(ctx context.Context, conn *pgx.Conn, version uint, dirty bool) error {
+	err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations"); err != nil {
+			return err
+		}
+		if version == 0 && !dirty {
+			return nil
+		}
+		recorded := int64(version)
+		if version == 0 {
+			recorded = -1
+		}
+		_, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)", recorded, dirty)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("cannot record schema version: %w", err)
+	}
+	return nil
+}
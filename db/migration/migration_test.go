@@ -0,0 +1,147 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/RakibRahman/fincore-api/config"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	all := Migrations()
+	require.NotEmpty(t, all)
+	for i, migration := range all {
+		require.Equal(t, uint(i+1), migration.Version, "migrations must be numbered without gaps")
+		require.NotEmpty(t, migration.up)
+		require.NotEmpty(t, migration.down)
+	}
+	require.Equal(t, "init_schema", all[0].Name)
+}
+
+func TestMustParse(t *testing.T) {
+	parsed := mustParse(fstest.MapFS{
+		"000002_b.up.sql":   {Data: []byte("up b")},
+		"000002_b.down.sql": {Data: []byte("down b")},
+		"000001_a.up.sql":   {Data: []byte("up a")},
+		"000001_a.down.sql": {Data: []byte("down a")},
+	})
+	require.Equal(t, []Migration{
+		{Version: 1, Name: "a", up: "up a", down: "down a"},
+		{Version: 2, Name: "b", up: "up b", down: "down b"},
+	}, parsed)
+
+	require.Panics(t, func() {
+		mustParse(fstest.MapFS{"000001_a.up.sql": {Data: []byte("up a")}})
+	})
+	require.Panics(t, func() {
+		mustParse(fstest.MapFS{"a.up.sql": {Data: []byte("up a")}})
+	})
+}
+
+// newDatabase creates an empty database for the test and returns a pool on it. The
+// database is dropped when the test ends.
+func newDatabase(t *testing.T) *pgxpool.Pool {
+	ctx := context.Background()
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+
+	admin, err := pgx.Connect(ctx, cfg.DBSource)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Close(ctx) })
+
+	name := "fincore_migration_" + uuid.NewString()[:8]
+	_, err = admin.Exec(ctx, fmt.Sprintf("CREATE DATABASE %q", name))
+	require.NoError(t, err)
+
+	poolConfig, err := cfg.PoolConfig()
+	require.NoError(t, err)
+	poolConfig.ConnConfig.Database = name
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		pool.Close()
+		_, err := admin.Exec(ctx, fmt.Sprintf("DROP DATABASE %q WITH (FORCE)", name))
+		require.NoError(t, err)
+	})
+	return pool
+}
+
+func requireVersion(t *testing.T, m *Migrator, version uint) {
+	status, err := m.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, version, status.Version)
+	require.False(t, status.Dirty)
+	require.Len(t, status.Pending, int(status.Latest-version))
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	m := New(newDatabase(t))
+	latest := migrations[len(migrations)-1].Version
+
+	requireVersion(t, m, 0)
+	require.ErrorIs(t, m.Down(ctx, 1), ErrNoChange)
+
+	require.NoError(t, m.Up(ctx))
+	requireVersion(t, m, latest)
+	require.NoError(t, m.Up(ctx))
+	requireVersion(t, m, latest)
+
+	require.NoError(t, m.Down(ctx, 2))
+	requireVersion(t, m, latest-2)
+
+	require.NoError(t, m.Goto(ctx, 3))
+	requireVersion(t, m, 3)
+	require.ErrorIs(t, m.Goto(ctx, latest+1), ErrUnknownVersion)
+
+	require.NoError(t, m.Down(ctx, 100))
+	requireVersion(t, m, 0)
+
+	require.NoError(t, m.Goto(ctx, latest))
+	requireVersion(t, m, latest)
+}
+
+func TestMigratorDirty(t *testing.T) {
+	ctx := context.Background()
+	m := New(newDatabase(t))
+	m.migrations = append(Migrations()[:2], Migration{Version: 3, Name: "broken", up: "SELECT 1/0", down: "SELECT 1"})
+
+	require.Error(t, m.Up(ctx))
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(3), status.Version)
+	require.True(t, status.Dirty)
+	require.ErrorIs(t, m.Up(ctx), ErrDirty)
+
+	require.NoError(t, m.Force(ctx, 2))
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(2), status.Version)
+	require.False(t, status.Dirty)
+}
+
+func TestMigratorConcurrentUp(t *testing.T) {
+	pool := newDatabase(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Go(func() {
+			errs <- Up(context.Background(), pool)
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	requireVersion(t, New(pool), migrations[len(migrations)-1].Version)
+}
@@ -107,3 +107,564 @@ ALTER TABLE "transactions" ADD FOREIGN KEY ("related_account_id") REFERENCES "ac
 ALTER TABLE "transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "users" ADD CONSTRAINT "users_password_hash_format"
+  CHECK ("password_hash" ~ '^\$(2[aby]|argon2id)\$') NOT VALID;
+
+ALTER TABLE "transfers" ADD COLUMN "reference" varchar UNIQUE;
+
+COMMENT ON COLUMN "transfers"."reference" IS 'Idempotency key / external reference';
+
+ALTER TABLE "transfers" ADD COLUMN "failure_reason" varchar;
+
+COMMENT ON COLUMN "transfers"."failure_reason" IS 'Why the transfer failed, e.g. insufficient_balance; set only on failed transfers';
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_processed_at_status"
+  CHECK (("status" = 'pending') = ("processed_at" IS NULL)) NOT VALID;
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_failure_reason_status"
+  CHECK (("status" = 'failed') = ("failure_reason" IS NOT NULL)) NOT VALID;
+
+ALTER TYPE "TransactionType" ADD VALUE IF NOT EXISTS 'reversal';
+
+CREATE TYPE "ReversalReason" AS ENUM (
+  'customer_request',
+  'duplicate',
+  'fraud',
+  'processing_error'
+);
+
+CREATE TABLE "transfer_reversals" (
+  "id" uuid PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "transfer_id" uuid UNIQUE NOT NULL,
+  "amount_cents" bigint NOT NULL,
+  "reason" "ReversalReason" NOT NULL,
+  "debit_transaction_id" uuid NOT NULL,
+  "credit_transaction_id" uuid NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "transfer_reversals_amount_positive" CHECK ("amount_cents" > 0)
+);
+
+COMMENT ON TABLE "transfer_reversals" IS 'Compensation of a completed transfer. At most one per transfer, for all or part of its amount.';
+
+COMMENT ON COLUMN "transfer_reversals"."debit_transaction_id" IS 'Takes the money back from the original recipient';
+
+COMMENT ON COLUMN "transfer_reversals"."credit_transaction_id" IS 'Returns the money to the original sender';
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("debit_transaction_id") REFERENCES "transactions" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("credit_transaction_id") REFERENCES "transactions" ("id");
+
+CREATE TABLE "account_status_events" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "from_status" "AccountStatus" NOT NULL,
+  "to_status" "AccountStatus" NOT NULL,
+  "reason" varchar NOT NULL,
+  "actor" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "account_status_events" ("account_id", "created_at");
+
+COMMENT ON TABLE "account_status_events" IS 'Audit trail of account freezes, unfreezes and closures.';
+
+COMMENT ON COLUMN "account_status_events"."actor" IS 'Who made the change, e.g. user:<uuid> or the name of a system job';
+
+ALTER TABLE "account_status_events" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+CREATE TABLE "exchange_rates" (
+  "id" bigserial PRIMARY KEY,
+  "base_currency" "Currency" NOT NULL,
+  "quote_currency" "Currency" NOT NULL,
+  "rate" numeric(20,10) NOT NULL,
+  "effective_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "exchange_rates_rate_positive" CHECK ("rate" > 0),
+  CONSTRAINT "exchange_rates_distinct_currencies" CHECK ("base_currency" <> "quote_currency")
+);
+
+CREATE INDEX ON "exchange_rates" ("base_currency", "quote_currency", "effective_at");
+
+COMMENT ON TABLE "exchange_rates" IS 'Conversion rates for cross-currency transfers. The newest rate already in effect wins.';
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'Units of quote_currency per one unit of base_currency';
+
+ALTER TABLE "transfers" ADD COLUMN "to_amount_cents" bigint;
+
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" numeric(20,10);
+
+COMMENT ON COLUMN "transfers"."to_amount_cents" IS 'Amount credited in the destination currency; set only on cross-currency transfers';
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'Rate applied to amount_cents to get to_amount_cents';
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_conversion_complete"
+  CHECK (("to_amount_cents" IS NULL) = ("exchange_rate" IS NULL));
+
+CREATE TYPE "JournalEntryType" AS ENUM (
+  'opening_balance',
+  'deposit',
+  'withdrawal',
+  'transfer',
+  'reversal'
+);
+
+CREATE TYPE "SystemAccountKind" AS ENUM (
+  'cash_in',
+  'cash_out',
+  'fx_clearing'
+);
+
+CREATE TABLE "system_accounts" (
+  "id" bigserial PRIMARY KEY,
+  "kind" "SystemAccountKind" NOT NULL,
+  "currency" "Currency" NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "system_accounts_kind_currency_key" UNIQUE ("kind", "currency"),
+  CONSTRAINT "system_accounts_id_currency_key" UNIQUE ("id", "currency")
+);
+
+COMMENT ON TABLE "system_accounts" IS 'Internal ledger accounts on the other side of customer postings; one per kind and currency.';
+
+CREATE TABLE "journal_entries" (
+  "id" uuid PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "type" "JournalEntryType" NOT NULL,
+  "transfer_id" uuid,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "journal_entries" ("transfer_id");
+
+COMMENT ON TABLE "journal_entries" IS 'One balanced business event in the double-entry ledger; its postings sum to zero per currency.';
+
+ALTER TABLE "journal_entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_id_currency_key" UNIQUE ("id", "currency");
+
+CREATE TABLE "postings" (
+  "id" bigserial PRIMARY KEY,
+  "entry_id" uuid NOT NULL,
+  "account_id" bigint,
+  "system_account_id" bigint,
+  "transaction_id" uuid,
+  "currency" "Currency" NOT NULL,
+  "amount_cents" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "postings_single_account" CHECK (num_nonnulls("account_id", "system_account_id") = 1),
+  CONSTRAINT "postings_amount_nonzero" CHECK ("amount_cents" <> 0)
+);
+
+CREATE INDEX ON "postings" ("entry_id");
+
+CREATE INDEX ON "postings" ("account_id");
+
+CREATE INDEX ON "postings" ("system_account_id");
+
+COMMENT ON TABLE "postings" IS 'Append-only legs of journal entries. Each moves amount_cents into (positive) or out of (negative) one ledger account.';
+
+COMMENT ON COLUMN "postings"."transaction_id" IS 'The customer-facing transaction this leg produced, for account postings';
+
+-- The composite keys also pin each posting to its account's currency
+ALTER TABLE "postings" ADD FOREIGN KEY ("entry_id") REFERENCES "journal_entries" ("id");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("account_id", "currency") REFERENCES "accounts" ("id", "currency");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("system_account_id", "currency") REFERENCES "system_accounts" ("id", "currency");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("transaction_id") REFERENCES "transactions" ("id");
+
+CREATE FUNCTION "postings_check_entry_balanced"() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM "postings"
+    WHERE "entry_id" = NEW."entry_id"
+    GROUP BY "currency"
+    HAVING sum("amount_cents") <> 0
+  ) THEN
+    RAISE EXCEPTION 'journal entry % does not balance', NEW."entry_id"
+      USING ERRCODE = 'check_violation', CONSTRAINT = 'postings_entry_balanced';
+  END IF;
+  RETURN NULL;
+END;
+$$;
+
+-- Checked at commit, once every leg of the entry has been written
+CREATE CONSTRAINT TRIGGER "postings_entry_balanced"
+  AFTER INSERT ON "postings"
+  DEFERRABLE INITIALLY DEFERRED
+  FOR EACH ROW EXECUTE FUNCTION "postings_check_entry_balanced"();
+
+CREATE FUNCTION "postings_reject_change"() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+  RAISE EXCEPTION 'postings are append-only; post a correcting entry instead'
+    USING ERRCODE = 'restrict_violation';
+END;
+$$;
+
+CREATE TRIGGER "postings_append_only"
+  BEFORE UPDATE OR DELETE ON "postings"
+  FOR EACH ROW EXECUTE FUNCTION "postings_reject_change"();
+
+-- Rows of one account are inserted while its row lock is held, so a sequence orders
+-- them as they were applied. created_at can't: it is the start time of the transaction.
+CREATE SEQUENCE "transactions_seq_seq";
+
+ALTER TABLE "transactions" ADD COLUMN "seq" bigint;
+
+ALTER TABLE "transactions"
+  ALTER COLUMN "seq" SET DEFAULT nextval('transactions_seq_seq'),
+  ALTER COLUMN "seq" SET NOT NULL;
+
+ALTER SEQUENCE "transactions_seq_seq" OWNED BY "transactions"."seq";
+
+CREATE UNIQUE INDEX ON "transactions" ("account_id", "seq");
+
+COMMENT ON COLUMN "transactions"."seq" IS 'Order in which rows were applied to their account; balance_after_cents chains along it';
+
+CREATE TYPE "DriftKind" AS ENUM (
+  'transactions_total',
+  'ledger_total',
+  'balance_chain'
+);
+
+CREATE TABLE "reconciliation_incidents" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "kind" "DriftKind" NOT NULL,
+  "expected_cents" bigint NOT NULL,
+  "actual_cents" bigint NOT NULL,
+  "transaction_id" uuid,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "resolved_at" timestamptz
+);
+
+CREATE UNIQUE INDEX "reconciliation_incidents_open_key" ON "reconciliation_incidents" ("account_id", "kind")
+  WHERE "resolved_at" IS NULL;
+
+COMMENT ON TABLE "reconciliation_incidents" IS 'Drift found by reconciliation. At most one open incident per account and kind.';
+
+COMMENT ON COLUMN "reconciliation_incidents"."transaction_id" IS 'First transaction that breaks the balance chain, for balance_chain drift';
+
+ALTER TABLE "reconciliation_incidents" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "reconciliation_incidents" ADD FOREIGN KEY ("transaction_id") REFERENCES "transactions" ("id");
+
+ALTER TABLE "accounts" ADD COLUMN "overdraft_limit_cents" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_overdraft_limit_nonnegative"
+  CHECK ("overdraft_limit_cents" >= 0);
+
+COMMENT ON COLUMN "accounts"."overdraft_limit_cents" IS 'How far below zero customer debits may take the balance';
+
+COMMENT ON COLUMN "accounts"."balance_cents" IS 'Balance stored in cents; negative while the account is in overdraft';
+
+CREATE INDEX "accounts_overdrawn_idx" ON "accounts" ("id") WHERE "balance_cents" < 0;
+
+ALTER TYPE "TransactionType" ADD VALUE IF NOT EXISTS 'overdraft_interest';
+
+ALTER TYPE "TransactionType" ADD VALUE IF NOT EXISTS 'overdraft_fee';
+
+ALTER TYPE "JournalEntryType" ADD VALUE IF NOT EXISTS 'overdraft_charge';
+
+ALTER TYPE "SystemAccountKind" ADD VALUE IF NOT EXISTS 'fee_income';
+
+ALTER TABLE "accounts" ADD COLUMN "held_cents" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_held_nonnegative" CHECK ("held_cents" >= 0);
+
+COMMENT ON COLUMN "accounts"."held_cents" IS 'Sum of active holds: reserved out of the available balance but not posted';
+
+CREATE TYPE "HoldStatus" AS ENUM (
+  'active',
+  'captured',
+  'voided',
+  'expired'
+);
+
+CREATE TABLE "holds" (
+  "id" uuid PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "account_id" bigint NOT NULL,
+  "amount_cents" bigint NOT NULL,
+  "status" "HoldStatus" NOT NULL DEFAULT 'active',
+  "captured_cents" bigint,
+  "capture_transaction_id" uuid,
+  "capture_transfer_id" uuid,
+  "reference" varchar UNIQUE,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "resolved_at" timestamptz,
+  CONSTRAINT "holds_amount_positive" CHECK ("amount_cents" > 0),
+  CONSTRAINT "holds_captured_within_amount" CHECK ("captured_cents" > 0 AND "captured_cents" <= "amount_cents"),
+  CONSTRAINT "holds_captured_status" CHECK (("status" = 'captured') = ("captured_cents" IS NOT NULL)),
+  CONSTRAINT "holds_resolved_status" CHECK (("status" = 'active') = ("resolved_at" IS NULL))
+);
+
+CREATE INDEX ON "holds" ("account_id", "created_at");
+
+CREATE INDEX "holds_active_expiry_idx" ON "holds" ("expires_at") WHERE "status" = 'active';
+
+COMMENT ON TABLE "holds" IS 'Card-style authorizations: funds reserved on an account until captured, voided or expired.';
+
+COMMENT ON COLUMN "holds"."captured_cents" IS 'Amount actually taken on capture; the rest of the hold is released';
+
+COMMENT ON COLUMN "holds"."capture_transaction_id" IS 'Debit that captured the hold, for a withdrawal or the outgoing leg of a transfer';
+
+COMMENT ON COLUMN "holds"."capture_transfer_id" IS 'Transfer that captured the hold, when captured into a transfer';
+
+COMMENT ON COLUMN "holds"."reference" IS 'Idempotency key / external reference';
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("capture_transaction_id") REFERENCES "transactions" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("capture_transfer_id") REFERENCES "transfers" ("id");
+
+CREATE TYPE "ScheduleFrequency" AS ENUM (
+  'once',
+  'daily',
+  'weekly',
+  'monthly'
+);
+
+CREATE TYPE "ScheduleStatus" AS ENUM (
+  'active',
+  'completed',
+  'cancelled'
+);
+
+CREATE TYPE "InsufficientFundsPolicy" AS ENUM (
+  'retry',
+  'skip'
+);
+
+CREATE TYPE "ScheduleRunStatus" AS ENUM (
+  'succeeded',
+  'retrying',
+  'skipped',
+  'failed'
+);
+
+CREATE TABLE "transfer_schedules" (
+  "id" uuid PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount_cents" bigint NOT NULL,
+  "frequency" "ScheduleFrequency" NOT NULL,
+  "day_of_month" integer,
+  "status" "ScheduleStatus" NOT NULL DEFAULT 'active',
+  "insufficient_funds_policy" "InsufficientFundsPolicy" NOT NULL DEFAULT 'skip',
+  "max_attempts" integer NOT NULL DEFAULT 3,
+  "failed_attempts" integer NOT NULL DEFAULT 0,
+  "next_occurrence_at" timestamptz NOT NULL,
+  "next_run_at" timestamptz NOT NULL,
+  "ends_at" timestamptz,
+  "locked_until" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "transfer_schedules_amount_positive" CHECK ("amount_cents" > 0),
+  CONSTRAINT "transfer_schedules_different_accounts" CHECK ("from_account_id" <> "to_account_id"),
+  CONSTRAINT "transfer_schedules_day_of_month" CHECK (("frequency" = 'monthly') = ("day_of_month" IS NOT NULL) AND "day_of_month" BETWEEN 1 AND 31),
+  CONSTRAINT "transfer_schedules_max_attempts" CHECK ("max_attempts" >= 1),
+  CONSTRAINT "transfer_schedules_failed_attempts" CHECK ("failed_attempts" >= 0 AND "failed_attempts" < "max_attempts")
+);
+
+CREATE INDEX ON "transfer_schedules" ("from_account_id", "created_at");
+
+CREATE INDEX "transfer_schedules_due_idx" ON "transfer_schedules" ("next_run_at") WHERE "status" = 'active';
+
+COMMENT ON TABLE "transfer_schedules" IS 'Future-dated and recurring transfers (standing orders)';
+
+COMMENT ON COLUMN "transfer_schedules"."day_of_month" IS 'Monthly schedules only; clamped to the last day of shorter months';
+
+COMMENT ON COLUMN "transfer_schedules"."failed_attempts" IS 'Attempts of the current occurrence refused for insufficient funds';
+
+COMMENT ON COLUMN "transfer_schedules"."next_occurrence_at" IS 'When the current occurrence was due';
+
+COMMENT ON COLUMN "transfer_schedules"."next_run_at" IS 'When the current occurrence is next attempted; later than next_occurrence_at while retrying';
+
+COMMENT ON COLUMN "transfer_schedules"."locked_until" IS 'Lease held by the worker running the schedule';
+
+ALTER TABLE "transfer_schedules" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_schedules" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+CREATE TABLE "transfer_schedule_runs" (
+  "id" bigserial PRIMARY KEY,
+  "schedule_id" uuid NOT NULL,
+  "occurrence_at" timestamptz NOT NULL,
+  "attempt" integer NOT NULL,
+  "status" "ScheduleRunStatus" NOT NULL,
+  "transfer_id" uuid,
+  "failure_reason" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "transfer_schedule_runs_attempt_key" UNIQUE ("schedule_id", "occurrence_at", "attempt")
+);
+
+CREATE INDEX ON "transfer_schedule_runs" ("schedule_id", "id");
+
+COMMENT ON TABLE "transfer_schedule_runs" IS 'One row per attempt of a scheduled transfer';
+
+ALTER TABLE "transfer_schedule_runs" ADD FOREIGN KEY ("schedule_id") REFERENCES "transfer_schedules" ("id");
+
+ALTER TABLE "transfer_schedule_runs" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE TYPE "EventType" AS ENUM (
+  'deposit.completed',
+  'withdrawal.completed',
+  'transfer.completed',
+  'transfer.failed',
+  'transfer.reversed',
+  'overdraft.charged'
+);
+
+CREATE TYPE "WebhookDeliveryStatus" AS ENUM (
+  'pending',
+  'delivered',
+  'dead'
+);
+
+CREATE TABLE "outbox_events" (
+  "id" bigserial PRIMARY KEY,
+  "type" "EventType" NOT NULL,
+  "account_ids" bigint[] NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "dispatched_at" timestamptz
+);
+
+CREATE INDEX "outbox_events_undispatched_idx" ON "outbox_events" ("id") WHERE "dispatched_at" IS NULL;
+
+COMMENT ON TABLE "outbox_events" IS 'Ledger events, written in the same database transaction as the money movement they describe';
+
+COMMENT ON COLUMN "outbox_events"."account_ids" IS 'Customer accounts the event concerns; their owners'' webhook endpoints receive it';
+
+COMMENT ON COLUMN "outbox_events"."dispatched_at" IS 'When a delivery was queued for every subscribed endpoint';
+
+CREATE TABLE "webhook_endpoints" (
+  "id" uuid PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "owner_id" uuid NOT NULL,
+  "url" text NOT NULL,
+  "secret" text NOT NULL,
+  "event_types" text[] NOT NULL DEFAULT '{}',
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "webhook_endpoints" ("owner_id");
+
+COMMENT ON TABLE "webhook_endpoints" IS 'URLs that receive a user''s ledger events';
+
+COMMENT ON COLUMN "webhook_endpoints"."secret" IS 'HMAC-SHA256 key deliveries are signed with';
+
+COMMENT ON COLUMN "webhook_endpoints"."event_types" IS 'Event types delivered to the endpoint; empty means all of them';
+
+ALTER TABLE "webhook_endpoints" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "event_id" bigint NOT NULL,
+  "endpoint_id" uuid NOT NULL,
+  "status" "WebhookDeliveryStatus" NOT NULL DEFAULT 'pending',
+  "attempts" integer NOT NULL DEFAULT 0,
+  "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+  "locked_until" timestamptz,
+  "last_status_code" integer,
+  "last_error" text,
+  "delivered_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "webhook_deliveries_event_endpoint_key" UNIQUE ("event_id", "endpoint_id")
+);
+
+CREATE INDEX "webhook_deliveries_due_idx" ON "webhook_deliveries" ("next_attempt_at") WHERE "status" = 'pending';
+
+CREATE INDEX ON "webhook_deliveries" ("endpoint_id", "id");
+
+COMMENT ON TABLE "webhook_deliveries" IS 'One event on its way to one webhook endpoint';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'dead once every attempt has failed; redelivery puts it back to pending';
+
+COMMENT ON COLUMN "webhook_deliveries"."locked_until" IS 'Lease held by the dispatcher sending the delivery';
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("event_id") REFERENCES "outbox_events" ("id");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id");
+
+-- List queries page on (created_at, id) or id instead of OFFSET. The id tie-breaker makes
+-- the order total, so the (…, created_at) indexes it extends are dropped.
+DROP INDEX IF EXISTS "transactions_account_id_created_at_idx";
+
+CREATE INDEX "transactions_account_keyset_idx" ON "transactions" ("account_id", "created_at", "id");
+
+CREATE INDEX "transfers_keyset_idx" ON "transfers" ("created_at", "id");
+
+CREATE INDEX "transfers_from_account_keyset_idx" ON "transfers" ("from_account_id", "created_at", "id");
+
+CREATE INDEX "transfers_to_account_keyset_idx" ON "transfers" ("to_account_id", "created_at", "id");
+
+CREATE INDEX "accounts_owner_keyset_idx" ON "accounts" ("owner_id", "id");
+
+DROP INDEX IF EXISTS "account_status_events_account_id_created_at_idx";
+
+CREATE INDEX "account_status_events_account_keyset_idx" ON "account_status_events" ("account_id", "created_at", "id");
+
+DROP INDEX IF EXISTS "holds_account_id_created_at_idx";
+
+CREATE INDEX "holds_account_keyset_idx" ON "holds" ("account_id", "created_at", "id");
+
+DROP INDEX IF EXISTS "transfer_schedules_from_account_id_created_at_idx";
+
+CREATE INDEX "transfer_schedules_from_account_keyset_idx" ON "transfer_schedules" ("from_account_id", "created_at", "id");
+
+DROP INDEX IF EXISTS "webhook_endpoints_owner_id_idx";
+
+CREATE INDEX "webhook_endpoints_owner_keyset_idx" ON "webhook_endpoints" ("owner_id", "created_at", "id");
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE "transactions" ADD COLUMN "description" varchar;
+
+COMMENT ON COLUMN "transactions"."description" IS 'Free-text memo given with the deposit, withdrawal or transfer';
+
+-- Transfer legs written before this migration keep a NULL related_account_id
+CREATE INDEX "transactions_account_type_keyset_idx" ON "transactions" ("account_id", "type", "created_at", "id");
+
+CREATE INDEX "transactions_account_amount_keyset_idx" ON "transactions" ("account_id", abs("amount_cents"), "id");
+
+CREATE INDEX "transactions_account_related_keyset_idx" ON "transactions" ("account_id", "related_account_id", "created_at", "id")
+WHERE "related_account_id" IS NOT NULL;
+
+CREATE INDEX "transactions_description_trgm_idx" ON "transactions" USING gin ("description" gin_trgm_ops);
+
+COMMENT ON COLUMN "transactions"."reference" IS 'Idempotency key of a deposit or withdrawal; NULL on transfer legs';
+
+ALTER TABLE "transactions" DROP CONSTRAINT "transactions_reference_key";
+
+ALTER TABLE "transactions" ADD CONSTRAINT "transactions_account_reference_key" UNIQUE ("account_id", "reference");
+
+ALTER TABLE "transfers" DROP CONSTRAINT "transfers_reference_key";
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_from_account_reference_key" UNIQUE ("from_account_id", "reference");
+
+ALTER TABLE "holds" DROP CONSTRAINT "holds_reference_key";
+
+ALTER TABLE "holds" ADD CONSTRAINT "holds_account_reference_key" UNIQUE ("account_id", "reference");
+
+CREATE TABLE "overdraft_accruals" (
+  "account_id" bigint NOT NULL,
+  "day" date NOT NULL,
+  "type" "TransactionType" NOT NULL,
+  "transaction_id" uuid UNIQUE NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "day", "type")
+);
+
+COMMENT ON TABLE "overdraft_accruals" IS 'Overdraft charges booked per account, day and charge type; keeps each from being booked twice';
+
+ALTER TABLE "overdraft_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "overdraft_accruals" ADD FOREIGN KEY ("transaction_id") REFERENCES "transactions" ("id");
@@ -0,0 +1,1976 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/RakibRahman/fincore-api/db/sqlc (interfaces: Store)
+//
+// Generated by this command:
+//
+//	mockgen -package mockdb -destination db/mock/store.go github.com/RakibRahman/fincore-api/db/sqlc Store
+//
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	sqlc "github.com/RakibRahman/fincore-api/db/sqlc"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// AccrueOverdraftTx mocks base method.
+func (m *MockStore) AccrueOverdraftTx(ctx context.Context, arg sqlc.AccrueOverdraftTxParams) (sqlc.AccrueOverdraftTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccrueOverdraftTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.AccrueOverdraftTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccrueOverdraftTx indicates an expected call of AccrueOverdraftTx.
+func (mr *MockStoreMockRecorder) AccrueOverdraftTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccrueOverdraftTx", reflect.TypeOf((*MockStore)(nil).AccrueOverdraftTx), ctx, arg)
+}
+
+// AccrueOverdrafts mocks base method.
+func (m *MockStore) AccrueOverdrafts(ctx context.Context, day time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccrueOverdrafts", ctx, day)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccrueOverdrafts indicates an expected call of AccrueOverdrafts.
+func (mr *MockStoreMockRecorder) AccrueOverdrafts(ctx, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccrueOverdrafts", reflect.TypeOf((*MockStore)(nil).AccrueOverdrafts), ctx, day)
+}
+
+// AdvanceTransferSchedule mocks base method.
+func (m *MockStore) AdvanceTransferSchedule(ctx context.Context, arg sqlc.AdvanceTransferScheduleParams) (sqlc.TransferSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceTransferSchedule", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TransferSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceTransferSchedule indicates an expected call of AdvanceTransferSchedule.
+func (mr *MockStoreMockRecorder) AdvanceTransferSchedule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceTransferSchedule", reflect.TypeOf((*MockStore)(nil).AdvanceTransferSchedule), ctx, arg)
+}
+
+// CancelTransferSchedule mocks base method.
+func (m *MockStore) CancelTransferSchedule(ctx context.Context, id pgtype.UUID) (sqlc.TransferSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTransferSchedule", ctx, id)
+	ret0, _ := ret[0].(sqlc.TransferSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelTransferSchedule indicates an expected call of CancelTransferSchedule.
+func (mr *MockStoreMockRecorder) CancelTransferSchedule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTransferSchedule", reflect.TypeOf((*MockStore)(nil).CancelTransferSchedule), ctx, id)
+}
+
+// CaptureHoldTx mocks base method.
+func (m *MockStore) CaptureHoldTx(ctx context.Context, arg sqlc.CaptureHoldTxParams) (sqlc.CaptureHoldTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHoldTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.CaptureHoldTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureHoldTx indicates an expected call of CaptureHoldTx.
+func (mr *MockStoreMockRecorder) CaptureHoldTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHoldTx", reflect.TypeOf((*MockStore)(nil).CaptureHoldTx), ctx, arg)
+}
+
+// ChangePasswordTx mocks base method.
+func (m *MockStore) ChangePasswordTx(ctx context.Context, arg sqlc.ChangePasswordTxParams) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePasswordTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePasswordTx indicates an expected call of ChangePasswordTx.
+func (mr *MockStoreMockRecorder) ChangePasswordTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePasswordTx", reflect.TypeOf((*MockStore)(nil).ChangePasswordTx), ctx, arg)
+}
+
+// ClaimDueTransferSchedules mocks base method.
+func (m *MockStore) ClaimDueTransferSchedules(ctx context.Context, arg sqlc.ClaimDueTransferSchedulesParams) ([]sqlc.TransferSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueTransferSchedules", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.TransferSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueTransferSchedules indicates an expected call of ClaimDueTransferSchedules.
+func (mr *MockStoreMockRecorder) ClaimDueTransferSchedules(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueTransferSchedules", reflect.TypeOf((*MockStore)(nil).ClaimDueTransferSchedules), ctx, arg)
+}
+
+// ClaimDueWebhookDeliveries mocks base method.
+func (m *MockStore) ClaimDueWebhookDeliveries(ctx context.Context, arg sqlc.ClaimDueWebhookDeliveriesParams) ([]sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueWebhookDeliveries indicates an expected call of ClaimDueWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ClaimDueWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ClaimDueWebhookDeliveries), ctx, arg)
+}
+
+// ClaimUndispatchedOutboxEvents mocks base method.
+func (m *MockStore) ClaimUndispatchedOutboxEvents(ctx context.Context, limit int32) ([]sqlc.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUndispatchedOutboxEvents", ctx, limit)
+	ret0, _ := ret[0].([]sqlc.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimUndispatchedOutboxEvents indicates an expected call of ClaimUndispatchedOutboxEvents.
+func (mr *MockStoreMockRecorder) ClaimUndispatchedOutboxEvents(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUndispatchedOutboxEvents", reflect.TypeOf((*MockStore)(nil).ClaimUndispatchedOutboxEvents), ctx, limit)
+}
+
+// CloseAccountTx mocks base method.
+func (m *MockStore) CloseAccountTx(ctx context.Context, arg sqlc.ChangeAccountStatusParams) (sqlc.ChangeAccountStatusResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAccountTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ChangeAccountStatusResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAccountTx indicates an expected call of CloseAccountTx.
+func (mr *MockStoreMockRecorder) CloseAccountTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAccountTx", reflect.TypeOf((*MockStore)(nil).CloseAccountTx), ctx, arg)
+}
+
+// CreateAccount mocks base method.
+func (m *MockStore) CreateAccount(ctx context.Context, arg sqlc.CreateAccountParams) (sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockStoreMockRecorder) CreateAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), ctx, arg)
+}
+
+// CreateAccountStatusEvent mocks base method.
+func (m *MockStore) CreateAccountStatusEvent(ctx context.Context, arg sqlc.CreateAccountStatusEventParams) (sqlc.AccountStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountStatusEvent", ctx, arg)
+	ret0, _ := ret[0].(sqlc.AccountStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountStatusEvent indicates an expected call of CreateAccountStatusEvent.
+func (mr *MockStoreMockRecorder) CreateAccountStatusEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountStatusEvent", reflect.TypeOf((*MockStore)(nil).CreateAccountStatusEvent), ctx, arg)
+}
+
+// CreateExchangeRate mocks base method.
+func (m *MockStore) CreateExchangeRate(ctx context.Context, arg sqlc.CreateExchangeRateParams) (sqlc.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExchangeRate", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExchangeRate indicates an expected call of CreateExchangeRate.
+func (mr *MockStoreMockRecorder) CreateExchangeRate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchangeRate", reflect.TypeOf((*MockStore)(nil).CreateExchangeRate), ctx, arg)
+}
+
+// CreateHold mocks base method.
+func (m *MockStore) CreateHold(ctx context.Context, arg sqlc.CreateHoldParams) (sqlc.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockStoreMockRecorder) CreateHold(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockStore)(nil).CreateHold), ctx, arg)
+}
+
+// CreateJournalEntry mocks base method.
+func (m *MockStore) CreateJournalEntry(ctx context.Context, arg sqlc.CreateJournalEntryParams) (sqlc.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJournalEntry", ctx, arg)
+	ret0, _ := ret[0].(sqlc.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJournalEntry indicates an expected call of CreateJournalEntry.
+func (mr *MockStoreMockRecorder) CreateJournalEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJournalEntry", reflect.TypeOf((*MockStore)(nil).CreateJournalEntry), ctx, arg)
+}
+
+// CreateOutboxEvent mocks base method.
+func (m *MockStore) CreateOutboxEvent(ctx context.Context, arg sqlc.CreateOutboxEventParams) (sqlc.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutboxEvent", ctx, arg)
+	ret0, _ := ret[0].(sqlc.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOutboxEvent indicates an expected call of CreateOutboxEvent.
+func (mr *MockStoreMockRecorder) CreateOutboxEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxEvent", reflect.TypeOf((*MockStore)(nil).CreateOutboxEvent), ctx, arg)
+}
+
+// CreateOverdraftAccrual mocks base method.
+func (m *MockStore) CreateOverdraftAccrual(ctx context.Context, arg sqlc.CreateOverdraftAccrualParams) (sqlc.OverdraftAccrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOverdraftAccrual", ctx, arg)
+	ret0, _ := ret[0].(sqlc.OverdraftAccrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOverdraftAccrual indicates an expected call of CreateOverdraftAccrual.
+func (mr *MockStoreMockRecorder) CreateOverdraftAccrual(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOverdraftAccrual", reflect.TypeOf((*MockStore)(nil).CreateOverdraftAccrual), ctx, arg)
+}
+
+// CreatePosting mocks base method.
+func (m *MockStore) CreatePosting(ctx context.Context, arg sqlc.CreatePostingParams) (sqlc.Posting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePosting", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Posting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePosting indicates an expected call of CreatePosting.
+func (mr *MockStoreMockRecorder) CreatePosting(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePosting", reflect.TypeOf((*MockStore)(nil).CreatePosting), ctx, arg)
+}
+
+// CreateTransaction mocks base method.
+func (m *MockStore) CreateTransaction(ctx context.Context, arg sqlc.CreateTransactionParams) (sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransaction", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransaction indicates an expected call of CreateTransaction.
+func (mr *MockStoreMockRecorder) CreateTransaction(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockStore)(nil).CreateTransaction), ctx, arg)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStore) CreateTransfer(ctx context.Context, arg sqlc.CreateTransferParams) (sqlc.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStoreMockRecorder) CreateTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), ctx, arg)
+}
+
+// CreateTransferReversal mocks base method.
+func (m *MockStore) CreateTransferReversal(ctx context.Context, arg sqlc.CreateTransferReversalParams) (sqlc.TransferReversal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferReversal", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TransferReversal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferReversal indicates an expected call of CreateTransferReversal.
+func (mr *MockStoreMockRecorder) CreateTransferReversal(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferReversal", reflect.TypeOf((*MockStore)(nil).CreateTransferReversal), ctx, arg)
+}
+
+// CreateTransferSchedule mocks base method.
+func (m *MockStore) CreateTransferSchedule(ctx context.Context, arg sqlc.CreateTransferScheduleParams) (sqlc.TransferSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferSchedule", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TransferSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferSchedule indicates an expected call of CreateTransferSchedule.
+func (mr *MockStoreMockRecorder) CreateTransferSchedule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferSchedule", reflect.TypeOf((*MockStore)(nil).CreateTransferSchedule), ctx, arg)
+}
+
+// CreateTransferScheduleRun mocks base method.
+func (m *MockStore) CreateTransferScheduleRun(ctx context.Context, arg sqlc.CreateTransferScheduleRunParams) (sqlc.TransferScheduleRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferScheduleRun", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TransferScheduleRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferScheduleRun indicates an expected call of CreateTransferScheduleRun.
+func (mr *MockStoreMockRecorder) CreateTransferScheduleRun(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferScheduleRun", reflect.TypeOf((*MockStore)(nil).CreateTransferScheduleRun), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+}
+
+// CreateWebhookDeliveries mocks base method.
+func (m *MockStore) CreateWebhookDeliveries(ctx context.Context, eventID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDeliveries", ctx, eventID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDeliveries indicates an expected call of CreateWebhookDeliveries.
+func (mr *MockStoreMockRecorder) CreateWebhookDeliveries(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).CreateWebhookDeliveries), ctx, eventID)
+}
+
+// CreateWebhookEndpoint mocks base method.
+func (m *MockStore) CreateWebhookEndpoint(ctx context.Context, arg sqlc.CreateWebhookEndpointParams) (sqlc.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookEndpoint", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookEndpoint indicates an expected call of CreateWebhookEndpoint.
+func (mr *MockStoreMockRecorder) CreateWebhookEndpoint(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEndpoint", reflect.TypeOf((*MockStore)(nil).CreateWebhookEndpoint), ctx, arg)
+}
+
+// DeactivateWebhookEndpoint mocks base method.
+func (m *MockStore) DeactivateWebhookEndpoint(ctx context.Context, id pgtype.UUID) (sqlc.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateWebhookEndpoint", ctx, id)
+	ret0, _ := ret[0].(sqlc.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateWebhookEndpoint indicates an expected call of DeactivateWebhookEndpoint.
+func (mr *MockStoreMockRecorder) DeactivateWebhookEndpoint(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateWebhookEndpoint", reflect.TypeOf((*MockStore)(nil).DeactivateWebhookEndpoint), ctx, id)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockStore) DeleteAccount(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStoreMockRecorder) DeleteAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), ctx, id)
+}
+
+// DepositMoneyTx mocks base method.
+func (m *MockStore) DepositMoneyTx(ctx context.Context, arg sqlc.AccountTransactionParams) (sqlc.AccountTransactionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DepositMoneyTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.AccountTransactionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DepositMoneyTx indicates an expected call of DepositMoneyTx.
+func (mr *MockStoreMockRecorder) DepositMoneyTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DepositMoneyTx", reflect.TypeOf((*MockStore)(nil).DepositMoneyTx), ctx, arg)
+}
+
+// DispatchOutboxEvents mocks base method.
+func (m *MockStore) DispatchOutboxEvents(ctx context.Context, limit int32) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchOutboxEvents", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DispatchOutboxEvents indicates an expected call of DispatchOutboxEvents.
+func (mr *MockStoreMockRecorder) DispatchOutboxEvents(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchOutboxEvents", reflect.TypeOf((*MockStore)(nil).DispatchOutboxEvents), ctx, limit)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockStore) ExpireHolds(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockStoreMockRecorder) ExpireHolds(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockStore)(nil).ExpireHolds), ctx, now)
+}
+
+// FreezeAccountTx mocks base method.
+func (m *MockStore) FreezeAccountTx(ctx context.Context, arg sqlc.ChangeAccountStatusParams) (sqlc.ChangeAccountStatusResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeAccountTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ChangeAccountStatusResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeAccountTx indicates an expected call of FreezeAccountTx.
+func (mr *MockStoreMockRecorder) FreezeAccountTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeAccountTx", reflect.TypeOf((*MockStore)(nil).FreezeAccountTx), ctx, arg)
+}
+
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(ctx context.Context, id int64) (sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, id)
+	ret0, _ := ret[0].(sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
+	ret0, _ := ret[0].(sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+}
+
+// GetAccountLedgerBalance mocks base method.
+func (m *MockStore) GetAccountLedgerBalance(ctx context.Context, accountID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountLedgerBalance", ctx, accountID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountLedgerBalance indicates an expected call of GetAccountLedgerBalance.
+func (mr *MockStoreMockRecorder) GetAccountLedgerBalance(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountLedgerBalance", reflect.TypeOf((*MockStore)(nil).GetAccountLedgerBalance), ctx, accountID)
+}
+
+// GetHold mocks base method.
+func (m *MockStore) GetHold(ctx context.Context, id pgtype.UUID) (sqlc.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, id)
+	ret0, _ := ret[0].(sqlc.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockStoreMockRecorder) GetHold(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockStore)(nil).GetHold), ctx, id)
+}
+
+// GetHoldByReference mocks base method.
+func (m *MockStore) GetHoldByReference(ctx context.Context, arg sqlc.GetHoldByReferenceParams) (sqlc.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldByReference", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldByReference indicates an expected call of GetHoldByReference.
+func (mr *MockStoreMockRecorder) GetHoldByReference(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldByReference", reflect.TypeOf((*MockStore)(nil).GetHoldByReference), ctx, arg)
+}
+
+// GetHoldForUpdate mocks base method.
+func (m *MockStore) GetHoldForUpdate(ctx context.Context, id pgtype.UUID) (sqlc.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldForUpdate", ctx, id)
+	ret0, _ := ret[0].(sqlc.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldForUpdate indicates an expected call of GetHoldForUpdate.
+func (mr *MockStoreMockRecorder) GetHoldForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldForUpdate", reflect.TypeOf((*MockStore)(nil).GetHoldForUpdate), ctx, id)
+}
+
+// GetJournalEntry mocks base method.
+func (m *MockStore) GetJournalEntry(ctx context.Context, id pgtype.UUID) (sqlc.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournalEntry", ctx, id)
+	ret0, _ := ret[0].(sqlc.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournalEntry indicates an expected call of GetJournalEntry.
+func (mr *MockStoreMockRecorder) GetJournalEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalEntry", reflect.TypeOf((*MockStore)(nil).GetJournalEntry), ctx, id)
+}
+
+// GetLastTransactionBefore mocks base method.
+func (m *MockStore) GetLastTransactionBefore(ctx context.Context, arg sqlc.GetLastTransactionBeforeParams) (sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastTransactionBefore", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastTransactionBefore indicates an expected call of GetLastTransactionBefore.
+func (mr *MockStoreMockRecorder) GetLastTransactionBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastTransactionBefore", reflect.TypeOf((*MockStore)(nil).GetLastTransactionBefore), ctx, arg)
+}
+
+// GetLatestExchangeRate mocks base method.
+func (m *MockStore) GetLatestExchangeRate(ctx context.Context, arg sqlc.GetLatestExchangeRateParams) (sqlc.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestExchangeRate", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestExchangeRate indicates an expected call of GetLatestExchangeRate.
+func (mr *MockStoreMockRecorder) GetLatestExchangeRate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestExchangeRate", reflect.TypeOf((*MockStore)(nil).GetLatestExchangeRate), ctx, arg)
+}
+
+// GetOutboxEvent mocks base method.
+func (m *MockStore) GetOutboxEvent(ctx context.Context, id int64) (sqlc.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxEvent", ctx, id)
+	ret0, _ := ret[0].(sqlc.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxEvent indicates an expected call of GetOutboxEvent.
+func (mr *MockStoreMockRecorder) GetOutboxEvent(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxEvent", reflect.TypeOf((*MockStore)(nil).GetOutboxEvent), ctx, id)
+}
+
+// GetOverdraftAccrual mocks base method.
+func (m *MockStore) GetOverdraftAccrual(ctx context.Context, arg sqlc.GetOverdraftAccrualParams) (sqlc.OverdraftAccrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdraftAccrual", ctx, arg)
+	ret0, _ := ret[0].(sqlc.OverdraftAccrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdraftAccrual indicates an expected call of GetOverdraftAccrual.
+func (mr *MockStoreMockRecorder) GetOverdraftAccrual(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdraftAccrual", reflect.TypeOf((*MockStore)(nil).GetOverdraftAccrual), ctx, arg)
+}
+
+// GetPostingByTransaction mocks base method.
+func (m *MockStore) GetPostingByTransaction(ctx context.Context, transactionID pgtype.UUID) (sqlc.Posting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostingByTransaction", ctx, transactionID)
+	ret0, _ := ret[0].(sqlc.Posting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostingByTransaction indicates an expected call of GetPostingByTransaction.
+func (mr *MockStoreMockRecorder) GetPostingByTransaction(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostingByTransaction", reflect.TypeOf((*MockStore)(nil).GetPostingByTransaction), ctx, transactionID)
+}
+
+// GetSystemAccount mocks base method.
+func (m *MockStore) GetSystemAccount(ctx context.Context, arg sqlc.GetSystemAccountParams) (sqlc.SystemAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSystemAccount", ctx, arg)
+	ret0, _ := ret[0].(sqlc.SystemAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSystemAccount indicates an expected call of GetSystemAccount.
+func (mr *MockStoreMockRecorder) GetSystemAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemAccount", reflect.TypeOf((*MockStore)(nil).GetSystemAccount), ctx, arg)
+}
+
+// GetTransaction mocks base method.
+func (m *MockStore) GetTransaction(ctx context.Context, id pgtype.UUID) (sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransaction", ctx, id)
+	ret0, _ := ret[0].(sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransaction indicates an expected call of GetTransaction.
+func (mr *MockStoreMockRecorder) GetTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockStore)(nil).GetTransaction), ctx, id)
+}
+
+// GetTransactionByReference mocks base method.
+func (m *MockStore) GetTransactionByReference(ctx context.Context, arg sqlc.GetTransactionByReferenceParams) (sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByReference", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByReference indicates an expected call of GetTransactionByReference.
+func (mr *MockStoreMockRecorder) GetTransactionByReference(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByReference", reflect.TypeOf((*MockStore)(nil).GetTransactionByReference), ctx, arg)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(ctx context.Context, id pgtype.UUID) (sqlc.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
+	ret0, _ := ret[0].(sqlc.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+}
+
+// GetTransferByReference mocks base method.
+func (m *MockStore) GetTransferByReference(ctx context.Context, arg sqlc.GetTransferByReferenceParams) (sqlc.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferByReference", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferByReference indicates an expected call of GetTransferByReference.
+func (mr *MockStoreMockRecorder) GetTransferByReference(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferByReference", reflect.TypeOf((*MockStore)(nil).GetTransferByReference), ctx, arg)
+}
+
+// GetTransferForUpdate mocks base method.
+func (m *MockStore) GetTransferForUpdate(ctx context.Context, id pgtype.UUID) (sqlc.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferForUpdate", ctx, id)
+	ret0, _ := ret[0].(sqlc.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferForUpdate indicates an expected call of GetTransferForUpdate.
+func (mr *MockStoreMockRecorder) GetTransferForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferForUpdate", reflect.TypeOf((*MockStore)(nil).GetTransferForUpdate), ctx, id)
+}
+
+// GetTransferReversalByTransfer mocks base method.
+func (m *MockStore) GetTransferReversalByTransfer(ctx context.Context, transferID pgtype.UUID) (sqlc.TransferReversal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferReversalByTransfer", ctx, transferID)
+	ret0, _ := ret[0].(sqlc.TransferReversal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferReversalByTransfer indicates an expected call of GetTransferReversalByTransfer.
+func (mr *MockStoreMockRecorder) GetTransferReversalByTransfer(ctx, transferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferReversalByTransfer", reflect.TypeOf((*MockStore)(nil).GetTransferReversalByTransfer), ctx, transferID)
+}
+
+// GetTransferSchedule mocks base method.
+func (m *MockStore) GetTransferSchedule(ctx context.Context, id pgtype.UUID) (sqlc.TransferSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferSchedule", ctx, id)
+	ret0, _ := ret[0].(sqlc.TransferSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferSchedule indicates an expected call of GetTransferSchedule.
+func (mr *MockStoreMockRecorder) GetTransferSchedule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferSchedule", reflect.TypeOf((*MockStore)(nil).GetTransferSchedule), ctx, id)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, id)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserForUpdate mocks base method.
+func (m *MockStore) GetUserForUpdate(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserForUpdate", ctx, id)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserForUpdate indicates an expected call of GetUserForUpdate.
+func (mr *MockStoreMockRecorder) GetUserForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserForUpdate", reflect.TypeOf((*MockStore)(nil).GetUserForUpdate), ctx, id)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockStore) GetWebhookDelivery(ctx context.Context, id int64) (sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockStoreMockRecorder) GetWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockStore)(nil).GetWebhookDelivery), ctx, id)
+}
+
+// GetWebhookEndpoint mocks base method.
+func (m *MockStore) GetWebhookEndpoint(ctx context.Context, id pgtype.UUID) (sqlc.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookEndpoint", ctx, id)
+	ret0, _ := ret[0].(sqlc.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookEndpoint indicates an expected call of GetWebhookEndpoint.
+func (mr *MockStoreMockRecorder) GetWebhookEndpoint(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookEndpoint", reflect.TypeOf((*MockStore)(nil).GetWebhookEndpoint), ctx, id)
+}
+
+// ListAccountBalanceTotals mocks base method.
+func (m *MockStore) ListAccountBalanceTotals(ctx context.Context, arg sqlc.ListAccountBalanceTotalsParams) ([]sqlc.ListAccountBalanceTotalsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountBalanceTotals", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListAccountBalanceTotalsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountBalanceTotals indicates an expected call of ListAccountBalanceTotals.
+func (mr *MockStoreMockRecorder) ListAccountBalanceTotals(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountBalanceTotals", reflect.TypeOf((*MockStore)(nil).ListAccountBalanceTotals), ctx, arg)
+}
+
+// ListAccountStatusEvents mocks base method.
+func (m *MockStore) ListAccountStatusEvents(ctx context.Context, arg sqlc.ListAccountStatusEventsParams) ([]sqlc.AccountStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountStatusEvents", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.AccountStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountStatusEvents indicates an expected call of ListAccountStatusEvents.
+func (mr *MockStoreMockRecorder) ListAccountStatusEvents(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountStatusEvents", reflect.TypeOf((*MockStore)(nil).ListAccountStatusEvents), ctx, arg)
+}
+
+// ListAccountStatusEventsBefore mocks base method.
+func (m *MockStore) ListAccountStatusEventsBefore(ctx context.Context, arg sqlc.ListAccountStatusEventsBeforeParams) ([]sqlc.AccountStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountStatusEventsBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.AccountStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountStatusEventsBefore indicates an expected call of ListAccountStatusEventsBefore.
+func (mr *MockStoreMockRecorder) ListAccountStatusEventsBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountStatusEventsBefore", reflect.TypeOf((*MockStore)(nil).ListAccountStatusEventsBefore), ctx, arg)
+}
+
+// ListAccountStatusEventsPage mocks base method.
+func (m *MockStore) ListAccountStatusEventsPage(ctx context.Context, arg sqlc.ListAccountStatusEventsPageParams) (sqlc.Page[sqlc.AccountStatusEvent], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountStatusEventsPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.AccountStatusEvent])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountStatusEventsPage indicates an expected call of ListAccountStatusEventsPage.
+func (mr *MockStoreMockRecorder) ListAccountStatusEventsPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountStatusEventsPage", reflect.TypeOf((*MockStore)(nil).ListAccountStatusEventsPage), ctx, arg)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(ctx context.Context, arg sqlc.ListAccountsParams) ([]sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+}
+
+// ListAccountsBefore mocks base method.
+func (m *MockStore) ListAccountsBefore(ctx context.Context, arg sqlc.ListAccountsBeforeParams) ([]sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsBefore indicates an expected call of ListAccountsBefore.
+func (mr *MockStoreMockRecorder) ListAccountsBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsBefore", reflect.TypeOf((*MockStore)(nil).ListAccountsBefore), ctx, arg)
+}
+
+// ListAccountsByOwner mocks base method.
+func (m *MockStore) ListAccountsByOwner(ctx context.Context, arg sqlc.ListAccountsByOwnerParams) ([]sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByOwner", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByOwner indicates an expected call of ListAccountsByOwner.
+func (mr *MockStoreMockRecorder) ListAccountsByOwner(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByOwner", reflect.TypeOf((*MockStore)(nil).ListAccountsByOwner), ctx, arg)
+}
+
+// ListAccountsByOwnerBefore mocks base method.
+func (m *MockStore) ListAccountsByOwnerBefore(ctx context.Context, arg sqlc.ListAccountsByOwnerBeforeParams) ([]sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByOwnerBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByOwnerBefore indicates an expected call of ListAccountsByOwnerBefore.
+func (mr *MockStoreMockRecorder) ListAccountsByOwnerBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByOwnerBefore", reflect.TypeOf((*MockStore)(nil).ListAccountsByOwnerBefore), ctx, arg)
+}
+
+// ListAccountsByOwnerPage mocks base method.
+func (m *MockStore) ListAccountsByOwnerPage(ctx context.Context, arg sqlc.ListAccountsByOwnerPageParams) (sqlc.Page[sqlc.Account], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByOwnerPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.Account])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByOwnerPage indicates an expected call of ListAccountsByOwnerPage.
+func (mr *MockStoreMockRecorder) ListAccountsByOwnerPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByOwnerPage", reflect.TypeOf((*MockStore)(nil).ListAccountsByOwnerPage), ctx, arg)
+}
+
+// ListAccountsPage mocks base method.
+func (m *MockStore) ListAccountsPage(ctx context.Context, arg sqlc.PageParams) (sqlc.Page[sqlc.Account], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.Account])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsPage indicates an expected call of ListAccountsPage.
+func (mr *MockStoreMockRecorder) ListAccountsPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsPage", reflect.TypeOf((*MockStore)(nil).ListAccountsPage), ctx, arg)
+}
+
+// ListBalanceChainBreaks mocks base method.
+func (m *MockStore) ListBalanceChainBreaks(ctx context.Context, accountIds []int64) ([]sqlc.ListBalanceChainBreaksRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBalanceChainBreaks", ctx, accountIds)
+	ret0, _ := ret[0].([]sqlc.ListBalanceChainBreaksRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBalanceChainBreaks indicates an expected call of ListBalanceChainBreaks.
+func (mr *MockStoreMockRecorder) ListBalanceChainBreaks(ctx, accountIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBalanceChainBreaks", reflect.TypeOf((*MockStore)(nil).ListBalanceChainBreaks), ctx, accountIds)
+}
+
+// ListExpiredHolds mocks base method.
+func (m *MockStore) ListExpiredHolds(ctx context.Context, arg sqlc.ListExpiredHoldsParams) ([]pgtype.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredHolds", ctx, arg)
+	ret0, _ := ret[0].([]pgtype.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredHolds indicates an expected call of ListExpiredHolds.
+func (mr *MockStoreMockRecorder) ListExpiredHolds(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredHolds", reflect.TypeOf((*MockStore)(nil).ListExpiredHolds), ctx, arg)
+}
+
+// ListHoldsByAccount mocks base method.
+func (m *MockStore) ListHoldsByAccount(ctx context.Context, arg sqlc.ListHoldsByAccountParams) ([]sqlc.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHoldsByAccount", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHoldsByAccount indicates an expected call of ListHoldsByAccount.
+func (mr *MockStoreMockRecorder) ListHoldsByAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHoldsByAccount", reflect.TypeOf((*MockStore)(nil).ListHoldsByAccount), ctx, arg)
+}
+
+// ListHoldsByAccountBefore mocks base method.
+func (m *MockStore) ListHoldsByAccountBefore(ctx context.Context, arg sqlc.ListHoldsByAccountBeforeParams) ([]sqlc.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHoldsByAccountBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHoldsByAccountBefore indicates an expected call of ListHoldsByAccountBefore.
+func (mr *MockStoreMockRecorder) ListHoldsByAccountBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHoldsByAccountBefore", reflect.TypeOf((*MockStore)(nil).ListHoldsByAccountBefore), ctx, arg)
+}
+
+// ListHoldsByAccountPage mocks base method.
+func (m *MockStore) ListHoldsByAccountPage(ctx context.Context, arg sqlc.ListHoldsByAccountPageParams) (sqlc.Page[sqlc.Hold], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHoldsByAccountPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.Hold])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHoldsByAccountPage indicates an expected call of ListHoldsByAccountPage.
+func (mr *MockStoreMockRecorder) ListHoldsByAccountPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHoldsByAccountPage", reflect.TypeOf((*MockStore)(nil).ListHoldsByAccountPage), ctx, arg)
+}
+
+// ListJournalEntriesByTransfer mocks base method.
+func (m *MockStore) ListJournalEntriesByTransfer(ctx context.Context, transferID pgtype.UUID) ([]sqlc.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJournalEntriesByTransfer", ctx, transferID)
+	ret0, _ := ret[0].([]sqlc.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJournalEntriesByTransfer indicates an expected call of ListJournalEntriesByTransfer.
+func (mr *MockStoreMockRecorder) ListJournalEntriesByTransfer(ctx, transferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJournalEntriesByTransfer", reflect.TypeOf((*MockStore)(nil).ListJournalEntriesByTransfer), ctx, transferID)
+}
+
+// ListOpenReconciliationIncidents mocks base method.
+func (m *MockStore) ListOpenReconciliationIncidents(ctx context.Context, accountID int64) ([]sqlc.ReconciliationIncident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenReconciliationIncidents", ctx, accountID)
+	ret0, _ := ret[0].([]sqlc.ReconciliationIncident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenReconciliationIncidents indicates an expected call of ListOpenReconciliationIncidents.
+func (mr *MockStoreMockRecorder) ListOpenReconciliationIncidents(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenReconciliationIncidents", reflect.TypeOf((*MockStore)(nil).ListOpenReconciliationIncidents), ctx, accountID)
+}
+
+// ListOutboxEventsByAccount mocks base method.
+func (m *MockStore) ListOutboxEventsByAccount(ctx context.Context, arg sqlc.ListOutboxEventsByAccountParams) ([]sqlc.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOutboxEventsByAccount", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOutboxEventsByAccount indicates an expected call of ListOutboxEventsByAccount.
+func (mr *MockStoreMockRecorder) ListOutboxEventsByAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutboxEventsByAccount", reflect.TypeOf((*MockStore)(nil).ListOutboxEventsByAccount), ctx, arg)
+}
+
+// ListOverdrawnAccounts mocks base method.
+func (m *MockStore) ListOverdrawnAccounts(ctx context.Context, arg sqlc.ListOverdrawnAccountsParams) ([]sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdrawnAccounts", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdrawnAccounts indicates an expected call of ListOverdrawnAccounts.
+func (mr *MockStoreMockRecorder) ListOverdrawnAccounts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdrawnAccounts", reflect.TypeOf((*MockStore)(nil).ListOverdrawnAccounts), ctx, arg)
+}
+
+// ListPostingsByEntry mocks base method.
+func (m *MockStore) ListPostingsByEntry(ctx context.Context, entryID pgtype.UUID) ([]sqlc.Posting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostingsByEntry", ctx, entryID)
+	ret0, _ := ret[0].([]sqlc.Posting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostingsByEntry indicates an expected call of ListPostingsByEntry.
+func (mr *MockStoreMockRecorder) ListPostingsByEntry(ctx, entryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostingsByEntry", reflect.TypeOf((*MockStore)(nil).ListPostingsByEntry), ctx, entryID)
+}
+
+// ListTransactions mocks base method.
+func (m *MockStore) ListTransactions(ctx context.Context, arg sqlc.ListTransactionsParams) ([]sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockStoreMockRecorder) ListTransactions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockStore)(nil).ListTransactions), ctx, arg)
+}
+
+// ListTransactionsBefore mocks base method.
+func (m *MockStore) ListTransactionsBefore(ctx context.Context, arg sqlc.ListTransactionsBeforeParams) ([]sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactionsBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactionsBefore indicates an expected call of ListTransactionsBefore.
+func (mr *MockStoreMockRecorder) ListTransactionsBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactionsBefore", reflect.TypeOf((*MockStore)(nil).ListTransactionsBefore), ctx, arg)
+}
+
+// ListTransactionsInPeriod mocks base method.
+func (m *MockStore) ListTransactionsInPeriod(ctx context.Context, arg sqlc.ListTransactionsInPeriodParams) ([]sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactionsInPeriod", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactionsInPeriod indicates an expected call of ListTransactionsInPeriod.
+func (mr *MockStoreMockRecorder) ListTransactionsInPeriod(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactionsInPeriod", reflect.TypeOf((*MockStore)(nil).ListTransactionsInPeriod), ctx, arg)
+}
+
+// ListTransactionsPage mocks base method.
+func (m *MockStore) ListTransactionsPage(ctx context.Context, arg sqlc.ListTransactionsPageParams) (sqlc.Page[sqlc.Transaction], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactionsPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.Transaction])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactionsPage indicates an expected call of ListTransactionsPage.
+func (mr *MockStoreMockRecorder) ListTransactionsPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactionsPage", reflect.TypeOf((*MockStore)(nil).ListTransactionsPage), ctx, arg)
+}
+
+// ListTransferScheduleRuns mocks base method.
+func (m *MockStore) ListTransferScheduleRuns(ctx context.Context, arg sqlc.ListTransferScheduleRunsParams) ([]sqlc.TransferScheduleRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferScheduleRuns", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.TransferScheduleRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferScheduleRuns indicates an expected call of ListTransferScheduleRuns.
+func (mr *MockStoreMockRecorder) ListTransferScheduleRuns(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferScheduleRuns", reflect.TypeOf((*MockStore)(nil).ListTransferScheduleRuns), ctx, arg)
+}
+
+// ListTransferScheduleRunsBefore mocks base method.
+func (m *MockStore) ListTransferScheduleRunsBefore(ctx context.Context, arg sqlc.ListTransferScheduleRunsBeforeParams) ([]sqlc.TransferScheduleRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferScheduleRunsBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.TransferScheduleRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferScheduleRunsBefore indicates an expected call of ListTransferScheduleRunsBefore.
+func (mr *MockStoreMockRecorder) ListTransferScheduleRunsBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferScheduleRunsBefore", reflect.TypeOf((*MockStore)(nil).ListTransferScheduleRunsBefore), ctx, arg)
+}
+
+// ListTransferScheduleRunsPage mocks base method.
+func (m *MockStore) ListTransferScheduleRunsPage(ctx context.Context, arg sqlc.ListTransferScheduleRunsPageParams) (sqlc.Page[sqlc.TransferScheduleRun], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferScheduleRunsPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.TransferScheduleRun])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferScheduleRunsPage indicates an expected call of ListTransferScheduleRunsPage.
+func (mr *MockStoreMockRecorder) ListTransferScheduleRunsPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferScheduleRunsPage", reflect.TypeOf((*MockStore)(nil).ListTransferScheduleRunsPage), ctx, arg)
+}
+
+// ListTransferSchedulesByAccount mocks base method.
+func (m *MockStore) ListTransferSchedulesByAccount(ctx context.Context, arg sqlc.ListTransferSchedulesByAccountParams) ([]sqlc.TransferSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferSchedulesByAccount", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.TransferSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferSchedulesByAccount indicates an expected call of ListTransferSchedulesByAccount.
+func (mr *MockStoreMockRecorder) ListTransferSchedulesByAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferSchedulesByAccount", reflect.TypeOf((*MockStore)(nil).ListTransferSchedulesByAccount), ctx, arg)
+}
+
+// ListTransferSchedulesByAccountBefore mocks base method.
+func (m *MockStore) ListTransferSchedulesByAccountBefore(ctx context.Context, arg sqlc.ListTransferSchedulesByAccountBeforeParams) ([]sqlc.TransferSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferSchedulesByAccountBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.TransferSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferSchedulesByAccountBefore indicates an expected call of ListTransferSchedulesByAccountBefore.
+func (mr *MockStoreMockRecorder) ListTransferSchedulesByAccountBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferSchedulesByAccountBefore", reflect.TypeOf((*MockStore)(nil).ListTransferSchedulesByAccountBefore), ctx, arg)
+}
+
+// ListTransferSchedulesByAccountPage mocks base method.
+func (m *MockStore) ListTransferSchedulesByAccountPage(ctx context.Context, arg sqlc.ListTransferSchedulesByAccountPageParams) (sqlc.Page[sqlc.TransferSchedule], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferSchedulesByAccountPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.TransferSchedule])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferSchedulesByAccountPage indicates an expected call of ListTransferSchedulesByAccountPage.
+func (mr *MockStoreMockRecorder) ListTransferSchedulesByAccountPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferSchedulesByAccountPage", reflect.TypeOf((*MockStore)(nil).ListTransferSchedulesByAccountPage), ctx, arg)
+}
+
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(ctx context.Context, arg sqlc.ListTransfersParams) ([]sqlc.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfers", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx, arg)
+}
+
+// ListTransfersBefore mocks base method.
+func (m *MockStore) ListTransfersBefore(ctx context.Context, arg sqlc.ListTransfersBeforeParams) ([]sqlc.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersBefore indicates an expected call of ListTransfersBefore.
+func (mr *MockStoreMockRecorder) ListTransfersBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersBefore", reflect.TypeOf((*MockStore)(nil).ListTransfersBefore), ctx, arg)
+}
+
+// ListTransfersByAccount mocks base method.
+func (m *MockStore) ListTransfersByAccount(ctx context.Context, arg sqlc.ListTransfersByAccountParams) ([]sqlc.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersByAccount", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersByAccount indicates an expected call of ListTransfersByAccount.
+func (mr *MockStoreMockRecorder) ListTransfersByAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersByAccount", reflect.TypeOf((*MockStore)(nil).ListTransfersByAccount), ctx, arg)
+}
+
+// ListTransfersByAccountBefore mocks base method.
+func (m *MockStore) ListTransfersByAccountBefore(ctx context.Context, arg sqlc.ListTransfersByAccountBeforeParams) ([]sqlc.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersByAccountBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersByAccountBefore indicates an expected call of ListTransfersByAccountBefore.
+func (mr *MockStoreMockRecorder) ListTransfersByAccountBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersByAccountBefore", reflect.TypeOf((*MockStore)(nil).ListTransfersByAccountBefore), ctx, arg)
+}
+
+// ListTransfersByAccountPage mocks base method.
+func (m *MockStore) ListTransfersByAccountPage(ctx context.Context, arg sqlc.ListTransfersByAccountPageParams) (sqlc.Page[sqlc.Transfer], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersByAccountPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.Transfer])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersByAccountPage indicates an expected call of ListTransfersByAccountPage.
+func (mr *MockStoreMockRecorder) ListTransfersByAccountPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersByAccountPage", reflect.TypeOf((*MockStore)(nil).ListTransfersByAccountPage), ctx, arg)
+}
+
+// ListTransfersPage mocks base method.
+func (m *MockStore) ListTransfersPage(ctx context.Context, arg sqlc.PageParams) (sqlc.Page[sqlc.Transfer], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.Transfer])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersPage indicates an expected call of ListTransfersPage.
+func (mr *MockStoreMockRecorder) ListTransfersPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersPage", reflect.TypeOf((*MockStore)(nil).ListTransfersPage), ctx, arg)
+}
+
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(ctx context.Context, arg sqlc.ListUsersParams) ([]sqlc.ListUsersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListUsersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), ctx, arg)
+}
+
+// ListUsersBefore mocks base method.
+func (m *MockStore) ListUsersBefore(ctx context.Context, arg sqlc.ListUsersBeforeParams) ([]sqlc.ListUsersBeforeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListUsersBeforeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersBefore indicates an expected call of ListUsersBefore.
+func (mr *MockStoreMockRecorder) ListUsersBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersBefore", reflect.TypeOf((*MockStore)(nil).ListUsersBefore), ctx, arg)
+}
+
+// ListUsersPage mocks base method.
+func (m *MockStore) ListUsersPage(ctx context.Context, arg sqlc.PageParams) (sqlc.Page[sqlc.ListUsersRow], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.ListUsersRow])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersPage indicates an expected call of ListUsersPage.
+func (mr *MockStoreMockRecorder) ListUsersPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersPage", reflect.TypeOf((*MockStore)(nil).ListUsersPage), ctx, arg)
+}
+
+// ListWebhookDeliveriesByEndpoint mocks base method.
+func (m *MockStore) ListWebhookDeliveriesByEndpoint(ctx context.Context, arg sqlc.ListWebhookDeliveriesByEndpointParams) ([]sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveriesByEndpoint", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveriesByEndpoint indicates an expected call of ListWebhookDeliveriesByEndpoint.
+func (mr *MockStoreMockRecorder) ListWebhookDeliveriesByEndpoint(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveriesByEndpoint", reflect.TypeOf((*MockStore)(nil).ListWebhookDeliveriesByEndpoint), ctx, arg)
+}
+
+// ListWebhookDeliveriesByEndpointBefore mocks base method.
+func (m *MockStore) ListWebhookDeliveriesByEndpointBefore(ctx context.Context, arg sqlc.ListWebhookDeliveriesByEndpointBeforeParams) ([]sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveriesByEndpointBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveriesByEndpointBefore indicates an expected call of ListWebhookDeliveriesByEndpointBefore.
+func (mr *MockStoreMockRecorder) ListWebhookDeliveriesByEndpointBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveriesByEndpointBefore", reflect.TypeOf((*MockStore)(nil).ListWebhookDeliveriesByEndpointBefore), ctx, arg)
+}
+
+// ListWebhookDeliveriesByEndpointPage mocks base method.
+func (m *MockStore) ListWebhookDeliveriesByEndpointPage(ctx context.Context, arg sqlc.ListWebhookDeliveriesByEndpointPageParams) (sqlc.Page[sqlc.WebhookDelivery], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveriesByEndpointPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.WebhookDelivery])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveriesByEndpointPage indicates an expected call of ListWebhookDeliveriesByEndpointPage.
+func (mr *MockStoreMockRecorder) ListWebhookDeliveriesByEndpointPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveriesByEndpointPage", reflect.TypeOf((*MockStore)(nil).ListWebhookDeliveriesByEndpointPage), ctx, arg)
+}
+
+// ListWebhookEndpointsByOwner mocks base method.
+func (m *MockStore) ListWebhookEndpointsByOwner(ctx context.Context, arg sqlc.ListWebhookEndpointsByOwnerParams) ([]sqlc.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookEndpointsByOwner", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookEndpointsByOwner indicates an expected call of ListWebhookEndpointsByOwner.
+func (mr *MockStoreMockRecorder) ListWebhookEndpointsByOwner(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookEndpointsByOwner", reflect.TypeOf((*MockStore)(nil).ListWebhookEndpointsByOwner), ctx, arg)
+}
+
+// ListWebhookEndpointsByOwnerBefore mocks base method.
+func (m *MockStore) ListWebhookEndpointsByOwnerBefore(ctx context.Context, arg sqlc.ListWebhookEndpointsByOwnerBeforeParams) ([]sqlc.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookEndpointsByOwnerBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookEndpointsByOwnerBefore indicates an expected call of ListWebhookEndpointsByOwnerBefore.
+func (mr *MockStoreMockRecorder) ListWebhookEndpointsByOwnerBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookEndpointsByOwnerBefore", reflect.TypeOf((*MockStore)(nil).ListWebhookEndpointsByOwnerBefore), ctx, arg)
+}
+
+// ListWebhookEndpointsByOwnerPage mocks base method.
+func (m *MockStore) ListWebhookEndpointsByOwnerPage(ctx context.Context, arg sqlc.ListWebhookEndpointsByOwnerPageParams) (sqlc.Page[sqlc.WebhookEndpoint], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookEndpointsByOwnerPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.WebhookEndpoint])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookEndpointsByOwnerPage indicates an expected call of ListWebhookEndpointsByOwnerPage.
+func (mr *MockStoreMockRecorder) ListWebhookEndpointsByOwnerPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookEndpointsByOwnerPage", reflect.TypeOf((*MockStore)(nil).ListWebhookEndpointsByOwnerPage), ctx, arg)
+}
+
+// MarkOutboxEventDispatched mocks base method.
+func (m *MockStore) MarkOutboxEventDispatched(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxEventDispatched", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOutboxEventDispatched indicates an expected call of MarkOutboxEventDispatched.
+func (mr *MockStoreMockRecorder) MarkOutboxEventDispatched(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxEventDispatched", reflect.TypeOf((*MockStore)(nil).MarkOutboxEventDispatched), ctx, id)
+}
+
+// OpenReconciliationIncident mocks base method.
+func (m *MockStore) OpenReconciliationIncident(ctx context.Context, arg sqlc.OpenReconciliationIncidentParams) (sqlc.ReconciliationIncident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenReconciliationIncident", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ReconciliationIncident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenReconciliationIncident indicates an expected call of OpenReconciliationIncident.
+func (mr *MockStoreMockRecorder) OpenReconciliationIncident(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenReconciliationIncident", reflect.TypeOf((*MockStore)(nil).OpenReconciliationIncident), ctx, arg)
+}
+
+// PlaceHoldTx mocks base method.
+func (m *MockStore) PlaceHoldTx(ctx context.Context, arg sqlc.PlaceHoldTxParams) (sqlc.PlaceHoldTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceHoldTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.PlaceHoldTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceHoldTx indicates an expected call of PlaceHoldTx.
+func (mr *MockStoreMockRecorder) PlaceHoldTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceHoldTx", reflect.TypeOf((*MockStore)(nil).PlaceHoldTx), ctx, arg)
+}
+
+// RecordWebhookDeliveryAttempt mocks base method.
+func (m *MockStore) RecordWebhookDeliveryAttempt(ctx context.Context, arg sqlc.RecordWebhookDeliveryAttemptParams) (sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWebhookDeliveryAttempt", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordWebhookDeliveryAttempt indicates an expected call of RecordWebhookDeliveryAttempt.
+func (mr *MockStoreMockRecorder) RecordWebhookDeliveryAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookDeliveryAttempt", reflect.TypeOf((*MockStore)(nil).RecordWebhookDeliveryAttempt), ctx, arg)
+}
+
+// RedeliverWebhookDelivery mocks base method.
+func (m *MockStore) RedeliverWebhookDelivery(ctx context.Context, id int64) (sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeliverWebhookDelivery indicates an expected call of RedeliverWebhookDelivery.
+func (mr *MockStoreMockRecorder) RedeliverWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverWebhookDelivery", reflect.TypeOf((*MockStore)(nil).RedeliverWebhookDelivery), ctx, id)
+}
+
+// ResolveHold mocks base method.
+func (m *MockStore) ResolveHold(ctx context.Context, arg sqlc.ResolveHoldParams) (sqlc.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveHold", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveHold indicates an expected call of ResolveHold.
+func (mr *MockStoreMockRecorder) ResolveHold(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveHold", reflect.TypeOf((*MockStore)(nil).ResolveHold), ctx, arg)
+}
+
+// ResolveReconciliationIncident mocks base method.
+func (m *MockStore) ResolveReconciliationIncident(ctx context.Context, id int64) (sqlc.ReconciliationIncident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveReconciliationIncident", ctx, id)
+	ret0, _ := ret[0].(sqlc.ReconciliationIncident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveReconciliationIncident indicates an expected call of ResolveReconciliationIncident.
+func (mr *MockStoreMockRecorder) ResolveReconciliationIncident(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReconciliationIncident", reflect.TypeOf((*MockStore)(nil).ResolveReconciliationIncident), ctx, id)
+}
+
+// ReverseTransferTx mocks base method.
+func (m *MockStore) ReverseTransferTx(ctx context.Context, arg sqlc.ReverseTransferTxParams) (sqlc.ReverseTransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTransferTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ReverseTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseTransferTx indicates an expected call of ReverseTransferTx.
+func (mr *MockStoreMockRecorder) ReverseTransferTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransferTx", reflect.TypeOf((*MockStore)(nil).ReverseTransferTx), ctx, arg)
+}
+
+// RunTransferScheduleTx mocks base method.
+func (m *MockStore) RunTransferScheduleTx(ctx context.Context, arg sqlc.RunTransferScheduleTxParams) (sqlc.RunTransferScheduleTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunTransferScheduleTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.RunTransferScheduleTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunTransferScheduleTx indicates an expected call of RunTransferScheduleTx.
+func (mr *MockStoreMockRecorder) RunTransferScheduleTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTransferScheduleTx", reflect.TypeOf((*MockStore)(nil).RunTransferScheduleTx), ctx, arg)
+}
+
+// ScheduleTransfer mocks base method.
+func (m *MockStore) ScheduleTransfer(ctx context.Context, arg sqlc.ScheduleTransferParams) (sqlc.TransferSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleTransfer", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TransferSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleTransfer indicates an expected call of ScheduleTransfer.
+func (mr *MockStoreMockRecorder) ScheduleTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleTransfer", reflect.TypeOf((*MockStore)(nil).ScheduleTransfer), ctx, arg)
+}
+
+// SearchTransactionsLargest mocks base method.
+func (m *MockStore) SearchTransactionsLargest(ctx context.Context, arg sqlc.SearchTransactionsLargestParams) ([]sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTransactionsLargest", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTransactionsLargest indicates an expected call of SearchTransactionsLargest.
+func (mr *MockStoreMockRecorder) SearchTransactionsLargest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTransactionsLargest", reflect.TypeOf((*MockStore)(nil).SearchTransactionsLargest), ctx, arg)
+}
+
+// SearchTransactionsNewest mocks base method.
+func (m *MockStore) SearchTransactionsNewest(ctx context.Context, arg sqlc.SearchTransactionsNewestParams) ([]sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTransactionsNewest", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTransactionsNewest indicates an expected call of SearchTransactionsNewest.
+func (mr *MockStoreMockRecorder) SearchTransactionsNewest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTransactionsNewest", reflect.TypeOf((*MockStore)(nil).SearchTransactionsNewest), ctx, arg)
+}
+
+// SearchTransactionsOldest mocks base method.
+func (m *MockStore) SearchTransactionsOldest(ctx context.Context, arg sqlc.SearchTransactionsOldestParams) ([]sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTransactionsOldest", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTransactionsOldest indicates an expected call of SearchTransactionsOldest.
+func (mr *MockStoreMockRecorder) SearchTransactionsOldest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTransactionsOldest", reflect.TypeOf((*MockStore)(nil).SearchTransactionsOldest), ctx, arg)
+}
+
+// SearchTransactionsPage mocks base method.
+func (m *MockStore) SearchTransactionsPage(ctx context.Context, arg sqlc.SearchTransactionsPageParams) (sqlc.Page[sqlc.Transaction], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTransactionsPage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Page[sqlc.Transaction])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTransactionsPage indicates an expected call of SearchTransactionsPage.
+func (mr *MockStoreMockRecorder) SearchTransactionsPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTransactionsPage", reflect.TypeOf((*MockStore)(nil).SearchTransactionsPage), ctx, arg)
+}
+
+// SearchTransactionsSmallest mocks base method.
+func (m *MockStore) SearchTransactionsSmallest(ctx context.Context, arg sqlc.SearchTransactionsSmallestParams) ([]sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTransactionsSmallest", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTransactionsSmallest indicates an expected call of SearchTransactionsSmallest.
+func (mr *MockStoreMockRecorder) SearchTransactionsSmallest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTransactionsSmallest", reflect.TypeOf((*MockStore)(nil).SearchTransactionsSmallest), ctx, arg)
+}
+
+// SetOverdraftLimitTx mocks base method.
+func (m *MockStore) SetOverdraftLimitTx(ctx context.Context, arg sqlc.SetOverdraftLimitTxParams) (sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOverdraftLimitTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOverdraftLimitTx indicates an expected call of SetOverdraftLimitTx.
+func (mr *MockStoreMockRecorder) SetOverdraftLimitTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOverdraftLimitTx", reflect.TypeOf((*MockStore)(nil).SetOverdraftLimitTx), ctx, arg)
+}
+
+// TransferMoneyTx mocks base method.
+func (m *MockStore) TransferMoneyTx(ctx context.Context, arg sqlc.TransferMoneyTxParams) (sqlc.TransferMoneyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferMoneyTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TransferMoneyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferMoneyTx indicates an expected call of TransferMoneyTx.
+func (mr *MockStoreMockRecorder) TransferMoneyTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferMoneyTx", reflect.TypeOf((*MockStore)(nil).TransferMoneyTx), ctx, arg)
+}
+
+// TxStats mocks base method.
+func (m *MockStore) TxStats() map[sqlc.TxOperation]sqlc.TxStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxStats")
+	ret0, _ := ret[0].(map[sqlc.TxOperation]sqlc.TxStats)
+	return ret0
+}
+
+// TxStats indicates an expected call of TxStats.
+func (mr *MockStoreMockRecorder) TxStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxStats", reflect.TypeOf((*MockStore)(nil).TxStats))
+}
+
+// UnfreezeAccountTx mocks base method.
+func (m *MockStore) UnfreezeAccountTx(ctx context.Context, arg sqlc.ChangeAccountStatusParams) (sqlc.ChangeAccountStatusResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfreezeAccountTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ChangeAccountStatusResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnfreezeAccountTx indicates an expected call of UnfreezeAccountTx.
+func (mr *MockStoreMockRecorder) UnfreezeAccountTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfreezeAccountTx", reflect.TypeOf((*MockStore)(nil).UnfreezeAccountTx), ctx, arg)
+}
+
+// UpdateAccountHeld mocks base method.
+func (m *MockStore) UpdateAccountHeld(ctx context.Context, arg sqlc.UpdateAccountHeldParams) (sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountHeld", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountHeld indicates an expected call of UpdateAccountHeld.
+func (mr *MockStoreMockRecorder) UpdateAccountHeld(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountHeld", reflect.TypeOf((*MockStore)(nil).UpdateAccountHeld), ctx, arg)
+}
+
+// UpdateAccountOverdraftLimit mocks base method.
+func (m *MockStore) UpdateAccountOverdraftLimit(ctx context.Context, arg sqlc.UpdateAccountOverdraftLimitParams) (sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountOverdraftLimit", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountOverdraftLimit indicates an expected call of UpdateAccountOverdraftLimit.
+func (mr *MockStoreMockRecorder) UpdateAccountOverdraftLimit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountOverdraftLimit", reflect.TypeOf((*MockStore)(nil).UpdateAccountOverdraftLimit), ctx, arg)
+}
+
+// UpdateAccountStatus mocks base method.
+func (m *MockStore) UpdateAccountStatus(ctx context.Context, arg sqlc.UpdateAccountStatusParams) (sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountStatus", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountStatus indicates an expected call of UpdateAccountStatus.
+func (mr *MockStoreMockRecorder) UpdateAccountStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatus", reflect.TypeOf((*MockStore)(nil).UpdateAccountStatus), ctx, arg)
+}
+
+// UpdateTransferStatus mocks base method.
+func (m *MockStore) UpdateTransferStatus(ctx context.Context, arg sqlc.UpdateTransferStatusParams) (sqlc.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferStatus", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransferStatus indicates an expected call of UpdateTransferStatus.
+func (mr *MockStoreMockRecorder) UpdateTransferStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferStatus", reflect.TypeOf((*MockStore)(nil).UpdateTransferStatus), ctx, arg)
+}
+
+// UpdateUser mocks base method.
+func (m *MockStore) UpdateUser(ctx context.Context, arg sqlc.UpdateUserParams) (sqlc.UpdateUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, arg)
+	ret0, _ := ret[0].(sqlc.UpdateUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockStoreMockRecorder) UpdateUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), ctx, arg)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockStore) UpdateUserPassword(ctx context.Context, arg sqlc.UpdateUserPasswordParams) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, arg)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockStoreMockRecorder) UpdateUserPassword(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserPassword), ctx, arg)
+}
+
+// VoidHoldTx mocks base method.
+func (m *MockStore) VoidHoldTx(ctx context.Context, holdID pgtype.UUID) (sqlc.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidHoldTx", ctx, holdID)
+	ret0, _ := ret[0].(sqlc.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidHoldTx indicates an expected call of VoidHoldTx.
+func (mr *MockStoreMockRecorder) VoidHoldTx(ctx, holdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidHoldTx", reflect.TypeOf((*MockStore)(nil).VoidHoldTx), ctx, holdID)
+}
+
+// WithdrawMoneyTx mocks base method.
+func (m *MockStore) WithdrawMoneyTx(ctx context.Context, arg sqlc.AccountTransactionParams) (sqlc.AccountTransactionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawMoneyTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.AccountTransactionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithdrawMoneyTx indicates an expected call of WithdrawMoneyTx.
+func (mr *MockStoreMockRecorder) WithdrawMoneyTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawMoneyTx", reflect.TypeOf((*MockStore)(nil).WithdrawMoneyTx), ctx, arg)
+}
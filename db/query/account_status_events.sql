@@ -0,0 +1,29 @@
+-- name: CreateAccountStatusEvent :one
+INSERT INTO account_status_events (
+  account_id,
+  from_status,
+  to_status,
+  reason,
+  actor
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListAccountStatusEvents :many
+-- Pages through the account's status changes newest first, continuing after the
+-- (after_created_at, after_id) key of the previous page's last row.
+SELECT * FROM account_status_events
+WHERE account_id = sqlc.arg(account_id)
+  AND (created_at, id) < (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::bigint)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListAccountStatusEventsBefore :many
+-- Pages back through the account's status changes from the (before_created_at, before_id)
+-- key, returning the rows nearest to it first.
+SELECT * FROM account_status_events
+WHERE account_id = sqlc.arg(account_id)
+  AND (created_at, id) > (sqlc.arg(before_created_at)::timestamptz, sqlc.arg(before_id)::bigint)
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
@@ -16,17 +16,75 @@ WHERE id = $1 LIMIT 1
 FOR UPDATE;
 
 -- name: ListAccounts :many
+-- Pages through every account by id, continuing after after_id.
 SELECT * FROM accounts
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit');
 
--- name: UpdateAccountBalance :one
+-- name: ListAccountsBefore :many
+-- Pages back through every account from before_id, returning the rows
+-- nearest to it first.
+SELECT * FROM accounts
+WHERE id < sqlc.arg(before_id)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListAccountsByOwner :many
+-- Pages through the owner's accounts by id, continuing after after_id.
+SELECT * FROM accounts
+WHERE owner_id = sqlc.arg(owner_id)
+  AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: ListAccountsByOwnerBefore :many
+-- Pages back through the owner's accounts from before_id, returning the rows
+-- nearest to it first.
+SELECT * FROM accounts
+WHERE owner_id = sqlc.arg(owner_id)
+  AND id < sqlc.arg(before_id)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: UpdateAccountStatus :one
 UPDATE accounts
-SET balance_cents = $2
+SET status = $2
 WHERE id = $1
 RETURNING *;
 
 -- name: DeleteAccount :exec
 DELETE FROM accounts
 WHERE id = $1;
+
+-- name: ListAccountBalanceTotals :many
+-- Pages through accounts by id, each with the totals its balance has to agree with,
+-- read in one snapshot. An empty account_ids covers every account.
+SELECT
+  a.id,
+  a.balance_cents,
+  COALESCE((SELECT sum(t.amount_cents) FROM transactions t WHERE t.account_id = a.id), 0)::bigint AS transactions_total_cents,
+  COALESCE((SELECT sum(p.amount_cents) FROM postings p WHERE p.account_id = a.id), 0)::bigint AS ledger_total_cents
+FROM accounts a
+WHERE a.id > sqlc.arg(after_id)
+  AND (COALESCE(cardinality(sqlc.arg(account_ids)::bigint[]), 0) = 0 OR a.id = ANY(sqlc.arg(account_ids)::bigint[]))
+ORDER BY a.id
+LIMIT sqlc.arg('limit');
+
+-- name: ListOverdrawnAccounts :many
+SELECT * FROM accounts
+WHERE balance_cents < 0 AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit_cents = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateAccountHeld :one
+UPDATE accounts
+SET held_cents = $2
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,18 @@
+-- name: CreateExchangeRate :one
+INSERT INTO exchange_rates (
+  base_currency,
+  quote_currency,
+  rate,
+  effective_at
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetLatestExchangeRate :one
+SELECT * FROM exchange_rates
+WHERE base_currency = $1
+  AND quote_currency = $2
+  AND effective_at <= now()
+ORDER BY effective_at DESC, id DESC
+LIMIT 1;
@@ -0,0 +1,59 @@
+-- name: CreateHold :one
+INSERT INTO holds (
+  account_id,
+  amount_cents,
+  reference,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetHold :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldByReference :one
+-- Idempotency keys are scoped to the account the hold is placed on.
+SELECT * FROM holds
+WHERE account_id = $1 AND reference = $2 LIMIT 1;
+
+-- name: GetHoldForUpdate :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListExpiredHolds :many
+SELECT id FROM holds
+WHERE status = 'active' AND expires_at <= sqlc.arg(now)
+ORDER BY expires_at
+LIMIT sqlc.arg('limit');
+
+-- name: ListHoldsByAccount :many
+-- Pages through the account's holds newest first, continuing after the
+-- (after_created_at, after_id) key of the previous page's last row.
+SELECT * FROM holds
+WHERE account_id = sqlc.arg(account_id)
+  AND (created_at, id) < (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListHoldsByAccountBefore :many
+-- Pages back through the account's holds from the (before_created_at, before_id)
+-- key, returning the rows nearest to it first.
+SELECT * FROM holds
+WHERE account_id = sqlc.arg(account_id)
+  AND (created_at, id) > (sqlc.arg(before_created_at)::timestamptz, sqlc.arg(before_id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: ResolveHold :one
+-- Settles an active hold; returns no row if it was already resolved.
+UPDATE holds
+SET status = sqlc.arg(status),
+    captured_cents = sqlc.narg(captured_cents),
+    capture_transaction_id = sqlc.narg(capture_transaction_id),
+    capture_transfer_id = sqlc.narg(capture_transfer_id),
+    resolved_at = now()
+WHERE id = sqlc.arg(id) AND status = 'active'
+RETURNING *;
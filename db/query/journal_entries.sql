@@ -0,0 +1,17 @@
+-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (
+  type,
+  transfer_id
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: GetJournalEntry :one
+SELECT * FROM journal_entries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListJournalEntriesByTransfer :many
+SELECT * FROM journal_entries
+WHERE transfer_id = $1
+ORDER BY created_at, id;
@@ -0,0 +1,33 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox_events (
+  type,
+  account_ids,
+  payload
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetOutboxEvent :one
+SELECT * FROM outbox_events
+WHERE id = $1 LIMIT 1;
+
+-- name: ListOutboxEventsByAccount :many
+SELECT * FROM outbox_events
+WHERE sqlc.arg(account_id)::bigint = ANY (account_ids)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: ClaimUndispatchedOutboxEvents :many
+-- Locks the oldest events still waiting for their deliveries to be queued.
+-- SKIP LOCKED lets concurrent dispatchers take disjoint batches.
+SELECT * FROM outbox_events
+WHERE dispatched_at IS NULL
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventDispatched :exec
+UPDATE outbox_events
+SET dispatched_at = now()
+WHERE id = $1;
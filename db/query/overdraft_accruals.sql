@@ -0,0 +1,14 @@
+-- name: CreateOverdraftAccrual :one
+INSERT INTO overdraft_accruals (
+  account_id,
+  day,
+  type,
+  transaction_id
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetOverdraftAccrual :one
+SELECT * FROM overdraft_accruals
+WHERE account_id = $1 AND day = $2 AND type = $3 LIMIT 1;
@@ -0,0 +1,28 @@
+-- name: CreatePosting :one
+INSERT INTO postings (
+  entry_id,
+  account_id,
+  system_account_id,
+  transaction_id,
+  currency,
+  amount_cents
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetPostingByTransaction :one
+SELECT * FROM postings
+WHERE transaction_id = $1 LIMIT 1;
+
+-- name: ListPostingsByEntry :many
+SELECT * FROM postings
+WHERE entry_id = $1
+ORDER BY id;
+
+-- name: GetAccountLedgerBalance :one
+-- Sums every posting to a customer account; it should always equal the
+-- account's balance_cents.
+SELECT COALESCE(sum(amount_cents), 0)::bigint AS balance_cents
+FROM postings
+WHERE account_id = sqlc.arg(account_id)::bigint;
@@ -0,0 +1,24 @@
+-- name: OpenReconciliationIncident :one
+-- Returns no row when an open incident of the same kind already covers the account.
+INSERT INTO reconciliation_incidents (
+  account_id,
+  kind,
+  expected_cents,
+  actual_cents,
+  transaction_id
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (account_id, kind) WHERE resolved_at IS NULL DO NOTHING
+RETURNING *;
+
+-- name: ListOpenReconciliationIncidents :many
+SELECT * FROM reconciliation_incidents
+WHERE account_id = $1 AND resolved_at IS NULL
+ORDER BY id;
+
+-- name: ResolveReconciliationIncident :one
+UPDATE reconciliation_incidents
+SET resolved_at = now()
+WHERE id = $1 AND resolved_at IS NULL
+RETURNING *;
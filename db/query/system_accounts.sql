@@ -0,0 +1,5 @@
+-- name: GetSystemAccount :one
+SELECT * FROM system_accounts
+WHERE kind = $1
+  AND currency = $2
+LIMIT 1;
@@ -3,9 +3,12 @@ INSERT INTO transactions (
   account_id,
   type,
   amount_cents,
-  balance_after_cents
+  balance_after_cents,
+  related_account_id,
+  reference,
+  description
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5, $6, $7
 )
 RETURNING *;
 
@@ -13,9 +16,123 @@ RETURNING *;
 SELECT * FROM transactions
 WHERE id = $1 LIMIT 1;
 
+-- name: GetLastTransactionBefore :one
+-- The last transaction applied to the account before a point in time. Its
+-- balance_after_cents is the account's balance at that point.
+SELECT * FROM transactions
+WHERE account_id = sqlc.arg(account_id) AND created_at < sqlc.arg(before)
+ORDER BY seq DESC
+LIMIT 1;
+
+-- name: GetTransactionByReference :one
+-- Idempotency keys are scoped to the account they were used on.
+SELECT * FROM transactions
+WHERE account_id = $1 AND reference = $2 LIMIT 1;
+
 -- name: ListTransactions :many
+-- Pages through the account's transactions newest first, continuing after the
+-- (after_created_at, after_id) key of the previous page's last row.
+SELECT * FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND (created_at, id) < (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListTransactionsBefore :many
+-- Pages back through the account's transactions from the (before_created_at, before_id)
+-- key, returning the rows nearest to it first.
+SELECT * FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND (created_at, id) > (sqlc.arg(before_created_at)::timestamptz, sqlc.arg(before_id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: ListTransactionsInPeriod :many
+-- Pages through the account's transactions created in [from_time, to_time), in the
+-- order they were applied, continuing after after_seq.
+SELECT * FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(from_time)
+  AND created_at < sqlc.arg(to_time)
+  AND seq > sqlc.arg(after_seq)
+ORDER BY seq
+LIMIT sqlc.arg('limit');
+
+-- name: ListBalanceChainBreaks :many
+-- Finds transactions whose balance_after_cents doesn't follow from the row applied
+-- before it on the same account. An account's first row follows from zero.
+SELECT account_id, id, seq, amount_cents, balance_after_cents, previous_balance_cents
+FROM (
+  SELECT
+    account_id,
+    id,
+    seq,
+    amount_cents,
+    balance_after_cents,
+    COALESCE(lag(balance_after_cents) OVER (PARTITION BY account_id ORDER BY seq), 0)::bigint AS previous_balance_cents
+  FROM transactions
+  WHERE account_id = ANY(sqlc.arg(account_ids)::bigint[])
+) chain
+WHERE balance_after_cents <> previous_balance_cents + amount_cents
+ORDER BY account_id, seq;
+
+-- name: SearchTransactionsNewest :many
+-- Searches the account's transactions newest first, continuing after the
+-- (after_created_at, after_id) key. An empty types matches every type; amounts are
+-- compared by absolute value.
+SELECT * FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(from_time) AND created_at < sqlc.arg(to_time)
+  AND (COALESCE(cardinality(sqlc.arg(types)::text[]), 0) = 0 OR type = ANY(sqlc.arg(types)::text[]::"TransactionType"[]))
+  AND abs(amount_cents) BETWEEN sqlc.arg(min_amount_cents)::bigint AND sqlc.arg(max_amount_cents)::bigint
+  AND (sqlc.narg(related_account_id)::bigint IS NULL OR related_account_id = sqlc.narg(related_account_id))
+  AND (sqlc.narg(reference)::text IS NULL OR reference = sqlc.narg(reference))
+  AND (sqlc.narg(description_pattern)::text IS NULL OR description ILIKE sqlc.narg(description_pattern))
+  AND (created_at, id) < (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: SearchTransactionsOldest :many
+-- Searches the account's transactions oldest first, continuing after the
+-- (after_created_at, after_id) key. Filters as in SearchTransactionsNewest.
+SELECT * FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(from_time) AND created_at < sqlc.arg(to_time)
+  AND (COALESCE(cardinality(sqlc.arg(types)::text[]), 0) = 0 OR type = ANY(sqlc.arg(types)::text[]::"TransactionType"[]))
+  AND abs(amount_cents) BETWEEN sqlc.arg(min_amount_cents)::bigint AND sqlc.arg(max_amount_cents)::bigint
+  AND (sqlc.narg(related_account_id)::bigint IS NULL OR related_account_id = sqlc.narg(related_account_id))
+  AND (sqlc.narg(reference)::text IS NULL OR reference = sqlc.narg(reference))
+  AND (sqlc.narg(description_pattern)::text IS NULL OR description ILIKE sqlc.narg(description_pattern))
+  AND (created_at, id) > (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchTransactionsLargest :many
+-- Searches the account's transactions largest absolute amount first, continuing after
+-- the (after_amount_cents, after_id) key. Filters as in SearchTransactionsNewest.
+SELECT * FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(from_time) AND created_at < sqlc.arg(to_time)
+  AND (COALESCE(cardinality(sqlc.arg(types)::text[]), 0) = 0 OR type = ANY(sqlc.arg(types)::text[]::"TransactionType"[]))
+  AND abs(amount_cents) BETWEEN sqlc.arg(min_amount_cents)::bigint AND sqlc.arg(max_amount_cents)::bigint
+  AND (sqlc.narg(related_account_id)::bigint IS NULL OR related_account_id = sqlc.narg(related_account_id))
+  AND (sqlc.narg(reference)::text IS NULL OR reference = sqlc.narg(reference))
+  AND (sqlc.narg(description_pattern)::text IS NULL OR description ILIKE sqlc.narg(description_pattern))
+  AND (abs(amount_cents), id) < (sqlc.arg(after_amount_cents)::bigint, sqlc.arg(after_id)::uuid)
+ORDER BY abs(amount_cents) DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: SearchTransactionsSmallest :many
+-- Searches the account's transactions smallest absolute amount first, continuing after
+-- the (after_amount_cents, after_id) key. Filters as in SearchTransactionsNewest.
 SELECT * FROM transactions
-WHERE account_id = $1
-ORDER BY created_at DESC
-LIMIT $2
-OFFSET $3;
+WHERE account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(from_time) AND created_at < sqlc.arg(to_time)
+  AND (COALESCE(cardinality(sqlc.arg(types)::text[]), 0) = 0 OR type = ANY(sqlc.arg(types)::text[]::"TransactionType"[]))
+  AND abs(amount_cents) BETWEEN sqlc.arg(min_amount_cents)::bigint AND sqlc.arg(max_amount_cents)::bigint
+  AND (sqlc.narg(related_account_id)::bigint IS NULL OR related_account_id = sqlc.narg(related_account_id))
+  AND (sqlc.narg(reference)::text IS NULL OR reference = sqlc.narg(reference))
+  AND (sqlc.narg(description_pattern)::text IS NULL OR description ILIKE sqlc.narg(description_pattern))
+  AND (abs(amount_cents), id) > (sqlc.arg(after_amount_cents)::bigint, sqlc.arg(after_id)::uuid)
+ORDER BY abs(amount_cents), id
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,15 @@
+-- name: CreateTransferReversal :one
+INSERT INTO transfer_reversals (
+  transfer_id,
+  amount_cents,
+  reason,
+  debit_transaction_id,
+  credit_transaction_id
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetTransferReversalByTransfer :one
+SELECT * FROM transfer_reversals
+WHERE transfer_id = $1 LIMIT 1;
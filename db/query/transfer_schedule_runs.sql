@@ -0,0 +1,29 @@
+-- name: CreateTransferScheduleRun :one
+INSERT INTO transfer_schedule_runs (
+  schedule_id,
+  occurrence_at,
+  attempt,
+  status,
+  transfer_id,
+  failure_reason
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: ListTransferScheduleRuns :many
+-- Pages through the schedule's runs newest first, continuing after after_id.
+SELECT * FROM transfer_schedule_runs
+WHERE schedule_id = sqlc.arg(schedule_id)
+  AND id < sqlc.arg(after_id)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListTransferScheduleRunsBefore :many
+-- Pages back through the schedule's runs from before_id, returning the rows
+-- nearest to it first.
+SELECT * FROM transfer_schedule_runs
+WHERE schedule_id = sqlc.arg(schedule_id)
+  AND id > sqlc.arg(before_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
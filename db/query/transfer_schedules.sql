@@ -0,0 +1,78 @@
+-- name: CreateTransferSchedule :one
+INSERT INTO transfer_schedules (
+  from_account_id,
+  to_account_id,
+  amount_cents,
+  frequency,
+  day_of_month,
+  insufficient_funds_policy,
+  max_attempts,
+  next_occurrence_at,
+  next_run_at,
+  ends_at
+) VALUES (
+  $1, $2, $3, $4, $5,
+  $6, $7, $8, $8, $9
+)
+RETURNING *;
+
+-- name: GetTransferSchedule :one
+SELECT * FROM transfer_schedules
+WHERE id = $1 LIMIT 1;
+
+-- name: ListTransferSchedulesByAccount :many
+-- Pages through the schedules paying from the account newest first, continuing after the
+-- (after_created_at, after_id) key of the previous page's last row.
+SELECT * FROM transfer_schedules
+WHERE from_account_id = sqlc.arg(from_account_id)
+  AND (created_at, id) < (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListTransferSchedulesByAccountBefore :many
+-- Pages back through the schedules paying from the account from the (before_created_at, before_id)
+-- key, returning the rows nearest to it first.
+SELECT * FROM transfer_schedules
+WHERE from_account_id = sqlc.arg(from_account_id)
+  AND (created_at, id) > (sqlc.arg(before_created_at)::timestamptz, sqlc.arg(before_id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: ClaimDueTransferSchedules :many
+-- Leases up to limit due schedules to the caller. SKIP LOCKED lets concurrent workers
+-- claim disjoint batches, and the lease keeps a claimed schedule from being picked up
+-- again until it is recorded or the lease runs out.
+UPDATE transfer_schedules
+SET locked_until = sqlc.arg(locked_until)
+WHERE id IN (
+  SELECT id FROM transfer_schedules
+  WHERE status = 'active'
+    AND next_run_at <= sqlc.arg(now)
+    AND (locked_until IS NULL OR locked_until <= sqlc.arg(now))
+  ORDER BY next_run_at
+  LIMIT sqlc.arg('limit')
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: AdvanceTransferSchedule :one
+-- Moves a schedule on from the occurrence and attempt a worker ran, and releases its lease.
+-- Returns no row when that run was already recorded. A schedule cancelled meanwhile stays cancelled.
+UPDATE transfer_schedules
+SET status = CASE WHEN status = 'active' THEN sqlc.arg(status)::"ScheduleStatus" ELSE status END,
+    failed_attempts = sqlc.arg(failed_attempts),
+    next_occurrence_at = sqlc.arg(next_occurrence_at),
+    next_run_at = sqlc.arg(next_run_at),
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND next_occurrence_at = sqlc.arg(ran_occurrence_at)
+  AND failed_attempts = sqlc.arg(ran_failed_attempts)
+RETURNING *;
+
+-- name: CancelTransferSchedule :one
+UPDATE transfer_schedules
+SET status = 'cancelled',
+    updated_at = now()
+WHERE id = $1 AND status = 'active'
+RETURNING *;
@@ -2,9 +2,13 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount_cents
+  amount_cents,
+  reference,
+  to_amount_cents,
+  exchange_rate
 ) VALUES (
-  $1, $2, $3
+  sqlc.arg(from_account_id), sqlc.arg(to_account_id), sqlc.arg(amount_cents), sqlc.narg(idempotency_key),
+  sqlc.narg(to_amount_cents), sqlc.narg(exchange_rate)
 )
 RETURNING *;
 
@@ -12,8 +16,57 @@ RETURNING *;
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
+-- name: GetTransferByReference :one
+-- Idempotency keys are scoped to the account the transfer is sent from.
+SELECT * FROM transfers
+WHERE from_account_id = $1 AND reference = $2 LIMIT 1;
+
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: ListTransfers :many
+-- Pages through every transfer newest first, continuing after the
+-- (after_created_at, after_id) key of the previous page's last row.
+SELECT * FROM transfers
+WHERE (created_at, id) < (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListTransfersBefore :many
+-- Pages back through every transfer from the (before_created_at, before_id)
+-- key, returning the rows nearest to it first.
 SELECT * FROM transfers
-ORDER BY created_at DESC
-LIMIT $1
-OFFSET $2;
+WHERE (created_at, id) > (sqlc.arg(before_created_at)::timestamptz, sqlc.arg(before_id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: ListTransfersByAccount :many
+-- Pages through the account's incoming and outgoing transfers newest first,
+-- continuing after the (after_created_at, after_id) key of the previous page's last row.
+SELECT * FROM transfers
+WHERE (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+  AND (created_at, id) < (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListTransfersByAccountBefore :many
+-- Pages back through the account's incoming and outgoing transfers from the
+-- (before_created_at, before_id) key, returning the rows nearest to it first.
+SELECT * FROM transfers
+WHERE (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+  AND (created_at, id) > (sqlc.arg(before_created_at)::timestamptz, sqlc.arg(before_id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: UpdateTransferStatus :one
+-- Compare-and-set on the current status, so two concurrent transitions of the same
+-- transfer cannot both succeed. processed_at is stamped the first time a transfer
+-- leaves pending and kept afterwards.
+UPDATE transfers
+SET status = sqlc.arg(status),
+    failure_reason = sqlc.narg(failure_reason),
+    processed_at = COALESCE(processed_at, now())
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status)
+RETURNING *;
@@ -10,12 +10,31 @@ RETURNING *;
 SELECT * FROM users
 WHERE id = $1 LIMIT 1;
 
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE email = $1 LIMIT 1;
+
 -- name: ListUsers :many
+-- Pages through users by id, continuing after after_id.
 SELECT id, first_name, last_name, email
 FROM users
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit');
+
+-- name: ListUsersBefore :many
+-- Pages back through users from before_id, returning the rows
+-- nearest to it first.
+SELECT id, first_name, last_name, email
+FROM users
+WHERE id < sqlc.arg(before_id)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
 
 -- name: UpdateUser :one
 UPDATE users
@@ -26,4 +45,10 @@ SET
 WHERE id = $1
 RETURNING id, first_name, last_name, email;
 
+-- name: UpdateUserPassword :one
+UPDATE users
+SET password_hash = $2
+WHERE id = $1
+RETURNING *;
+
 --name 
\ No newline at end of file
@@ -0,0 +1,82 @@
+-- name: CreateWebhookDeliveries :execrows
+-- Queues the event for every active endpoint subscribed to its type whose owner
+-- holds one of the event's accounts.
+INSERT INTO webhook_deliveries (
+  event_id,
+  endpoint_id
+)
+SELECT DISTINCT e.id, w.id
+FROM outbox_events e
+JOIN accounts a ON a.id = ANY (e.account_ids)
+JOIN webhook_endpoints w ON w.owner_id = a.owner_id
+WHERE e.id = $1
+  AND w.active
+  AND (cardinality(w.event_types) = 0 OR e.type::text = ANY (w.event_types))
+ON CONFLICT (event_id, endpoint_id) DO NOTHING;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookDeliveriesByEndpoint :many
+-- Pages through the endpoint's deliveries newest first, continuing after after_id.
+SELECT * FROM webhook_deliveries
+WHERE endpoint_id = sqlc.arg(endpoint_id)
+  AND (sqlc.narg(status)::"WebhookDeliveryStatus" IS NULL OR status = sqlc.narg(status))
+  AND id < sqlc.arg(after_id)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListWebhookDeliveriesByEndpointBefore :many
+-- Pages back through the endpoint's deliveries from before_id, returning the rows
+-- nearest to it first.
+SELECT * FROM webhook_deliveries
+WHERE endpoint_id = sqlc.arg(endpoint_id)
+  AND (sqlc.narg(status)::"WebhookDeliveryStatus" IS NULL OR status = sqlc.narg(status))
+  AND id > sqlc.arg(before_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: ClaimDueWebhookDeliveries :many
+-- Leases up to limit pending deliveries that are due to the caller, the same way
+-- ClaimDueTransferSchedules leases schedules.
+UPDATE webhook_deliveries
+SET locked_until = sqlc.arg(locked_until)
+WHERE id IN (
+  SELECT id FROM webhook_deliveries
+  WHERE status = 'pending'
+    AND next_attempt_at <= sqlc.arg(now)
+    AND (locked_until IS NULL OR locked_until <= sqlc.arg(now))
+  ORDER BY next_attempt_at
+  LIMIT sqlc.arg('limit')
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RecordWebhookDeliveryAttempt :one
+-- Stores the outcome of an attempt and releases the lease. Returns no row when the
+-- delivery was already moved on by another dispatcher or a redelivery.
+UPDATE webhook_deliveries
+SET status = sqlc.arg(status),
+    attempts = attempts + 1,
+    next_attempt_at = sqlc.arg(next_attempt_at),
+    last_status_code = sqlc.arg(last_status_code),
+    last_error = sqlc.arg(last_error),
+    delivered_at = sqlc.arg(delivered_at),
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'pending'
+  AND attempts = sqlc.arg(ran_attempts)
+RETURNING *;
+
+-- name: RedeliverWebhookDelivery :one
+-- Queues a delivered or dead delivery to be sent again now, with a fresh set of attempts.
+UPDATE webhook_deliveries
+SET status = 'pending',
+    attempts = 0,
+    next_attempt_at = now(),
+    locked_until = NULL,
+    updated_at = now()
+WHERE id = $1 AND status <> 'pending'
+RETURNING *;
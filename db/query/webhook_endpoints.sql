@@ -0,0 +1,38 @@
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (
+  owner_id,
+  url,
+  secret,
+  event_types
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetWebhookEndpoint :one
+SELECT * FROM webhook_endpoints
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookEndpointsByOwner :many
+-- Pages through the owner's endpoints oldest first, continuing after the
+-- (after_created_at, after_id) key of the previous page's last row.
+SELECT * FROM webhook_endpoints
+WHERE owner_id = sqlc.arg(owner_id)
+  AND (created_at, id) > (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: ListWebhookEndpointsByOwnerBefore :many
+-- Pages back through the owner's endpoints from the (before_created_at, before_id)
+-- key, returning the rows nearest to it first.
+SELECT * FROM webhook_endpoints
+WHERE owner_id = sqlc.arg(owner_id)
+  AND (created_at, id) < (sqlc.arg(before_created_at)::timestamptz, sqlc.arg(before_id)::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: DeactivateWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET active = false
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,34 @@
+package sqlc
+
+import "context"
+
+// updateAccountBalance is kept out of db/query, so that sqlc leaves it off Querier and
+// with it off the exported Store: a balance set outside a Store transaction would have no
+// transaction or journal entry behind it. Store transactions reach it through ledgerQuerier.
+const updateAccountBalance = `-- name: UpdateAccountBalance :one
+UPDATE accounts
+SET balance_cents = $2
+WHERE id = $1
+RETURNING id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents
+`
+
+type UpdateAccountBalanceParams struct {
+	ID           int64
+	BalanceCents int64
+}
+
+func (q *Queries) updateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountBalance, arg.ID, arg.BalanceCents)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.BalanceCents,
+		&i.Currency,
+		&i.Status,
+		&i.CreatedAt,
+		&i.OverdraftLimitCents,
+		&i.HeldCents,
+	)
+	return i, err
+}
@@ -0,0 +1,177 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrAccountFrozen            = errors.New("account is frozen")
+	ErrAccountClosed            = errors.New("account is closed")
+	ErrAccountBalanceNotZero    = errors.New("account balance must be zero to close it")
+	ErrAccountHasActiveHolds    = errors.New("account has active holds")
+	ErrInvalidAccountTransition = errors.New("account status transition not allowed")
+	ErrFreezeNotOwned           = errors.New("account was frozen by someone else")
+)
+
+// AccountStatusError reports a money movement refused because of an account's status.
+// It matches ErrAccountFrozen or ErrAccountClosed with errors.Is.
+type AccountStatusError struct {
+	AccountID int64
+	Status    AccountStatus
+	// Debit is true when money was to leave the account, false when it was to arrive.
+	Debit bool
+}
+
+func (e *AccountStatusError) Error() string {
+	direction := "credit"
+	if e.Debit {
+		direction = "debit"
+	}
+	return fmt.Sprintf("cannot %s account %d: account is %s", direction, e.AccountID, e.Status)
+}
+
+func (e *AccountStatusError) Is(target error) bool {
+	switch target {
+	case ErrAccountFrozen:
+		return e.Status == AccountStatusFrozen
+	case ErrAccountClosed:
+		return e.Status == AccountStatusClosed
+	}
+	return false
+}
+
+// checkDebit returns an *AccountStatusError unless money may leave account.
+func (store *SQLStore) checkDebit(account Account) error {
+	if account.Status == AccountStatusFrozen || account.Status == AccountStatusClosed {
+		return &AccountStatusError{AccountID: account.ID, Status: account.Status, Debit: true}
+	}
+	return nil
+}
+
+// checkCredit returns an *AccountStatusError unless money may arrive in account.
+// Frozen accounts accept credits only when the store was built with AllowFrozenCredits.
+func (store *SQLStore) checkCredit(account Account) error {
+	if account.Status == AccountStatusClosed || (account.Status == AccountStatusFrozen && !store.allowFrozenCredits) {
+		return &AccountStatusError{AccountID: account.ID, Status: account.Status}
+	}
+	return nil
+}
+
+// accountTransitions lists the statuses an account may move to from each status.
+// Closed is final.
+var accountTransitions = map[AccountStatus][]AccountStatus{
+	AccountStatusActive: {AccountStatusFrozen, AccountStatusClosed},
+	AccountStatusFrozen: {AccountStatusActive, AccountStatusClosed},
+}
+
+type ChangeAccountStatusParams struct {
+	AccountID int64
+	Reason    string
+	// Actor identifies who made the change, e.g. "user:<uuid>" or a system job name.
+	Actor string
+	// FrozenBy, when set on an unfreeze, refuses it with ErrFreezeNotOwned unless this
+	// actor made the account's last freeze, so that owners can't lift system freezes.
+	FrozenBy string
+}
+
+type ChangeAccountStatusResult struct {
+	Account Account
+	Event   AccountStatusEvent
+}
+
+// FreezeAccountTx blocks money from leaving an active account.
+func (store *SQLStore) FreezeAccountTx(ctx context.Context, arg ChangeAccountStatusParams) (ChangeAccountStatusResult, error) {
+	return store.changeAccountStatus(ctx, arg, AccountStatusFrozen)
+}
+
+// UnfreezeAccountTx returns a frozen account to active.
+func (store *SQLStore) UnfreezeAccountTx(ctx context.Context, arg ChangeAccountStatusParams) (ChangeAccountStatusResult, error) {
+	return store.changeAccountStatus(ctx, arg, AccountStatusActive)
+}
+
+// CloseAccountTx permanently closes an active or frozen account. The balance must be zero
+// and no holds may be active.
+func (store *SQLStore) CloseAccountTx(ctx context.Context, arg ChangeAccountStatusParams) (ChangeAccountStatusResult, error) {
+	return store.changeAccountStatus(ctx, arg, AccountStatusClosed)
+}
+
+func (store *SQLStore) changeAccountStatus(ctx context.Context, arg ChangeAccountStatusParams, next AccountStatus) (ChangeAccountStatusResult, error) {
+	var result ChangeAccountStatusResult
+
+	if arg.Reason == "" || arg.Actor == "" {
+		return result, errors.New("account status change requires a reason and an actor")
+	}
+
+	err := store.executeTransaction(ctx, TxChangeAccountStatus, func(q storeQueries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if !canTransitionAccount(account.Status, next) {
+			if account.Status == AccountStatusClosed {
+				return &AccountStatusError{AccountID: account.ID, Status: account.Status}
+			}
+			return fmt.Errorf("%w: %s to %s", ErrInvalidAccountTransition, account.Status, next)
+		}
+		if next == AccountStatusActive && arg.FrozenBy != "" {
+			if err := checkFrozenBy(ctx, q, account.ID, arg.FrozenBy); err != nil {
+				return err
+			}
+		}
+		if next == AccountStatusClosed && account.BalanceCents != 0 {
+			return ErrAccountBalanceNotZero
+		}
+		if next == AccountStatusClosed && account.HeldCents != 0 {
+			return ErrAccountHasActiveHolds
+		}
+
+		result.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:     account.ID,
+			Status: next,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Event, err = q.CreateAccountStatusEvent(ctx, CreateAccountStatusEventParams{
+			AccountID:  account.ID,
+			FromStatus: account.Status,
+			ToStatus:   next,
+			Reason:     arg.Reason,
+			Actor:      arg.Actor,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// checkFrozenBy returns ErrFreezeNotOwned unless the account's latest status change is a
+// freeze made by actor. The caller holds the account's row lock, so no change can come
+// in between.
+func checkFrozenBy(ctx context.Context, q storeQueries, accountID int64, actor string) error {
+	events, err := q.ListAccountStatusEvents(ctx, ListAccountStatusEventsParams{
+		AccountID:      accountID,
+		AfterCreatedAt: afterNewest,
+		Limit:          1,
+	})
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 || events[0].ToStatus != AccountStatusFrozen || events[0].Actor != actor {
+		return ErrFreezeNotOwned
+	}
+	return nil
+}
+
+func canTransitionAccount(from, to AccountStatus) bool {
+	for _, allowed := range accountTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func randomStatusChange(accountID int64) ChangeAccountStatusParams {
+	return ChangeAccountStatusParams{
+		AccountID: accountID,
+		Reason:    utils.RandomString(12),
+		Actor:     "test:" + utils.RandomString(6),
+	}
+}
+
+// createAccountWithStatus creates an account holding balance cents and moves it to status.
+func createAccountWithStatus(t *testing.T, store Store, balance int64, status AccountStatus) Account {
+	account := createAccountInCurrency(t, store, CurrencyUSD, balance)
+	account, err := store.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{ID: account.ID, Status: status})
+	require.NoError(t, err)
+	return account
+}
+
+func TestAccountStatusLifecycle(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithStatus(t, store, 0, AccountStatusActive)
+	ctx := context.Background()
+
+	freeze := randomStatusChange(account.ID)
+	result, err := store.FreezeAccountTx(ctx, freeze)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, result.Account.Status)
+	require.Equal(t, AccountStatusActive, result.Event.FromStatus)
+	require.Equal(t, AccountStatusFrozen, result.Event.ToStatus)
+	require.Equal(t, freeze.Reason, result.Event.Reason)
+	require.Equal(t, freeze.Actor, result.Event.Actor)
+
+	_, err = store.FreezeAccountTx(ctx, randomStatusChange(account.ID))
+	require.ErrorIs(t, err, ErrInvalidAccountTransition)
+
+	result, err = store.UnfreezeAccountTx(ctx, randomStatusChange(account.ID))
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, result.Account.Status)
+
+	result, err = store.CloseAccountTx(ctx, randomStatusChange(account.ID))
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, result.Account.Status)
+
+	_, err = store.UnfreezeAccountTx(ctx, randomStatusChange(account.ID))
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	page, err := store.ListAccountStatusEventsPage(ctx, ListAccountStatusEventsPageParams{AccountID: account.ID, Limit: 10})
+	require.NoError(t, err)
+	events := page.Items
+	require.Len(t, events, 3)
+	require.Equal(t, AccountStatusClosed, events[0].ToStatus)
+	require.Equal(t, freeze.Reason, events[2].Reason)
+}
+
+func TestUnfreezeAccountTx_FrozenBy(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithStatus(t, store, 0, AccountStatusActive)
+	ctx := context.Background()
+
+	system := randomStatusChange(account.ID)
+	system.Actor = "system:reconcile"
+	_, err := store.FreezeAccountTx(ctx, system)
+	require.NoError(t, err)
+
+	owner := randomStatusChange(account.ID)
+	owner.FrozenBy = owner.Actor
+	_, err = store.UnfreezeAccountTx(ctx, owner)
+	require.ErrorIs(t, err, ErrFreezeNotOwned)
+
+	unchanged, err := store.GetAccount(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, unchanged.Status)
+
+	// An operator lifts the system freeze; the owner may lift their own freeze
+	_, err = store.UnfreezeAccountTx(ctx, randomStatusChange(account.ID))
+	require.NoError(t, err)
+	freeze := randomStatusChange(account.ID)
+	_, err = store.FreezeAccountTx(ctx, freeze)
+	require.NoError(t, err)
+	owner.Actor = freeze.Actor
+	owner.FrozenBy = freeze.Actor
+	result, err := store.UnfreezeAccountTx(ctx, owner)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, result.Account.Status)
+}
+
+func TestCloseAccountTx_NonZeroBalance(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithStatus(t, store, 100, AccountStatusActive)
+
+	_, err := store.CloseAccountTx(context.Background(), randomStatusChange(account.ID))
+	require.ErrorIs(t, err, ErrAccountBalanceNotZero)
+
+	_, err = store.CloseAccountTx(context.Background(), ChangeAccountStatusParams{AccountID: account.ID})
+	require.Error(t, err)
+
+	updated, err := store.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, updated.Status)
+}
+
+func TestAccountStatusEnforcement(t *testing.T) {
+	testCases := []struct {
+		name               string
+		status             AccountStatus
+		allowFrozenCredits bool
+		wantDebitErr       error
+		wantCreditErr      error
+	}{
+		{name: "Active", status: AccountStatusActive},
+		{name: "Frozen", status: AccountStatusFrozen, wantDebitErr: ErrAccountFrozen, wantCreditErr: ErrAccountFrozen},
+		{name: "FrozenAllowingCredits", status: AccountStatusFrozen, allowFrozenCredits: true, wantDebitErr: ErrAccountFrozen},
+		{name: "Closed", status: AccountStatusClosed, wantDebitErr: ErrAccountClosed, wantCreditErr: ErrAccountClosed},
+		{name: "ClosedAllowingCredits", status: AccountStatusClosed, allowFrozenCredits: true, wantDebitErr: ErrAccountClosed, wantCreditErr: ErrAccountClosed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore(testDB, AllowFrozenCredits(tc.allowFrozenCredits))
+			ctx := context.Background()
+			account := createAccountWithStatus(t, store, 1000, tc.status)
+			counterparty := createAccountWithStatus(t, store, 1000, AccountStatusActive)
+
+			_, err := store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 10})
+			requireStatusErr(t, err, tc.wantDebitErr, account.ID)
+
+			_, err = store.DepositMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 10})
+			requireStatusErr(t, err, tc.wantCreditErr, account.ID)
+
+			outgoing, err := store.TransferMoneyTx(ctx, TransferMoneyTxParams{FromAccountID: account.ID, ToAccountID: counterparty.ID, AmountCents: 10})
+			requireStatusErr(t, err, tc.wantDebitErr, account.ID)
+			if tc.wantDebitErr != nil {
+				require.Equal(t, TransferStatusFailed, outgoing.Transfer.Status)
+				require.Equal(t, "from_account_"+string(tc.status), outgoing.Transfer.FailureReason.String)
+			}
+
+			incoming, err := store.TransferMoneyTx(ctx, TransferMoneyTxParams{FromAccountID: counterparty.ID, ToAccountID: account.ID, AmountCents: 10})
+			requireStatusErr(t, err, tc.wantCreditErr, account.ID)
+			if tc.wantCreditErr != nil {
+				require.Equal(t, TransferStatusFailed, incoming.Transfer.Status)
+				require.Equal(t, "to_account_"+string(tc.status), incoming.Transfer.FailureReason.String)
+				require.ErrorIs(t, transferFailure(incoming.Transfer), tc.wantCreditErr)
+			}
+		})
+	}
+}
+
+func requireStatusErr(t *testing.T, err, want error, accountID int64) {
+	t.Helper()
+	if want == nil {
+		require.NoError(t, err)
+		return
+	}
+
+	require.ErrorIs(t, err, want)
+	var statusErr *AccountStatusError
+	require.ErrorAs(t, err, &statusErr)
+	require.Equal(t, accountID, statusErr.AccountID)
+}
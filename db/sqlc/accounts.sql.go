@@ -17,7 +17,7 @@ INSERT INTO accounts (
 ) VALUES (
   $1, $2, $3
 )
-RETURNING id, owner_id, balance_cents, currency, status, created_at
+RETURNING id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents
 `
 
 type CreateAccountParams struct {
@@ -36,6 +36,8 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Currency,
 		&i.Status,
 		&i.CreatedAt,
+		&i.OverdraftLimitCents,
+		&i.HeldCents,
 	)
 	return i, err
 }
@@ -51,7 +53,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner_id, balance_cents, currency, status, created_at FROM accounts
+SELECT id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
@@ -65,12 +67,14 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Currency,
 		&i.Status,
 		&i.CreatedAt,
+		&i.OverdraftLimitCents,
+		&i.HeldCents,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner_id, balance_cents, currency, status, created_at FROM accounts
+SELECT id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents FROM accounts
 WHERE id = $1 LIMIT 1
 FOR UPDATE
 `
@@ -85,24 +89,253 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Currency,
 		&i.Status,
 		&i.CreatedAt,
+		&i.OverdraftLimitCents,
+		&i.HeldCents,
 	)
 	return i, err
 }
 
+const listAccountBalanceTotals = `-- name: ListAccountBalanceTotals :many
+SELECT
+  a.id,
+  a.balance_cents,
+  COALESCE((SELECT sum(t.amount_cents) FROM transactions t WHERE t.account_id = a.id), 0)::bigint AS transactions_total_cents,
+  COALESCE((SELECT sum(p.amount_cents) FROM postings p WHERE p.account_id = a.id), 0)::bigint AS ledger_total_cents
+FROM accounts a
+WHERE a.id > $1
+  AND (COALESCE(cardinality($2::bigint[]), 0) = 0 OR a.id = ANY($2::bigint[]))
+ORDER BY a.id
+LIMIT $3
+`
+
+type ListAccountBalanceTotalsParams struct {
+	AfterID    int64
+	AccountIds []int64
+	Limit      int32
+}
+
+type ListAccountBalanceTotalsRow struct {
+	ID                     int64
+	BalanceCents           int64
+	TransactionsTotalCents int64
+	LedgerTotalCents       int64
+}
+
+// Pages through accounts by id, each with the totals its balance has to agree with,
+// read in one snapshot. An empty account_ids covers every account.
+func (q *Queries) ListAccountBalanceTotals(ctx context.Context, arg ListAccountBalanceTotalsParams) ([]ListAccountBalanceTotalsRow, error) {
+	rows, err := q.db.Query(ctx, listAccountBalanceTotals, arg.AfterID, arg.AccountIds, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAccountBalanceTotalsRow
+	for rows.Next() {
+		var i ListAccountBalanceTotalsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BalanceCents,
+			&i.TransactionsTotalCents,
+			&i.LedgerTotalCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner_id, balance_cents, currency, status, created_at FROM accounts
+SELECT id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents FROM accounts
+WHERE id > $1
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
 `
 
 type ListAccountsParams struct {
-	Limit  int32
-	Offset int32
+	AfterID int64
+	Limit   int32
 }
 
+// Pages through every account by id, continuing after after_id.
 func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
-	rows, err := q.db.Query(ctx, listAccounts, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listAccounts, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.BalanceCents,
+			&i.Currency,
+			&i.Status,
+			&i.CreatedAt,
+			&i.OverdraftLimitCents,
+			&i.HeldCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsBefore = `-- name: ListAccountsBefore :many
+SELECT id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents FROM accounts
+WHERE id < $1
+ORDER BY id DESC
+LIMIT $2
+`
+
+type ListAccountsBeforeParams struct {
+	BeforeID int64
+	Limit    int32
+}
+
+// Pages back through every account from before_id, returning the rows
+// nearest to it first.
+func (q *Queries) ListAccountsBefore(ctx context.Context, arg ListAccountsBeforeParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsBefore, arg.BeforeID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.BalanceCents,
+			&i.Currency,
+			&i.Status,
+			&i.CreatedAt,
+			&i.OverdraftLimitCents,
+			&i.HeldCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsByOwner = `-- name: ListAccountsByOwner :many
+SELECT id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents FROM accounts
+WHERE owner_id = $1
+  AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListAccountsByOwnerParams struct {
+	OwnerID pgtype.UUID
+	AfterID int64
+	Limit   int32
+}
+
+// Pages through the owner's accounts by id, continuing after after_id.
+func (q *Queries) ListAccountsByOwner(ctx context.Context, arg ListAccountsByOwnerParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsByOwner, arg.OwnerID, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.BalanceCents,
+			&i.Currency,
+			&i.Status,
+			&i.CreatedAt,
+			&i.OverdraftLimitCents,
+			&i.HeldCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsByOwnerBefore = `-- name: ListAccountsByOwnerBefore :many
+SELECT id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents FROM accounts
+WHERE owner_id = $1
+  AND id < $2
+ORDER BY id DESC
+LIMIT $3
+`
+
+type ListAccountsByOwnerBeforeParams struct {
+	OwnerID  pgtype.UUID
+	BeforeID int64
+	Limit    int32
+}
+
+// Pages back through the owner's accounts from before_id, returning the rows
+// nearest to it first.
+func (q *Queries) ListAccountsByOwnerBefore(ctx context.Context, arg ListAccountsByOwnerBeforeParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsByOwnerBefore, arg.OwnerID, arg.BeforeID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.BalanceCents,
+			&i.Currency,
+			&i.Status,
+			&i.CreatedAt,
+			&i.OverdraftLimitCents,
+			&i.HeldCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverdrawnAccounts = `-- name: ListOverdrawnAccounts :many
+SELECT id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents FROM accounts
+WHERE balance_cents < 0 AND id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListOverdrawnAccountsParams struct {
+	AfterID int64
+	Limit   int32
+}
+
+func (q *Queries) ListOverdrawnAccounts(ctx context.Context, arg ListOverdrawnAccountsParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listOverdrawnAccounts, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +350,8 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Currency,
 			&i.Status,
 			&i.CreatedAt,
+			&i.OverdraftLimitCents,
+			&i.HeldCents,
 		); err != nil {
 			return nil, err
 		}
@@ -128,20 +363,76 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
-const updateAccountBalance = `-- name: UpdateAccountBalance :one
+const updateAccountHeld = `-- name: UpdateAccountHeld :one
 UPDATE accounts
-SET balance_cents = $2
+SET held_cents = $2
 WHERE id = $1
-RETURNING id, owner_id, balance_cents, currency, status, created_at
+RETURNING id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents
 `
 
-type UpdateAccountBalanceParams struct {
-	ID           int64
-	BalanceCents int64
+type UpdateAccountHeldParams struct {
+	ID        int64
+	HeldCents int64
+}
+
+func (q *Queries) UpdateAccountHeld(ctx context.Context, arg UpdateAccountHeldParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountHeld, arg.ID, arg.HeldCents)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.BalanceCents,
+		&i.Currency,
+		&i.Status,
+		&i.CreatedAt,
+		&i.OverdraftLimitCents,
+		&i.HeldCents,
+	)
+	return i, err
+}
+
+const updateAccountOverdraftLimit = `-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit_cents = $2
+WHERE id = $1
+RETURNING id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents
+`
+
+type UpdateAccountOverdraftLimitParams struct {
+	ID                  int64
+	OverdraftLimitCents int64
+}
+
+func (q *Queries) UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountOverdraftLimit, arg.ID, arg.OverdraftLimitCents)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.BalanceCents,
+		&i.Currency,
+		&i.Status,
+		&i.CreatedAt,
+		&i.OverdraftLimitCents,
+		&i.HeldCents,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = $2
+WHERE id = $1
+RETURNING id, owner_id, balance_cents, currency, status, created_at, overdraft_limit_cents, held_cents
+`
+
+type UpdateAccountStatusParams struct {
+	ID     int64
+	Status AccountStatus
 }
 
-func (q *Queries) UpdateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (Account, error) {
-	row := q.db.QueryRow(ctx, updateAccountBalance, arg.ID, arg.BalanceCents)
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountStatus, arg.ID, arg.Status)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -150,6 +441,8 @@ func (q *Queries) UpdateAccountBalance(ctx context.Context, arg UpdateAccountBal
 		&i.Currency,
 		&i.Status,
 		&i.CreatedAt,
+		&i.OverdraftLimitCents,
+		&i.HeldCents,
 	)
 	return i, err
 }
@@ -4,12 +4,14 @@ import (
 	"context"
 	"testing"
 
+	"github.com/RakibRahman/fincore-api/db/dberr"
 	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
-func createRandomAccountWithQueries(t *testing.T, q *Queries) Account {
+func createRandomAccountWithQueries(t *testing.T, q Querier) Account {
 	user := createRandomUserWithQueries(t, q)
 
 	arg := CreateAccountParams{
@@ -63,7 +65,7 @@ func TestUpdateAccount(t *testing.T) {
 		BalanceCents: utils.RandomInt(0, 10000),
 	}
 
-	account2, err := q.UpdateAccountBalance(ctx, arg)
+	account2, err := q.updateAccountBalance(ctx, arg)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, account2)
@@ -97,17 +99,13 @@ func TestListAccounts(t *testing.T) {
 		createRandomAccountWithQueries(t, q)
 	}
 
-	params := ListAccountsParams{
-		Limit:  accountLimit,
-		Offset: 0,
-	}
-
-	accounts, err := q.ListAccounts(ctx, params)
+	page, err := q.ListAccountsPage(ctx, PageParams{Limit: accountLimit})
 
 	require.NoError(t, err)
-	require.Len(t, accounts, accountLimit) // Exact count since we're in isolated transaction
+	require.Len(t, page.Items, accountLimit)
+	require.Empty(t, page.PrevCursor)
 
-	for _, account := range accounts {
+	for _, account := range page.Items {
 		require.NotEmpty(t, account.ID)
 		require.NotEmpty(t, account.OwnerID)
 		require.NotEmpty(t, account.Currency)
@@ -115,6 +113,35 @@ func TestListAccounts(t *testing.T) {
 	}
 }
 
+func TestListAccountsByOwner(t *testing.T) {
+	_, q := createTestTx(t)
+	ctx := context.Background()
+	owner := createRandomUserWithQueries(t, q)
+	const accountLimit = 3
+
+	for i := 0; i < accountLimit; i++ {
+		_, err := q.CreateAccount(ctx, CreateAccountParams{
+			OwnerID:  owner.ID,
+			Currency: CurrencyUSD,
+		})
+		require.NoError(t, err)
+	}
+	// an account owned by someone else must not be listed
+	createRandomAccountWithQueries(t, q)
+
+	page, err := q.ListAccountsByOwnerPage(ctx, ListAccountsByOwnerPageParams{
+		OwnerID: owner.ID,
+		Limit:   10,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, page.Items, accountLimit)
+	require.Empty(t, page.NextCursor)
+	for _, account := range page.Items {
+		require.Equal(t, owner.ID, account.OwnerID)
+	}
+}
+
 // Negative test cases
 
 func TestGetAccountNotFound(t *testing.T) {
@@ -125,7 +152,8 @@ func TestGetAccountNotFound(t *testing.T) {
 
 	account, err := q.GetAccount(ctx, fakeID)
 
-	require.Error(t, err)
+	require.ErrorIs(t, err, dberr.ErrNotFound)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
 	require.Empty(t, account.ID)
 }
 
@@ -144,7 +172,8 @@ func TestCreateAccountInvalidOwner(t *testing.T) {
 
 	account, err := q.CreateAccount(ctx, arg)
 
-	require.Error(t, err) // Should fail due to foreign key constraint
+	require.ErrorIs(t, err, dberr.ErrOwnerNotFound)
+	require.ErrorIs(t, err, dberr.ErrReferenceNotFound)
 	require.Empty(t, account.ID)
 }
 
@@ -159,7 +188,7 @@ func TestUpdateAccountNotFound(t *testing.T) {
 		BalanceCents: utils.RandomInt(0, 10000),
 	}
 
-	account, err := q.UpdateAccountBalance(ctx, arg)
+	account, err := q.updateAccountBalance(ctx, arg)
 
 	require.Error(t, err)
 	require.Empty(t, account.ID)
@@ -175,3 +204,14 @@ func TestDeleteAccountNotFound(t *testing.T) {
 
 	require.NoError(t, err) // DELETE typically doesn't error if ID not found
 }
+
+func TestDeleteAccountStillReferenced(t *testing.T) {
+	_, q := createTestTx(t)
+	ctx := context.Background()
+	transaction := createRandomTransactionWithQueries(t, q)
+
+	err := q.DeleteAccount(ctx, transaction.AccountID)
+
+	require.ErrorIs(t, err, dberr.ErrStillReferenced)
+	require.NotErrorIs(t, err, dberr.ErrAccountNotFound)
+}
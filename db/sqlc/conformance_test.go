@@ -0,0 +1,20 @@
+package sqlc_test
+
+import (
+	"testing"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/db/storetest"
+)
+
+func TestMemoryStoreConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) db.Store {
+		return db.NewMemoryStore()
+	})
+}
+
+func TestSQLStoreConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) db.Store {
+		return db.NewStore(db.SharedTestDB())
+	})
+}
@@ -0,0 +1,83 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrCurrencyMismatch     = errors.New("accounts hold different currencies")
+	ErrExchangeRateNotFound = errors.New("no exchange rate available for the currency pair")
+	ErrConvertedAmountZero  = errors.New("amount converts to zero in the recipient's currency")
+)
+
+// transferConversion is what a transfer credits the recipient, in the recipient's currency.
+type transferConversion struct {
+	creditAmount int64
+	// rate is only valid for cross-currency transfers.
+	rate pgtype.Numeric
+}
+
+// convertTransfer prices a transfer in the recipient's currency. Accounts of the same
+// currency convert 1:1. Different currencies are refused unless the caller opted in with
+// ConvertCurrency, in which case the latest exchange rate in effect is applied. An amount
+// too small to be worth a cent after conversion is refused rather than debited for nothing.
+func (q storeQueries) convertTransfer(ctx context.Context, fromAccount, toAccount Account, arg TransferMoneyTxParams) (transferConversion, *transferRejection, error) {
+	if fromAccount.Currency == toAccount.Currency {
+		return transferConversion{creditAmount: arg.AmountCents}, nil, nil
+	}
+	if !arg.ConvertCurrency {
+		return transferConversion{}, &transferRejection{reason: TransferFailureCurrencyMismatch, err: ErrCurrencyMismatch}, nil
+	}
+
+	rate, err := q.GetLatestExchangeRate(ctx, GetLatestExchangeRateParams{
+		BaseCurrency:  fromAccount.Currency,
+		QuoteCurrency: toAccount.Currency,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return transferConversion{}, &transferRejection{reason: TransferFailureNoExchangeRate, err: ErrExchangeRateNotFound}, nil
+	}
+	if err != nil {
+		return transferConversion{}, nil, err
+	}
+
+	creditAmount, err := convertAmount(arg.AmountCents, rate.Rate)
+	if err != nil {
+		return transferConversion{}, nil, err
+	}
+	if creditAmount <= 0 {
+		return transferConversion{}, &transferRejection{reason: TransferFailureConvertedAmountZero, err: ErrConvertedAmountZero}, nil
+	}
+	return transferConversion{creditAmount: creditAmount, rate: rate.Rate}, nil, nil
+}
+
+// convertAmount multiplies cents by rate, rounding half away from zero. Every supported
+// currency has two minor units, so cents of one convert straight into cents of another.
+func convertAmount(amount int64, rate pgtype.Numeric) (int64, error) {
+	if !rate.Valid || rate.NaN || rate.InfinityModifier != pgtype.Finite || rate.Int == nil {
+		return 0, fmt.Errorf("invalid exchange rate %v", rate)
+	}
+
+	value := new(big.Int).Mul(big.NewInt(amount), rate.Int)
+	if rate.Exp >= 0 {
+		value.Mul(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(rate.Exp)), nil))
+	} else {
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-rate.Exp)), nil)
+		sign := int64(value.Sign())
+		var remainder big.Int
+		value.QuoRem(value, divisor, &remainder) // truncates toward zero
+		if remainder.Abs(&remainder).Lsh(&remainder, 1).Cmp(divisor) >= 0 {
+			value.Add(value, big.NewInt(sign))
+		}
+	}
+
+	if !value.IsInt64() {
+		return 0, fmt.Errorf("converted amount overflows: %d at rate %v", amount, rate)
+	}
+	return value.Int64(), nil
+}
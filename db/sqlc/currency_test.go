@@ -0,0 +1,241 @@
+package sqlc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func numeric(t *testing.T, value string) pgtype.Numeric {
+	var n pgtype.Numeric
+	require.NoError(t, n.Scan(value))
+	return n
+}
+
+func TestConvertAmount(t *testing.T) {
+	testCases := []struct {
+		amount int64
+		rate   string
+		want   int64
+	}{
+		{amount: 10000, rate: "1", want: 10000},
+		{amount: 10000, rate: "0.9215", want: 9215},
+		{amount: 10000, rate: "117.45", want: 1174500},
+		{amount: 333, rate: "0.5", want: 167},    // 166.5 rounds up
+		{amount: 333, rate: "0.4985", want: 166}, // 165.99 rounds up
+		{amount: 1, rate: "0.0049", want: 0},
+		{amount: 1, rate: "0.5", want: 1},
+		{amount: -333, rate: "0.5", want: -167},
+	}
+
+	// a positive exponent, as pgx produces for whole rates with trailing zeros
+	got, err := convertAmount(25, pgtype.Numeric{Int: big.NewInt(1), Exp: 2, Valid: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(2500), got)
+
+	for _, tc := range testCases {
+		got, err = convertAmount(tc.amount, numeric(t, tc.rate))
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, "%d at %s", tc.amount, tc.rate)
+	}
+
+	_, err = convertAmount(100, pgtype.Numeric{})
+	require.Error(t, err)
+
+	_, err = convertAmount(1<<62, numeric(t, "10"))
+	require.Error(t, err)
+}
+
+// createAccountInCurrency creates an active account holding balance cents of currency.
+func createAccountInCurrency(t *testing.T, store Store, currency Currency, balance int64) Account {
+	user := createRandomUserWithQueries(t, store)
+	account, err := store.CreateAccount(context.Background(), CreateAccountParams{
+		OwnerID:      user.ID,
+		BalanceCents: balance,
+		Currency:     currency,
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func TestTransferMoneyTx_CurrencyMismatch(t *testing.T) {
+	store := NewStore(testDB)
+	fromAccount := createAccountInCurrency(t, store, CurrencyUSD, 1000)
+	toAccount := createAccountInCurrency(t, store, CurrencyBDT, 1000)
+
+	result, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		AmountCents:   100,
+	})
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+	require.Equal(t, TransferStatusFailed, result.Transfer.Status)
+	require.Equal(t, TransferFailureCurrencyMismatch, result.Transfer.FailureReason.String)
+
+	updated, err := store.GetAccount(context.Background(), fromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.BalanceCents, updated.BalanceCents)
+}
+
+func TestTransferMoneyTx_ConvertCurrency(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	// GBP -> INR is used only by this test, so the rates below are the only ones in play
+	fromAccount := createAccountInCurrency(t, store, CurrencyGBP, 10000)
+	toAccount := createAccountInCurrency(t, store, CurrencyINR, 0)
+
+	_, err := store.CreateExchangeRate(ctx, CreateExchangeRateParams{
+		BaseCurrency:  CurrencyGBP,
+		QuoteCurrency: CurrencyINR,
+		Rate:          numeric(t, "105.1234"),
+		EffectiveAt:   pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+	// not in effect yet
+	_, err = store.CreateExchangeRate(ctx, CreateExchangeRateParams{
+		BaseCurrency:  CurrencyGBP,
+		QuoteCurrency: CurrencyINR,
+		Rate:          numeric(t, "999"),
+		EffectiveAt:   pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	rate, err := store.GetLatestExchangeRate(ctx, GetLatestExchangeRateParams{BaseCurrency: CurrencyGBP, QuoteCurrency: CurrencyINR})
+	require.NoError(t, err)
+	wantCredit, err := convertAmount(2500, rate.Rate)
+	require.NoError(t, err)
+
+	result, err := store.TransferMoneyTx(ctx, TransferMoneyTxParams{
+		FromAccountID:   fromAccount.ID,
+		ToAccountID:     toAccount.ID,
+		AmountCents:     2500,
+		ConvertCurrency: true,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, TransferStatusCompleted, result.Transfer.Status)
+	require.Equal(t, int64(2500), result.Transfer.AmountCents)
+	require.Equal(t, wantCredit, result.Transfer.ToAmountCents.Int64)
+	require.Equal(t, rate.Rate, result.Transfer.ExchangeRate)
+
+	require.Equal(t, int64(-2500), result.FromTx.AmountCents)
+	require.Equal(t, wantCredit, result.ToTx.AmountCents)
+	require.Equal(t, fromAccount.BalanceCents-2500, result.FromAccount.BalanceCents)
+	require.Equal(t, wantCredit, result.ToAccount.BalanceCents)
+
+	// unwinding it takes back exactly what was credited
+	reversal, err := store.ReverseTransferTx(ctx, ReverseTransferTxParams{
+		TransferID: result.Transfer.ID,
+		Reason:     ReversalReasonCustomerRequest,
+	})
+	require.NoError(t, err)
+	require.Equal(t, -wantCredit, reversal.DebitTx.AmountCents)
+	require.Equal(t, int64(2500), reversal.CreditTx.AmountCents)
+	require.Zero(t, reversal.ToAccount.BalanceCents)
+	require.Equal(t, fromAccount.BalanceCents, reversal.FromAccount.BalanceCents)
+}
+
+func TestReverseTransferTx_PartialConvertedAmountZero(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	// BDT -> USD is used only by this test
+	fromAccount := createAccountInCurrency(t, store, CurrencyBDT, 10000)
+	toAccount := createAccountInCurrency(t, store, CurrencyUSD, 0)
+
+	_, err := store.CreateExchangeRate(ctx, CreateExchangeRateParams{
+		BaseCurrency:  CurrencyBDT,
+		QuoteCurrency: CurrencyUSD,
+		Rate:          numeric(t, "0.0091"),
+		EffectiveAt:   pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferMoneyTx(ctx, TransferMoneyTxParams{
+		FromAccountID:   fromAccount.ID,
+		ToAccountID:     toAccount.ID,
+		AmountCents:     10000,
+		ConvertCurrency: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(91), result.Transfer.ToAmountCents.Int64)
+
+	// 50 poisha are worth 0.455 cents, which rounds to nothing
+	_, err = store.ReverseTransferTx(ctx, ReverseTransferTxParams{
+		TransferID:  result.Transfer.ID,
+		AmountCents: 50,
+		Reason:      ReversalReasonCustomerRequest,
+	})
+	require.ErrorIs(t, err, ErrConvertedAmountZero)
+
+	transfer, err := store.GetTransfer(ctx, result.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCompleted, transfer.Status)
+	fromAccount, err = store.GetAccount(ctx, fromAccount.ID)
+	require.NoError(t, err)
+	require.Zero(t, fromAccount.BalanceCents)
+
+	// 5000 poisha are worth 45.5 cents, rounded to 46
+	reversal, err := store.ReverseTransferTx(ctx, ReverseTransferTxParams{
+		TransferID:  result.Transfer.ID,
+		AmountCents: 5000,
+		Reason:      ReversalReasonCustomerRequest,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-46), reversal.DebitTx.AmountCents)
+	require.Equal(t, int64(5000), reversal.CreditTx.AmountCents)
+	require.Equal(t, int64(45), reversal.ToAccount.BalanceCents)
+}
+
+func TestTransferMoneyTx_NoExchangeRate(t *testing.T) {
+	store := NewStore(testDB)
+	// EUR -> BDT rates are never stored by the tests
+	fromAccount := createAccountInCurrency(t, store, CurrencyEUR, 1000)
+	toAccount := createAccountInCurrency(t, store, CurrencyBDT, 0)
+
+	result, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
+		FromAccountID:   fromAccount.ID,
+		ToAccountID:     toAccount.ID,
+		AmountCents:     100,
+		ConvertCurrency: true,
+	})
+	require.ErrorIs(t, err, ErrExchangeRateNotFound)
+	require.Equal(t, TransferFailureNoExchangeRate, result.Transfer.FailureReason.String)
+}
+
+func TestTransferMoneyTx_ConvertedAmountZero(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	// INR -> USD is used only by this test
+	fromAccount := createAccountInCurrency(t, store, CurrencyINR, 1000)
+	toAccount := createAccountInCurrency(t, store, CurrencyUSD, 0)
+
+	_, err := store.CreateExchangeRate(ctx, CreateExchangeRateParams{
+		BaseCurrency:  CurrencyINR,
+		QuoteCurrency: CurrencyUSD,
+		Rate:          numeric(t, "0.012"),
+		EffectiveAt:   pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	// One paisa is worth 0.012 cents
+	result, err := store.TransferMoneyTx(ctx, TransferMoneyTxParams{
+		FromAccountID:   fromAccount.ID,
+		ToAccountID:     toAccount.ID,
+		AmountCents:     1,
+		ConvertCurrency: true,
+	})
+	require.ErrorIs(t, err, ErrConvertedAmountZero)
+	require.Equal(t, TransferStatusFailed, result.Transfer.Status)
+	require.Equal(t, TransferFailureConvertedAmountZero, result.Transfer.FailureReason.String)
+
+	fromAccount, err = store.GetAccount(ctx, fromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), fromAccount.BalanceCents)
+	toAccount, err = store.GetAccount(ctx, toAccount.ID)
+	require.NoError(t, err)
+	require.Zero(t, toAccount.BalanceCents)
+}
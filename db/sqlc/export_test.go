@@ -0,0 +1,8 @@
+package sqlc
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+// SharedTestDB exposes the test database to the external test package.
+func SharedTestDB() *pgxpool.Pool {
+	return testDB
+}
@@ -0,0 +1,312 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrHoldNotActive      = errors.New("hold is no longer active")
+	ErrHoldExpired        = errors.New("hold has expired")
+	ErrCaptureExceedsHold = errors.New("capture amount exceeds the hold")
+	ErrInvalidHoldExpiry  = errors.New("hold must expire in the future")
+)
+
+// holdsReferenceKey makes a hold's idempotency key unique on its account.
+const holdsReferenceKey = "holds_account_reference_key"
+
+// holdExpiryBatch is how many expired holds ExpireHolds fetches per query.
+const holdExpiryBatch = 100
+
+type PlaceHoldTxParams struct {
+	AccountID   int64
+	AmountCents int64
+	ExpiresAt   time.Time
+	// IdempotencyKey is stored as the hold's reference. A repeated call with the same key
+	// returns the original hold; with a different payload it fails with ErrIdempotencyKeyReused.
+	IdempotencyKey pgtype.Text
+}
+
+type PlaceHoldTxResult struct {
+	Hold    Hold
+	Account Account
+	// Replayed is set when the result belongs to an earlier request with the same idempotency key.
+	Replayed bool
+}
+
+// PlaceHoldTx reserves funds on an account without posting a transaction. The held amount
+// stops counting towards the available balance until the hold is captured, voided or expires;
+// the ledger balance is untouched. Funds are checked under the same account lock withdrawals
+// and transfers take, so a hold can never reserve money a concurrent debit already spent.
+func (store *SQLStore) PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (PlaceHoldTxResult, error) {
+	var result PlaceHoldTxResult
+
+	if arg.AmountCents <= 0 {
+		return result, ErrInvalidAmount
+	}
+	if !arg.ExpiresAt.After(time.Now()) {
+		return result, ErrInvalidHoldExpiry
+	}
+
+	err := store.executeTransaction(ctx, TxPlaceHold, func(q storeQueries) error {
+		var err error
+		result.Account, err = q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		// Checked under the account lock, so concurrent requests with the same key
+		// for the same account see each other's hold
+		if arg.IdempotencyKey.Valid {
+			hold, err := q.GetHoldByReference(ctx, GetHoldByReferenceParams{
+				AccountID: arg.AccountID,
+				Reference: arg.IdempotencyKey,
+			})
+			if err == nil {
+				if hold.AmountCents != arg.AmountCents {
+					return ErrIdempotencyKeyReused
+				}
+				result.Hold = hold
+				result.Replayed = true
+				return nil
+			}
+			if !errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+		}
+
+		if err := store.checkDebit(result.Account); err != nil {
+			return err
+		}
+		if result.Account.AvailableCents() < arg.AmountCents {
+			return ErrInsufficientBalance
+		}
+
+		result.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+			AccountID:   arg.AccountID,
+			AmountCents: arg.AmountCents,
+			Reference:   arg.IdempotencyKey,
+			ExpiresAt:   pgtype.Timestamptz{Time: arg.ExpiresAt, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.UpdateAccountHeld(ctx, UpdateAccountHeldParams{
+			ID:        arg.AccountID,
+			HeldCents: result.Account.HeldCents + arg.AmountCents,
+		})
+		return err
+	})
+	if isUniqueViolation(err, holdsReferenceKey) {
+		return result, ErrIdempotencyKeyReused
+	}
+
+	return result, err
+}
+
+type CaptureHoldTxParams struct {
+	HoldID pgtype.UUID
+	// AmountCents is how much of the hold to capture. Zero captures all of it.
+	// Whatever is left uncaptured is released.
+	AmountCents int64
+	// ToAccountID captures the hold into a transfer to that account.
+	// Zero captures it into a withdrawal.
+	ToAccountID int64
+}
+
+type CaptureHoldTxResult struct {
+	Hold Hold
+	// Withdrawal is set when the hold was captured into a withdrawal,
+	// Transfer when it was captured into a transfer.
+	Withdrawal *AccountTransactionResult
+	Transfer   *TransferMoneyResult
+}
+
+// CaptureHoldTx turns an active hold into a real withdrawal or transfer, fully or partially,
+// in one database transaction. The whole hold is released and the captured amount debited
+// in its place, so the captured money never counts twice against the available balance.
+// A capture refused by a business rule rolls back entirely and leaves the hold active.
+//
+// The hold row is locked first so concurrent captures and voids of the same hold queue up,
+// then the accounts are locked in the same order TransferMoneyTx uses.
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	if arg.AmountCents < 0 {
+		return result, ErrInvalidAmount
+	}
+
+	err := store.executeTransaction(ctx, TxCaptureHold, func(q storeQueries) error {
+		hold, err := q.GetHoldForUpdate(ctx, arg.HoldID)
+		if err != nil {
+			return err
+		}
+		if err := checkHoldCapturable(hold, time.Now()); err != nil {
+			return err
+		}
+
+		amount := arg.AmountCents
+		if amount == 0 {
+			amount = hold.AmountCents
+		}
+		if amount > hold.AmountCents {
+			return ErrCaptureExceedsHold
+		}
+		if arg.ToAccountID == hold.AccountID {
+			return errors.New("cannot capture a hold into a transfer to the same account")
+		}
+
+		if arg.ToAccountID != 0 {
+			if _, _, err := q.lockAccountPair(ctx, hold.AccountID, arg.ToAccountID); err != nil {
+				return err
+			}
+		}
+		if _, err := q.releaseHold(ctx, hold); err != nil {
+			return err
+		}
+
+		// The capture writes no idempotency key: the hold row lock and ResolveHold,
+		// which only settles an active hold, keep it from being captured twice
+		resolve := ResolveHoldParams{
+			ID:            hold.ID,
+			Status:        HoldStatusCaptured,
+			CapturedCents: pgtype.Int8{Int64: amount, Valid: true},
+		}
+
+		if arg.ToAccountID == 0 {
+			withdrawal, err := store.withdraw(ctx, q, AccountTransactionParams{
+				AccountID: hold.AccountID,
+				Amount:    amount,
+			})
+			if err != nil {
+				return err
+			}
+			result.Withdrawal = &withdrawal
+			resolve.CaptureTransactionID = withdrawal.Transaction.ID
+		} else {
+			transfer, failure, err := store.transfer(ctx, q, TransferMoneyTxParams{
+				FromAccountID: hold.AccountID,
+				ToAccountID:   arg.ToAccountID,
+				AmountCents:   amount,
+			})
+			if err != nil {
+				return err
+			}
+			if failure != nil {
+				return failure
+			}
+			result.Transfer = &transfer
+			resolve.CaptureTransactionID = transfer.FromTx.ID
+			resolve.CaptureTransferID = transfer.Transfer.ID
+		}
+
+		result.Hold, err = q.ResolveHold(ctx, resolve)
+		return err
+	})
+
+	return result, err
+}
+
+// VoidHoldTx cancels an active hold and releases its funds.
+func (store *SQLStore) VoidHoldTx(ctx context.Context, holdID pgtype.UUID) (Hold, error) {
+	var hold Hold
+
+	err := store.executeTransaction(ctx, TxVoidHold, func(q storeQueries) error {
+		var err error
+		hold, err = q.GetHoldForUpdate(ctx, holdID)
+		if err != nil {
+			return err
+		}
+		if hold.Status != HoldStatusActive {
+			return fmt.Errorf("%w: hold is %s", ErrHoldNotActive, hold.Status)
+		}
+
+		if _, err := q.releaseHold(ctx, hold); err != nil {
+			return err
+		}
+		hold, err = q.ResolveHold(ctx, ResolveHoldParams{ID: hold.ID, Status: HoldStatusVoided})
+		return err
+	})
+
+	return hold, err
+}
+
+// ExpireHolds releases every active hold whose expiry is at or before now and returns
+// how many it expired. Each hold is expired in its own transaction, so a hold captured
+// or voided concurrently is simply skipped.
+func (store *SQLStore) ExpireHolds(ctx context.Context, now time.Time) (int, error) {
+	expired := 0
+
+	for {
+		ids, err := store.ListExpiredHolds(ctx, ListExpiredHoldsParams{
+			Now:   pgtype.Timestamptz{Time: now, Valid: true},
+			Limit: holdExpiryBatch,
+		})
+		if err != nil {
+			return expired, err
+		}
+
+		for _, id := range ids {
+			err := store.executeTransaction(ctx, TxExpireHold, func(q storeQueries) error {
+				hold, err := q.GetHoldForUpdate(ctx, id)
+				if err != nil {
+					return err
+				}
+				if hold.Status != HoldStatusActive || hold.ExpiresAt.Time.After(now) {
+					return errHoldSkipped
+				}
+
+				if _, err := q.releaseHold(ctx, hold); err != nil {
+					return err
+				}
+				_, err = q.ResolveHold(ctx, ResolveHoldParams{ID: hold.ID, Status: HoldStatusExpired})
+				return err
+			})
+			if errors.Is(err, errHoldSkipped) {
+				continue
+			}
+			if err != nil {
+				return expired, fmt.Errorf("expire hold %s: %w", id, err)
+			}
+			expired++
+		}
+
+		if len(ids) < holdExpiryBatch {
+			return expired, nil
+		}
+	}
+}
+
+// errHoldSkipped rolls back the expiry of a hold that was resolved or extended after it was listed.
+var errHoldSkipped = errors.New("hold no longer due to expire")
+
+// checkHoldCapturable reports why a hold can't be captured at now, or nil if it can.
+func checkHoldCapturable(hold Hold, now time.Time) error {
+	if hold.Status != HoldStatusActive {
+		return fmt.Errorf("%w: hold is %s", ErrHoldNotActive, hold.Status)
+	}
+	if !now.Before(hold.ExpiresAt.Time) {
+		return ErrHoldExpired
+	}
+	return nil
+}
+
+// releaseHold returns a hold's reserved funds to its account's available balance.
+// It locks the account, which is a no-op if the caller already holds the lock.
+func (q storeQueries) releaseHold(ctx context.Context, hold Hold) (Account, error) {
+	account, err := q.GetAccountForUpdate(ctx, hold.AccountID)
+	if err != nil {
+		return account, err
+	}
+
+	return q.UpdateAccountHeld(ctx, UpdateAccountHeldParams{
+		ID:        account.ID,
+		HeldCents: account.HeldCents - hold.AmountCents,
+	})
+}
@@ -0,0 +1,261 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// placeHold holds amount cents on the account for an hour.
+func placeHold(t *testing.T, store Store, accountID, amount int64) Hold {
+	result, err := store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID:   accountID,
+		AmountCents: amount,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusActive, result.Hold.Status)
+	return result.Hold
+}
+
+func TestPlaceHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createAccountInCurrency(t, store, CurrencyUSD, 1000)
+
+	result, err := store.PlaceHoldTx(ctx, PlaceHoldTxParams{
+		AccountID:   account.ID,
+		AmountCents: 600,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), result.Account.BalanceCents)
+	require.Equal(t, int64(600), result.Account.HeldCents)
+	require.Equal(t, int64(400), result.Account.AvailableCents())
+
+	// The held funds are out of reach of withdrawals, transfers and further holds
+	_, err = store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 401})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	other := createAccountInCurrency(t, store, CurrencyUSD, 0)
+	_, err = store.TransferMoneyTx(ctx, TransferMoneyTxParams{FromAccountID: account.ID, ToAccountID: other.ID, AmountCents: 401})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	_, err = store.PlaceHoldTx(ctx, PlaceHoldTxParams{AccountID: account.ID, AmountCents: 401, ExpiresAt: time.Now().Add(time.Hour)})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	withdrawal, err := store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 400})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), withdrawal.Account.AvailableCents())
+
+	_, err = store.PlaceHoldTx(ctx, PlaceHoldTxParams{AccountID: account.ID, AmountCents: 100, ExpiresAt: time.Now().Add(-time.Second)})
+	require.ErrorIs(t, err, ErrInvalidHoldExpiry)
+}
+
+func TestPlaceHoldTx_Idempotent(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createAccountInCurrency(t, store, CurrencyUSD, 1000)
+
+	arg := PlaceHoldTxParams{
+		AccountID:      account.ID,
+		AmountCents:    300,
+		ExpiresAt:      time.Now().Add(time.Hour),
+		IdempotencyKey: pgtype.Text{String: utils.RandomString(16), Valid: true},
+	}
+	first, err := store.PlaceHoldTx(ctx, arg)
+	require.NoError(t, err)
+
+	second, err := store.PlaceHoldTx(ctx, arg)
+	require.NoError(t, err)
+	require.True(t, second.Replayed)
+	require.Equal(t, first.Hold.ID, second.Hold.ID)
+	require.Equal(t, int64(300), second.Account.HeldCents)
+
+	arg.AmountCents = 200
+	_, err = store.PlaceHoldTx(ctx, arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+}
+
+func TestCaptureHoldTx_Withdrawal(t *testing.T) {
+	testCases := []struct {
+		name     string
+		capture  int64
+		captured int64
+	}{
+		{name: "Full", capture: 0, captured: 600},
+		{name: "Partial", capture: 250, captured: 250},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore(testDB)
+			ctx := context.Background()
+			account := createAccountInCurrency(t, store, CurrencyUSD, 1000)
+			hold := placeHold(t, store, account.ID, 600)
+
+			result, err := store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID, AmountCents: tc.capture})
+			require.NoError(t, err)
+			require.Nil(t, result.Transfer)
+			require.NotNil(t, result.Withdrawal)
+
+			require.Equal(t, HoldStatusCaptured, result.Hold.Status)
+			require.Equal(t, tc.captured, result.Hold.CapturedCents.Int64)
+			require.Equal(t, result.Withdrawal.Transaction.ID, result.Hold.CaptureTransactionID)
+			require.True(t, result.Hold.ResolvedAt.Valid)
+
+			// The uncaptured remainder is released along with the captured amount
+			require.Equal(t, -tc.captured, result.Withdrawal.Transaction.AmountCents)
+			require.Equal(t, 1000-tc.captured, result.Withdrawal.Account.BalanceCents)
+			require.Equal(t, int64(0), result.Withdrawal.Account.HeldCents)
+
+			posting, err := store.GetPostingByTransaction(ctx, result.Withdrawal.Transaction.ID)
+			require.NoError(t, err)
+			requireBalancedEntry(t, store, posting.EntryID)
+
+			_, err = store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID})
+			require.ErrorIs(t, err, ErrHoldNotActive)
+		})
+	}
+}
+
+func TestCaptureHoldTx_CallerKeyLikeOldCaptureKey(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createAccountInCurrency(t, store, CurrencyUSD, 1000)
+	hold := placeHold(t, store, account.ID, 600)
+
+	// captures used to be keyed on this, so a deposit claiming it first blocked them
+	_, err := store.DepositMoneyTx(ctx, AccountTransactionParams{
+		AccountID:      account.ID,
+		Amount:         1,
+		IdempotencyKey: pgtype.Text{String: "hold:" + hold.ID.String(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	result, err := store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusCaptured, result.Hold.Status)
+	require.False(t, result.Withdrawal.Transaction.Reference.Valid)
+	require.Equal(t, int64(401), result.Withdrawal.Account.BalanceCents)
+}
+
+func TestCaptureHoldTx_Transfer(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	from := createAccountInCurrency(t, store, CurrencyUSD, 1000)
+	to := createAccountInCurrency(t, store, CurrencyUSD, 0)
+	hold := placeHold(t, store, from.ID, 500)
+
+	result, err := store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID, AmountCents: 400, ToAccountID: to.ID})
+	require.NoError(t, err)
+	require.Nil(t, result.Withdrawal)
+	require.NotNil(t, result.Transfer)
+
+	require.Equal(t, TransferStatusCompleted, result.Transfer.Transfer.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Hold.CaptureTransferID)
+	require.Equal(t, result.Transfer.FromTx.ID, result.Hold.CaptureTransactionID)
+	require.Equal(t, int64(600), result.Transfer.FromAccount.BalanceCents)
+	require.Equal(t, int64(0), result.Transfer.FromAccount.HeldCents)
+	require.Equal(t, int64(400), result.Transfer.ToAccount.BalanceCents)
+}
+
+func TestCaptureHoldTx_Rejected(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createAccountInCurrency(t, store, CurrencyUSD, 1000)
+	hold := placeHold(t, store, account.ID, 500)
+
+	_, err := store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID, AmountCents: 501})
+	require.ErrorIs(t, err, ErrCaptureExceedsHold)
+
+	// A refused transfer rolls the whole capture back
+	euro := createAccountInCurrency(t, store, CurrencyEUR, 0)
+	_, err = store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID, ToAccountID: euro.ID})
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	hold, err = store.GetHold(ctx, hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusActive, hold.Status)
+
+	account, err = store.GetAccount(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), account.BalanceCents)
+	require.Equal(t, int64(500), account.HeldCents)
+}
+
+func TestVoidHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createAccountInCurrency(t, store, CurrencyUSD, 1000)
+	hold := placeHold(t, store, account.ID, 700)
+
+	_, err := store.CloseAccountTx(ctx, randomStatusChange(account.ID))
+	require.ErrorIs(t, err, ErrAccountBalanceNotZero)
+
+	voided, err := store.VoidHoldTx(ctx, hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusVoided, voided.Status)
+	require.False(t, voided.CapturedCents.Valid)
+
+	account, err = store.GetAccount(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), account.AvailableCents())
+
+	_, err = store.VoidHoldTx(ctx, hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotActive)
+	_, err = store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestCloseAccountTx_ActiveHolds(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createOverdraftAccount(t, store, 500)
+	hold := placeHold(t, store, account.ID, 100)
+
+	_, err := store.CloseAccountTx(ctx, randomStatusChange(account.ID))
+	require.ErrorIs(t, err, ErrAccountHasActiveHolds)
+
+	_, err = store.VoidHoldTx(ctx, hold.ID)
+	require.NoError(t, err)
+
+	_, err = store.CloseAccountTx(ctx, randomStatusChange(account.ID))
+	require.NoError(t, err)
+}
+
+func TestExpireHolds(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createAccountInCurrency(t, store, CurrencyUSD, 1000)
+	short := placeHold(t, store, account.ID, 200)
+	long := placeHold(t, store, account.ID, 300)
+
+	// Backdate the first hold so only it is due
+	_, err := testDB.Exec(ctx, "UPDATE holds SET expires_at = now() - interval '1 minute' WHERE id = $1", short.ID)
+	require.NoError(t, err)
+
+	expired, err := store.ExpireHolds(ctx, time.Now())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, expired, 1)
+
+	short, err = store.GetHold(ctx, short.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusExpired, short.Status)
+
+	long, err = store.GetHold(ctx, long.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusActive, long.Status)
+
+	account, err = store.GetAccount(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), account.HeldCents)
+	require.Equal(t, int64(700), account.AvailableCents())
+
+	_, err = store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: short.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
@@ -0,0 +1,220 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is presented again
+// with a request that differs from the one it was first used for.
+var ErrIdempotencyKeyReused = errors.New("idempotency key already used for a different request")
+
+// ErrReservedIdempotencyKey is returned by CheckIdempotencyKey for a key that starts
+// like the references the service has written itself.
+var ErrReservedIdempotencyKey = errors.New("idempotency key uses a reserved prefix")
+
+// reservedKeyPrefixes start the references that hold captures, scheduled transfers and
+// overdraft charges have been written with.
+var reservedKeyPrefixes = []string{"hold:", "schedule:", "overdraft:"}
+
+// CheckIdempotencyKey returns ErrReservedIdempotencyKey when a caller's key could be
+// mistaken for a reference the service has written itself. APIs check the keys they accept
+// with it.
+func CheckIdempotencyKey(key string) error {
+	for _, prefix := range reservedKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return fmt.Errorf("%w %q", ErrReservedIdempotencyKey, prefix)
+		}
+	}
+	return nil
+}
+
+// Unique constraints backing the idempotency keys, which are scoped to an account so
+// that callers choosing the same key never collide.
+const (
+	transactionsReferenceKey = "transactions_account_reference_key"
+	transfersReferenceKey    = "transfers_from_account_reference_key"
+)
+
+func isUniqueViolation(err error, constraints ...string) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return false
+	}
+	for _, constraint := range constraints {
+		if pgErr.ConstraintName == constraint {
+			return true
+		}
+	}
+	return false
+}
+
+// idempotentAccountTransaction runs fn at most once per account and idempotency key. A
+// key that was already used on the account returns the original transaction instead,
+// provided it was for the same type and amount. Requests that fail record nothing, so their key may
+// be retried.
+//
+// The pre-check only avoids a wasted transaction; the UNIQUE constraint on
+// (account_id, reference) is what guarantees a single ledger effect when duplicates
+// race, and the loser of that race falls back to the replay path.
+func (store *SQLStore) idempotentAccountTransaction(
+	ctx context.Context,
+	arg AccountTransactionParams,
+	txType TransactionType,
+	signedAmount int64,
+	fn func() (AccountTransactionResult, error),
+) (AccountTransactionResult, error) {
+	if !arg.IdempotencyKey.Valid {
+		return fn()
+	}
+
+	if result, found, err := store.replayAccountTransaction(ctx, arg, txType, signedAmount); found || err != nil {
+		return result, err
+	}
+
+	result, err := fn()
+	if isUniqueViolation(err, transactionsReferenceKey) {
+		if replayed, found, replayErr := store.replayAccountTransaction(ctx, arg, txType, signedAmount); found || replayErr != nil {
+			return replayed, replayErr
+		}
+	}
+	return result, err
+}
+
+func (store *SQLStore) replayAccountTransaction(
+	ctx context.Context,
+	arg AccountTransactionParams,
+	txType TransactionType,
+	signedAmount int64,
+) (result AccountTransactionResult, found bool, err error) {
+	transaction, err := store.GetTransactionByReference(ctx, GetTransactionByReferenceParams{
+		AccountID: arg.AccountID,
+		Reference: arg.IdempotencyKey,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+
+	if transaction.Type != txType || transaction.AmountCents != signedAmount {
+		return result, true, ErrIdempotencyKeyReused
+	}
+
+	result.Account, err = store.GetAccount(ctx, transaction.AccountID)
+	if err != nil {
+		return result, true, err
+	}
+	result.Transaction = transaction
+	result.Replayed = true
+	return result, true, nil
+}
+
+// idempotentTransfer is the transfer counterpart of idempotentAccountTransaction.
+// Unlike deposits and withdrawals, a transfer rejected for insufficient balance is
+// recorded as failed, so replaying its key returns that failure again.
+func (store *SQLStore) idempotentTransfer(
+	ctx context.Context,
+	arg TransferMoneyTxParams,
+	fn func() (TransferMoneyResult, error),
+) (TransferMoneyResult, error) {
+	if !arg.IdempotencyKey.Valid {
+		return fn()
+	}
+
+	if result, found, err := store.replayTransfer(ctx, arg); found || err != nil {
+		return result, err
+	}
+
+	result, err := fn()
+	if isUniqueViolation(err, transfersReferenceKey) {
+		if replayed, found, replayErr := store.replayTransfer(ctx, arg); found || replayErr != nil {
+			return replayed, replayErr
+		}
+	}
+	return result, err
+}
+
+func (store *SQLStore) replayTransfer(ctx context.Context, arg TransferMoneyTxParams) (result TransferMoneyResult, found bool, err error) {
+	transfer, err := store.GetTransferByReference(ctx, GetTransferByReferenceParams{
+		FromAccountID: arg.FromAccountID,
+		Reference:     arg.IdempotencyKey,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+
+	if transfer.ToAccountID != arg.ToAccountID || transfer.AmountCents != arg.AmountCents {
+		return result, true, ErrIdempotencyKeyReused
+	}
+
+	result.Transfer = transfer
+	result.FromAccount, err = store.GetAccount(ctx, transfer.FromAccountID)
+	if err != nil {
+		return result, true, err
+	}
+	result.ToAccount, err = store.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		return result, true, err
+	}
+	result.Replayed = true
+
+	// a failed attempt has no transaction legs; replay its failure
+	if transfer.Status == TransferStatusFailed {
+		return result, true, transferFailure(transfer)
+	}
+
+	result.FromTx, result.ToTx, err = store.transferLegs(ctx, transfer)
+	if err != nil {
+		return result, true, err
+	}
+	return result, true, nil
+}
+
+// transferLegs returns the transactions a completed transfer wrote, found through the
+// postings of its journal entry. The legs carry no reference of their own, so that a
+// transfer's key never collides with the key of a deposit or withdrawal.
+func (store *SQLStore) transferLegs(ctx context.Context, transfer Transfer) (fromTx, toTx Transaction, err error) {
+	entries, err := store.ListJournalEntriesByTransfer(ctx, transfer.ID)
+	if err != nil {
+		return fromTx, toTx, err
+	}
+	for _, entry := range entries {
+		if entry.Type != JournalEntryTypeTransfer {
+			continue
+		}
+		postings, err := store.ListPostingsByEntry(ctx, entry.ID)
+		if err != nil {
+			return fromTx, toTx, err
+		}
+		for _, posting := range postings {
+			if !posting.TransactionID.Valid {
+				continue
+			}
+			transaction, err := store.GetTransaction(ctx, posting.TransactionID)
+			if err != nil {
+				return fromTx, toTx, err
+			}
+			switch transaction.Type {
+			case TransactionTypeTransferOut:
+				fromTx = transaction
+			case TransactionTypeTransferIn:
+				toTx = transaction
+			}
+		}
+	}
+	if !fromTx.ID.Valid || !toTx.ID.Valid {
+		return fromTx, toTx, fmt.Errorf("transfer %s has no transaction legs in the ledger", uuid.UUID(transfer.ID.Bytes))
+	}
+	return fromTx, toTx, nil
+}
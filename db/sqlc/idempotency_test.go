@@ -0,0 +1,252 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func randomIdempotencyKey() pgtype.Text {
+	return pgtype.Text{String: utils.RandomString(32), Valid: true}
+}
+
+func TestDepositMoneyTx_IdempotentReplay(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccountWithQueries(t, store)
+	arg := AccountTransactionParams{
+		AccountID:      account.ID,
+		Amount:         25,
+		IdempotencyKey: randomIdempotencyKey(),
+	}
+
+	first, err := store.DepositMoneyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, first.Replayed)
+	require.Equal(t, arg.IdempotencyKey, first.Transaction.Reference)
+
+	second, err := store.DepositMoneyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, second.Replayed)
+	require.Equal(t, first.Transaction.ID, second.Transaction.ID)
+
+	// the money moved once
+	updatedAccount, err := store.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.BalanceCents+arg.Amount, updatedAccount.BalanceCents)
+}
+
+func TestAccountTransactionTx_IdempotencyKeyReused(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccountWithQueries(t, store)
+	key := randomIdempotencyKey()
+
+	_, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{
+		AccountID:      account.ID,
+		Amount:         25,
+		IdempotencyKey: key,
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		call func() (AccountTransactionResult, error)
+	}{
+		{
+			name: "DifferentAmount",
+			call: func() (AccountTransactionResult, error) {
+				return store.DepositMoneyTx(context.Background(), AccountTransactionParams{AccountID: account.ID, Amount: 26, IdempotencyKey: key})
+			},
+		},
+		{
+			name: "DifferentType",
+			call: func() (AccountTransactionResult, error) {
+				return store.WithdrawMoneyTx(context.Background(), AccountTransactionParams{AccountID: account.ID, Amount: 25, IdempotencyKey: key})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.call()
+			require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+		})
+	}
+}
+
+func TestConcurrentWithdrawMoneyTx_SameIdempotencyKey(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccountWithQueries(t, store)
+	arg := AccountTransactionParams{
+		AccountID:      account.ID,
+		Amount:         10,
+		IdempotencyKey: randomIdempotencyKey(),
+	}
+	n := 10
+
+	errs := make(chan error, n)
+	results := make(chan AccountTransactionResult, n)
+
+	for range n {
+		go func() {
+			result, err := store.WithdrawMoneyTx(context.Background(), arg)
+			results <- result
+			errs <- err
+		}()
+	}
+
+	var transactionID pgtype.UUID
+	replayed := 0
+	for range n {
+		require.NoError(t, <-errs)
+		result := <-results
+		if result.Replayed {
+			replayed++
+		}
+		if transactionID.Valid {
+			require.Equal(t, transactionID, result.Transaction.ID)
+		}
+		transactionID = result.Transaction.ID
+	}
+	require.Equal(t, n-1, replayed)
+
+	updatedAccount, err := store.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.BalanceCents-arg.Amount, updatedAccount.BalanceCents)
+}
+
+func TestTransferMoneyTx_IdempotentReplay(t *testing.T) {
+	store := NewStore(testDB)
+	fromAccount := createRandomAccountWithQueries(t, store)
+	toAccount := createRandomAccountWithQueries(t, store)
+	arg := TransferMoneyTxParams{
+		FromAccountID:  fromAccount.ID,
+		ToAccountID:    toAccount.ID,
+		AmountCents:    10,
+		IdempotencyKey: randomIdempotencyKey(),
+	}
+
+	first, err := store.TransferMoneyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, first.Replayed)
+	require.Equal(t, arg.IdempotencyKey, first.Transfer.Reference)
+
+	second, err := store.TransferMoneyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, second.Replayed)
+	require.Equal(t, first.Transfer.ID, second.Transfer.ID)
+	require.Equal(t, first.FromTx.ID, second.FromTx.ID)
+	require.Equal(t, first.ToTx.ID, second.ToTx.ID)
+	require.Equal(t, fromAccount.BalanceCents-arg.AmountCents, second.FromAccount.BalanceCents)
+	require.Equal(t, toAccount.BalanceCents+arg.AmountCents, second.ToAccount.BalanceCents)
+
+	arg.AmountCents++
+	_, err = store.TransferMoneyTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+}
+
+func TestTransferMoneyTx_IdempotentReplayOfFailure(t *testing.T) {
+	store := NewStore(testDB)
+	fromAccount := createRandomAccountWithQueries(t, store)
+	toAccount := createRandomAccountWithQueries(t, store)
+	arg := TransferMoneyTxParams{
+		FromAccountID:  fromAccount.ID,
+		ToAccountID:    toAccount.ID,
+		AmountCents:    fromAccount.BalanceCents + 1,
+		IdempotencyKey: randomIdempotencyKey(),
+	}
+
+	first, err := store.TransferMoneyTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+	require.Equal(t, TransferStatusFailed, first.Transfer.Status)
+
+	// topping up doesn't turn a recorded failure into a success under the same key
+	_, err = store.DepositMoneyTx(context.Background(), AccountTransactionParams{AccountID: fromAccount.ID, Amount: arg.AmountCents})
+	require.NoError(t, err)
+
+	second, err := store.TransferMoneyTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+	require.True(t, second.Replayed)
+	require.Equal(t, first.Transfer.ID, second.Transfer.ID)
+}
+
+func TestTransferMoneyTx_KeyOutsideDepositKeys(t *testing.T) {
+	store := NewStore(testDB)
+	fromAccount := createRandomAccountWithQueries(t, store)
+	toAccount := createRandomAccountWithQueries(t, store)
+	key := randomIdempotencyKey()
+
+	// deposit keys that look like the legs transfers used to store don't block a transfer
+	for _, suffix := range []string{":out", ":in"} {
+		_, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{
+			AccountID:      fromAccount.ID,
+			Amount:         5,
+			IdempotencyKey: pgtype.Text{String: key.String + suffix, Valid: true},
+		})
+		require.NoError(t, err)
+	}
+
+	arg := TransferMoneyTxParams{
+		FromAccountID:  fromAccount.ID,
+		ToAccountID:    toAccount.ID,
+		AmountCents:    10,
+		IdempotencyKey: key,
+	}
+	first, err := store.TransferMoneyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCompleted, first.Transfer.Status)
+	require.False(t, first.FromTx.Reference.Valid)
+	require.False(t, first.ToTx.Reference.Valid)
+
+	second, err := store.TransferMoneyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, second.Replayed)
+	require.Equal(t, first.FromTx.ID, second.FromTx.ID)
+	require.Equal(t, first.ToTx.ID, second.ToTx.ID)
+
+	// and a transfer's key leaves the deposit key space alone
+	deposit, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{
+		AccountID:      toAccount.ID,
+		Amount:         5,
+		IdempotencyKey: key,
+	})
+	require.NoError(t, err)
+	require.False(t, deposit.Replayed)
+}
+
+func TestIdempotencyKeysScopedToAccount(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithQueries(t, store)
+	account2 := createRandomAccountWithQueries(t, store)
+	key := randomIdempotencyKey()
+
+	// the same key on two accounts makes two independent deposits
+	first, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{AccountID: account1.ID, Amount: 10, IdempotencyKey: key})
+	require.NoError(t, err)
+	second, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{AccountID: account2.ID, Amount: 10, IdempotencyKey: key})
+	require.NoError(t, err)
+	require.False(t, second.Replayed)
+	require.NotEqual(t, first.Transaction.ID, second.Transaction.ID)
+
+	// and transfers are scoped to the account they are sent from
+	out, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
+		FromAccountID: account1.ID, ToAccountID: account2.ID, AmountCents: 5, IdempotencyKey: key,
+	})
+	require.NoError(t, err)
+	back, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
+		FromAccountID: account2.ID, ToAccountID: account1.ID, AmountCents: 5, IdempotencyKey: key,
+	})
+	require.NoError(t, err)
+	require.False(t, back.Replayed)
+	require.NotEqual(t, out.Transfer.ID, back.Transfer.ID)
+}
+
+func TestCheckIdempotencyKey(t *testing.T) {
+	require.NoError(t, CheckIdempotencyKey("order-42"))
+	require.NoError(t, CheckIdempotencyKey("my-hold:1"))
+	for _, key := range []string{"hold:1", "schedule:1:2:3", "overdraft:1:2026-01-01:overdraft_fee"} {
+		require.ErrorIs(t, CheckIdempotencyKey(key), ErrReservedIdempotencyKey)
+	}
+}
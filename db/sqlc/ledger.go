@@ -0,0 +1,115 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrUnbalancedEntry is returned for a journal entry whose postings don't sum to zero
+// in every currency. The database enforces the same rule when the transaction commits.
+var ErrUnbalancedEntry = errors.New("journal entry does not balance")
+
+// ledgerPosting is one leg of a journal entry before it is written. It moves amount
+// on either a customer account (accountID) or the system account of the given kind.
+type ledgerPosting struct {
+	accountID   int64
+	system      SystemAccountKind
+	transaction pgtype.UUID
+	currency    Currency
+	amount      int64
+}
+
+// accountPosting books a customer transaction against its account.
+func accountPosting(account Account, transaction Transaction) ledgerPosting {
+	return ledgerPosting{
+		accountID:   account.ID,
+		transaction: transaction.ID,
+		currency:    account.Currency,
+		amount:      transaction.AmountCents,
+	}
+}
+
+func systemPosting(kind SystemAccountKind, currency Currency, amount int64) ledgerPosting {
+	return ledgerPosting{system: kind, currency: currency, amount: amount}
+}
+
+// movementPostings books money leaving one customer account and arriving on another.
+// When the accounts hold different currencies each side is balanced against the
+// fx_clearing account of its own currency, so the entry nets to zero per currency.
+func movementPostings(debited Account, debit Transaction, credited Account, credit Transaction) []ledgerPosting {
+	postings := []ledgerPosting{
+		accountPosting(debited, debit),
+		accountPosting(credited, credit),
+	}
+	if debited.Currency != credited.Currency {
+		postings = append(postings,
+			systemPosting(SystemAccountKindFxClearing, debited.Currency, -debit.AmountCents),
+			systemPosting(SystemAccountKindFxClearing, credited.Currency, -credit.AmountCents),
+		)
+	}
+	return postings
+}
+
+// postJournalEntry writes a journal entry and its postings. transferID links the entry to
+// the transfer it settles and is left invalid for deposits and withdrawals. Legs of zero
+// move nothing and are dropped.
+func (q storeQueries) postJournalEntry(ctx context.Context, entryType JournalEntryType, transferID pgtype.UUID, postings ...ledgerPosting) (JournalEntry, error) {
+	if err := checkBalanced(postings); err != nil {
+		return JournalEntry{}, err
+	}
+
+	entry, err := q.CreateJournalEntry(ctx, CreateJournalEntryParams{
+		Type:       entryType,
+		TransferID: transferID,
+	})
+	if err != nil {
+		return JournalEntry{}, err
+	}
+
+	for _, posting := range postings {
+		if posting.amount == 0 {
+			continue
+		}
+
+		arg := CreatePostingParams{
+			EntryID:       entry.ID,
+			TransactionID: posting.transaction,
+			Currency:      posting.currency,
+			AmountCents:   posting.amount,
+		}
+		if posting.system != "" {
+			account, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+				Kind:     posting.system,
+				Currency: posting.currency,
+			})
+			if err != nil {
+				return JournalEntry{}, fmt.Errorf("system account %s %s: %w", posting.system, posting.currency, err)
+			}
+			arg.SystemAccountID = pgtype.Int8{Int64: account.ID, Valid: true}
+		} else {
+			arg.AccountID = pgtype.Int8{Int64: posting.accountID, Valid: true}
+		}
+
+		if _, err := q.CreatePosting(ctx, arg); err != nil {
+			return JournalEntry{}, err
+		}
+	}
+
+	return entry, nil
+}
+
+func checkBalanced(postings []ledgerPosting) error {
+	sums := make(map[Currency]int64)
+	for _, posting := range postings {
+		sums[posting.currency] += posting.amount
+	}
+	for currency, sum := range sums {
+		if sum != 0 {
+			return fmt.Errorf("%w: %s postings sum to %d", ErrUnbalancedEntry, currency, sum)
+		}
+	}
+	return nil
+}
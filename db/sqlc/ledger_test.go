@@ -0,0 +1,326 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBalanced(t *testing.T) {
+	testCases := []struct {
+		name     string
+		postings []ledgerPosting
+		wantErr  bool
+	}{
+		{
+			name:     "Empty",
+			postings: nil,
+		},
+		{
+			name: "Balanced",
+			postings: []ledgerPosting{
+				{accountID: 1, currency: CurrencyUSD, amount: 500},
+				systemPosting(SystemAccountKindCashIn, CurrencyUSD, -500),
+			},
+		},
+		{
+			name: "BalancedPerCurrency",
+			postings: []ledgerPosting{
+				{accountID: 1, currency: CurrencyUSD, amount: -1000},
+				{accountID: 2, currency: CurrencyEUR, amount: 921},
+				systemPosting(SystemAccountKindFxClearing, CurrencyUSD, 1000),
+				systemPosting(SystemAccountKindFxClearing, CurrencyEUR, -921),
+			},
+		},
+		{
+			name: "Unbalanced",
+			postings: []ledgerPosting{
+				{accountID: 1, currency: CurrencyUSD, amount: 500},
+				systemPosting(SystemAccountKindCashIn, CurrencyUSD, -499),
+			},
+			wantErr: true,
+		},
+		{
+			// nets to zero overall but not within each currency
+			name: "CrossCurrency",
+			postings: []ledgerPosting{
+				{accountID: 1, currency: CurrencyUSD, amount: -1000},
+				{accountID: 2, currency: CurrencyEUR, amount: 1000},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkBalanced(tc.postings)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrUnbalancedEntry)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// requireBalancedEntry loads the postings of a journal entry and checks they net to zero per currency.
+func requireBalancedEntry(t *testing.T, q Querier, entryID pgtype.UUID) []Posting {
+	postings, err := q.ListPostingsByEntry(context.Background(), entryID)
+	require.NoError(t, err)
+	require.NotEmpty(t, postings)
+
+	sums := make(map[Currency]int64)
+	for _, posting := range postings {
+		sums[posting.Currency] += posting.AmountCents
+	}
+	for currency, sum := range sums {
+		require.Zero(t, sum, "%s postings of entry %s", currency, entryID)
+	}
+	return postings
+}
+
+// requireSystemPosting checks posting is on the system account of the given kind.
+func requireSystemPosting(t *testing.T, q Querier, posting Posting, kind SystemAccountKind, amount int64) {
+	require.False(t, posting.AccountID.Valid)
+	require.True(t, posting.SystemAccountID.Valid)
+
+	account, err := q.GetSystemAccount(context.Background(), GetSystemAccountParams{Kind: kind, Currency: posting.Currency})
+	require.NoError(t, err)
+	require.Equal(t, account.ID, posting.SystemAccountID.Int64)
+	require.Equal(t, amount, posting.AmountCents)
+}
+
+func TestDepositAndWithdrawPostJournalEntries(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createAccountInCurrency(t, store, CurrencyUSD, 0)
+
+	deposit, err := store.DepositMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 700})
+	require.NoError(t, err)
+	withdrawal, err := store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 250})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		transaction Transaction
+		entryType   JournalEntryType
+		system      SystemAccountKind
+	}{
+		{name: "Deposit", transaction: deposit.Transaction, entryType: JournalEntryTypeDeposit, system: SystemAccountKindCashIn},
+		{name: "Withdrawal", transaction: withdrawal.Transaction, entryType: JournalEntryTypeWithdrawal, system: SystemAccountKindCashOut},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			posting, err := store.GetPostingByTransaction(ctx, tc.transaction.ID)
+			require.NoError(t, err)
+			require.Equal(t, account.ID, posting.AccountID.Int64)
+			require.Equal(t, tc.transaction.AmountCents, posting.AmountCents)
+
+			entry, err := store.GetJournalEntry(ctx, posting.EntryID)
+			require.NoError(t, err)
+			require.Equal(t, tc.entryType, entry.Type)
+			require.False(t, entry.TransferID.Valid)
+
+			postings := requireBalancedEntry(t, store, entry.ID)
+			require.Len(t, postings, 2)
+			requireSystemPosting(t, store, postings[1], tc.system, -tc.transaction.AmountCents)
+		})
+	}
+
+	balance, err := store.GetAccountLedgerBalance(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, withdrawal.Account.BalanceCents, balance)
+}
+
+func TestCreateAccountPostsOpeningBalance(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	testCases := []struct {
+		name    string
+		balance int64
+	}{
+		{name: "Funded", balance: 1200},
+		{name: "Empty", balance: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			account := createAccountInCurrency(t, store, CurrencyEUR, tc.balance)
+			require.Equal(t, tc.balance, account.BalanceCents)
+
+			// The opening balance is on the ledger without any transaction behind it
+			balance, err := store.GetAccountLedgerBalance(ctx, account.ID)
+			require.NoError(t, err)
+			require.Equal(t, tc.balance, balance)
+		})
+	}
+}
+
+func TestTransferAndReversalPostJournalEntries(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	fromAccount := createAccountInCurrency(t, store, CurrencyUSD, 0)
+	toAccount := createAccountInCurrency(t, store, CurrencyUSD, 0)
+
+	_, err := store.DepositMoneyTx(ctx, AccountTransactionParams{AccountID: fromAccount.ID, Amount: 1000})
+	require.NoError(t, err)
+
+	transfer, err := store.TransferMoneyTx(ctx, TransferMoneyTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		AmountCents:   400,
+	})
+	require.NoError(t, err)
+
+	reversal, err := store.ReverseTransferTx(ctx, ReverseTransferTxParams{
+		TransferID:  transfer.Transfer.ID,
+		AmountCents: 150,
+		Reason:      ReversalReasonCustomerRequest,
+	})
+	require.NoError(t, err)
+
+	entries, err := store.ListJournalEntriesByTransfer(ctx, transfer.Transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, JournalEntryTypeTransfer, entries[0].Type)
+	require.Equal(t, JournalEntryTypeReversal, entries[1].Type)
+
+	postings := requireBalancedEntry(t, store, entries[0].ID)
+	require.Len(t, postings, 2)
+	require.Equal(t, transfer.FromTx.ID, postings[0].TransactionID)
+	require.Equal(t, int64(-400), postings[0].AmountCents)
+	require.Equal(t, transfer.ToTx.ID, postings[1].TransactionID)
+	require.Equal(t, int64(400), postings[1].AmountCents)
+
+	postings = requireBalancedEntry(t, store, entries[1].ID)
+	require.Len(t, postings, 2)
+	require.Equal(t, reversal.DebitTx.ID, postings[0].TransactionID)
+	require.Equal(t, reversal.CreditTx.ID, postings[1].TransactionID)
+
+	for _, account := range []Account{reversal.FromAccount, reversal.ToAccount} {
+		balance, err := store.GetAccountLedgerBalance(ctx, account.ID)
+		require.NoError(t, err)
+		require.Equal(t, account.BalanceCents, balance)
+	}
+}
+
+func TestCrossCurrencyTransferPostsFXClearing(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	fromAccount := createAccountInCurrency(t, store, CurrencyGBP, 0)
+	toAccount := createAccountInCurrency(t, store, CurrencyEUR, 0)
+
+	_, err := store.CreateExchangeRate(ctx, CreateExchangeRateParams{
+		BaseCurrency:  CurrencyGBP,
+		QuoteCurrency: CurrencyEUR,
+		Rate:          numeric(t, "1.17"),
+		EffectiveAt:   pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = store.DepositMoneyTx(ctx, AccountTransactionParams{AccountID: fromAccount.ID, Amount: 1000})
+	require.NoError(t, err)
+
+	result, err := store.TransferMoneyTx(ctx, TransferMoneyTxParams{
+		FromAccountID:   fromAccount.ID,
+		ToAccountID:     toAccount.ID,
+		AmountCents:     1000,
+		ConvertCurrency: true,
+	})
+	require.NoError(t, err)
+	credited := result.ToTx.AmountCents
+
+	entries, err := store.ListJournalEntriesByTransfer(ctx, result.Transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	postings := requireBalancedEntry(t, store, entries[0].ID)
+	require.Len(t, postings, 4)
+	require.Equal(t, CurrencyGBP, postings[0].Currency)
+	require.Equal(t, CurrencyEUR, postings[1].Currency)
+	require.Equal(t, credited, postings[1].AmountCents)
+	requireSystemPosting(t, store, postings[2], SystemAccountKindFxClearing, 1000)
+	require.Equal(t, CurrencyGBP, postings[2].Currency)
+	requireSystemPosting(t, store, postings[3], SystemAccountKindFxClearing, -credited)
+	require.Equal(t, CurrencyEUR, postings[3].Currency)
+}
+
+func TestFailedTransferPostsNothing(t *testing.T) {
+	store := NewStore(testDB)
+	fromAccount := createAccountInCurrency(t, store, CurrencyUSD, 0)
+	toAccount := createAccountInCurrency(t, store, CurrencyUSD, 0)
+
+	result, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		AmountCents:   100,
+	})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	entries, err := store.ListJournalEntriesByTransfer(context.Background(), result.Transfer.ID)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestLedgerRejectsUnbalancedEntry(t *testing.T) {
+	tx, q := createTestTx(t)
+	ctx := context.Background()
+	account := createRandomAccountWithQueries(t, q)
+
+	// check at statement end instead of commit, so the test can stay inside its rolled-back tx
+	_, err := tx.Exec(ctx, `SET CONSTRAINTS postings_entry_balanced IMMEDIATE`)
+	require.NoError(t, err)
+
+	entry, err := q.CreateJournalEntry(ctx, CreateJournalEntryParams{Type: JournalEntryTypeDeposit})
+	require.NoError(t, err)
+
+	_, err = q.CreatePosting(ctx, CreatePostingParams{
+		EntryID:     entry.ID,
+		AccountID:   pgtype.Int8{Int64: account.ID, Valid: true},
+		Currency:    account.Currency,
+		AmountCents: 100,
+	})
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "postings_entry_balanced", pgErr.ConstraintName)
+}
+
+func TestLedgerRejectsPostingInOtherCurrency(t *testing.T) {
+	_, q := createTestTx(t)
+	ctx := context.Background()
+	account := createRandomAccountWithQueries(t, q) // USD
+
+	entry, err := q.CreateJournalEntry(ctx, CreateJournalEntryParams{Type: JournalEntryTypeDeposit})
+	require.NoError(t, err)
+
+	_, err = q.CreatePosting(ctx, CreatePostingParams{
+		EntryID:     entry.ID,
+		AccountID:   pgtype.Int8{Int64: account.ID, Valid: true},
+		Currency:    CurrencyEUR,
+		AmountCents: 100,
+	})
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "23503", pgErr.Code) // foreign_key_violation
+}
+
+func TestPostingsAreAppendOnly(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createAccountInCurrency(t, store, CurrencyUSD, 0)
+
+	result, err := store.DepositMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 100})
+	require.NoError(t, err)
+	posting, err := store.GetPostingByTransaction(ctx, result.Transaction.ID)
+	require.NoError(t, err)
+
+	_, err = testDB.Exec(ctx, `UPDATE postings SET amount_cents = 1 WHERE id = $1`, posting.ID)
+	require.Error(t, err)
+	_, err = testDB.Exec(ctx, `DELETE FROM postings WHERE id = $1`, posting.ID)
+	require.Error(t, err)
+}
@@ -0,0 +1,366 @@
+package sqlc
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/db/dberr"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NewMemoryStore returns a SQLStore that keeps its data in memory, for tests and local
+// runs without Postgres. It runs the same transactions as NewStore over queries that
+// enforce the schema's keys and constraints, and fails with the same dberr errors.
+//
+// Transactions are serialized: each one holds the whole store until it commits or
+// rolls back, which gives every row lock a transaction takes for free. A failed
+// transaction leaves no trace except for ids drawn from sequences, as in Postgres.
+func NewMemoryStore(opts ...StoreOption) Store {
+	db := newMemDB()
+	return newSQLStore(memQueries{db: db}, db, opts...)
+}
+
+// memDB holds the tables of an in-memory store.
+type memDB struct {
+	mu sync.Mutex
+
+	users          map[pgtype.UUID]User
+	accounts       map[int64]Account
+	transactions   map[pgtype.UUID]Transaction
+	transfers      map[pgtype.UUID]Transfer
+	reversals      map[pgtype.UUID]TransferReversal
+	statusEvents   map[int64]AccountStatusEvent
+	exchangeRates  map[int64]ExchangeRate
+	systemAccounts map[int64]SystemAccount
+	journalEntries map[pgtype.UUID]JournalEntry
+	postings       map[int64]Posting
+	accruals       map[overdraftAccrualKey]OverdraftAccrual
+	incidents      map[int64]ReconciliationIncident
+	holds          map[pgtype.UUID]Hold
+	schedules      map[pgtype.UUID]TransferSchedule
+	scheduleRuns   map[int64]TransferScheduleRun
+	outboxEvents   map[int64]OutboxEvent
+	endpoints      map[pgtype.UUID]WebhookEndpoint
+	deliveries     map[int64]WebhookDelivery
+
+	seq memSequences
+}
+
+// overdraftAccrualKey is the primary key of overdraft_accruals.
+type overdraftAccrualKey struct {
+	accountID  int64
+	day        string
+	chargeType TransactionType
+}
+
+func newOverdraftAccrualKey(accountID int64, day pgtype.Date, chargeType TransactionType) overdraftAccrualKey {
+	return overdraftAccrualKey{accountID: accountID, day: day.Time.Format(time.DateOnly), chargeType: chargeType}
+}
+
+// memSequences are the last values handed out for the bigserial ids. Like Postgres
+// sequences they are not rolled back with the transaction that drew from them.
+type memSequences struct {
+	accounts      int64
+	transactions  int64
+	statusEvents  int64
+	exchangeRates int64
+	postings      int64
+	incidents     int64
+	scheduleRuns  int64
+	outboxEvents  int64
+	deliveries    int64
+}
+
+func newMemDB() *memDB {
+	db := &memDB{
+		users:          make(map[pgtype.UUID]User),
+		accounts:       make(map[int64]Account),
+		transactions:   make(map[pgtype.UUID]Transaction),
+		transfers:      make(map[pgtype.UUID]Transfer),
+		reversals:      make(map[pgtype.UUID]TransferReversal),
+		statusEvents:   make(map[int64]AccountStatusEvent),
+		exchangeRates:  make(map[int64]ExchangeRate),
+		systemAccounts: make(map[int64]SystemAccount),
+		journalEntries: make(map[pgtype.UUID]JournalEntry),
+		postings:       make(map[int64]Posting),
+		accruals:       make(map[overdraftAccrualKey]OverdraftAccrual),
+		incidents:      make(map[int64]ReconciliationIncident),
+		holds:          make(map[pgtype.UUID]Hold),
+		schedules:      make(map[pgtype.UUID]TransferSchedule),
+		scheduleRuns:   make(map[int64]TransferScheduleRun),
+		outboxEvents:   make(map[int64]OutboxEvent),
+		endpoints:      make(map[pgtype.UUID]WebhookEndpoint),
+		deliveries:     make(map[int64]WebhookDelivery),
+	}
+
+	// The migrations seed a system account of every kind in every currency
+	createdAt := memNow()
+	for _, kind := range []SystemAccountKind{SystemAccountKindCashIn, SystemAccountKindCashOut, SystemAccountKindFxClearing, SystemAccountKindFeeIncome} {
+		for _, currency := range []Currency{CurrencyUSD, CurrencyEUR, CurrencyGBP, CurrencyBDT, CurrencyINR} {
+			id := int64(len(db.systemAccounts)) + 1
+			db.systemAccounts[id] = SystemAccount{ID: id, Kind: kind, Currency: currency, CreatedAt: createdAt}
+		}
+	}
+	return db
+}
+
+// memTx is one transaction on a memDB, which it holds locked until it ends.
+type memTx struct {
+	*memDB
+
+	// now is the transaction's start time, which now() returns throughout it
+	now pgtype.Timestamptz
+	// undo reverts the transaction's writes, newest last
+	undo []func()
+	// entries are the journal entries posted to, checked for balance at commit like
+	// the deferred postings_entry_balanced trigger
+	entries map[pgtype.UUID]struct{}
+	// aborted is set by a failed statement; as in Postgres, the transaction can then
+	// only roll back
+	aborted bool
+}
+
+func (db *memDB) runTx(ctx context.Context, _ pgx.TxIsoLevel, fn func(q ledgerQuerier) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.transact(func(tx *memTx) error {
+		return fn(memQueries{db: db, tx: tx})
+	})
+}
+
+// transact runs fn in a transaction of its own and commits it unless fn fails.
+func (db *memDB) transact(fn func(tx *memTx) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tx := &memTx{memDB: db, now: memNow(), entries: make(map[pgtype.UUID]struct{})}
+	err := fn(tx)
+	if err == nil {
+		err = tx.commit()
+	}
+	if err != nil {
+		for _, undo := range slices.Backward(tx.undo) {
+			undo()
+		}
+	}
+	return err
+}
+
+func (tx *memTx) commit() error {
+	if tx.aborted {
+		return pgx.ErrTxCommitRollback
+	}
+	for entryID := range tx.entries {
+		sums := make(map[Currency]int64)
+		for _, posting := range tx.postings {
+			if posting.EntryID == entryID {
+				sums[posting.Currency] += posting.AmountCents
+			}
+		}
+		for _, sum := range sums {
+			if sum != 0 {
+				return dberr.Translate(&pgconn.PgError{
+					Code:           "23514",
+					Message:        fmt.Sprintf("journal entry %s does not balance", uuid.UUID(entryID.Bytes)),
+					ConstraintName: "postings_entry_balanced",
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// memQueries runs queries on a memDB: inside tx when it is set, otherwise each in a
+// transaction of its own.
+type memQueries struct {
+	db *memDB
+	tx *memTx
+}
+
+var _ Querier = memQueries{}
+
+// query runs one statement. A statement either applies in full or fails without
+// writing anything; a failed one aborts the transaction it ran in.
+func query[T any](ctx context.Context, q memQueries, stmt func(tx *memTx) (T, error)) (T, error) {
+	var result T
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	if q.tx == nil {
+		err := q.db.transact(func(tx *memTx) error {
+			var err error
+			result, err = stmt(tx)
+			return err
+		})
+		return result, err
+	}
+
+	if q.tx.aborted {
+		return result, dberr.Translate(&pgconn.PgError{
+			Code:    "25P02",
+			Message: "current transaction is aborted, commands ignored until end of transaction block",
+		})
+	}
+	result, err := stmt(q.tx)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		q.tx.aborted = true
+	}
+	return result, err
+}
+
+// put writes row under key, to be reverted if the transaction rolls back.
+func put[K comparable, R any](tx *memTx, rows map[K]R, key K, row R) {
+	old, existed := rows[key]
+	tx.undo = append(tx.undo, func() {
+		if existed {
+			rows[key] = old
+		} else {
+			delete(rows, key)
+		}
+	})
+	rows[key] = row
+}
+
+// del deletes the row under key, to be restored if the transaction rolls back.
+func del[K comparable, R any](tx *memTx, rows map[K]R, key K) {
+	old, existed := rows[key]
+	if !existed {
+		return
+	}
+	tx.undo = append(tx.undo, func() {
+		rows[key] = old
+	})
+	delete(rows, key)
+}
+
+// get returns the row under key, or pgx.ErrNoRows.
+func get[K comparable, R any](rows map[K]R, key K) (R, error) {
+	row, ok := rows[key]
+	if !ok {
+		return row, errNoRows()
+	}
+	return row, nil
+}
+
+// find returns a row that matches where, or pgx.ErrNoRows.
+func find[K comparable, R any](rows map[K]R, where func(R) bool) (R, error) {
+	for _, row := range rows {
+		if where(row) {
+			return row, nil
+		}
+	}
+	var zero R
+	return zero, errNoRows()
+}
+
+func exists[K comparable, R any](rows map[K]R, where func(R) bool) bool {
+	_, err := find(rows, where)
+	return err == nil
+}
+
+// selectRows returns the rows that match where in the given order, cut to limit.
+func selectRows[K comparable, R any](rows map[K]R, where func(R) bool, order func(a, b R) int, limit int32) []R {
+	var result []R
+	for _, row := range rows {
+		if where(row) {
+			result = append(result, row)
+		}
+	}
+	slices.SortFunc(result, order)
+	if len(result) > int(limit) {
+		result = result[:limit]
+	}
+	return result
+}
+
+func next(seq *int64) int64 {
+	*seq++
+	return *seq
+}
+
+// memNow returns the current time at the microsecond precision of timestamptz.
+func memNow() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now().Truncate(time.Microsecond), Valid: true}
+}
+
+func newUUID() pgtype.UUID {
+	return pgtype.UUID{Bytes: uuid.New(), Valid: true}
+}
+
+// compareTime orders timestamps as Postgres does, infinities included. Both must be
+// valid.
+func compareTime(a, b pgtype.Timestamptz) int {
+	if c := cmp.Compare(a.InfinityModifier, b.InfinityModifier); c != 0 || a.InfinityModifier != pgtype.Finite {
+		return c
+	}
+	return a.Time.Compare(b.Time)
+}
+
+// compareUUID orders uuids bytewise, as Postgres does.
+func compareUUID(a, b pgtype.UUID) int {
+	return bytes.Compare(a.Bytes[:], b.Bytes[:])
+}
+
+// compareTimeKey orders (created_at, id) keys the way Postgres compares row values.
+func compareTimeKey[ID any](aTime pgtype.Timestamptz, aID ID, bTime pgtype.Timestamptz, bID ID, compareID func(a, b ID) int) int {
+	if c := compareTime(aTime, bTime); c != 0 {
+		return c
+	}
+	return compareID(aID, bID)
+}
+
+// Errors are built the way Postgres reports them, so that dberr translates them to
+// the same domain errors.
+
+func errNoRows() error {
+	return dberr.Translate(pgx.ErrNoRows)
+}
+
+func errUniqueViolation(constraint string) error {
+	return dberr.Translate(&pgconn.PgError{
+		Code:           "23505",
+		Message:        fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+		ConstraintName: constraint,
+	})
+}
+
+func errCheckViolation(table, constraint string) error {
+	return dberr.Translate(&pgconn.PgError{
+		Code:           "23514",
+		Message:        fmt.Sprintf("new row for relation %q violates check constraint %q", table, constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	})
+}
+
+// errForeignKeyViolation reports a row of table referencing a row that doesn't exist.
+func errForeignKeyViolation(table, constraint string) error {
+	return dberr.Translate(&pgconn.PgError{
+		Code:           "23503",
+		Message:        fmt.Sprintf("insert or update on table %q violates foreign key constraint %q", table, constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	})
+}
+
+// errStillReferenced reports a delete from table of a row that referencing still
+// references.
+func errStillReferenced(table, constraint, referencing string) error {
+	return dberr.Translate(&pgconn.PgError{
+		Code:           "23503",
+		Message:        fmt.Sprintf("update or delete on table %q violates foreign key constraint %q on table %q", table, constraint, referencing),
+		TableName:      referencing,
+		ConstraintName: constraint,
+	})
+}
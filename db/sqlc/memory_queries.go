@@ -0,0 +1,1530 @@
+package sqlc
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/RakibRahman/fincore-api/db/dberr"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The queries below follow the SQL in the generated *.sql.go files, including the
+// WHERE clauses that make an UPDATE return no row.
+
+func (q memQueries) AdvanceTransferSchedule(ctx context.Context, arg AdvanceTransferScheduleParams) (TransferSchedule, error) {
+	return query(ctx, q, func(tx *memTx) (TransferSchedule, error) {
+		schedule, ok := tx.schedules[arg.ID]
+		if !ok || !sameTime(schedule.NextOccurrenceAt, arg.RanOccurrenceAt) || schedule.FailedAttempts != arg.RanFailedAttempts {
+			return TransferSchedule{}, errNoRows()
+		}
+		if schedule.Status == ScheduleStatusActive {
+			schedule.Status = arg.Status
+		}
+		schedule.FailedAttempts = arg.FailedAttempts
+		schedule.NextOccurrenceAt = arg.NextOccurrenceAt
+		schedule.NextRunAt = arg.NextRunAt
+		schedule.LockedUntil = pgtype.Timestamptz{}
+		schedule.UpdatedAt = tx.now
+		if err := checkTransferSchedule(schedule); err != nil {
+			return TransferSchedule{}, err
+		}
+		put(tx, tx.schedules, schedule.ID, schedule)
+		return schedule, nil
+	})
+}
+
+func (q memQueries) CancelTransferSchedule(ctx context.Context, id pgtype.UUID) (TransferSchedule, error) {
+	return query(ctx, q, func(tx *memTx) (TransferSchedule, error) {
+		schedule, ok := tx.schedules[id]
+		if !ok || schedule.Status != ScheduleStatusActive {
+			return TransferSchedule{}, errNoRows()
+		}
+		schedule.Status = ScheduleStatusCancelled
+		schedule.UpdatedAt = tx.now
+		put(tx, tx.schedules, schedule.ID, schedule)
+		return schedule, nil
+	})
+}
+
+func (q memQueries) ClaimDueTransferSchedules(ctx context.Context, arg ClaimDueTransferSchedulesParams) ([]TransferSchedule, error) {
+	return query(ctx, q, func(tx *memTx) ([]TransferSchedule, error) {
+		due := selectRows(tx.schedules, func(s TransferSchedule) bool {
+			return s.Status == ScheduleStatusActive && notAfter(s.NextRunAt, arg.Now) &&
+				(!s.LockedUntil.Valid || notAfter(s.LockedUntil, arg.Now))
+		}, func(a, b TransferSchedule) int {
+			return compareTime(a.NextRunAt, b.NextRunAt)
+		}, arg.Limit)
+		for i := range due {
+			due[i].LockedUntil = arg.LockedUntil
+			put(tx, tx.schedules, due[i].ID, due[i])
+		}
+		return due, nil
+	})
+}
+
+func (q memQueries) ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	return query(ctx, q, func(tx *memTx) ([]WebhookDelivery, error) {
+		due := selectRows(tx.deliveries, func(d WebhookDelivery) bool {
+			return d.Status == WebhookDeliveryStatusPending && notAfter(d.NextAttemptAt, arg.Now) &&
+				(!d.LockedUntil.Valid || notAfter(d.LockedUntil, arg.Now))
+		}, func(a, b WebhookDelivery) int {
+			return compareTime(a.NextAttemptAt, b.NextAttemptAt)
+		}, arg.Limit)
+		for i := range due {
+			due[i].LockedUntil = arg.LockedUntil
+			put(tx, tx.deliveries, due[i].ID, due[i])
+		}
+		return due, nil
+	})
+}
+
+func (q memQueries) ClaimUndispatchedOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error) {
+	return query(ctx, q, func(tx *memTx) ([]OutboxEvent, error) {
+		events := selectRows(tx.outboxEvents, func(e OutboxEvent) bool {
+			return !e.DispatchedAt.Valid
+		}, func(a, b OutboxEvent) int {
+			return cmp.Compare(a.ID, b.ID)
+		}, limit)
+		return cloneOutboxEvents(events), nil
+	})
+}
+
+func (q memQueries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	return query(ctx, q, func(tx *memTx) (Account, error) {
+		if _, ok := tx.users[arg.OwnerID]; !ok {
+			return Account{}, errForeignKeyViolation("accounts", "accounts_owner_id_fkey")
+		}
+		account := Account{
+			ID:           next(&tx.seq.accounts),
+			OwnerID:      arg.OwnerID,
+			BalanceCents: arg.BalanceCents,
+			Currency:     arg.Currency,
+			Status:       AccountStatusActive,
+			CreatedAt:    tx.now,
+		}
+		put(tx, tx.accounts, account.ID, account)
+		return account, nil
+	})
+}
+
+func (q memQueries) CreateAccountStatusEvent(ctx context.Context, arg CreateAccountStatusEventParams) (AccountStatusEvent, error) {
+	return query(ctx, q, func(tx *memTx) (AccountStatusEvent, error) {
+		if _, ok := tx.accounts[arg.AccountID]; !ok {
+			return AccountStatusEvent{}, errForeignKeyViolation("account_status_events", "account_status_events_account_id_fkey")
+		}
+		event := AccountStatusEvent{
+			ID:         next(&tx.seq.statusEvents),
+			AccountID:  arg.AccountID,
+			FromStatus: arg.FromStatus,
+			ToStatus:   arg.ToStatus,
+			Reason:     arg.Reason,
+			Actor:      arg.Actor,
+			CreatedAt:  tx.now,
+		}
+		put(tx, tx.statusEvents, event.ID, event)
+		return event, nil
+	})
+}
+
+func (q memQueries) CreateExchangeRate(ctx context.Context, arg CreateExchangeRateParams) (ExchangeRate, error) {
+	return query(ctx, q, func(tx *memTx) (ExchangeRate, error) {
+		if !arg.Rate.Valid || arg.Rate.Int == nil || arg.Rate.Int.Sign() <= 0 {
+			return ExchangeRate{}, errCheckViolation("exchange_rates", "exchange_rates_rate_positive")
+		}
+		if arg.BaseCurrency == arg.QuoteCurrency {
+			return ExchangeRate{}, errCheckViolation("exchange_rates", "exchange_rates_distinct_currencies")
+		}
+		rate := ExchangeRate{
+			ID:            next(&tx.seq.exchangeRates),
+			BaseCurrency:  arg.BaseCurrency,
+			QuoteCurrency: arg.QuoteCurrency,
+			Rate:          arg.Rate,
+			EffectiveAt:   arg.EffectiveAt,
+			CreatedAt:     tx.now,
+		}
+		put(tx, tx.exchangeRates, rate.ID, rate)
+		return rate, nil
+	})
+}
+
+func (q memQueries) CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error) {
+	return query(ctx, q, func(tx *memTx) (Hold, error) {
+		if arg.AmountCents <= 0 {
+			return Hold{}, errCheckViolation("holds", "holds_amount_positive")
+		}
+		if arg.Reference.Valid && exists(tx.holds, func(h Hold) bool { return h.AccountID == arg.AccountID && h.Reference == arg.Reference }) {
+			return Hold{}, errUniqueViolation("holds_account_reference_key")
+		}
+		if _, ok := tx.accounts[arg.AccountID]; !ok {
+			return Hold{}, errForeignKeyViolation("holds", "holds_account_id_fkey")
+		}
+		hold := Hold{
+			ID:          newUUID(),
+			AccountID:   arg.AccountID,
+			AmountCents: arg.AmountCents,
+			Status:      HoldStatusActive,
+			Reference:   arg.Reference,
+			ExpiresAt:   arg.ExpiresAt,
+			CreatedAt:   tx.now,
+		}
+		put(tx, tx.holds, hold.ID, hold)
+		return hold, nil
+	})
+}
+
+func (q memQueries) CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (JournalEntry, error) {
+	return query(ctx, q, func(tx *memTx) (JournalEntry, error) {
+		if arg.TransferID.Valid {
+			if _, ok := tx.transfers[arg.TransferID]; !ok {
+				return JournalEntry{}, errForeignKeyViolation("journal_entries", "journal_entries_transfer_id_fkey")
+			}
+		}
+		entry := JournalEntry{
+			ID:         newUUID(),
+			Type:       arg.Type,
+			TransferID: arg.TransferID,
+			CreatedAt:  tx.now,
+		}
+		put(tx, tx.journalEntries, entry.ID, entry)
+		return entry, nil
+	})
+}
+
+func (q memQueries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (OutboxEvent, error) {
+	return query(ctx, q, func(tx *memTx) (OutboxEvent, error) {
+		event := OutboxEvent{
+			ID:         next(&tx.seq.outboxEvents),
+			Type:       arg.Type,
+			AccountIds: slices.Clone(arg.AccountIds),
+			Payload:    slices.Clone(arg.Payload),
+			CreatedAt:  tx.now,
+		}
+		put(tx, tx.outboxEvents, event.ID, event)
+		return cloneOutboxEvent(event), nil
+	})
+}
+
+func (q memQueries) CreateOverdraftAccrual(ctx context.Context, arg CreateOverdraftAccrualParams) (OverdraftAccrual, error) {
+	return query(ctx, q, func(tx *memTx) (OverdraftAccrual, error) {
+		key := newOverdraftAccrualKey(arg.AccountID, arg.Day, arg.Type)
+		if _, ok := tx.accruals[key]; ok {
+			return OverdraftAccrual{}, errUniqueViolation("overdraft_accruals_pkey")
+		}
+		if exists(tx.accruals, func(a OverdraftAccrual) bool { return a.TransactionID == arg.TransactionID }) {
+			return OverdraftAccrual{}, errUniqueViolation("overdraft_accruals_transaction_id_key")
+		}
+		if _, ok := tx.accounts[arg.AccountID]; !ok {
+			return OverdraftAccrual{}, errForeignKeyViolation("overdraft_accruals", "overdraft_accruals_account_id_fkey")
+		}
+		if _, ok := tx.transactions[arg.TransactionID]; !ok {
+			return OverdraftAccrual{}, errForeignKeyViolation("overdraft_accruals", "overdraft_accruals_transaction_id_fkey")
+		}
+		accrual := OverdraftAccrual{
+			AccountID:     arg.AccountID,
+			Day:           arg.Day,
+			Type:          arg.Type,
+			TransactionID: arg.TransactionID,
+			CreatedAt:     tx.now,
+		}
+		put(tx, tx.accruals, key, accrual)
+		return accrual, nil
+	})
+}
+
+func (q memQueries) CreatePosting(ctx context.Context, arg CreatePostingParams) (Posting, error) {
+	return query(ctx, q, func(tx *memTx) (Posting, error) {
+		if arg.AccountID.Valid == arg.SystemAccountID.Valid {
+			return Posting{}, errCheckViolation("postings", "postings_single_account")
+		}
+		if arg.AmountCents == 0 {
+			return Posting{}, errCheckViolation("postings", "postings_amount_nonzero")
+		}
+		if _, ok := tx.journalEntries[arg.EntryID]; !ok {
+			return Posting{}, errForeignKeyViolation("postings", "postings_entry_id_fkey")
+		}
+		if arg.AccountID.Valid {
+			if account, ok := tx.accounts[arg.AccountID.Int64]; !ok || account.Currency != arg.Currency {
+				return Posting{}, errForeignKeyViolation("postings", "postings_account_id_currency_fkey")
+			}
+		}
+		if arg.SystemAccountID.Valid {
+			if account, ok := tx.systemAccounts[arg.SystemAccountID.Int64]; !ok || account.Currency != arg.Currency {
+				return Posting{}, errForeignKeyViolation("postings", "postings_system_account_id_currency_fkey")
+			}
+		}
+		if arg.TransactionID.Valid {
+			if _, ok := tx.transactions[arg.TransactionID]; !ok {
+				return Posting{}, errForeignKeyViolation("postings", "postings_transaction_id_fkey")
+			}
+		}
+		posting := Posting{
+			ID:              next(&tx.seq.postings),
+			EntryID:         arg.EntryID,
+			AccountID:       arg.AccountID,
+			SystemAccountID: arg.SystemAccountID,
+			TransactionID:   arg.TransactionID,
+			Currency:        arg.Currency,
+			AmountCents:     arg.AmountCents,
+			CreatedAt:       tx.now,
+		}
+		put(tx, tx.postings, posting.ID, posting)
+		tx.entries[posting.EntryID] = struct{}{}
+		return posting, nil
+	})
+}
+
+func (q memQueries) CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error) {
+	return query(ctx, q, func(tx *memTx) (Transaction, error) {
+		if arg.Reference.Valid && exists(tx.transactions, func(t Transaction) bool {
+			return t.AccountID == arg.AccountID && t.Reference == arg.Reference
+		}) {
+			return Transaction{}, errUniqueViolation("transactions_account_reference_key")
+		}
+		if _, ok := tx.accounts[arg.AccountID]; !ok {
+			return Transaction{}, errForeignKeyViolation("transactions", "transactions_account_id_fkey")
+		}
+		if arg.RelatedAccountID.Valid {
+			if _, ok := tx.accounts[arg.RelatedAccountID.Int64]; !ok {
+				return Transaction{}, errForeignKeyViolation("transactions", "transactions_related_account_id_fkey")
+			}
+		}
+		transaction := Transaction{
+			ID:                newUUID(),
+			AccountID:         arg.AccountID,
+			Type:              arg.Type,
+			AmountCents:       arg.AmountCents,
+			BalanceAfterCents: arg.BalanceAfterCents,
+			RelatedAccountID:  arg.RelatedAccountID,
+			Reference:         arg.Reference,
+			CreatedAt:         tx.now,
+			Seq:               next(&tx.seq.transactions),
+			Description:       arg.Description,
+		}
+		put(tx, tx.transactions, transaction.ID, transaction)
+		return transaction, nil
+	})
+}
+
+func (q memQueries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
+	return query(ctx, q, func(tx *memTx) (Transfer, error) {
+		if arg.ToAmountCents.Valid != arg.ExchangeRate.Valid {
+			return Transfer{}, errCheckViolation("transfers", "transfers_conversion_complete")
+		}
+		if arg.IdempotencyKey.Valid && exists(tx.transfers, func(t Transfer) bool {
+			return t.FromAccountID == arg.FromAccountID && t.Reference == arg.IdempotencyKey
+		}) {
+			return Transfer{}, errUniqueViolation("transfers_from_account_reference_key")
+		}
+		if _, ok := tx.accounts[arg.FromAccountID]; !ok {
+			return Transfer{}, errForeignKeyViolation("transfers", "transfers_from_account_id_fkey")
+		}
+		if _, ok := tx.accounts[arg.ToAccountID]; !ok {
+			return Transfer{}, errForeignKeyViolation("transfers", "transfers_to_account_id_fkey")
+		}
+		transfer := Transfer{
+			ID:            newUUID(),
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			AmountCents:   arg.AmountCents,
+			Status:        TransferStatusPending,
+			CreatedAt:     tx.now,
+			Reference:     arg.IdempotencyKey,
+			ToAmountCents: arg.ToAmountCents,
+			ExchangeRate:  arg.ExchangeRate,
+		}
+		put(tx, tx.transfers, transfer.ID, transfer)
+		return transfer, nil
+	})
+}
+
+func (q memQueries) CreateTransferReversal(ctx context.Context, arg CreateTransferReversalParams) (TransferReversal, error) {
+	return query(ctx, q, func(tx *memTx) (TransferReversal, error) {
+		if arg.AmountCents <= 0 {
+			return TransferReversal{}, errCheckViolation("transfer_reversals", "transfer_reversals_amount_positive")
+		}
+		if exists(tx.reversals, func(r TransferReversal) bool { return r.TransferID == arg.TransferID }) {
+			return TransferReversal{}, errUniqueViolation("transfer_reversals_transfer_id_key")
+		}
+		if _, ok := tx.transfers[arg.TransferID]; !ok {
+			return TransferReversal{}, errForeignKeyViolation("transfer_reversals", "transfer_reversals_transfer_id_fkey")
+		}
+		if _, ok := tx.transactions[arg.DebitTransactionID]; !ok {
+			return TransferReversal{}, errForeignKeyViolation("transfer_reversals", "transfer_reversals_debit_transaction_id_fkey")
+		}
+		if _, ok := tx.transactions[arg.CreditTransactionID]; !ok {
+			return TransferReversal{}, errForeignKeyViolation("transfer_reversals", "transfer_reversals_credit_transaction_id_fkey")
+		}
+		reversal := TransferReversal{
+			ID:                  newUUID(),
+			TransferID:          arg.TransferID,
+			AmountCents:         arg.AmountCents,
+			Reason:              arg.Reason,
+			DebitTransactionID:  arg.DebitTransactionID,
+			CreditTransactionID: arg.CreditTransactionID,
+			CreatedAt:           tx.now,
+		}
+		put(tx, tx.reversals, reversal.ID, reversal)
+		return reversal, nil
+	})
+}
+
+func (q memQueries) CreateTransferSchedule(ctx context.Context, arg CreateTransferScheduleParams) (TransferSchedule, error) {
+	return query(ctx, q, func(tx *memTx) (TransferSchedule, error) {
+		schedule := TransferSchedule{
+			ID:                      newUUID(),
+			FromAccountID:           arg.FromAccountID,
+			ToAccountID:             arg.ToAccountID,
+			AmountCents:             arg.AmountCents,
+			Frequency:               arg.Frequency,
+			DayOfMonth:              arg.DayOfMonth,
+			Status:                  ScheduleStatusActive,
+			InsufficientFundsPolicy: arg.InsufficientFundsPolicy,
+			MaxAttempts:             arg.MaxAttempts,
+			NextOccurrenceAt:        arg.NextOccurrenceAt,
+			NextRunAt:               arg.NextOccurrenceAt,
+			EndsAt:                  arg.EndsAt,
+			CreatedAt:               tx.now,
+			UpdatedAt:               tx.now,
+		}
+		if err := checkTransferSchedule(schedule); err != nil {
+			return TransferSchedule{}, err
+		}
+		if _, ok := tx.accounts[arg.FromAccountID]; !ok {
+			return TransferSchedule{}, errForeignKeyViolation("transfer_schedules", "transfer_schedules_from_account_id_fkey")
+		}
+		if _, ok := tx.accounts[arg.ToAccountID]; !ok {
+			return TransferSchedule{}, errForeignKeyViolation("transfer_schedules", "transfer_schedules_to_account_id_fkey")
+		}
+		put(tx, tx.schedules, schedule.ID, schedule)
+		return schedule, nil
+	})
+}
+
+func (q memQueries) CreateTransferScheduleRun(ctx context.Context, arg CreateTransferScheduleRunParams) (TransferScheduleRun, error) {
+	return query(ctx, q, func(tx *memTx) (TransferScheduleRun, error) {
+		if exists(tx.scheduleRuns, func(r TransferScheduleRun) bool {
+			return r.ScheduleID == arg.ScheduleID && sameTime(r.OccurrenceAt, arg.OccurrenceAt) && r.Attempt == arg.Attempt
+		}) {
+			return TransferScheduleRun{}, errUniqueViolation("transfer_schedule_runs_attempt_key")
+		}
+		if _, ok := tx.schedules[arg.ScheduleID]; !ok {
+			return TransferScheduleRun{}, errForeignKeyViolation("transfer_schedule_runs", "transfer_schedule_runs_schedule_id_fkey")
+		}
+		if arg.TransferID.Valid {
+			if _, ok := tx.transfers[arg.TransferID]; !ok {
+				return TransferScheduleRun{}, errForeignKeyViolation("transfer_schedule_runs", "transfer_schedule_runs_transfer_id_fkey")
+			}
+		}
+		run := TransferScheduleRun{
+			ID:            next(&tx.seq.scheduleRuns),
+			ScheduleID:    arg.ScheduleID,
+			OccurrenceAt:  arg.OccurrenceAt,
+			Attempt:       arg.Attempt,
+			Status:        arg.Status,
+			TransferID:    arg.TransferID,
+			FailureReason: arg.FailureReason,
+			CreatedAt:     tx.now,
+		}
+		put(tx, tx.scheduleRuns, run.ID, run)
+		return run, nil
+	})
+}
+
+// passwordHashFormat is the users_password_hash_format check.
+var passwordHashFormat = regexp.MustCompile(`^\$(2[aby]|argon2id)\$`)
+
+func (q memQueries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	return query(ctx, q, func(tx *memTx) (User, error) {
+		if !passwordHashFormat.MatchString(arg.PasswordHash) {
+			return User{}, errCheckViolation("users", "users_password_hash_format")
+		}
+		if exists(tx.users, func(u User) bool { return u.Email == arg.Email }) {
+			return User{}, errUniqueViolation("users_email_key")
+		}
+		user := User{
+			ID:           newUUID(),
+			FirstName:    arg.FirstName,
+			LastName:     arg.LastName,
+			Email:        arg.Email,
+			PasswordHash: arg.PasswordHash,
+			CreatedAt:    tx.now,
+		}
+		put(tx, tx.users, user.ID, user)
+		return user, nil
+	})
+}
+
+func (q memQueries) CreateWebhookDeliveries(ctx context.Context, eventID int64) (int64, error) {
+	return query(ctx, q, func(tx *memTx) (int64, error) {
+		event, ok := tx.outboxEvents[eventID]
+		if !ok {
+			return 0, nil
+		}
+		owners := make(map[pgtype.UUID]bool)
+		for _, accountID := range event.AccountIds {
+			if account, ok := tx.accounts[accountID]; ok {
+				owners[account.OwnerID] = true
+			}
+		}
+		endpoints := selectRows(tx.endpoints, func(w WebhookEndpoint) bool {
+			return owners[w.OwnerID] && w.Active &&
+				(len(w.EventTypes) == 0 || slices.Contains(w.EventTypes, string(event.Type)))
+		}, func(a, b WebhookEndpoint) int {
+			return compareUUID(a.ID, b.ID)
+		}, int32(len(tx.endpoints)))
+
+		var inserted int64
+		for _, endpoint := range endpoints {
+			if exists(tx.deliveries, func(d WebhookDelivery) bool {
+				return d.EventID == event.ID && d.EndpointID == endpoint.ID
+			}) {
+				continue
+			}
+			delivery := WebhookDelivery{
+				ID:            next(&tx.seq.deliveries),
+				EventID:       event.ID,
+				EndpointID:    endpoint.ID,
+				Status:        WebhookDeliveryStatusPending,
+				NextAttemptAt: tx.now,
+				CreatedAt:     tx.now,
+				UpdatedAt:     tx.now,
+			}
+			put(tx, tx.deliveries, delivery.ID, delivery)
+			inserted++
+		}
+		return inserted, nil
+	})
+}
+
+func (q memQueries) CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error) {
+	return query(ctx, q, func(tx *memTx) (WebhookEndpoint, error) {
+		if _, ok := tx.users[arg.OwnerID]; !ok {
+			return WebhookEndpoint{}, errForeignKeyViolation("webhook_endpoints", "webhook_endpoints_owner_id_fkey")
+		}
+		endpoint := WebhookEndpoint{
+			ID:         newUUID(),
+			OwnerID:    arg.OwnerID,
+			Url:        arg.Url,
+			Secret:     arg.Secret,
+			EventTypes: slices.Clone(arg.EventTypes),
+			Active:     true,
+			CreatedAt:  tx.now,
+		}
+		if endpoint.EventTypes == nil {
+			endpoint.EventTypes = []string{}
+		}
+		put(tx, tx.endpoints, endpoint.ID, endpoint)
+		return cloneWebhookEndpoint(endpoint), nil
+	})
+}
+
+func (q memQueries) DeactivateWebhookEndpoint(ctx context.Context, id pgtype.UUID) (WebhookEndpoint, error) {
+	return query(ctx, q, func(tx *memTx) (WebhookEndpoint, error) {
+		endpoint, err := get(tx.endpoints, id)
+		if err != nil {
+			return WebhookEndpoint{}, err
+		}
+		endpoint.Active = false
+		put(tx, tx.endpoints, endpoint.ID, endpoint)
+		return cloneWebhookEndpoint(endpoint), nil
+	})
+}
+
+func (q memQueries) DeleteAccount(ctx context.Context, id int64) error {
+	_, err := query(ctx, q, func(tx *memTx) (struct{}, error) {
+		if _, ok := tx.accounts[id]; !ok {
+			return struct{}{}, nil
+		}
+		for _, ref := range []struct {
+			constraint, table string
+			referenced        bool
+		}{
+			{"transactions_account_id_fkey", "transactions", exists(tx.transactions, func(t Transaction) bool { return t.AccountID == id })},
+			{"transactions_related_account_id_fkey", "transactions", exists(tx.transactions, func(t Transaction) bool {
+				return t.RelatedAccountID.Valid && t.RelatedAccountID.Int64 == id
+			})},
+			{"transfers_from_account_id_fkey", "transfers", exists(tx.transfers, func(t Transfer) bool { return t.FromAccountID == id })},
+			{"transfers_to_account_id_fkey", "transfers", exists(tx.transfers, func(t Transfer) bool { return t.ToAccountID == id })},
+			{"account_status_events_account_id_fkey", "account_status_events", exists(tx.statusEvents, func(e AccountStatusEvent) bool { return e.AccountID == id })},
+			{"postings_account_id_currency_fkey", "postings", exists(tx.postings, func(p Posting) bool {
+				return p.AccountID.Valid && p.AccountID.Int64 == id
+			})},
+			{"reconciliation_incidents_account_id_fkey", "reconciliation_incidents", exists(tx.incidents, func(i ReconciliationIncident) bool { return i.AccountID == id })},
+			{"holds_account_id_fkey", "holds", exists(tx.holds, func(h Hold) bool { return h.AccountID == id })},
+			{"transfer_schedules_from_account_id_fkey", "transfer_schedules", exists(tx.schedules, func(s TransferSchedule) bool { return s.FromAccountID == id })},
+			{"transfer_schedules_to_account_id_fkey", "transfer_schedules", exists(tx.schedules, func(s TransferSchedule) bool { return s.ToAccountID == id })},
+		} {
+			if ref.referenced {
+				return struct{}{}, errStillReferenced("accounts", ref.constraint, ref.table)
+			}
+		}
+		del(tx, tx.accounts, id)
+		return struct{}{}, nil
+	})
+	return err
+}
+
+func (q memQueries) GetAccount(ctx context.Context, id int64) (Account, error) {
+	return query(ctx, q, func(tx *memTx) (Account, error) {
+		account, err := get(tx.accounts, id)
+		return account, dberr.TranslateQuery("GetAccount", err)
+	})
+}
+
+// GetAccountForUpdate needs no lock of its own: the transaction it runs in already
+// holds the whole store.
+func (q memQueries) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
+	return q.GetAccount(ctx, id)
+}
+
+func (q memQueries) GetAccountLedgerBalance(ctx context.Context, accountID int64) (int64, error) {
+	return query(ctx, q, func(tx *memTx) (int64, error) {
+		return tx.ledgerTotal(accountID), nil
+	})
+}
+
+func (q memQueries) GetHold(ctx context.Context, id pgtype.UUID) (Hold, error) {
+	return query(ctx, q, func(tx *memTx) (Hold, error) {
+		return get(tx.holds, id)
+	})
+}
+
+func (q memQueries) GetHoldByReference(ctx context.Context, arg GetHoldByReferenceParams) (Hold, error) {
+	return query(ctx, q, func(tx *memTx) (Hold, error) {
+		return find(tx.holds, func(h Hold) bool {
+			return arg.Reference.Valid && h.AccountID == arg.AccountID && h.Reference == arg.Reference
+		})
+	})
+}
+
+func (q memQueries) GetHoldForUpdate(ctx context.Context, id pgtype.UUID) (Hold, error) {
+	return q.GetHold(ctx, id)
+}
+
+func (q memQueries) GetJournalEntry(ctx context.Context, id pgtype.UUID) (JournalEntry, error) {
+	return query(ctx, q, func(tx *memTx) (JournalEntry, error) {
+		return get(tx.journalEntries, id)
+	})
+}
+
+func (q memQueries) GetLastTransactionBefore(ctx context.Context, arg GetLastTransactionBeforeParams) (Transaction, error) {
+	return query(ctx, q, func(tx *memTx) (Transaction, error) {
+		last := selectRows(tx.transactions, func(t Transaction) bool {
+			return t.AccountID == arg.AccountID && arg.Before.Valid && compareTime(t.CreatedAt, arg.Before) < 0
+		}, func(a, b Transaction) int {
+			return cmp.Compare(b.Seq, a.Seq)
+		}, 1)
+		if len(last) == 0 {
+			return Transaction{}, errNoRows()
+		}
+		return last[0], nil
+	})
+}
+
+func (q memQueries) GetLatestExchangeRate(ctx context.Context, arg GetLatestExchangeRateParams) (ExchangeRate, error) {
+	return query(ctx, q, func(tx *memTx) (ExchangeRate, error) {
+		latest := selectRows(tx.exchangeRates, func(r ExchangeRate) bool {
+			return r.BaseCurrency == arg.BaseCurrency && r.QuoteCurrency == arg.QuoteCurrency && notAfter(r.EffectiveAt, tx.now)
+		}, func(a, b ExchangeRate) int {
+			return -compareTimeKey(a.EffectiveAt, a.ID, b.EffectiveAt, b.ID, cmp.Compare[int64])
+		}, 1)
+		if len(latest) == 0 {
+			return ExchangeRate{}, errNoRows()
+		}
+		return latest[0], nil
+	})
+}
+
+func (q memQueries) GetOutboxEvent(ctx context.Context, id int64) (OutboxEvent, error) {
+	return query(ctx, q, func(tx *memTx) (OutboxEvent, error) {
+		event, err := get(tx.outboxEvents, id)
+		return cloneOutboxEvent(event), err
+	})
+}
+
+func (q memQueries) GetOverdraftAccrual(ctx context.Context, arg GetOverdraftAccrualParams) (OverdraftAccrual, error) {
+	return query(ctx, q, func(tx *memTx) (OverdraftAccrual, error) {
+		return get(tx.accruals, newOverdraftAccrualKey(arg.AccountID, arg.Day, arg.Type))
+	})
+}
+
+func (q memQueries) GetPostingByTransaction(ctx context.Context, transactionID pgtype.UUID) (Posting, error) {
+	return query(ctx, q, func(tx *memTx) (Posting, error) {
+		return find(tx.postings, func(p Posting) bool { return transactionID.Valid && p.TransactionID == transactionID })
+	})
+}
+
+func (q memQueries) GetSystemAccount(ctx context.Context, arg GetSystemAccountParams) (SystemAccount, error) {
+	return query(ctx, q, func(tx *memTx) (SystemAccount, error) {
+		return find(tx.systemAccounts, func(a SystemAccount) bool { return a.Kind == arg.Kind && a.Currency == arg.Currency })
+	})
+}
+
+func (q memQueries) GetTransaction(ctx context.Context, id pgtype.UUID) (Transaction, error) {
+	return query(ctx, q, func(tx *memTx) (Transaction, error) {
+		return get(tx.transactions, id)
+	})
+}
+
+func (q memQueries) GetTransactionByReference(ctx context.Context, arg GetTransactionByReferenceParams) (Transaction, error) {
+	return query(ctx, q, func(tx *memTx) (Transaction, error) {
+		return find(tx.transactions, func(t Transaction) bool {
+			return arg.Reference.Valid && t.AccountID == arg.AccountID && t.Reference == arg.Reference
+		})
+	})
+}
+
+func (q memQueries) GetTransfer(ctx context.Context, id pgtype.UUID) (Transfer, error) {
+	return query(ctx, q, func(tx *memTx) (Transfer, error) {
+		transfer, err := get(tx.transfers, id)
+		return transfer, dberr.TranslateQuery("GetTransfer", err)
+	})
+}
+
+func (q memQueries) GetTransferByReference(ctx context.Context, arg GetTransferByReferenceParams) (Transfer, error) {
+	return query(ctx, q, func(tx *memTx) (Transfer, error) {
+		return find(tx.transfers, func(t Transfer) bool {
+			return arg.Reference.Valid && t.FromAccountID == arg.FromAccountID && t.Reference == arg.Reference
+		})
+	})
+}
+
+func (q memQueries) GetTransferForUpdate(ctx context.Context, id pgtype.UUID) (Transfer, error) {
+	return q.GetTransfer(ctx, id)
+}
+
+func (q memQueries) GetTransferReversalByTransfer(ctx context.Context, transferID pgtype.UUID) (TransferReversal, error) {
+	return query(ctx, q, func(tx *memTx) (TransferReversal, error) {
+		return find(tx.reversals, func(r TransferReversal) bool { return r.TransferID == transferID })
+	})
+}
+
+func (q memQueries) GetTransferSchedule(ctx context.Context, id pgtype.UUID) (TransferSchedule, error) {
+	return query(ctx, q, func(tx *memTx) (TransferSchedule, error) {
+		return get(tx.schedules, id)
+	})
+}
+
+func (q memQueries) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	return query(ctx, q, func(tx *memTx) (User, error) {
+		return get(tx.users, id)
+	})
+}
+
+func (q memQueries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	return query(ctx, q, func(tx *memTx) (User, error) {
+		return find(tx.users, func(u User) bool { return u.Email == email })
+	})
+}
+
+func (q memQueries) GetUserForUpdate(ctx context.Context, id pgtype.UUID) (User, error) {
+	return q.GetUser(ctx, id)
+}
+
+func (q memQueries) GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error) {
+	return query(ctx, q, func(tx *memTx) (WebhookDelivery, error) {
+		return get(tx.deliveries, id)
+	})
+}
+
+func (q memQueries) GetWebhookEndpoint(ctx context.Context, id pgtype.UUID) (WebhookEndpoint, error) {
+	return query(ctx, q, func(tx *memTx) (WebhookEndpoint, error) {
+		endpoint, err := get(tx.endpoints, id)
+		return cloneWebhookEndpoint(endpoint), err
+	})
+}
+
+func (q memQueries) ListAccountBalanceTotals(ctx context.Context, arg ListAccountBalanceTotalsParams) ([]ListAccountBalanceTotalsRow, error) {
+	return query(ctx, q, func(tx *memTx) ([]ListAccountBalanceTotalsRow, error) {
+		accounts := selectRows(tx.accounts, func(a Account) bool {
+			return a.ID > arg.AfterID && (len(arg.AccountIds) == 0 || slices.Contains(arg.AccountIds, a.ID))
+		}, compareAccountIDs, arg.Limit)
+		var items []ListAccountBalanceTotalsRow
+		for _, account := range accounts {
+			row := ListAccountBalanceTotalsRow{
+				ID:               account.ID,
+				BalanceCents:     account.BalanceCents,
+				LedgerTotalCents: tx.ledgerTotal(account.ID),
+			}
+			for _, t := range tx.transactions {
+				if t.AccountID == account.ID {
+					row.TransactionsTotalCents += t.AmountCents
+				}
+			}
+			items = append(items, row)
+		}
+		return items, nil
+	})
+}
+
+func (q memQueries) ListAccountStatusEvents(ctx context.Context, arg ListAccountStatusEventsParams) ([]AccountStatusEvent, error) {
+	return query(ctx, q, func(tx *memTx) ([]AccountStatusEvent, error) {
+		return selectRows(tx.statusEvents, func(e AccountStatusEvent) bool {
+			return e.AccountID == arg.AccountID && compareStatusEvent(e, arg.AfterCreatedAt, arg.AfterID) < 0
+		}, newestStatusEventFirst, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListAccountStatusEventsBefore(ctx context.Context, arg ListAccountStatusEventsBeforeParams) ([]AccountStatusEvent, error) {
+	return query(ctx, q, func(tx *memTx) ([]AccountStatusEvent, error) {
+		return selectRows(tx.statusEvents, func(e AccountStatusEvent) bool {
+			return e.AccountID == arg.AccountID && compareStatusEvent(e, arg.BeforeCreatedAt, arg.BeforeID) > 0
+		}, reverse(newestStatusEventFirst), arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
+	return query(ctx, q, func(tx *memTx) ([]Account, error) {
+		return selectRows(tx.accounts, func(a Account) bool {
+			return a.ID > arg.AfterID
+		}, compareAccountIDs, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListAccountsBefore(ctx context.Context, arg ListAccountsBeforeParams) ([]Account, error) {
+	return query(ctx, q, func(tx *memTx) ([]Account, error) {
+		return selectRows(tx.accounts, func(a Account) bool {
+			return a.ID < arg.BeforeID
+		}, reverse(compareAccountIDs), arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListAccountsByOwner(ctx context.Context, arg ListAccountsByOwnerParams) ([]Account, error) {
+	return query(ctx, q, func(tx *memTx) ([]Account, error) {
+		return selectRows(tx.accounts, func(a Account) bool {
+			return a.OwnerID == arg.OwnerID && a.ID > arg.AfterID
+		}, compareAccountIDs, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListAccountsByOwnerBefore(ctx context.Context, arg ListAccountsByOwnerBeforeParams) ([]Account, error) {
+	return query(ctx, q, func(tx *memTx) ([]Account, error) {
+		return selectRows(tx.accounts, func(a Account) bool {
+			return a.OwnerID == arg.OwnerID && a.ID < arg.BeforeID
+		}, reverse(compareAccountIDs), arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListBalanceChainBreaks(ctx context.Context, accountIds []int64) ([]ListBalanceChainBreaksRow, error) {
+	return query(ctx, q, func(tx *memTx) ([]ListBalanceChainBreaksRow, error) {
+		chain := selectRows(tx.transactions, func(t Transaction) bool {
+			return slices.Contains(accountIds, t.AccountID)
+		}, func(a, b Transaction) int {
+			return cmp.Or(cmp.Compare(a.AccountID, b.AccountID), cmp.Compare(a.Seq, b.Seq))
+		}, int32(len(tx.transactions)))
+
+		var items []ListBalanceChainBreaksRow
+		for i, t := range chain {
+			var previous int64
+			if i > 0 && chain[i-1].AccountID == t.AccountID {
+				previous = chain[i-1].BalanceAfterCents
+			}
+			if t.BalanceAfterCents != previous+t.AmountCents {
+				items = append(items, ListBalanceChainBreaksRow{
+					AccountID:            t.AccountID,
+					ID:                   t.ID,
+					Seq:                  t.Seq,
+					AmountCents:          t.AmountCents,
+					BalanceAfterCents:    t.BalanceAfterCents,
+					PreviousBalanceCents: previous,
+				})
+			}
+		}
+		return items, nil
+	})
+}
+
+func (q memQueries) ListExpiredHolds(ctx context.Context, arg ListExpiredHoldsParams) ([]pgtype.UUID, error) {
+	return query(ctx, q, func(tx *memTx) ([]pgtype.UUID, error) {
+		holds := selectRows(tx.holds, func(h Hold) bool {
+			return h.Status == HoldStatusActive && notAfter(h.ExpiresAt, arg.Now)
+		}, func(a, b Hold) int {
+			return compareTime(a.ExpiresAt, b.ExpiresAt)
+		}, arg.Limit)
+		var items []pgtype.UUID
+		for _, hold := range holds {
+			items = append(items, hold.ID)
+		}
+		return items, nil
+	})
+}
+
+func (q memQueries) ListHoldsByAccount(ctx context.Context, arg ListHoldsByAccountParams) ([]Hold, error) {
+	return query(ctx, q, func(tx *memTx) ([]Hold, error) {
+		return selectRows(tx.holds, func(h Hold) bool {
+			return h.AccountID == arg.AccountID && compareTimeKey(h.CreatedAt, h.ID, arg.AfterCreatedAt, arg.AfterID, compareUUID) < 0
+		}, newestHoldFirst, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListHoldsByAccountBefore(ctx context.Context, arg ListHoldsByAccountBeforeParams) ([]Hold, error) {
+	return query(ctx, q, func(tx *memTx) ([]Hold, error) {
+		return selectRows(tx.holds, func(h Hold) bool {
+			return h.AccountID == arg.AccountID && compareTimeKey(h.CreatedAt, h.ID, arg.BeforeCreatedAt, arg.BeforeID, compareUUID) > 0
+		}, reverse(newestHoldFirst), arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListJournalEntriesByTransfer(ctx context.Context, transferID pgtype.UUID) ([]JournalEntry, error) {
+	return query(ctx, q, func(tx *memTx) ([]JournalEntry, error) {
+		return selectRows(tx.journalEntries, func(e JournalEntry) bool {
+			return transferID.Valid && e.TransferID == transferID
+		}, func(a, b JournalEntry) int {
+			return compareTimeKey(a.CreatedAt, a.ID, b.CreatedAt, b.ID, compareUUID)
+		}, int32(len(tx.journalEntries))), nil
+	})
+}
+
+func (q memQueries) ListOpenReconciliationIncidents(ctx context.Context, accountID int64) ([]ReconciliationIncident, error) {
+	return query(ctx, q, func(tx *memTx) ([]ReconciliationIncident, error) {
+		return selectRows(tx.incidents, func(i ReconciliationIncident) bool {
+			return i.AccountID == accountID && !i.ResolvedAt.Valid
+		}, func(a, b ReconciliationIncident) int {
+			return cmp.Compare(a.ID, b.ID)
+		}, int32(len(tx.incidents))), nil
+	})
+}
+
+func (q memQueries) ListOutboxEventsByAccount(ctx context.Context, arg ListOutboxEventsByAccountParams) ([]OutboxEvent, error) {
+	return query(ctx, q, func(tx *memTx) ([]OutboxEvent, error) {
+		events := selectRows(tx.outboxEvents, func(e OutboxEvent) bool {
+			return slices.Contains(e.AccountIds, arg.AccountID)
+		}, func(a, b OutboxEvent) int {
+			return cmp.Compare(a.ID, b.ID)
+		}, arg.Limit)
+		return cloneOutboxEvents(events), nil
+	})
+}
+
+func (q memQueries) ListOverdrawnAccounts(ctx context.Context, arg ListOverdrawnAccountsParams) ([]Account, error) {
+	return query(ctx, q, func(tx *memTx) ([]Account, error) {
+		return selectRows(tx.accounts, func(a Account) bool {
+			return a.BalanceCents < 0 && a.ID > arg.AfterID
+		}, compareAccountIDs, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListPostingsByEntry(ctx context.Context, entryID pgtype.UUID) ([]Posting, error) {
+	return query(ctx, q, func(tx *memTx) ([]Posting, error) {
+		return selectRows(tx.postings, func(p Posting) bool {
+			return p.EntryID == entryID
+		}, func(a, b Posting) int {
+			return cmp.Compare(a.ID, b.ID)
+		}, int32(len(tx.postings))), nil
+	})
+}
+
+func (q memQueries) ListTransactions(ctx context.Context, arg ListTransactionsParams) ([]Transaction, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transaction, error) {
+		return selectRows(tx.transactions, func(t Transaction) bool {
+			return t.AccountID == arg.AccountID && compareTimeKey(t.CreatedAt, t.ID, arg.AfterCreatedAt, arg.AfterID, compareUUID) < 0
+		}, newestTransactionFirst, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListTransactionsBefore(ctx context.Context, arg ListTransactionsBeforeParams) ([]Transaction, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transaction, error) {
+		return selectRows(tx.transactions, func(t Transaction) bool {
+			return t.AccountID == arg.AccountID && compareTimeKey(t.CreatedAt, t.ID, arg.BeforeCreatedAt, arg.BeforeID, compareUUID) > 0
+		}, reverse(newestTransactionFirst), arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListTransactionsInPeriod(ctx context.Context, arg ListTransactionsInPeriodParams) ([]Transaction, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transaction, error) {
+		return selectRows(tx.transactions, func(t Transaction) bool {
+			return t.AccountID == arg.AccountID && inPeriod(t.CreatedAt, arg.FromTime, arg.ToTime) && t.Seq > arg.AfterSeq
+		}, func(a, b Transaction) int {
+			return cmp.Compare(a.Seq, b.Seq)
+		}, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListTransferScheduleRuns(ctx context.Context, arg ListTransferScheduleRunsParams) ([]TransferScheduleRun, error) {
+	return query(ctx, q, func(tx *memTx) ([]TransferScheduleRun, error) {
+		return selectRows(tx.scheduleRuns, func(r TransferScheduleRun) bool {
+			return r.ScheduleID == arg.ScheduleID && r.ID < arg.AfterID
+		}, func(a, b TransferScheduleRun) int {
+			return cmp.Compare(b.ID, a.ID)
+		}, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListTransferScheduleRunsBefore(ctx context.Context, arg ListTransferScheduleRunsBeforeParams) ([]TransferScheduleRun, error) {
+	return query(ctx, q, func(tx *memTx) ([]TransferScheduleRun, error) {
+		return selectRows(tx.scheduleRuns, func(r TransferScheduleRun) bool {
+			return r.ScheduleID == arg.ScheduleID && r.ID > arg.BeforeID
+		}, func(a, b TransferScheduleRun) int {
+			return cmp.Compare(a.ID, b.ID)
+		}, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListTransferSchedulesByAccount(ctx context.Context, arg ListTransferSchedulesByAccountParams) ([]TransferSchedule, error) {
+	return query(ctx, q, func(tx *memTx) ([]TransferSchedule, error) {
+		return selectRows(tx.schedules, func(s TransferSchedule) bool {
+			return s.FromAccountID == arg.FromAccountID && compareTimeKey(s.CreatedAt, s.ID, arg.AfterCreatedAt, arg.AfterID, compareUUID) < 0
+		}, newestScheduleFirst, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListTransferSchedulesByAccountBefore(ctx context.Context, arg ListTransferSchedulesByAccountBeforeParams) ([]TransferSchedule, error) {
+	return query(ctx, q, func(tx *memTx) ([]TransferSchedule, error) {
+		return selectRows(tx.schedules, func(s TransferSchedule) bool {
+			return s.FromAccountID == arg.FromAccountID && compareTimeKey(s.CreatedAt, s.ID, arg.BeforeCreatedAt, arg.BeforeID, compareUUID) > 0
+		}, reverse(newestScheduleFirst), arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transfer, error) {
+		return selectRows(tx.transfers, func(t Transfer) bool {
+			return compareTimeKey(t.CreatedAt, t.ID, arg.AfterCreatedAt, arg.AfterID, compareUUID) < 0
+		}, newestTransferFirst, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListTransfersBefore(ctx context.Context, arg ListTransfersBeforeParams) ([]Transfer, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transfer, error) {
+		return selectRows(tx.transfers, func(t Transfer) bool {
+			return compareTimeKey(t.CreatedAt, t.ID, arg.BeforeCreatedAt, arg.BeforeID, compareUUID) > 0
+		}, reverse(newestTransferFirst), arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListTransfersByAccount(ctx context.Context, arg ListTransfersByAccountParams) ([]Transfer, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transfer, error) {
+		return selectRows(tx.transfers, func(t Transfer) bool {
+			return (t.FromAccountID == arg.AccountID || t.ToAccountID == arg.AccountID) &&
+				compareTimeKey(t.CreatedAt, t.ID, arg.AfterCreatedAt, arg.AfterID, compareUUID) < 0
+		}, newestTransferFirst, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListTransfersByAccountBefore(ctx context.Context, arg ListTransfersByAccountBeforeParams) ([]Transfer, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transfer, error) {
+		return selectRows(tx.transfers, func(t Transfer) bool {
+			return (t.FromAccountID == arg.AccountID || t.ToAccountID == arg.AccountID) &&
+				compareTimeKey(t.CreatedAt, t.ID, arg.BeforeCreatedAt, arg.BeforeID, compareUUID) > 0
+		}, reverse(newestTransferFirst), arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error) {
+	return query(ctx, q, func(tx *memTx) ([]ListUsersRow, error) {
+		users := selectRows(tx.users, func(u User) bool {
+			return compareUUID(u.ID, arg.AfterID) > 0
+		}, compareUserIDs, arg.Limit)
+		var items []ListUsersRow
+		for _, u := range users {
+			items = append(items, ListUsersRow{ID: u.ID, FirstName: u.FirstName, LastName: u.LastName, Email: u.Email})
+		}
+		return items, nil
+	})
+}
+
+func (q memQueries) ListUsersBefore(ctx context.Context, arg ListUsersBeforeParams) ([]ListUsersBeforeRow, error) {
+	return query(ctx, q, func(tx *memTx) ([]ListUsersBeforeRow, error) {
+		users := selectRows(tx.users, func(u User) bool {
+			return compareUUID(u.ID, arg.BeforeID) < 0
+		}, reverse(compareUserIDs), arg.Limit)
+		var items []ListUsersBeforeRow
+		for _, u := range users {
+			items = append(items, ListUsersBeforeRow{ID: u.ID, FirstName: u.FirstName, LastName: u.LastName, Email: u.Email})
+		}
+		return items, nil
+	})
+}
+
+func (q memQueries) ListWebhookDeliveriesByEndpoint(ctx context.Context, arg ListWebhookDeliveriesByEndpointParams) ([]WebhookDelivery, error) {
+	return query(ctx, q, func(tx *memTx) ([]WebhookDelivery, error) {
+		return selectRows(tx.deliveries, func(d WebhookDelivery) bool {
+			return d.EndpointID == arg.EndpointID && (!arg.Status.Valid || d.Status == arg.Status.WebhookDeliveryStatus) && d.ID < arg.AfterID
+		}, func(a, b WebhookDelivery) int {
+			return cmp.Compare(b.ID, a.ID)
+		}, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListWebhookDeliveriesByEndpointBefore(ctx context.Context, arg ListWebhookDeliveriesByEndpointBeforeParams) ([]WebhookDelivery, error) {
+	return query(ctx, q, func(tx *memTx) ([]WebhookDelivery, error) {
+		return selectRows(tx.deliveries, func(d WebhookDelivery) bool {
+			return d.EndpointID == arg.EndpointID && (!arg.Status.Valid || d.Status == arg.Status.WebhookDeliveryStatus) && d.ID > arg.BeforeID
+		}, func(a, b WebhookDelivery) int {
+			return cmp.Compare(a.ID, b.ID)
+		}, arg.Limit), nil
+	})
+}
+
+func (q memQueries) ListWebhookEndpointsByOwner(ctx context.Context, arg ListWebhookEndpointsByOwnerParams) ([]WebhookEndpoint, error) {
+	return query(ctx, q, func(tx *memTx) ([]WebhookEndpoint, error) {
+		endpoints := selectRows(tx.endpoints, func(w WebhookEndpoint) bool {
+			return w.OwnerID == arg.OwnerID && compareTimeKey(w.CreatedAt, w.ID, arg.AfterCreatedAt, arg.AfterID, compareUUID) > 0
+		}, oldestEndpointFirst, arg.Limit)
+		return cloneWebhookEndpoints(endpoints), nil
+	})
+}
+
+func (q memQueries) ListWebhookEndpointsByOwnerBefore(ctx context.Context, arg ListWebhookEndpointsByOwnerBeforeParams) ([]WebhookEndpoint, error) {
+	return query(ctx, q, func(tx *memTx) ([]WebhookEndpoint, error) {
+		endpoints := selectRows(tx.endpoints, func(w WebhookEndpoint) bool {
+			return w.OwnerID == arg.OwnerID && compareTimeKey(w.CreatedAt, w.ID, arg.BeforeCreatedAt, arg.BeforeID, compareUUID) < 0
+		}, reverse(oldestEndpointFirst), arg.Limit)
+		return cloneWebhookEndpoints(endpoints), nil
+	})
+}
+
+func (q memQueries) MarkOutboxEventDispatched(ctx context.Context, id int64) error {
+	_, err := query(ctx, q, func(tx *memTx) (struct{}, error) {
+		if event, ok := tx.outboxEvents[id]; ok {
+			event.DispatchedAt = tx.now
+			put(tx, tx.outboxEvents, event.ID, event)
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+func (q memQueries) OpenReconciliationIncident(ctx context.Context, arg OpenReconciliationIncidentParams) (ReconciliationIncident, error) {
+	return query(ctx, q, func(tx *memTx) (ReconciliationIncident, error) {
+		// ON CONFLICT DO NOTHING against the open incidents' partial unique index
+		if exists(tx.incidents, func(i ReconciliationIncident) bool {
+			return i.AccountID == arg.AccountID && i.Kind == arg.Kind && !i.ResolvedAt.Valid
+		}) {
+			return ReconciliationIncident{}, errNoRows()
+		}
+		if _, ok := tx.accounts[arg.AccountID]; !ok {
+			return ReconciliationIncident{}, errForeignKeyViolation("reconciliation_incidents", "reconciliation_incidents_account_id_fkey")
+		}
+		if arg.TransactionID.Valid {
+			if _, ok := tx.transactions[arg.TransactionID]; !ok {
+				return ReconciliationIncident{}, errForeignKeyViolation("reconciliation_incidents", "reconciliation_incidents_transaction_id_fkey")
+			}
+		}
+		incident := ReconciliationIncident{
+			ID:            next(&tx.seq.incidents),
+			AccountID:     arg.AccountID,
+			Kind:          arg.Kind,
+			ExpectedCents: arg.ExpectedCents,
+			ActualCents:   arg.ActualCents,
+			TransactionID: arg.TransactionID,
+			CreatedAt:     tx.now,
+		}
+		put(tx, tx.incidents, incident.ID, incident)
+		return incident, nil
+	})
+}
+
+func (q memQueries) RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) (WebhookDelivery, error) {
+	return query(ctx, q, func(tx *memTx) (WebhookDelivery, error) {
+		delivery, ok := tx.deliveries[arg.ID]
+		if !ok || delivery.Status != WebhookDeliveryStatusPending || delivery.Attempts != arg.RanAttempts {
+			return WebhookDelivery{}, errNoRows()
+		}
+		delivery.Status = arg.Status
+		delivery.Attempts++
+		delivery.NextAttemptAt = arg.NextAttemptAt
+		delivery.LastStatusCode = arg.LastStatusCode
+		delivery.LastError = arg.LastError
+		delivery.DeliveredAt = arg.DeliveredAt
+		delivery.LockedUntil = pgtype.Timestamptz{}
+		delivery.UpdatedAt = tx.now
+		put(tx, tx.deliveries, delivery.ID, delivery)
+		return delivery, nil
+	})
+}
+
+func (q memQueries) RedeliverWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error) {
+	return query(ctx, q, func(tx *memTx) (WebhookDelivery, error) {
+		delivery, ok := tx.deliveries[id]
+		if !ok || delivery.Status == WebhookDeliveryStatusPending {
+			return WebhookDelivery{}, errNoRows()
+		}
+		delivery.Status = WebhookDeliveryStatusPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = tx.now
+		delivery.LockedUntil = pgtype.Timestamptz{}
+		delivery.UpdatedAt = tx.now
+		put(tx, tx.deliveries, delivery.ID, delivery)
+		return delivery, nil
+	})
+}
+
+func (q memQueries) ResolveHold(ctx context.Context, arg ResolveHoldParams) (Hold, error) {
+	return query(ctx, q, func(tx *memTx) (Hold, error) {
+		hold, ok := tx.holds[arg.ID]
+		if !ok || hold.Status != HoldStatusActive {
+			return Hold{}, errNoRows()
+		}
+		hold.Status = arg.Status
+		hold.CapturedCents = arg.CapturedCents
+		hold.CaptureTransactionID = arg.CaptureTransactionID
+		hold.CaptureTransferID = arg.CaptureTransferID
+		hold.ResolvedAt = tx.now
+
+		switch {
+		case hold.CapturedCents.Valid && (hold.CapturedCents.Int64 <= 0 || hold.CapturedCents.Int64 > hold.AmountCents):
+			return Hold{}, errCheckViolation("holds", "holds_captured_within_amount")
+		case (hold.Status == HoldStatusCaptured) != hold.CapturedCents.Valid:
+			return Hold{}, errCheckViolation("holds", "holds_captured_status")
+		case hold.Status == HoldStatusActive:
+			return Hold{}, errCheckViolation("holds", "holds_resolved_status")
+		}
+		if hold.CaptureTransactionID.Valid {
+			if _, ok := tx.transactions[hold.CaptureTransactionID]; !ok {
+				return Hold{}, errForeignKeyViolation("holds", "holds_capture_transaction_id_fkey")
+			}
+		}
+		if hold.CaptureTransferID.Valid {
+			if _, ok := tx.transfers[hold.CaptureTransferID]; !ok {
+				return Hold{}, errForeignKeyViolation("holds", "holds_capture_transfer_id_fkey")
+			}
+		}
+		put(tx, tx.holds, hold.ID, hold)
+		return hold, nil
+	})
+}
+
+func (q memQueries) ResolveReconciliationIncident(ctx context.Context, id int64) (ReconciliationIncident, error) {
+	return query(ctx, q, func(tx *memTx) (ReconciliationIncident, error) {
+		incident, ok := tx.incidents[id]
+		if !ok || incident.ResolvedAt.Valid {
+			return ReconciliationIncident{}, errNoRows()
+		}
+		incident.ResolvedAt = tx.now
+		put(tx, tx.incidents, incident.ID, incident)
+		return incident, nil
+	})
+}
+
+func (q memQueries) SearchTransactionsLargest(ctx context.Context, arg SearchTransactionsLargestParams) ([]Transaction, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transaction, error) {
+		filter := transactionFilter{arg.AccountID, arg.FromTime, arg.ToTime, arg.Types, arg.MinAmountCents, arg.MaxAmountCents, arg.RelatedAccountID, arg.Reference, arg.DescriptionPattern}
+		match := filter.matcher()
+		return selectRows(tx.transactions, func(t Transaction) bool {
+			return match(t) && compareAmountKey(t, arg.AfterAmountCents, arg.AfterID) < 0
+		}, reverse(smallestTransactionFirst), arg.Limit), nil
+	})
+}
+
+func (q memQueries) SearchTransactionsNewest(ctx context.Context, arg SearchTransactionsNewestParams) ([]Transaction, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transaction, error) {
+		filter := transactionFilter{arg.AccountID, arg.FromTime, arg.ToTime, arg.Types, arg.MinAmountCents, arg.MaxAmountCents, arg.RelatedAccountID, arg.Reference, arg.DescriptionPattern}
+		match := filter.matcher()
+		return selectRows(tx.transactions, func(t Transaction) bool {
+			return match(t) && compareTimeKey(t.CreatedAt, t.ID, arg.AfterCreatedAt, arg.AfterID, compareUUID) < 0
+		}, newestTransactionFirst, arg.Limit), nil
+	})
+}
+
+func (q memQueries) SearchTransactionsOldest(ctx context.Context, arg SearchTransactionsOldestParams) ([]Transaction, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transaction, error) {
+		filter := transactionFilter{arg.AccountID, arg.FromTime, arg.ToTime, arg.Types, arg.MinAmountCents, arg.MaxAmountCents, arg.RelatedAccountID, arg.Reference, arg.DescriptionPattern}
+		match := filter.matcher()
+		return selectRows(tx.transactions, func(t Transaction) bool {
+			return match(t) && compareTimeKey(t.CreatedAt, t.ID, arg.AfterCreatedAt, arg.AfterID, compareUUID) > 0
+		}, reverse(newestTransactionFirst), arg.Limit), nil
+	})
+}
+
+func (q memQueries) SearchTransactionsSmallest(ctx context.Context, arg SearchTransactionsSmallestParams) ([]Transaction, error) {
+	return query(ctx, q, func(tx *memTx) ([]Transaction, error) {
+		filter := transactionFilter{arg.AccountID, arg.FromTime, arg.ToTime, arg.Types, arg.MinAmountCents, arg.MaxAmountCents, arg.RelatedAccountID, arg.Reference, arg.DescriptionPattern}
+		match := filter.matcher()
+		return selectRows(tx.transactions, func(t Transaction) bool {
+			return match(t) && compareAmountKey(t, arg.AfterAmountCents, arg.AfterID) > 0
+		}, smallestTransactionFirst, arg.Limit), nil
+	})
+}
+
+func (q memQueries) updateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (Account, error) {
+	return updateAccount(ctx, q, "UpdateAccountBalance", arg.ID, func(account *Account) error {
+		account.BalanceCents = arg.BalanceCents
+		return nil
+	})
+}
+
+func (q memQueries) UpdateAccountHeld(ctx context.Context, arg UpdateAccountHeldParams) (Account, error) {
+	return updateAccount(ctx, q, "UpdateAccountHeld", arg.ID, func(account *Account) error {
+		if arg.HeldCents < 0 {
+			return errCheckViolation("accounts", "accounts_held_nonnegative")
+		}
+		account.HeldCents = arg.HeldCents
+		return nil
+	})
+}
+
+func (q memQueries) UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error) {
+	return updateAccount(ctx, q, "UpdateAccountOverdraftLimit", arg.ID, func(account *Account) error {
+		if arg.OverdraftLimitCents < 0 {
+			return errCheckViolation("accounts", "accounts_overdraft_limit_nonnegative")
+		}
+		account.OverdraftLimitCents = arg.OverdraftLimitCents
+		return nil
+	})
+}
+
+func (q memQueries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	return updateAccount(ctx, q, "UpdateAccountStatus", arg.ID, func(account *Account) error {
+		account.Status = arg.Status
+		return nil
+	})
+}
+
+func (q memQueries) UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) (Transfer, error) {
+	return query(ctx, q, func(tx *memTx) (Transfer, error) {
+		transfer, ok := tx.transfers[arg.ID]
+		if !ok || transfer.Status != arg.FromStatus {
+			return Transfer{}, errNoRows()
+		}
+		transfer.Status = arg.Status
+		transfer.FailureReason = arg.FailureReason
+		if !transfer.ProcessedAt.Valid {
+			transfer.ProcessedAt = tx.now
+		}
+		if (transfer.Status == TransferStatusFailed) != transfer.FailureReason.Valid {
+			return Transfer{}, errCheckViolation("transfers", "transfers_failure_reason_status")
+		}
+		if transfer.Status == TransferStatusPending {
+			return Transfer{}, errCheckViolation("transfers", "transfers_processed_at_status")
+		}
+		put(tx, tx.transfers, transfer.ID, transfer)
+		return transfer, nil
+	})
+}
+
+func (q memQueries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateUserRow, error) {
+	return query(ctx, q, func(tx *memTx) (UpdateUserRow, error) {
+		user, err := get(tx.users, arg.ID)
+		if err != nil {
+			return UpdateUserRow{}, err
+		}
+		if exists(tx.users, func(u User) bool { return u.ID != arg.ID && u.Email == arg.Email }) {
+			return UpdateUserRow{}, errUniqueViolation("users_email_key")
+		}
+		user.FirstName = arg.FirstName
+		user.LastName = arg.LastName
+		user.Email = arg.Email
+		put(tx, tx.users, user.ID, user)
+		return UpdateUserRow{ID: user.ID, FirstName: user.FirstName, LastName: user.LastName, Email: user.Email}, nil
+	})
+}
+
+func (q memQueries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	return query(ctx, q, func(tx *memTx) (User, error) {
+		user, err := get(tx.users, arg.ID)
+		if err != nil {
+			return User{}, err
+		}
+		if !passwordHashFormat.MatchString(arg.PasswordHash) {
+			return User{}, errCheckViolation("users", "users_password_hash_format")
+		}
+		user.PasswordHash = arg.PasswordHash
+		put(tx, tx.users, user.ID, user)
+		return user, nil
+	})
+}
+
+// updateAccount applies set to an account, which fails the update by returning an
+// error, and writes the result back. name is the query it stands for, which decides the
+// error for a missing account as in dberr.TranslateQuery.
+func updateAccount(ctx context.Context, q memQueries, name string, id int64, set func(account *Account) error) (Account, error) {
+	return query(ctx, q, func(tx *memTx) (Account, error) {
+		account, err := get(tx.accounts, id)
+		if err != nil {
+			return Account{}, dberr.TranslateQuery(name, err)
+		}
+		if err := set(&account); err != nil {
+			return Account{}, err
+		}
+		put(tx, tx.accounts, account.ID, account)
+		return account, nil
+	})
+}
+
+// checkTransferSchedule enforces the check constraints of transfer_schedules.
+func checkTransferSchedule(s TransferSchedule) error {
+	switch {
+	case s.AmountCents <= 0:
+		return errCheckViolation("transfer_schedules", "transfer_schedules_amount_positive")
+	case s.FromAccountID == s.ToAccountID:
+		return errCheckViolation("transfer_schedules", "transfer_schedules_different_accounts")
+	case (s.Frequency == ScheduleFrequencyMonthly) != s.DayOfMonth.Valid,
+		s.DayOfMonth.Valid && (s.DayOfMonth.Int32 < 1 || s.DayOfMonth.Int32 > 31):
+		return errCheckViolation("transfer_schedules", "transfer_schedules_day_of_month")
+	case s.MaxAttempts < 1:
+		return errCheckViolation("transfer_schedules", "transfer_schedules_max_attempts")
+	case s.FailedAttempts < 0 || s.FailedAttempts >= s.MaxAttempts:
+		return errCheckViolation("transfer_schedules", "transfer_schedules_failed_attempts")
+	}
+	return nil
+}
+
+// ledgerTotal sums the postings to a customer account.
+func (tx *memTx) ledgerTotal(accountID int64) int64 {
+	var total int64
+	for _, p := range tx.postings {
+		if p.AccountID.Valid && p.AccountID.Int64 == accountID {
+			total += p.AmountCents
+		}
+	}
+	return total
+}
+
+// transactionFilter is the WHERE clause the SearchTransactions queries share.
+type transactionFilter struct {
+	accountID          int64
+	fromTime, toTime   pgtype.Timestamptz
+	types              []string
+	minAmount          int64
+	maxAmount          int64
+	relatedAccountID   pgtype.Int8
+	reference          pgtype.Text
+	descriptionPattern pgtype.Text
+}
+
+func (f transactionFilter) matcher() func(t Transaction) bool {
+	var description *regexp.Regexp
+	if f.descriptionPattern.Valid {
+		description = ilike(f.descriptionPattern.String)
+	}
+	return func(t Transaction) bool {
+		amount := abs(t.AmountCents)
+		return t.AccountID == f.accountID &&
+			inPeriod(t.CreatedAt, f.fromTime, f.toTime) &&
+			(len(f.types) == 0 || slices.Contains(f.types, string(t.Type))) &&
+			amount >= f.minAmount && amount <= f.maxAmount &&
+			(!f.relatedAccountID.Valid || t.RelatedAccountID == f.relatedAccountID) &&
+			(!f.reference.Valid || t.Reference == f.reference) &&
+			(description == nil || t.Description.Valid && description.MatchString(t.Description.String))
+	}
+}
+
+// ilike compiles an ILIKE pattern, with its backslash escapes, to a regexp.
+func ilike(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString(`(?is)^`)
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			expr.WriteString(`.*`)
+		case r == '_':
+			expr.WriteString(`.`)
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString(`$`)
+	return regexp.MustCompile(expr.String())
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// sameTime reports whether two timestamps are equal; NULL equals nothing.
+func sameTime(a, b pgtype.Timestamptz) bool {
+	return a.Valid && b.Valid && compareTime(a, b) == 0
+}
+
+// notAfter reports whether t <= limit; NULL compares false.
+func notAfter(t, limit pgtype.Timestamptz) bool {
+	return t.Valid && limit.Valid && compareTime(t, limit) <= 0
+}
+
+// inPeriod reports whether from <= t < to; NULL bounds compare false.
+func inPeriod(t, from, to pgtype.Timestamptz) bool {
+	return from.Valid && to.Valid && compareTime(t, from) >= 0 && compareTime(t, to) < 0
+}
+
+// reverse turns an ordering around, for the lists that page back from a key.
+func reverse[R any](order func(a, b R) int) func(a, b R) int {
+	return func(a, b R) int {
+		return order(b, a)
+	}
+}
+
+func compareAccountIDs(a, b Account) int {
+	return cmp.Compare(a.ID, b.ID)
+}
+
+func compareUserIDs(a, b User) int {
+	return compareUUID(a.ID, b.ID)
+}
+
+func compareStatusEvent(e AccountStatusEvent, createdAt pgtype.Timestamptz, id int64) int {
+	return compareTimeKey(e.CreatedAt, e.ID, createdAt, id, cmp.Compare[int64])
+}
+
+func newestStatusEventFirst(a, b AccountStatusEvent) int {
+	return compareStatusEvent(b, a.CreatedAt, a.ID)
+}
+
+func newestHoldFirst(a, b Hold) int {
+	return compareTimeKey(b.CreatedAt, b.ID, a.CreatedAt, a.ID, compareUUID)
+}
+
+func newestScheduleFirst(a, b TransferSchedule) int {
+	return compareTimeKey(b.CreatedAt, b.ID, a.CreatedAt, a.ID, compareUUID)
+}
+
+func newestTransactionFirst(a, b Transaction) int {
+	return compareTimeKey(b.CreatedAt, b.ID, a.CreatedAt, a.ID, compareUUID)
+}
+
+func newestTransferFirst(a, b Transfer) int {
+	return compareTimeKey(b.CreatedAt, b.ID, a.CreatedAt, a.ID, compareUUID)
+}
+
+func oldestEndpointFirst(a, b WebhookEndpoint) int {
+	return compareTimeKey(a.CreatedAt, a.ID, b.CreatedAt, b.ID, compareUUID)
+}
+
+// compareAmountKey orders a transaction against an (abs(amount_cents), id) key.
+func compareAmountKey(t Transaction, amount int64, id pgtype.UUID) int {
+	return cmp.Or(cmp.Compare(abs(t.AmountCents), amount), compareUUID(t.ID, id))
+}
+
+func smallestTransactionFirst(a, b Transaction) int {
+	return compareAmountKey(a, abs(b.AmountCents), b.ID)
+}
+
+// Rows with slices are copied on the way in and out, so callers can't change what the
+// store holds.
+
+func cloneOutboxEvent(e OutboxEvent) OutboxEvent {
+	e.AccountIds = slices.Clone(e.AccountIds)
+	e.Payload = slices.Clone(e.Payload)
+	return e
+}
+
+func cloneOutboxEvents(events []OutboxEvent) []OutboxEvent {
+	for i := range events {
+		events[i] = cloneOutboxEvent(events[i])
+	}
+	return events
+}
+
+func cloneWebhookEndpoint(w WebhookEndpoint) WebhookEndpoint {
+	w.EventTypes = slices.Clone(w.EventTypes)
+	return w
+}
+
+func cloneWebhookEndpoints(endpoints []WebhookEndpoint) []WebhookEndpoint {
+	for i := range endpoints {
+		endpoints[i] = cloneWebhookEndpoint(endpoints[i])
+	}
+	return endpoints
+}
@@ -99,13 +99,456 @@ func (ns NullCurrency) Value() (driver.Value, error) {
 	return string(ns.Currency), nil
 }
 
+type DriftKind string
+
+const (
+	DriftKindTransactionsTotal DriftKind = "transactions_total"
+	DriftKindLedgerTotal       DriftKind = "ledger_total"
+	DriftKindBalanceChain      DriftKind = "balance_chain"
+)
+
+func (e *DriftKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DriftKind(s)
+	case string:
+		*e = DriftKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DriftKind: %T", src)
+	}
+	return nil
+}
+
+type NullDriftKind struct {
+	DriftKind DriftKind
+	Valid     bool // Valid is true if DriftKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDriftKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.DriftKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DriftKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDriftKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DriftKind), nil
+}
+
+type EventType string
+
+const (
+	EventTypeDepositCompleted    EventType = "deposit.completed"
+	EventTypeWithdrawalCompleted EventType = "withdrawal.completed"
+	EventTypeTransferCompleted   EventType = "transfer.completed"
+	EventTypeTransferFailed      EventType = "transfer.failed"
+	EventTypeTransferReversed    EventType = "transfer.reversed"
+	EventTypeOverdraftCharged    EventType = "overdraft.charged"
+)
+
+func (e *EventType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EventType(s)
+	case string:
+		*e = EventType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EventType: %T", src)
+	}
+	return nil
+}
+
+type NullEventType struct {
+	EventType EventType
+	Valid     bool // Valid is true if EventType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEventType) Scan(value interface{}) error {
+	if value == nil {
+		ns.EventType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EventType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEventType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EventType), nil
+}
+
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "active"
+	HoldStatusCaptured HoldStatus = "captured"
+	HoldStatusVoided   HoldStatus = "voided"
+	HoldStatusExpired  HoldStatus = "expired"
+)
+
+func (e *HoldStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = HoldStatus(s)
+	case string:
+		*e = HoldStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for HoldStatus: %T", src)
+	}
+	return nil
+}
+
+type NullHoldStatus struct {
+	HoldStatus HoldStatus
+	Valid      bool // Valid is true if HoldStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullHoldStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.HoldStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.HoldStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullHoldStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.HoldStatus), nil
+}
+
+type InsufficientFundsPolicy string
+
+const (
+	InsufficientFundsPolicyRetry InsufficientFundsPolicy = "retry"
+	InsufficientFundsPolicySkip  InsufficientFundsPolicy = "skip"
+)
+
+func (e *InsufficientFundsPolicy) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = InsufficientFundsPolicy(s)
+	case string:
+		*e = InsufficientFundsPolicy(s)
+	default:
+		return fmt.Errorf("unsupported scan type for InsufficientFundsPolicy: %T", src)
+	}
+	return nil
+}
+
+type NullInsufficientFundsPolicy struct {
+	InsufficientFundsPolicy InsufficientFundsPolicy
+	Valid                   bool // Valid is true if InsufficientFundsPolicy is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullInsufficientFundsPolicy) Scan(value interface{}) error {
+	if value == nil {
+		ns.InsufficientFundsPolicy, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.InsufficientFundsPolicy.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullInsufficientFundsPolicy) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.InsufficientFundsPolicy), nil
+}
+
+type JournalEntryType string
+
+const (
+	JournalEntryTypeOpeningBalance  JournalEntryType = "opening_balance"
+	JournalEntryTypeDeposit         JournalEntryType = "deposit"
+	JournalEntryTypeWithdrawal      JournalEntryType = "withdrawal"
+	JournalEntryTypeTransfer        JournalEntryType = "transfer"
+	JournalEntryTypeReversal        JournalEntryType = "reversal"
+	JournalEntryTypeOverdraftCharge JournalEntryType = "overdraft_charge"
+)
+
+func (e *JournalEntryType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = JournalEntryType(s)
+	case string:
+		*e = JournalEntryType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for JournalEntryType: %T", src)
+	}
+	return nil
+}
+
+type NullJournalEntryType struct {
+	JournalEntryType JournalEntryType
+	Valid            bool // Valid is true if JournalEntryType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullJournalEntryType) Scan(value interface{}) error {
+	if value == nil {
+		ns.JournalEntryType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.JournalEntryType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullJournalEntryType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.JournalEntryType), nil
+}
+
+type ReversalReason string
+
+const (
+	ReversalReasonCustomerRequest ReversalReason = "customer_request"
+	ReversalReasonDuplicate       ReversalReason = "duplicate"
+	ReversalReasonFraud           ReversalReason = "fraud"
+	ReversalReasonProcessingError ReversalReason = "processing_error"
+)
+
+func (e *ReversalReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReversalReason(s)
+	case string:
+		*e = ReversalReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReversalReason: %T", src)
+	}
+	return nil
+}
+
+type NullReversalReason struct {
+	ReversalReason ReversalReason
+	Valid          bool // Valid is true if ReversalReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReversalReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReversalReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReversalReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReversalReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReversalReason), nil
+}
+
+type ScheduleFrequency string
+
+const (
+	ScheduleFrequencyOnce    ScheduleFrequency = "once"
+	ScheduleFrequencyDaily   ScheduleFrequency = "daily"
+	ScheduleFrequencyWeekly  ScheduleFrequency = "weekly"
+	ScheduleFrequencyMonthly ScheduleFrequency = "monthly"
+)
+
+func (e *ScheduleFrequency) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduleFrequency(s)
+	case string:
+		*e = ScheduleFrequency(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduleFrequency: %T", src)
+	}
+	return nil
+}
+
+type NullScheduleFrequency struct {
+	ScheduleFrequency ScheduleFrequency
+	Valid             bool // Valid is true if ScheduleFrequency is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduleFrequency) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduleFrequency, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduleFrequency.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduleFrequency) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduleFrequency), nil
+}
+
+type ScheduleRunStatus string
+
+const (
+	ScheduleRunStatusSucceeded ScheduleRunStatus = "succeeded"
+	ScheduleRunStatusRetrying  ScheduleRunStatus = "retrying"
+	ScheduleRunStatusSkipped   ScheduleRunStatus = "skipped"
+	ScheduleRunStatusFailed    ScheduleRunStatus = "failed"
+)
+
+func (e *ScheduleRunStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduleRunStatus(s)
+	case string:
+		*e = ScheduleRunStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduleRunStatus: %T", src)
+	}
+	return nil
+}
+
+type NullScheduleRunStatus struct {
+	ScheduleRunStatus ScheduleRunStatus
+	Valid             bool // Valid is true if ScheduleRunStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduleRunStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduleRunStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduleRunStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduleRunStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduleRunStatus), nil
+}
+
+type ScheduleStatus string
+
+const (
+	ScheduleStatusActive    ScheduleStatus = "active"
+	ScheduleStatusCompleted ScheduleStatus = "completed"
+	ScheduleStatusCancelled ScheduleStatus = "cancelled"
+)
+
+func (e *ScheduleStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduleStatus(s)
+	case string:
+		*e = ScheduleStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduleStatus: %T", src)
+	}
+	return nil
+}
+
+type NullScheduleStatus struct {
+	ScheduleStatus ScheduleStatus
+	Valid          bool // Valid is true if ScheduleStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduleStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduleStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduleStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduleStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduleStatus), nil
+}
+
+type SystemAccountKind string
+
+const (
+	SystemAccountKindCashIn     SystemAccountKind = "cash_in"
+	SystemAccountKindCashOut    SystemAccountKind = "cash_out"
+	SystemAccountKindFxClearing SystemAccountKind = "fx_clearing"
+	SystemAccountKindFeeIncome  SystemAccountKind = "fee_income"
+)
+
+func (e *SystemAccountKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SystemAccountKind(s)
+	case string:
+		*e = SystemAccountKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SystemAccountKind: %T", src)
+	}
+	return nil
+}
+
+type NullSystemAccountKind struct {
+	SystemAccountKind SystemAccountKind
+	Valid             bool // Valid is true if SystemAccountKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSystemAccountKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.SystemAccountKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SystemAccountKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSystemAccountKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SystemAccountKind), nil
+}
+
 type TransactionType string
 
 const (
-	TransactionTypeDeposit     TransactionType = "deposit"
-	TransactionTypeWithdrawal  TransactionType = "withdrawal"
-	TransactionTypeTransferIn  TransactionType = "transfer_in"
-	TransactionTypeTransferOut TransactionType = "transfer_out"
+	TransactionTypeDeposit           TransactionType = "deposit"
+	TransactionTypeWithdrawal        TransactionType = "withdrawal"
+	TransactionTypeTransferIn        TransactionType = "transfer_in"
+	TransactionTypeTransferOut       TransactionType = "transfer_out"
+	TransactionTypeReversal          TransactionType = "reversal"
+	TransactionTypeOverdraftInterest TransactionType = "overdraft_interest"
+	TransactionTypeOverdraftFee      TransactionType = "overdraft_fee"
 )
 
 func (e *TransactionType) Scan(src interface{}) error {
@@ -188,15 +631,167 @@ func (ns NullTransferStatus) Value() (driver.Value, error) {
 	return string(ns.TransferStatus), nil
 }
 
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "dead"
+)
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatus(s)
+	case string:
+		*e = WebhookDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatus struct {
+	WebhookDeliveryStatus WebhookDeliveryStatus
+	Valid                 bool // Valid is true if WebhookDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatus), nil
+}
+
 // Each bank account belongs to a user and holds a balance in a fixed currency.
 type Account struct {
 	ID      int64
 	OwnerID pgtype.UUID
-	// Balance stored in cents; never negative unless overdraft allowed
+	// Balance stored in cents; negative while the account is in overdraft
 	BalanceCents int64
 	Currency     Currency
 	Status       AccountStatus
 	CreatedAt    pgtype.Timestamptz
+	// How far below zero customer debits may take the balance
+	OverdraftLimitCents int64
+	// Sum of active holds: reserved out of the available balance but not posted
+	HeldCents int64
+}
+
+// Audit trail of account freezes, unfreezes and closures.
+type AccountStatusEvent struct {
+	ID         int64
+	AccountID  int64
+	FromStatus AccountStatus
+	ToStatus   AccountStatus
+	Reason     string
+	// Who made the change, e.g. user:<uuid> or the name of a system job
+	Actor     string
+	CreatedAt pgtype.Timestamptz
+}
+
+// Conversion rates for cross-currency transfers. The newest rate already in effect wins.
+type ExchangeRate struct {
+	ID            int64
+	BaseCurrency  Currency
+	QuoteCurrency Currency
+	// Units of quote_currency per one unit of base_currency
+	Rate        pgtype.Numeric
+	EffectiveAt pgtype.Timestamptz
+	CreatedAt   pgtype.Timestamptz
+}
+
+// Card-style authorizations: funds reserved on an account until captured, voided or expired.
+type Hold struct {
+	ID          pgtype.UUID
+	AccountID   int64
+	AmountCents int64
+	Status      HoldStatus
+	// Amount actually taken on capture; the rest of the hold is released
+	CapturedCents pgtype.Int8
+	// Debit that captured the hold, for a withdrawal or the outgoing leg of a transfer
+	CaptureTransactionID pgtype.UUID
+	// Transfer that captured the hold, when captured into a transfer
+	CaptureTransferID pgtype.UUID
+	// Idempotency key / external reference
+	Reference  pgtype.Text
+	ExpiresAt  pgtype.Timestamptz
+	CreatedAt  pgtype.Timestamptz
+	ResolvedAt pgtype.Timestamptz
+}
+
+// One balanced business event in the double-entry ledger; its postings sum to zero per currency.
+type JournalEntry struct {
+	ID         pgtype.UUID
+	Type       JournalEntryType
+	TransferID pgtype.UUID
+	CreatedAt  pgtype.Timestamptz
+}
+
+// Ledger events, written in the same database transaction as the money movement they describe
+type OutboxEvent struct {
+	ID   int64
+	Type EventType
+	// Customer accounts the event concerns; their owners' webhook endpoints receive it
+	AccountIds []int64
+	Payload    []byte
+	CreatedAt  pgtype.Timestamptz
+	// When a delivery was queued for every subscribed endpoint
+	DispatchedAt pgtype.Timestamptz
+}
+
+// Overdraft charges booked per account, day and charge type; keeps each from being booked twice
+type OverdraftAccrual struct {
+	AccountID     int64
+	Day           pgtype.Date
+	Type          TransactionType
+	TransactionID pgtype.UUID
+	CreatedAt     pgtype.Timestamptz
+}
+
+// Append-only legs of journal entries. Each moves amount_cents into (positive) or out of (negative) one ledger account.
+type Posting struct {
+	ID              int64
+	EntryID         pgtype.UUID
+	AccountID       pgtype.Int8
+	SystemAccountID pgtype.Int8
+	// The customer-facing transaction this leg produced, for account postings
+	TransactionID pgtype.UUID
+	Currency      Currency
+	AmountCents   int64
+	CreatedAt     pgtype.Timestamptz
+}
+
+// Drift found by reconciliation. At most one open incident per account and kind.
+type ReconciliationIncident struct {
+	ID            int64
+	AccountID     int64
+	Kind          DriftKind
+	ExpectedCents int64
+	ActualCents   int64
+	// First transaction that breaks the balance chain, for balance_chain drift
+	TransactionID pgtype.UUID
+	CreatedAt     pgtype.Timestamptz
+	ResolvedAt    pgtype.Timestamptz
+}
+
+// Internal ledger accounts on the other side of customer postings; one per kind and currency.
+type SystemAccount struct {
+	ID        int64
+	Kind      SystemAccountKind
+	Currency  Currency
+	CreatedAt pgtype.Timestamptz
 }
 
 // Immutable ledger of all money movements. One record per event.
@@ -208,9 +803,13 @@ type Transaction struct {
 	BalanceAfterCents int64
 	// Used for transfers
 	RelatedAccountID pgtype.Int8
-	// Idempotency key / external reference
+	// Idempotency key of a deposit or withdrawal; NULL on transfer legs
 	Reference pgtype.Text
 	CreatedAt pgtype.Timestamptz
+	// Order in which rows were applied to their account; balance_after_cents chains along it
+	Seq int64
+	// Free-text memo given with the deposit, withdrawal or transfer
+	Description pgtype.Text
 }
 
 // Represents an intention to move money. A transfer usually results in two transaction records (out + in).
@@ -224,6 +823,64 @@ type Transfer struct {
 	CreatedAt   pgtype.Timestamptz
 	// Populated when completed or failed
 	ProcessedAt pgtype.Timestamptz
+	// Idempotency key / external reference
+	Reference pgtype.Text
+	// Why the transfer failed, e.g. insufficient_balance; set only on failed transfers
+	FailureReason pgtype.Text
+	// Amount credited in the destination currency; set only on cross-currency transfers
+	ToAmountCents pgtype.Int8
+	// Rate applied to amount_cents to get to_amount_cents
+	ExchangeRate pgtype.Numeric
+}
+
+// Compensation of a completed transfer. At most one per transfer, for all or part of its amount.
+type TransferReversal struct {
+	ID          pgtype.UUID
+	TransferID  pgtype.UUID
+	AmountCents int64
+	Reason      ReversalReason
+	// Takes the money back from the original recipient
+	DebitTransactionID pgtype.UUID
+	// Returns the money to the original sender
+	CreditTransactionID pgtype.UUID
+	CreatedAt           pgtype.Timestamptz
+}
+
+// Future-dated and recurring transfers (standing orders)
+type TransferSchedule struct {
+	ID            pgtype.UUID
+	FromAccountID int64
+	ToAccountID   int64
+	AmountCents   int64
+	Frequency     ScheduleFrequency
+	// Monthly schedules only; clamped to the last day of shorter months
+	DayOfMonth              pgtype.Int4
+	Status                  ScheduleStatus
+	InsufficientFundsPolicy InsufficientFundsPolicy
+	MaxAttempts             int32
+	// Attempts of the current occurrence refused for insufficient funds
+	FailedAttempts int32
+	// When the current occurrence was due
+	NextOccurrenceAt pgtype.Timestamptz
+	// When the current occurrence is next attempted; later than next_occurrence_at while retrying
+	NextRunAt pgtype.Timestamptz
+	EndsAt    pgtype.Timestamptz
+	// Lease held by the worker running the schedule
+	LockedUntil pgtype.Timestamptz
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+}
+
+// One row per attempt of a scheduled transfer
+type TransferScheduleRun struct {
+	ID            int64
+	ScheduleID    pgtype.UUID
+	OccurrenceAt  pgtype.Timestamptz
+	Attempt       int32
+	Status        ScheduleRunStatus
+	TransferID    pgtype.UUID
+	FailureReason pgtype.Text
+	CreatedAt     pgtype.Timestamptz
 }
 
 // Stores registered users of the system.
@@ -235,3 +892,34 @@ type User struct {
 	PasswordHash string
 	CreatedAt    pgtype.Timestamptz
 }
+
+// One event on its way to one webhook endpoint
+type WebhookDelivery struct {
+	ID         int64
+	EventID    int64
+	EndpointID pgtype.UUID
+	// dead once every attempt has failed; redelivery puts it back to pending
+	Status        WebhookDeliveryStatus
+	Attempts      int32
+	NextAttemptAt pgtype.Timestamptz
+	// Lease held by the dispatcher sending the delivery
+	LockedUntil    pgtype.Timestamptz
+	LastStatusCode pgtype.Int4
+	LastError      pgtype.Text
+	DeliveredAt    pgtype.Timestamptz
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+}
+
+// URLs that receive a user's ledger events
+type WebhookEndpoint struct {
+	ID      pgtype.UUID
+	OwnerID pgtype.UUID
+	Url     string
+	// HMAC-SHA256 key deliveries are signed with
+	Secret string
+	// Event types delivered to the endpoint; empty means all of them
+	EventTypes []string
+	Active     bool
+	CreatedAt  pgtype.Timestamptz
+}
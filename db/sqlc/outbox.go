@@ -0,0 +1,119 @@
+package sqlc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// TransactionEvent is the payload of deposit.completed, withdrawal.completed and
+// overdraft.charged events: one transaction on one account.
+type TransactionEvent struct {
+	TransactionID     string          `json:"transaction_id"`
+	AccountID         int64           `json:"account_id"`
+	Type              TransactionType `json:"type"`
+	AmountCents       int64           `json:"amount_cents"`
+	BalanceAfterCents int64           `json:"balance_after_cents"`
+	Currency          Currency        `json:"currency"`
+	Reference         string          `json:"reference,omitempty"`
+}
+
+// TransferEvent is the payload of transfer.completed and transfer.failed events.
+type TransferEvent struct {
+	TransferID    string         `json:"transfer_id"`
+	FromAccountID int64          `json:"from_account_id"`
+	ToAccountID   int64          `json:"to_account_id"`
+	Status        TransferStatus `json:"status"`
+	AmountCents   int64          `json:"amount_cents"`
+	Currency      Currency       `json:"currency"`
+	// ToAmountCents and ToCurrency are what the recipient was credited.
+	ToAmountCents int64    `json:"to_amount_cents"`
+	ToCurrency    Currency `json:"to_currency"`
+	Reference     string   `json:"reference,omitempty"`
+	FailureReason string   `json:"failure_reason,omitempty"`
+}
+
+// TransferReversedEvent is the payload of transfer.reversed events.
+type TransferReversedEvent struct {
+	ReversalID    string         `json:"reversal_id"`
+	TransferID    string         `json:"transfer_id"`
+	FromAccountID int64          `json:"from_account_id"`
+	ToAccountID   int64          `json:"to_account_id"`
+	AmountCents   int64          `json:"amount_cents"`
+	Currency      Currency       `json:"currency"`
+	Reason        ReversalReason `json:"reason"`
+}
+
+func newTransactionEvent(account Account, transaction Transaction) TransactionEvent {
+	return TransactionEvent{
+		TransactionID:     transaction.ID.String(),
+		AccountID:         transaction.AccountID,
+		Type:              transaction.Type,
+		AmountCents:       transaction.AmountCents,
+		BalanceAfterCents: transaction.BalanceAfterCents,
+		Currency:          account.Currency,
+		Reference:         transaction.Reference.String,
+	}
+}
+
+func newTransferEvent(result TransferMoneyResult) TransferEvent {
+	transfer := result.Transfer
+	event := TransferEvent{
+		TransferID:    transfer.ID.String(),
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Status:        transfer.Status,
+		AmountCents:   transfer.AmountCents,
+		Currency:      result.FromAccount.Currency,
+		ToAmountCents: transfer.AmountCents,
+		ToCurrency:    result.ToAccount.Currency,
+		Reference:     transfer.Reference.String,
+		FailureReason: transfer.FailureReason.String,
+	}
+	if transfer.ToAmountCents.Valid {
+		event.ToAmountCents = transfer.ToAmountCents.Int64
+	}
+	return event
+}
+
+// recordEvent writes an event to the outbox inside the caller's database transaction,
+// so it is committed if and only if the money movement it describes is. The owners of
+// accountIDs receive it on their webhook endpoints.
+func (q storeQueries) recordEvent(ctx context.Context, eventType EventType, payload any, accountIDs ...int64) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", eventType, err)
+	}
+
+	_, err = q.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+		Type:       eventType,
+		AccountIds: accountIDs,
+		Payload:    data,
+	})
+	return err
+}
+
+// DispatchOutboxEvents queues a webhook delivery of up to limit undispatched events for
+// every endpoint subscribed to them, and marks the events dispatched. Events are taken
+// oldest first with SKIP LOCKED, so concurrent dispatchers split the work. It returns
+// how many events were dispatched.
+func (store *SQLStore) DispatchOutboxEvents(ctx context.Context, limit int32) (int, error) {
+	var dispatched int
+	err := store.executeTransaction(ctx, TxDispatchOutbox, func(q storeQueries) error {
+		events, err := q.ClaimUndispatchedOutboxEvents(ctx, limit)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if _, err := q.CreateWebhookDeliveries(ctx, event.ID); err != nil {
+				return err
+			}
+			if err := q.MarkOutboxEventDispatched(ctx, event.ID); err != nil {
+				return err
+			}
+		}
+		dispatched = len(events)
+		return nil
+	})
+	return dispatched, err
+}
@@ -0,0 +1,207 @@
+package sqlc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestWebhookEndpoint(t *testing.T, ownerID pgtype.UUID, eventTypes ...string) WebhookEndpoint {
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	endpoint, err := testQueries.CreateWebhookEndpoint(context.Background(), CreateWebhookEndpointParams{
+		OwnerID:    ownerID,
+		Url:        "https://example.com/hooks",
+		Secret:     "whsec_test",
+		EventTypes: eventTypes,
+	})
+	require.NoError(t, err)
+	require.True(t, endpoint.Active)
+	return endpoint
+}
+
+// dispatchUntil runs DispatchOutboxEvents until the event is dispatched, since other
+// tests leave undispatched events of their own behind.
+func dispatchUntil(t *testing.T, store Store, eventID int64) {
+	for {
+		event, err := store.GetOutboxEvent(context.Background(), eventID)
+		require.NoError(t, err)
+		if event.DispatchedAt.Valid {
+			return
+		}
+		dispatched, err := store.DispatchOutboxEvents(context.Background(), 100)
+		require.NoError(t, err)
+		require.NotZero(t, dispatched)
+	}
+}
+
+func TestDepositMoneyTxRecordsEvent(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccountWithQueries(t, store)
+
+	result, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{AccountID: account.ID, Amount: 250})
+	require.NoError(t, err)
+
+	events, err := store.ListOutboxEventsByAccount(context.Background(), ListOutboxEventsByAccountParams{AccountID: account.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, EventTypeDepositCompleted, events[0].Type)
+	require.Equal(t, []int64{account.ID}, events[0].AccountIds)
+	require.False(t, events[0].DispatchedAt.Valid)
+
+	var payload TransactionEvent
+	require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+	require.Equal(t, result.Transaction.ID.String(), payload.TransactionID)
+	require.Equal(t, int64(250), payload.AmountCents)
+	require.Equal(t, result.Account.BalanceCents, payload.BalanceAfterCents)
+}
+
+func TestTransferMoneyTxRecordsEvent(t *testing.T) {
+	store := NewStore(testDB)
+	fromAccount := createRandomAccountWithQueries(t, store)
+	toAccount := createRandomAccountWithQueries(t, store)
+
+	// A rejected transfer is recorded as failed, without moving money
+	_, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		AmountCents:   fromAccount.BalanceCents + 1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	_, err = store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		AmountCents:   10,
+	})
+	require.NoError(t, err)
+
+	events, err := store.ListOutboxEventsByAccount(context.Background(), ListOutboxEventsByAccountParams{AccountID: fromAccount.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, EventTypeTransferFailed, events[0].Type)
+	require.Equal(t, []int64{fromAccount.ID}, events[0].AccountIds)
+	require.Equal(t, EventTypeTransferCompleted, events[1].Type)
+	require.ElementsMatch(t, []int64{fromAccount.ID, toAccount.ID}, events[1].AccountIds)
+
+	// The recipient only hears of the transfer that reached them
+	events, err = store.ListOutboxEventsByAccount(context.Background(), ListOutboxEventsByAccountParams{AccountID: toAccount.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, EventTypeTransferCompleted, events[0].Type)
+}
+
+func TestTransferFailedEventNotDeliveredToRecipient(t *testing.T) {
+	store := NewStore(testDB)
+	fromAccount := createRandomAccountWithQueries(t, store)
+	toAccount := createRandomAccountWithQueries(t, store)
+	sender := createTestWebhookEndpoint(t, fromAccount.OwnerID)
+	recipient := createTestWebhookEndpoint(t, toAccount.OwnerID)
+
+	_, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		AmountCents:   fromAccount.BalanceCents + 1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	events, err := store.ListOutboxEventsByAccount(context.Background(), ListOutboxEventsByAccountParams{AccountID: fromAccount.ID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, EventTypeTransferFailed, events[0].Type)
+
+	dispatchUntil(t, store, events[0].ID)
+
+	page, err := store.ListWebhookDeliveriesByEndpointPage(context.Background(), ListWebhookDeliveriesByEndpointPageParams{EndpointID: sender.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	require.Equal(t, events[0].ID, page.Items[0].EventID)
+
+	page, err = store.ListWebhookDeliveriesByEndpointPage(context.Background(), ListWebhookDeliveriesByEndpointPageParams{EndpointID: recipient.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, page.Items)
+}
+
+func TestDispatchOutboxEvents(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccountWithQueries(t, store)
+	all := createTestWebhookEndpoint(t, account.OwnerID)
+	transfersOnly := createTestWebhookEndpoint(t, account.OwnerID, string(EventTypeTransferCompleted))
+	deactivated := createTestWebhookEndpoint(t, account.OwnerID)
+	_, err := store.DeactivateWebhookEndpoint(context.Background(), deactivated.ID)
+	require.NoError(t, err)
+
+	_, err = store.DepositMoneyTx(context.Background(), AccountTransactionParams{AccountID: account.ID, Amount: 250})
+	require.NoError(t, err)
+	events, err := store.ListOutboxEventsByAccount(context.Background(), ListOutboxEventsByAccountParams{AccountID: account.ID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	dispatchUntil(t, store, events[0].ID)
+
+	page, err := store.ListWebhookDeliveriesByEndpointPage(context.Background(), ListWebhookDeliveriesByEndpointPageParams{EndpointID: all.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	deliveries := page.Items
+	require.Equal(t, events[0].ID, deliveries[0].EventID)
+	require.Equal(t, WebhookDeliveryStatusPending, deliveries[0].Status)
+
+	for _, endpoint := range []WebhookEndpoint{transfersOnly, deactivated} {
+		page, err := store.ListWebhookDeliveriesByEndpointPage(context.Background(), ListWebhookDeliveriesByEndpointPageParams{EndpointID: endpoint.ID, Limit: 10})
+		require.NoError(t, err)
+		require.Empty(t, page.Items)
+	}
+}
+
+func TestRecordAndRedeliverWebhookDelivery(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccountWithQueries(t, store)
+	endpoint := createTestWebhookEndpoint(t, account.OwnerID)
+
+	_, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{AccountID: account.ID, Amount: 250})
+	require.NoError(t, err)
+	events, err := store.ListOutboxEventsByAccount(context.Background(), ListOutboxEventsByAccountParams{AccountID: account.ID, Limit: 1})
+	require.NoError(t, err)
+	dispatchUntil(t, store, events[0].ID)
+
+	page, err := store.ListWebhookDeliveriesByEndpointPage(context.Background(), ListWebhookDeliveriesByEndpointPageParams{EndpointID: endpoint.ID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	delivery := page.Items[0]
+
+	// A pending delivery cannot be redelivered
+	_, err = store.RedeliverWebhookDelivery(context.Background(), delivery.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	dead, err := store.RecordWebhookDeliveryAttempt(context.Background(), RecordWebhookDeliveryAttemptParams{
+		Status:         WebhookDeliveryStatusDead,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: pgtype.Int4{Int32: 500, Valid: true},
+		LastError:      pgtype.Text{String: "unexpected response status 500", Valid: true},
+		ID:             delivery.ID,
+		RanAttempts:    delivery.Attempts,
+	})
+	require.NoError(t, err)
+	require.Equal(t, WebhookDeliveryStatusDead, dead.Status)
+	require.Equal(t, int32(1), dead.Attempts)
+
+	// Recording the same attempt twice is refused
+	_, err = store.RecordWebhookDeliveryAttempt(context.Background(), RecordWebhookDeliveryAttemptParams{
+		Status:      WebhookDeliveryStatusDelivered,
+		ID:          delivery.ID,
+		RanAttempts: delivery.Attempts,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	redelivered, err := store.RedeliverWebhookDelivery(context.Background(), delivery.ID)
+	require.NoError(t, err)
+	require.Equal(t, WebhookDeliveryStatusPending, redelivered.Status)
+	require.Zero(t, redelivered.Attempts)
+	require.WithinDuration(t, time.Now(), redelivered.NextAttemptAt.Time, time.Minute)
+}
@@ -0,0 +1,248 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInvalidOverdraftLimit = errors.New("overdraft limit must not be negative")
+
+// AvailableCents is how much customer debits may still take from the account: its
+// ledger balance plus the overdraft limit, less the funds reserved by active holds.
+func (account Account) AvailableCents() int64 {
+	return account.BalanceCents + account.OverdraftLimitCents - account.HeldCents
+}
+
+// OverdraftCharge is one amount an account pays for a day spent in overdraft.
+type OverdraftCharge struct {
+	// Type is TransactionTypeOverdraftInterest or TransactionTypeOverdraftFee.
+	Type        TransactionType
+	AmountCents int64
+}
+
+// OverdraftPolicy is the hook for overdraft interest and fees. AccrueOverdraftTx asks it
+// what an account owes for a day, with the account locked. Each charge type is booked at
+// most once per account and day.
+type OverdraftPolicy interface {
+	DailyCharges(account Account, day time.Time) []OverdraftCharge
+}
+
+// WithOverdraftPolicy sets the policy AccrueOverdraftTx charges by. Without one nothing is charged.
+func WithOverdraftPolicy(policy OverdraftPolicy) StoreOption {
+	return func(store *SQLStore) {
+		store.overdraftPolicy = policy
+	}
+}
+
+// StandardOverdraftPolicy charges simple interest on the overdrawn amount, accrued daily,
+// plus a flat fee for every day in overdraft.
+type StandardOverdraftPolicy struct {
+	// AnnualInterestBps is the yearly rate in basis points; a day accrues 1/365 of it.
+	AnnualInterestBps int64
+	DailyFeeCents     int64
+}
+
+func (policy StandardOverdraftPolicy) DailyCharges(account Account, _ time.Time) []OverdraftCharge {
+	if account.BalanceCents >= 0 {
+		return nil
+	}
+
+	var charges []OverdraftCharge
+	// overdrawn * bps / (10000 * 365), rounded half up; big.Int so large balances can't overflow
+	interest := new(big.Int).Mul(big.NewInt(-account.BalanceCents), big.NewInt(policy.AnnualInterestBps))
+	denominator := big.NewInt(10000 * 365)
+	interest.Add(interest, new(big.Int).Rsh(denominator, 1))
+	interest.Quo(interest, denominator)
+	if interest.Sign() > 0 && interest.IsInt64() {
+		charges = append(charges, OverdraftCharge{Type: TransactionTypeOverdraftInterest, AmountCents: interest.Int64()})
+	}
+	if policy.DailyFeeCents > 0 {
+		charges = append(charges, OverdraftCharge{Type: TransactionTypeOverdraftFee, AmountCents: policy.DailyFeeCents})
+	}
+	return charges
+}
+
+type SetOverdraftLimitTxParams struct {
+	AccountID  int64
+	LimitCents int64
+}
+
+// SetOverdraftLimitTx changes how far below zero an account may go. A limit lowered under
+// the current overdraft is accepted; the account then takes no debits until it is back
+// within the limit.
+func (store *SQLStore) SetOverdraftLimitTx(ctx context.Context, arg SetOverdraftLimitTxParams) (Account, error) {
+	if arg.LimitCents < 0 {
+		return Account{}, ErrInvalidOverdraftLimit
+	}
+
+	var account Account
+	err := store.executeTransaction(ctx, TxSetOverdraftLimit, func(q storeQueries) error {
+		var err error
+		account, err = q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if account.Status == AccountStatusClosed {
+			return &AccountStatusError{AccountID: account.ID, Status: account.Status, Debit: true}
+		}
+
+		account, err = q.UpdateAccountOverdraftLimit(ctx, UpdateAccountOverdraftLimitParams{
+			ID:                  arg.AccountID,
+			OverdraftLimitCents: arg.LimitCents,
+		})
+		return err
+	})
+
+	return account, err
+}
+
+type AccrueOverdraftTxParams struct {
+	AccountID int64
+	// Day is the day being charged for, taken as a calendar date in its own location.
+	Day time.Time
+}
+
+type AccrueOverdraftTxResult struct {
+	Account Account
+	// Transactions holds the charges booked by this call; charges already booked
+	// for the day are not repeated.
+	Transactions []Transaction
+}
+
+// AccrueOverdraftTx books the overdraft policy's charges for one account and day as
+// debits against the account, credited to the fee_income system account. Charges may
+// take the balance past the overdraft limit. Calling it again for the same day is a no-op.
+func (store *SQLStore) AccrueOverdraftTx(ctx context.Context, arg AccrueOverdraftTxParams) (AccrueOverdraftTxResult, error) {
+	var result AccrueOverdraftTxResult
+	if store.overdraftPolicy == nil {
+		return result, nil
+	}
+
+	err := store.executeTransaction(ctx, TxAccrueOverdraft, func(q storeQueries) error {
+		result.Transactions = nil
+		var err error
+		result.Account, err = q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		account := result.Account
+		balance := account.BalanceCents
+		day := overdraftDay(arg.Day)
+		var postings []ledgerPosting
+		for _, charge := range store.overdraftPolicy.DailyCharges(account, arg.Day) {
+			if charge.AmountCents <= 0 {
+				continue
+			}
+
+			// The account lock serializes accruals, so checking first is enough to skip a
+			// repeat; the accrual's primary key backs it
+			_, err := q.GetOverdraftAccrual(ctx, GetOverdraftAccrualParams{
+				AccountID: account.ID,
+				Day:       day,
+				Type:      charge.Type,
+			})
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+
+			balance -= charge.AmountCents
+			transaction, err := q.CreateTransaction(ctx, CreateTransactionParams{
+				AccountID:         account.ID,
+				Type:              charge.Type,
+				AmountCents:       -charge.AmountCents,
+				BalanceAfterCents: balance,
+			})
+			if err != nil {
+				return err
+			}
+			_, err = q.CreateOverdraftAccrual(ctx, CreateOverdraftAccrualParams{
+				AccountID:     account.ID,
+				Day:           day,
+				Type:          charge.Type,
+				TransactionID: transaction.ID,
+			})
+			if err != nil {
+				return err
+			}
+			result.Transactions = append(result.Transactions, transaction)
+			postings = append(postings,
+				accountPosting(account, transaction),
+				systemPosting(SystemAccountKindFeeIncome, account.Currency, charge.AmountCents),
+			)
+		}
+		if len(result.Transactions) == 0 {
+			return nil
+		}
+
+		result.Account, err = q.updateAccountBalance(ctx, UpdateAccountBalanceParams{
+			ID:           account.ID,
+			BalanceCents: balance,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.postJournalEntry(ctx, JournalEntryTypeOverdraftCharge, pgtype.UUID{}, postings...); err != nil {
+			return err
+		}
+
+		for _, transaction := range result.Transactions {
+			if err := q.recordEvent(ctx, EventTypeOverdraftCharged, newTransactionEvent(account, transaction), account.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// overdraftAccrualBatch is how many overdrawn accounts AccrueOverdrafts reads at a time.
+const overdraftAccrualBatch = 100
+
+// AccrueOverdrafts runs AccrueOverdraftTx for the day on every account currently in
+// overdraft and returns how many accounts were charged.
+func (store *SQLStore) AccrueOverdrafts(ctx context.Context, day time.Time) (int, error) {
+	if store.overdraftPolicy == nil {
+		return 0, nil
+	}
+
+	charged := 0
+	var afterID int64
+	for {
+		accounts, err := store.ListOverdrawnAccounts(ctx, ListOverdrawnAccountsParams{AfterID: afterID, Limit: overdraftAccrualBatch})
+		if err != nil {
+			return charged, err
+		}
+		for _, account := range accounts {
+			result, err := store.AccrueOverdraftTx(ctx, AccrueOverdraftTxParams{AccountID: account.ID, Day: day})
+			if err != nil {
+				return charged, fmt.Errorf("accrue overdraft for account %d: %w", account.ID, err)
+			}
+			if len(result.Transactions) > 0 {
+				charged++
+			}
+		}
+		if len(accounts) < overdraftAccrualBatch {
+			return charged, nil
+		}
+		afterID = accounts[len(accounts)-1].ID
+	}
+}
+
+// overdraftDay is the calendar date of day in its own location, which charges are
+// booked once per.
+func overdraftDay(day time.Time) pgtype.Date {
+	year, month, date := day.Date()
+	return pgtype.Date{Time: time.Date(year, month, date, 0, 0, 0, 0, time.UTC), Valid: true}
+}
@@ -0,0 +1,209 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandardOverdraftPolicy(t *testing.T) {
+	policy := StandardOverdraftPolicy{AnnualInterestBps: 1825, DailyFeeCents: 25} // 18.25% a year
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		balance int64
+		want    []OverdraftCharge
+	}{
+		{name: "InCredit", balance: 100},
+		{name: "Zero", balance: 0},
+		{
+			name:    "Overdrawn",
+			balance: -100000, // 0.05% a day
+			want: []OverdraftCharge{
+				{Type: TransactionTypeOverdraftInterest, AmountCents: 50},
+				{Type: TransactionTypeOverdraftFee, AmountCents: 25},
+			},
+		},
+		{
+			// 0.5 cents of interest rounds up
+			name:    "RoundsHalfUp",
+			balance: -1000,
+			want: []OverdraftCharge{
+				{Type: TransactionTypeOverdraftInterest, AmountCents: 1},
+				{Type: TransactionTypeOverdraftFee, AmountCents: 25},
+			},
+		},
+		{
+			name:    "InterestBelowHalfCent",
+			balance: -999,
+			want:    []OverdraftCharge{{Type: TransactionTypeOverdraftFee, AmountCents: 25}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, policy.DailyCharges(Account{BalanceCents: tc.balance}, day))
+		})
+	}
+}
+
+// createOverdraftAccount creates an empty USD account allowed to go limit cents below zero.
+func createOverdraftAccount(t *testing.T, store Store, limit int64) Account {
+	account := createAccountInCurrency(t, store, CurrencyUSD, 0)
+	account, err := store.SetOverdraftLimitTx(context.Background(), SetOverdraftLimitTxParams{
+		AccountID:  account.ID,
+		LimitCents: limit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, limit, account.OverdraftLimitCents)
+	return account
+}
+
+func TestWithdrawMoneyTx_Overdraft(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createOverdraftAccount(t, store, 500)
+
+	result, err := store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 300})
+	require.NoError(t, err)
+	require.Equal(t, int64(-300), result.Account.BalanceCents)
+	require.Equal(t, int64(200), result.Account.AvailableCents())
+
+	_, err = store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 201})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	result, err = store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 200})
+	require.NoError(t, err)
+	require.Equal(t, int64(-500), result.Account.BalanceCents)
+
+	overdrawn, err := store.ListOverdrawnAccounts(ctx, ListOverdrawnAccountsParams{AfterID: account.ID - 1, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, overdrawn, 1)
+	require.Equal(t, account.ID, overdrawn[0].ID)
+}
+
+func TestTransferMoneyTx_Overdraft(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	fromAccount := createOverdraftAccount(t, store, 1000)
+	toAccount := createAccountInCurrency(t, store, CurrencyUSD, 0)
+
+	result, err := store.TransferMoneyTx(ctx, TransferMoneyTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		AmountCents:   1000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-1000), result.FromAccount.BalanceCents)
+
+	result, err = store.TransferMoneyTx(ctx, TransferMoneyTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		AmountCents:   1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+	require.Equal(t, TransferFailureInsufficientBalance, result.Transfer.FailureReason.String)
+}
+
+func TestSetOverdraftLimitTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	account := createOverdraftAccount(t, store, 500)
+
+	_, err := store.SetOverdraftLimitTx(ctx, SetOverdraftLimitTxParams{AccountID: account.ID, LimitCents: -1})
+	require.ErrorIs(t, err, ErrInvalidOverdraftLimit)
+
+	_, err = store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 400})
+	require.NoError(t, err)
+
+	// lowering the limit under the current overdraft blocks further debits
+	account, err = store.SetOverdraftLimitTx(ctx, SetOverdraftLimitTxParams{AccountID: account.ID, LimitCents: 100})
+	require.NoError(t, err)
+	require.Equal(t, int64(-300), account.AvailableCents())
+
+	_, err = store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 1})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+}
+
+func TestAccrueOverdraftTx(t *testing.T) {
+	store := NewStore(testDB, WithOverdraftPolicy(StandardOverdraftPolicy{AnnualInterestBps: 3650, DailyFeeCents: 25}))
+	ctx := context.Background()
+	account := createOverdraftAccount(t, store, 20000)
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 10000})
+	require.NoError(t, err)
+
+	result, err := store.AccrueOverdraftTx(ctx, AccrueOverdraftTxParams{AccountID: account.ID, Day: day})
+	require.NoError(t, err)
+	require.Len(t, result.Transactions, 2)
+	require.Equal(t, TransactionTypeOverdraftInterest, result.Transactions[0].Type)
+	require.Equal(t, int64(-10), result.Transactions[0].AmountCents)
+	require.Equal(t, TransactionTypeOverdraftFee, result.Transactions[1].Type)
+	require.Equal(t, int64(-25), result.Transactions[1].AmountCents)
+	require.Equal(t, int64(-10035), result.Account.BalanceCents)
+
+	posting, err := store.GetPostingByTransaction(ctx, result.Transactions[0].ID)
+	require.NoError(t, err)
+	postings := requireBalancedEntry(t, store, posting.EntryID)
+	require.Len(t, postings, 4)
+	requireSystemPosting(t, store, postings[1], SystemAccountKindFeeIncome, 10)
+
+	// the same day is charged once
+	again, err := store.AccrueOverdraftTx(ctx, AccrueOverdraftTxParams{AccountID: account.ID, Day: day})
+	require.NoError(t, err)
+	require.Empty(t, again.Transactions)
+	require.Equal(t, result.Account.BalanceCents, again.Account.BalanceCents)
+
+	next, err := store.AccrueOverdraftTx(ctx, AccrueOverdraftTxParams{AccountID: account.ID, Day: day.AddDate(0, 0, 1)})
+	require.NoError(t, err)
+	require.Len(t, next.Transactions, 2)
+
+	balance, err := store.GetAccountLedgerBalance(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, next.Account.BalanceCents, balance)
+}
+
+func TestAccrueOverdraftTx_CallerKeyLikeOldChargeReference(t *testing.T) {
+	store := NewStore(testDB, WithOverdraftPolicy(StandardOverdraftPolicy{DailyFeeCents: 25}))
+	ctx := context.Background()
+	account := createOverdraftAccount(t, store, 20000)
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := store.WithdrawMoneyTx(ctx, AccountTransactionParams{AccountID: account.ID, Amount: 10000})
+	require.NoError(t, err)
+	// charges used to be recognized by this reference, which a deposit could claim first
+	_, err = store.DepositMoneyTx(ctx, AccountTransactionParams{
+		AccountID:      account.ID,
+		Amount:         1,
+		IdempotencyKey: pgtype.Text{String: fmt.Sprintf("overdraft:%d:2026-03-01:overdraft_fee", account.ID), Valid: true},
+	})
+	require.NoError(t, err)
+
+	result, err := store.AccrueOverdraftTx(ctx, AccrueOverdraftTxParams{AccountID: account.ID, Day: day})
+	require.NoError(t, err)
+	require.Len(t, result.Transactions, 1)
+	require.Equal(t, TransactionTypeOverdraftFee, result.Transactions[0].Type)
+	require.False(t, result.Transactions[0].Reference.Valid)
+	require.Equal(t, int64(-10024), result.Account.BalanceCents)
+
+	accrual, err := store.GetOverdraftAccrual(ctx, GetOverdraftAccrualParams{
+		AccountID: account.ID,
+		Day:       pgtype.Date{Time: day, Valid: true},
+		Type:      TransactionTypeOverdraftFee,
+	})
+	require.NoError(t, err)
+	require.Equal(t, result.Transactions[0].ID, accrual.TransactionID)
+}
+
+func TestAccrueOverdraftTx_NoPolicy(t *testing.T) {
+	store := NewStore(testDB)
+	result, err := store.AccrueOverdraftTx(context.Background(), AccrueOverdraftTxParams{AccountID: 1, Day: time.Now()})
+	require.NoError(t, err)
+	require.Empty(t, result.Transactions)
+}
@@ -0,0 +1,458 @@
+package sqlc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrInvalidCursor is returned for a page cursor that is malformed or was issued by
+// another list.
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// Page is one page of a list. NextCursor and PrevCursor are opaque; pass one back as
+// the Cursor of the next request to move through the list. Each is empty when there is
+// nothing further in its direction, and both are empty on an empty page.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	PrevCursor string
+}
+
+// PageParams selects a page of a list without filters: the first one when Cursor is
+// empty, otherwise the one a previous page's cursor points at.
+type PageParams struct {
+	Cursor string
+	Limit  int32
+}
+
+// cursor is what a page cursor encodes: the key of the row the page starts next to and
+// which way it runs. List names the list it was issued for, so a cursor can't be used
+// to page through a different one.
+type cursor[K any] struct {
+	List     string `json:"l"`
+	Key      K      `json:"k"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// timeKey orders lists by creation time, with the id breaking ties.
+type timeKey[ID any] struct {
+	CreatedAt pgtype.Timestamptz `json:"t"`
+	ID        ID                 `json:"i"`
+}
+
+func encodeCursor[K any](c cursor[K]) string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		// Keys are ids and timestamps, which always marshal
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor[K any](list, s string) (cursor[K], error) {
+	var c cursor[K]
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(data, &c) != nil || c.List != list {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// pager pages through one list with a pair of keyset queries. after returns the rows
+// following a key in list order; before returns the rows preceding it, nearest first.
+// start is the key the first page follows.
+type pager[T, K any] struct {
+	list   string
+	start  K
+	key    func(T) K
+	after  func(ctx context.Context, key K, limit int32) ([]T, error)
+	before func(ctx context.Context, key K, limit int32) ([]T, error)
+}
+
+// page reads the page the cursor points at. It asks for one row more than limit to
+// learn whether the list goes on past the page without a second query.
+func (p pager[T, K]) page(ctx context.Context, cursorString string, limit int32) (Page[T], error) {
+	c := cursor[K]{List: p.list, Key: p.start}
+	if cursorString != "" {
+		var err error
+		if c, err = decodeCursor[K](p.list, cursorString); err != nil {
+			return Page[T]{}, err
+		}
+	}
+
+	read := p.after
+	if c.Backward {
+		read = p.before
+	}
+	items, err := read(ctx, c.Key, limit+1)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	more := len(items) > int(limit)
+	if more {
+		items = items[:limit]
+	}
+	page := Page[T]{Items: items}
+	if len(items) == 0 {
+		return page, nil
+	}
+
+	if c.Backward {
+		slices.Reverse(items)
+	}
+	first, last := p.key(items[0]), p.key(items[len(items)-1])
+	// A cursor always comes from a neighbouring page, so the list goes on in the
+	// direction it was followed from
+	if (c.Backward && more) || (!c.Backward && cursorString != "") {
+		page.PrevCursor = encodeCursor(cursor[K]{List: p.list, Key: first, Backward: true})
+	}
+	if c.Backward || more {
+		page.NextCursor = encodeCursor(cursor[K]{List: p.list, Key: last})
+	}
+	return page, nil
+}
+
+// The first page of a list in newest first order follows a key later than every row,
+// and one in oldest first order a key earlier than every row.
+var (
+	afterNewest = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	afterOldest = pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true}
+	// noUUID sorts before every generated id
+	noUUID = pgtype.UUID{Valid: true}
+)
+
+// ListAccountsPage pages through every account by id.
+func (q storeQueries) ListAccountsPage(ctx context.Context, arg PageParams) (Page[Account], error) {
+	return pager[Account, int64]{
+		list:  "accounts",
+		start: 0,
+		key:   func(a Account) int64 { return a.ID },
+		after: func(ctx context.Context, key int64, limit int32) ([]Account, error) {
+			return q.ListAccounts(ctx, ListAccountsParams{AfterID: key, Limit: limit})
+		},
+		before: func(ctx context.Context, key int64, limit int32) ([]Account, error) {
+			return q.ListAccountsBefore(ctx, ListAccountsBeforeParams{BeforeID: key, Limit: limit})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+type ListAccountsByOwnerPageParams struct {
+	OwnerID pgtype.UUID
+	Cursor  string
+	Limit   int32
+}
+
+// ListAccountsByOwnerPage pages through the owner's accounts by id.
+func (q storeQueries) ListAccountsByOwnerPage(ctx context.Context, arg ListAccountsByOwnerPageParams) (Page[Account], error) {
+	return pager[Account, int64]{
+		list:  "accounts_by_owner",
+		start: 0,
+		key:   func(a Account) int64 { return a.ID },
+		after: func(ctx context.Context, key int64, limit int32) ([]Account, error) {
+			return q.ListAccountsByOwner(ctx, ListAccountsByOwnerParams{OwnerID: arg.OwnerID, AfterID: key, Limit: limit})
+		},
+		before: func(ctx context.Context, key int64, limit int32) ([]Account, error) {
+			return q.ListAccountsByOwnerBefore(ctx, ListAccountsByOwnerBeforeParams{OwnerID: arg.OwnerID, BeforeID: key, Limit: limit})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+// ListUsersPage pages through users by id.
+func (q storeQueries) ListUsersPage(ctx context.Context, arg PageParams) (Page[ListUsersRow], error) {
+	return pager[ListUsersRow, pgtype.UUID]{
+		list:  "users",
+		start: noUUID,
+		key:   func(u ListUsersRow) pgtype.UUID { return u.ID },
+		after: func(ctx context.Context, key pgtype.UUID, limit int32) ([]ListUsersRow, error) {
+			return q.ListUsers(ctx, ListUsersParams{AfterID: key, Limit: limit})
+		},
+		before: func(ctx context.Context, key pgtype.UUID, limit int32) ([]ListUsersRow, error) {
+			rows, err := q.ListUsersBefore(ctx, ListUsersBeforeParams{BeforeID: key, Limit: limit})
+			if err != nil {
+				return nil, err
+			}
+			users := make([]ListUsersRow, len(rows))
+			for i, row := range rows {
+				users[i] = ListUsersRow(row)
+			}
+			return users, nil
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+type ListTransactionsPageParams struct {
+	AccountID int64
+	Cursor    string
+	Limit     int32
+}
+
+// ListTransactionsPage pages through the account's transactions, newest first.
+func (q storeQueries) ListTransactionsPage(ctx context.Context, arg ListTransactionsPageParams) (Page[Transaction], error) {
+	return pager[Transaction, timeKey[pgtype.UUID]]{
+		list:  "transactions",
+		start: timeKey[pgtype.UUID]{CreatedAt: afterNewest, ID: noUUID},
+		key: func(t Transaction) timeKey[pgtype.UUID] {
+			return timeKey[pgtype.UUID]{CreatedAt: t.CreatedAt, ID: t.ID}
+		},
+		after: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]Transaction, error) {
+			return q.ListTransactions(ctx, ListTransactionsParams{
+				AccountID:      arg.AccountID,
+				AfterCreatedAt: key.CreatedAt,
+				AfterID:        key.ID,
+				Limit:          limit,
+			})
+		},
+		before: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]Transaction, error) {
+			return q.ListTransactionsBefore(ctx, ListTransactionsBeforeParams{
+				AccountID:       arg.AccountID,
+				BeforeCreatedAt: key.CreatedAt,
+				BeforeID:        key.ID,
+				Limit:           limit,
+			})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+func transferKey(t Transfer) timeKey[pgtype.UUID] {
+	return timeKey[pgtype.UUID]{CreatedAt: t.CreatedAt, ID: t.ID}
+}
+
+// ListTransfersPage pages through every transfer, newest first.
+func (q storeQueries) ListTransfersPage(ctx context.Context, arg PageParams) (Page[Transfer], error) {
+	return pager[Transfer, timeKey[pgtype.UUID]]{
+		list:  "transfers",
+		start: timeKey[pgtype.UUID]{CreatedAt: afterNewest, ID: noUUID},
+		key:   transferKey,
+		after: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]Transfer, error) {
+			return q.ListTransfers(ctx, ListTransfersParams{AfterCreatedAt: key.CreatedAt, AfterID: key.ID, Limit: limit})
+		},
+		before: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]Transfer, error) {
+			return q.ListTransfersBefore(ctx, ListTransfersBeforeParams{BeforeCreatedAt: key.CreatedAt, BeforeID: key.ID, Limit: limit})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+type ListTransfersByAccountPageParams struct {
+	AccountID int64
+	Cursor    string
+	Limit     int32
+}
+
+// ListTransfersByAccountPage pages through the account's incoming and outgoing
+// transfers, newest first.
+func (q storeQueries) ListTransfersByAccountPage(ctx context.Context, arg ListTransfersByAccountPageParams) (Page[Transfer], error) {
+	return pager[Transfer, timeKey[pgtype.UUID]]{
+		list:  "transfers_by_account",
+		start: timeKey[pgtype.UUID]{CreatedAt: afterNewest, ID: noUUID},
+		key:   transferKey,
+		after: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]Transfer, error) {
+			return q.ListTransfersByAccount(ctx, ListTransfersByAccountParams{
+				AccountID:      arg.AccountID,
+				AfterCreatedAt: key.CreatedAt,
+				AfterID:        key.ID,
+				Limit:          limit,
+			})
+		},
+		before: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]Transfer, error) {
+			return q.ListTransfersByAccountBefore(ctx, ListTransfersByAccountBeforeParams{
+				AccountID:       arg.AccountID,
+				BeforeCreatedAt: key.CreatedAt,
+				BeforeID:        key.ID,
+				Limit:           limit,
+			})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+type ListHoldsByAccountPageParams struct {
+	AccountID int64
+	Cursor    string
+	Limit     int32
+}
+
+// ListHoldsByAccountPage pages through the account's holds, newest first.
+func (q storeQueries) ListHoldsByAccountPage(ctx context.Context, arg ListHoldsByAccountPageParams) (Page[Hold], error) {
+	return pager[Hold, timeKey[pgtype.UUID]]{
+		list:  "holds_by_account",
+		start: timeKey[pgtype.UUID]{CreatedAt: afterNewest, ID: noUUID},
+		key: func(h Hold) timeKey[pgtype.UUID] {
+			return timeKey[pgtype.UUID]{CreatedAt: h.CreatedAt, ID: h.ID}
+		},
+		after: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]Hold, error) {
+			return q.ListHoldsByAccount(ctx, ListHoldsByAccountParams{
+				AccountID:      arg.AccountID,
+				AfterCreatedAt: key.CreatedAt,
+				AfterID:        key.ID,
+				Limit:          limit,
+			})
+		},
+		before: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]Hold, error) {
+			return q.ListHoldsByAccountBefore(ctx, ListHoldsByAccountBeforeParams{
+				AccountID:       arg.AccountID,
+				BeforeCreatedAt: key.CreatedAt,
+				BeforeID:        key.ID,
+				Limit:           limit,
+			})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+type ListAccountStatusEventsPageParams struct {
+	AccountID int64
+	Cursor    string
+	Limit     int32
+}
+
+// ListAccountStatusEventsPage pages through the account's status changes, newest first.
+func (q storeQueries) ListAccountStatusEventsPage(ctx context.Context, arg ListAccountStatusEventsPageParams) (Page[AccountStatusEvent], error) {
+	return pager[AccountStatusEvent, timeKey[int64]]{
+		list:  "account_status_events",
+		start: timeKey[int64]{CreatedAt: afterNewest},
+		key: func(e AccountStatusEvent) timeKey[int64] {
+			return timeKey[int64]{CreatedAt: e.CreatedAt, ID: e.ID}
+		},
+		after: func(ctx context.Context, key timeKey[int64], limit int32) ([]AccountStatusEvent, error) {
+			return q.ListAccountStatusEvents(ctx, ListAccountStatusEventsParams{
+				AccountID:      arg.AccountID,
+				AfterCreatedAt: key.CreatedAt,
+				AfterID:        key.ID,
+				Limit:          limit,
+			})
+		},
+		before: func(ctx context.Context, key timeKey[int64], limit int32) ([]AccountStatusEvent, error) {
+			return q.ListAccountStatusEventsBefore(ctx, ListAccountStatusEventsBeforeParams{
+				AccountID:       arg.AccountID,
+				BeforeCreatedAt: key.CreatedAt,
+				BeforeID:        key.ID,
+				Limit:           limit,
+			})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+type ListTransferSchedulesByAccountPageParams struct {
+	FromAccountID int64
+	Cursor        string
+	Limit         int32
+}
+
+// ListTransferSchedulesByAccountPage pages through the schedules paying from the
+// account, newest first.
+func (q storeQueries) ListTransferSchedulesByAccountPage(ctx context.Context, arg ListTransferSchedulesByAccountPageParams) (Page[TransferSchedule], error) {
+	return pager[TransferSchedule, timeKey[pgtype.UUID]]{
+		list:  "transfer_schedules_by_account",
+		start: timeKey[pgtype.UUID]{CreatedAt: afterNewest, ID: noUUID},
+		key: func(s TransferSchedule) timeKey[pgtype.UUID] {
+			return timeKey[pgtype.UUID]{CreatedAt: s.CreatedAt, ID: s.ID}
+		},
+		after: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]TransferSchedule, error) {
+			return q.ListTransferSchedulesByAccount(ctx, ListTransferSchedulesByAccountParams{
+				FromAccountID:  arg.FromAccountID,
+				AfterCreatedAt: key.CreatedAt,
+				AfterID:        key.ID,
+				Limit:          limit,
+			})
+		},
+		before: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]TransferSchedule, error) {
+			return q.ListTransferSchedulesByAccountBefore(ctx, ListTransferSchedulesByAccountBeforeParams{
+				FromAccountID:   arg.FromAccountID,
+				BeforeCreatedAt: key.CreatedAt,
+				BeforeID:        key.ID,
+				Limit:           limit,
+			})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+type ListTransferScheduleRunsPageParams struct {
+	ScheduleID pgtype.UUID
+	Cursor     string
+	Limit      int32
+}
+
+// ListTransferScheduleRunsPage pages through the schedule's runs, newest first.
+func (q storeQueries) ListTransferScheduleRunsPage(ctx context.Context, arg ListTransferScheduleRunsPageParams) (Page[TransferScheduleRun], error) {
+	return pager[TransferScheduleRun, int64]{
+		list:  "transfer_schedule_runs",
+		start: math.MaxInt64,
+		key:   func(r TransferScheduleRun) int64 { return r.ID },
+		after: func(ctx context.Context, key int64, limit int32) ([]TransferScheduleRun, error) {
+			return q.ListTransferScheduleRuns(ctx, ListTransferScheduleRunsParams{ScheduleID: arg.ScheduleID, AfterID: key, Limit: limit})
+		},
+		before: func(ctx context.Context, key int64, limit int32) ([]TransferScheduleRun, error) {
+			return q.ListTransferScheduleRunsBefore(ctx, ListTransferScheduleRunsBeforeParams{ScheduleID: arg.ScheduleID, BeforeID: key, Limit: limit})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+type ListWebhookEndpointsByOwnerPageParams struct {
+	OwnerID pgtype.UUID
+	Cursor  string
+	Limit   int32
+}
+
+// ListWebhookEndpointsByOwnerPage pages through the owner's endpoints, oldest first.
+func (q storeQueries) ListWebhookEndpointsByOwnerPage(ctx context.Context, arg ListWebhookEndpointsByOwnerPageParams) (Page[WebhookEndpoint], error) {
+	return pager[WebhookEndpoint, timeKey[pgtype.UUID]]{
+		list:  "webhook_endpoints_by_owner",
+		start: timeKey[pgtype.UUID]{CreatedAt: afterOldest, ID: noUUID},
+		key: func(e WebhookEndpoint) timeKey[pgtype.UUID] {
+			return timeKey[pgtype.UUID]{CreatedAt: e.CreatedAt, ID: e.ID}
+		},
+		after: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]WebhookEndpoint, error) {
+			return q.ListWebhookEndpointsByOwner(ctx, ListWebhookEndpointsByOwnerParams{
+				OwnerID:        arg.OwnerID,
+				AfterCreatedAt: key.CreatedAt,
+				AfterID:        key.ID,
+				Limit:          limit,
+			})
+		},
+		before: func(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]WebhookEndpoint, error) {
+			return q.ListWebhookEndpointsByOwnerBefore(ctx, ListWebhookEndpointsByOwnerBeforeParams{
+				OwnerID:         arg.OwnerID,
+				BeforeCreatedAt: key.CreatedAt,
+				BeforeID:        key.ID,
+				Limit:           limit,
+			})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
+
+type ListWebhookDeliveriesByEndpointPageParams struct {
+	EndpointID pgtype.UUID
+	Status     NullWebhookDeliveryStatus
+	Cursor     string
+	Limit      int32
+}
+
+// ListWebhookDeliveriesByEndpointPage pages through the endpoint's deliveries, newest
+// first, optionally only those in one status.
+func (q storeQueries) ListWebhookDeliveriesByEndpointPage(ctx context.Context, arg ListWebhookDeliveriesByEndpointPageParams) (Page[WebhookDelivery], error) {
+	return pager[WebhookDelivery, int64]{
+		list:  "webhook_deliveries_by_endpoint",
+		start: math.MaxInt64,
+		key:   func(d WebhookDelivery) int64 { return d.ID },
+		after: func(ctx context.Context, key int64, limit int32) ([]WebhookDelivery, error) {
+			return q.ListWebhookDeliveriesByEndpoint(ctx, ListWebhookDeliveriesByEndpointParams{
+				EndpointID: arg.EndpointID,
+				Status:     arg.Status,
+				AfterID:    key,
+				Limit:      limit,
+			})
+		},
+		before: func(ctx context.Context, key int64, limit int32) ([]WebhookDelivery, error) {
+			return q.ListWebhookDeliveriesByEndpointBefore(ctx, ListWebhookDeliveriesByEndpointBeforeParams{
+				EndpointID: arg.EndpointID,
+				Status:     arg.Status,
+				BeforeID:   key,
+				Limit:      limit,
+			})
+		},
+	}.page(ctx, arg.Cursor, arg.Limit)
+}
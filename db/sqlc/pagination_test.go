@@ -0,0 +1,88 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// numberPager pages through 1..n in ascending order, the way the keyset queries would.
+func numberPager(n int) pager[int, int] {
+	return pager[int, int]{
+		list:  "numbers",
+		start: 0,
+		key:   func(i int) int { return i },
+		after: func(_ context.Context, key int, limit int32) ([]int, error) {
+			var items []int
+			for i := key + 1; i <= n && len(items) < int(limit); i++ {
+				items = append(items, i)
+			}
+			return items, nil
+		},
+		before: func(_ context.Context, key int, limit int32) ([]int, error) {
+			var items []int
+			for i := key - 1; i >= 1 && len(items) < int(limit); i-- {
+				items = append(items, i)
+			}
+			return items, nil
+		},
+	}
+}
+
+func TestPagerWalksBothWays(t *testing.T) {
+	ctx := context.Background()
+	p := numberPager(7)
+
+	first, err := p.page(ctx, "", 3)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, first.Items)
+	require.Empty(t, first.PrevCursor)
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := p.page(ctx, first.NextCursor, 3)
+	require.NoError(t, err)
+	require.Equal(t, []int{4, 5, 6}, second.Items)
+
+	last, err := p.page(ctx, second.NextCursor, 3)
+	require.NoError(t, err)
+	require.Equal(t, []int{7}, last.Items)
+	require.Empty(t, last.NextCursor)
+
+	back, err := p.page(ctx, last.PrevCursor, 3)
+	require.NoError(t, err)
+	require.Equal(t, second, back)
+
+	back, err = p.page(ctx, back.PrevCursor, 3)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, back.Items)
+	require.Empty(t, back.PrevCursor)
+	require.Equal(t, first.NextCursor, back.NextCursor)
+}
+
+func TestPagerExactFit(t *testing.T) {
+	page, err := numberPager(3).page(context.Background(), "", 3)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, page.Items)
+	require.Empty(t, page.NextCursor)
+	require.Empty(t, page.PrevCursor)
+
+	page, err = numberPager(0).page(context.Background(), "", 3)
+	require.NoError(t, err)
+	require.Empty(t, page.Items)
+	require.Empty(t, page.NextCursor)
+}
+
+func TestPagerInvalidCursor(t *testing.T) {
+	p := numberPager(5)
+	other := numberPager(5)
+	other.list = "others"
+
+	page, err := other.page(context.Background(), "", 2)
+	require.NoError(t, err)
+
+	for _, cursor := range []string{"not base64!", "bm90IGpzb24", page.NextCursor} {
+		_, err := p.page(context.Background(), cursor, 2)
+		require.ErrorIs(t, err, ErrInvalidCursor, cursor)
+	}
+}
@@ -0,0 +1,44 @@
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ChangePasswordTxParams struct {
+	UserID          pgtype.UUID
+	NewPasswordHash string
+	// VerifyCurrent is called with the stored hash while the user row is locked.
+	// Returning an error aborts the change, so a concurrent update can never be overwritten
+	// by a caller that verified against a stale hash.
+	VerifyCurrent func(currentHash string) error
+}
+
+// ChangePasswordTx replaces a user's password hash. This is the only store operation that writes
+// users.password_hash after creation; UpdateUser deliberately cannot touch it.
+func (store *SQLStore) ChangePasswordTx(ctx context.Context, arg ChangePasswordTxParams) (User, error) {
+	var user User
+
+	err := store.executeTransaction(ctx, TxChangePassword, func(q storeQueries) error {
+		var err error
+		user, err = q.GetUserForUpdate(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		if arg.VerifyCurrent != nil {
+			if err := arg.VerifyCurrent(user.PasswordHash); err != nil {
+				return err
+			}
+		}
+
+		user, err = q.UpdateUserPassword(ctx, UpdateUserPasswordParams{
+			ID:           arg.UserID,
+			PasswordHash: arg.NewPasswordHash,
+		})
+		return err
+	})
+
+	return user, err
+}
@@ -0,0 +1,115 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAccountBalanceTotals(t *testing.T) {
+	_, q := createTestTx(t)
+	ctx := context.Background()
+	account := createRandomAccountWithQueries(t, q)
+
+	for _, amount := range []int64{300, -120} {
+		_, err := q.CreateTransaction(ctx, CreateTransactionParams{
+			AccountID:   account.ID,
+			Type:        TransactionTypeDeposit,
+			AmountCents: amount,
+		})
+		require.NoError(t, err)
+	}
+
+	rows, err := q.ListAccountBalanceTotals(ctx, ListAccountBalanceTotalsParams{
+		AfterID:    account.ID - 1,
+		AccountIds: []int64{account.ID},
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, account.ID, rows[0].ID)
+	require.Equal(t, account.BalanceCents, rows[0].BalanceCents)
+	require.Equal(t, int64(180), rows[0].TransactionsTotalCents)
+	require.Zero(t, rows[0].LedgerTotalCents)
+
+	// no account_ids pages through every account
+	rows, err = q.ListAccountBalanceTotals(ctx, ListAccountBalanceTotalsParams{AfterID: account.ID - 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, account.ID, rows[0].ID)
+}
+
+func TestListBalanceChainBreaks(t *testing.T) {
+	_, q := createTestTx(t)
+	ctx := context.Background()
+	account := createRandomAccountWithQueries(t, q)
+
+	var transactions []Transaction
+	for _, leg := range []struct{ amount, after int64 }{
+		{amount: 100, after: 100},
+		{amount: 50, after: 140}, // should be 150
+		{amount: 10, after: 160},
+		{amount: 40, after: 200},
+	} {
+		transaction, err := q.CreateTransaction(ctx, CreateTransactionParams{
+			AccountID:         account.ID,
+			Type:              TransactionTypeDeposit,
+			AmountCents:       leg.amount,
+			BalanceAfterCents: leg.after,
+		})
+		require.NoError(t, err)
+		transactions = append(transactions, transaction)
+	}
+	require.Less(t, transactions[0].Seq, transactions[1].Seq)
+
+	breaks, err := q.ListBalanceChainBreaks(ctx, []int64{account.ID})
+	require.NoError(t, err)
+	require.Len(t, breaks, 2)
+
+	require.Equal(t, transactions[1].ID, breaks[0].ID)
+	require.Equal(t, int64(100), breaks[0].PreviousBalanceCents)
+	require.Equal(t, int64(140), breaks[0].BalanceAfterCents)
+	// the next row is consistent with its amount but chains from the bad balance
+	require.Equal(t, transactions[2].ID, breaks[1].ID)
+}
+
+func TestOpenReconciliationIncident(t *testing.T) {
+	_, q := createTestTx(t)
+	ctx := context.Background()
+	account := createRandomAccountWithQueries(t, q)
+
+	arg := OpenReconciliationIncidentParams{
+		AccountID:     account.ID,
+		Kind:          DriftKindTransactionsTotal,
+		ExpectedCents: 100,
+		ActualCents:   90,
+	}
+	incident, err := q.OpenReconciliationIncident(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, incident.AccountID)
+	require.False(t, incident.ResolvedAt.Valid)
+
+	// one open incident per account and kind
+	_, err = q.OpenReconciliationIncident(ctx, arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = q.OpenReconciliationIncident(ctx, OpenReconciliationIncidentParams{
+		AccountID: account.ID,
+		Kind:      DriftKindLedgerTotal,
+	})
+	require.NoError(t, err)
+
+	resolved, err := q.ResolveReconciliationIncident(ctx, incident.ID)
+	require.NoError(t, err)
+	require.True(t, resolved.ResolvedAt.Valid)
+
+	open, err := q.ListOpenReconciliationIncidents(ctx, account.ID)
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	require.Equal(t, DriftKindLedgerTotal, open[0].Kind)
+
+	// a resolved incident makes room for a new one
+	_, err = q.OpenReconciliationIncident(ctx, arg)
+	require.NoError(t, err)
+}
@@ -0,0 +1,200 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrTransferAlreadyReversed = errors.New("transfer has already been reversed")
+	ErrReversalAmountTooLarge  = errors.New("reversal amount exceeds the transfer amount")
+)
+
+type ReverseTransferTxParams struct {
+	TransferID pgtype.UUID
+	// AmountCents is the amount returned to the sender, in the sender's currency.
+	// Zero reverses the whole transfer.
+	AmountCents int64
+	Reason      ReversalReason
+}
+
+type ReverseTransferTxResult struct {
+	Reversal TransferReversal
+	Transfer Transfer
+	// FromAccount and ToAccount are the accounts of the original transfer.
+	FromAccount Account
+	ToAccount   Account
+	// DebitTx takes the money back from the original recipient, CreditTx returns it to the sender.
+	DebitTx  Transaction
+	CreditTx Transaction
+}
+
+// ReverseTransferTx undoes all or part of a completed transfer. It writes a compensating
+// transaction on each account, journals them, records the reversal against the transfer and
+// moves the transfer to reversed, all in one database transaction. A transfer can be reversed once;
+// a partial reversal still settles it as reversed.
+//
+// The transfer row is locked first so concurrent reversals of the same transfer queue up,
+// then both accounts are locked in the same order TransferMoneyTx uses.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	if arg.AmountCents < 0 {
+		return result, ErrInvalidAmount
+	}
+	if !validReversalReason(arg.Reason) {
+		return result, fmt.Errorf("unknown reversal reason %q", arg.Reason)
+	}
+
+	err := store.executeTransaction(ctx, TxReverseTransfer, func(q storeQueries) error {
+		var err error
+		result.Transfer, err = q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		transfer := result.Transfer
+		if transfer.Status == TransferStatusReversed {
+			return ErrTransferAlreadyReversed
+		}
+		if !transfer.Status.CanTransitionTo(TransferStatusReversed) {
+			return fmt.Errorf("%w: %s to %s", ErrInvalidTransferTransition, transfer.Status, TransferStatusReversed)
+		}
+
+		amount := arg.AmountCents
+		if amount == 0 {
+			amount = transfer.AmountCents
+		}
+		if amount > transfer.AmountCents {
+			return ErrReversalAmountTooLarge
+		}
+
+		// A cross-currency transfer is unwound at its original rate, so the recipient
+		// gives back what they were credited for that share of the transfer
+		recipientAmount := amount
+		if transfer.ExchangeRate.Valid {
+			if amount == transfer.AmountCents {
+				recipientAmount = transfer.ToAmountCents.Int64
+			} else if recipientAmount, err = convertAmount(amount, transfer.ExchangeRate); err != nil {
+				return err
+			}
+			// A share too small to be worth a cent to the recipient would return money to
+			// the sender for nothing
+			if recipientAmount <= 0 {
+				return ErrConvertedAmountZero
+			}
+			// A transfer is reversed at most once, so none of its credit has been taken
+			// back yet; rounding must not take back more than that
+			recipientAmount = min(recipientAmount, transfer.ToAmountCents.Int64)
+		}
+
+		result.FromAccount, result.ToAccount, err = q.lockAccountPair(ctx, transfer.FromAccountID, transfer.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		// The money goes back the other way, so the original recipient is debited
+		if err := store.checkDebit(result.ToAccount); err != nil {
+			return err
+		}
+		if err := store.checkCredit(result.FromAccount); err != nil {
+			return err
+		}
+
+		// The recipient may have spent the money since
+		if result.ToAccount.AvailableCents() < recipientAmount {
+			return ErrInsufficientBalance
+		}
+
+		// Take the money back from the recipient
+		result.DebitTx, err = q.CreateTransaction(ctx, CreateTransactionParams{
+			AccountID:         transfer.ToAccountID,
+			Type:              TransactionTypeReversal,
+			AmountCents:       -recipientAmount,
+			BalanceAfterCents: result.ToAccount.BalanceCents - recipientAmount,
+			RelatedAccountID:  pgtype.Int8{Int64: transfer.FromAccountID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Return it to the sender
+		result.CreditTx, err = q.CreateTransaction(ctx, CreateTransactionParams{
+			AccountID:         transfer.FromAccountID,
+			Type:              TransactionTypeReversal,
+			AmountCents:       amount,
+			BalanceAfterCents: result.FromAccount.BalanceCents + amount,
+			RelatedAccountID:  pgtype.Int8{Int64: transfer.ToAccountID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ToAccount, err = q.updateAccountBalance(ctx, UpdateAccountBalanceParams{
+			ID:           transfer.ToAccountID,
+			BalanceCents: result.ToAccount.BalanceCents - recipientAmount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.FromAccount, err = q.updateAccountBalance(ctx, UpdateAccountBalanceParams{
+			ID:           transfer.FromAccountID,
+			BalanceCents: result.FromAccount.BalanceCents + amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.postJournalEntry(ctx, JournalEntryTypeReversal, transfer.ID,
+			movementPostings(result.ToAccount, result.DebitTx, result.FromAccount, result.CreditTx)...,
+		); err != nil {
+			return err
+		}
+
+		result.Reversal, err = q.CreateTransferReversal(ctx, CreateTransferReversalParams{
+			TransferID:          transfer.ID,
+			AmountCents:         amount,
+			Reason:              arg.Reason,
+			DebitTransactionID:  result.DebitTx.ID,
+			CreditTransactionID: result.CreditTx.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = q.transitionTransfer(ctx, transfer, TransferStatusReversed, "")
+		if err != nil {
+			return err
+		}
+
+		return q.recordEvent(ctx, EventTypeTransferReversed, TransferReversedEvent{
+			ReversalID:    result.Reversal.ID.String(),
+			TransferID:    transfer.ID.String(),
+			FromAccountID: transfer.FromAccountID,
+			ToAccountID:   transfer.ToAccountID,
+			AmountCents:   amount,
+			Currency:      result.FromAccount.Currency,
+			Reason:        arg.Reason,
+		}, transfer.FromAccountID, transfer.ToAccountID)
+	})
+	if isUniqueViolation(err, transferReversalsTransferKey) {
+		return result, ErrTransferAlreadyReversed
+	}
+
+	return result, err
+}
+
+// transferReversalsTransferKey enforces a single reversal per transfer.
+const transferReversalsTransferKey = "transfer_reversals_transfer_id_key"
+
+func validReversalReason(reason ReversalReason) bool {
+	switch reason {
+	case ReversalReasonCustomerRequest, ReversalReasonDuplicate, ReversalReasonFraud, ReversalReasonProcessingError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createCompletedTransfer(t *testing.T, store Store, amount int64) TransferMoneyResult {
+	fromAccount := createRandomAccountWithQueries(t, store)
+	toAccount := createRandomAccountWithQueries(t, store)
+
+	_, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{AccountID: fromAccount.ID, Amount: amount})
+	require.NoError(t, err)
+
+	result, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		AmountCents:   amount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCompleted, result.Transfer.Status)
+	return result
+}
+
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	testCases := []struct {
+		name        string
+		amount      int64
+		wantAmount  int64
+		transferred int64
+	}{
+		{name: "Full", amount: 0, wantAmount: 300, transferred: 300},
+		{name: "Partial", amount: 120, wantAmount: 120, transferred: 300},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := createCompletedTransfer(t, store, tc.transferred)
+
+			result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+				TransferID:  original.Transfer.ID,
+				AmountCents: tc.amount,
+				Reason:      ReversalReasonCustomerRequest,
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, TransferStatusReversed, result.Transfer.Status)
+			require.Equal(t, original.Transfer.ProcessedAt, result.Transfer.ProcessedAt)
+
+			require.Equal(t, original.Transfer.ID, result.Reversal.TransferID)
+			require.Equal(t, tc.wantAmount, result.Reversal.AmountCents)
+			require.Equal(t, ReversalReasonCustomerRequest, result.Reversal.Reason)
+			require.Equal(t, result.DebitTx.ID, result.Reversal.DebitTransactionID)
+			require.Equal(t, result.CreditTx.ID, result.Reversal.CreditTransactionID)
+
+			require.Equal(t, original.ToAccount.ID, result.DebitTx.AccountID)
+			require.Equal(t, TransactionTypeReversal, result.DebitTx.Type)
+			require.Equal(t, -tc.wantAmount, result.DebitTx.AmountCents)
+			require.Equal(t, original.FromAccount.ID, result.CreditTx.AccountID)
+			require.Equal(t, tc.wantAmount, result.CreditTx.AmountCents)
+
+			require.Equal(t, original.FromAccount.BalanceCents+tc.wantAmount, result.FromAccount.BalanceCents)
+			require.Equal(t, original.ToAccount.BalanceCents-tc.wantAmount, result.ToAccount.BalanceCents)
+			require.Equal(t, result.FromAccount.BalanceCents, result.CreditTx.BalanceAfterCents)
+			require.Equal(t, result.ToAccount.BalanceCents, result.DebitTx.BalanceAfterCents)
+
+			reversal, err := store.GetTransferReversalByTransfer(context.Background(), original.Transfer.ID)
+			require.NoError(t, err)
+			require.Equal(t, result.Reversal, reversal)
+		})
+	}
+}
+
+func TestReverseTransferTx_Rejected(t *testing.T) {
+	store := NewStore(testDB)
+
+	reversed := createCompletedTransfer(t, store, 100)
+	_, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: reversed.Transfer.ID,
+		Reason:     ReversalReasonDuplicate,
+	})
+	require.NoError(t, err)
+
+	completed := createCompletedTransfer(t, store, 100)
+
+	spent := createCompletedTransfer(t, store, 100)
+	_, err = store.WithdrawMoneyTx(context.Background(), AccountTransactionParams{
+		AccountID: spent.ToAccount.ID,
+		Amount:    spent.ToAccount.BalanceCents,
+	})
+	require.NoError(t, err)
+
+	failed, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
+		FromAccountID: completed.FromAccount.ID,
+		ToAccountID:   completed.ToAccount.ID,
+		AmountCents:   completed.FromAccount.BalanceCents + 1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	testCases := []struct {
+		name    string
+		arg     ReverseTransferTxParams
+		wantErr error
+	}{
+		{
+			name:    "AlreadyReversed",
+			arg:     ReverseTransferTxParams{TransferID: reversed.Transfer.ID, Reason: ReversalReasonDuplicate},
+			wantErr: ErrTransferAlreadyReversed,
+		},
+		{
+			name:    "FailedTransfer",
+			arg:     ReverseTransferTxParams{TransferID: failed.Transfer.ID, Reason: ReversalReasonProcessingError},
+			wantErr: ErrInvalidTransferTransition,
+		},
+		{
+			name:    "AmountTooLarge",
+			arg:     ReverseTransferTxParams{TransferID: completed.Transfer.ID, AmountCents: 101, Reason: ReversalReasonFraud},
+			wantErr: ErrReversalAmountTooLarge,
+		},
+		{
+			name:    "NegativeAmount",
+			arg:     ReverseTransferTxParams{TransferID: completed.Transfer.ID, AmountCents: -1, Reason: ReversalReasonFraud},
+			wantErr: ErrInvalidAmount,
+		},
+		{
+			name:    "RecipientSpentFunds",
+			arg:     ReverseTransferTxParams{TransferID: spent.Transfer.ID, Reason: ReversalReasonFraud},
+			wantErr: ErrInsufficientBalance,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.ReverseTransferTx(context.Background(), tc.arg)
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+
+	// nothing was reversed by the rejected attempts
+	transfer, err := store.GetTransfer(context.Background(), completed.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCompleted, transfer.Status)
+}
+
+func TestConcurrentReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	original := createCompletedTransfer(t, store, 500)
+	n := 5
+
+	errs := make(chan error, n)
+	for range n {
+		go func() {
+			_, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+				TransferID: original.Transfer.ID,
+				Reason:     ReversalReasonCustomerRequest,
+			})
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	for range n {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+	}
+	require.Equal(t, 1, succeeded)
+
+	fromAccount, err := store.GetAccount(context.Background(), original.FromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, original.FromAccount.BalanceCents+500, fromAccount.BalanceCents)
+}
@@ -3,15 +3,81 @@ package sqlc
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/RakibRahman/fincore-api/db/dberr"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// we'll expand Quesries funcality by embedding to store. store will provides necessay functions to execute db queries and transactions.
-type Store struct {
-	*Queries // all indivdual Queries functions will be available to Store
-	pool     *pgxpool.Pool
+// Store runs every query and transaction of the service. NewStore runs them on Postgres
+// and NewMemoryStore in memory; consumers depend on this interface, or on a subset of
+// it, so they can be tested against the mock in db/mock instead.
+type Store interface {
+	Querier
+
+	ListAccountsPage(ctx context.Context, arg PageParams) (Page[Account], error)
+	ListAccountsByOwnerPage(ctx context.Context, arg ListAccountsByOwnerPageParams) (Page[Account], error)
+	ListUsersPage(ctx context.Context, arg PageParams) (Page[ListUsersRow], error)
+	ListTransactionsPage(ctx context.Context, arg ListTransactionsPageParams) (Page[Transaction], error)
+	SearchTransactionsPage(ctx context.Context, arg SearchTransactionsPageParams) (Page[Transaction], error)
+	ListTransfersPage(ctx context.Context, arg PageParams) (Page[Transfer], error)
+	ListTransfersByAccountPage(ctx context.Context, arg ListTransfersByAccountPageParams) (Page[Transfer], error)
+	ListHoldsByAccountPage(ctx context.Context, arg ListHoldsByAccountPageParams) (Page[Hold], error)
+	ListAccountStatusEventsPage(ctx context.Context, arg ListAccountStatusEventsPageParams) (Page[AccountStatusEvent], error)
+	ListTransferSchedulesByAccountPage(ctx context.Context, arg ListTransferSchedulesByAccountPageParams) (Page[TransferSchedule], error)
+	ListTransferScheduleRunsPage(ctx context.Context, arg ListTransferScheduleRunsPageParams) (Page[TransferScheduleRun], error)
+	ListWebhookEndpointsByOwnerPage(ctx context.Context, arg ListWebhookEndpointsByOwnerPageParams) (Page[WebhookEndpoint], error)
+	ListWebhookDeliveriesByEndpointPage(ctx context.Context, arg ListWebhookDeliveriesByEndpointPageParams) (Page[WebhookDelivery], error)
+
+	TransferMoneyTx(ctx context.Context, arg TransferMoneyTxParams) (TransferMoneyResult, error)
+	DepositMoneyTx(ctx context.Context, arg AccountTransactionParams) (AccountTransactionResult, error)
+	WithdrawMoneyTx(ctx context.Context, arg AccountTransactionParams) (AccountTransactionResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	FreezeAccountTx(ctx context.Context, arg ChangeAccountStatusParams) (ChangeAccountStatusResult, error)
+	UnfreezeAccountTx(ctx context.Context, arg ChangeAccountStatusParams) (ChangeAccountStatusResult, error)
+	CloseAccountTx(ctx context.Context, arg ChangeAccountStatusParams) (ChangeAccountStatusResult, error)
+	ChangePasswordTx(ctx context.Context, arg ChangePasswordTxParams) (User, error)
+	PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (PlaceHoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	VoidHoldTx(ctx context.Context, holdID pgtype.UUID) (Hold, error)
+	ExpireHolds(ctx context.Context, now time.Time) (int, error)
+	ScheduleTransfer(ctx context.Context, arg ScheduleTransferParams) (TransferSchedule, error)
+	RunTransferScheduleTx(ctx context.Context, arg RunTransferScheduleTxParams) (RunTransferScheduleTxResult, error)
+	SetOverdraftLimitTx(ctx context.Context, arg SetOverdraftLimitTxParams) (Account, error)
+	AccrueOverdraftTx(ctx context.Context, arg AccrueOverdraftTxParams) (AccrueOverdraftTxResult, error)
+	AccrueOverdrafts(ctx context.Context, day time.Time) (int, error)
+	DispatchOutboxEvents(ctx context.Context, limit int32) (int, error)
+
+	TxStats() map[TxOperation]TxStats
+}
+
+var _ Store = (*SQLStore)(nil)
+
+// SQLStore extends a Querier, which it embeds, with the transactions that span several
+// of its queries. The same transactions run on Postgres and in memory, so both stores
+// enforce the same rules.
+type SQLStore struct {
+	storeQueries
+	db txRunner
+
+	allowFrozenCredits bool
+	overdraftPolicy    OverdraftPolicy
+	txPolicy           TxPolicy
+	txPolicies         map[TxOperation]TxPolicy
+	txStats            *txStatsRecorder
+}
+
+// StoreOption configures optional Store behaviour.
+type StoreOption func(*SQLStore)
+
+// AllowFrozenCredits lets frozen accounts keep receiving deposits and incoming transfers.
+// Debits from a frozen account are refused either way.
+func AllowFrozenCredits(allow bool) StoreOption {
+	return func(store *SQLStore) {
+		store.allowFrozenCredits = allow
+	}
 }
 
 type TransferMoneyResult struct {
@@ -20,209 +86,449 @@ type TransferMoneyResult struct {
 	ToAccount   Account
 	FromTx      Transaction
 	ToTx        Transaction
+	// Replayed is set when the result belongs to an earlier request with the same idempotency key.
+	Replayed bool
 }
 type AccountTransactionResult struct {
 	Transaction Transaction
 	Account     Account
+	// Replayed is set when the result belongs to an earlier request with the same idempotency key.
+	Replayed bool
+}
+
+// NewStore returns a SQLStore running on pool. Errors from its queries and transactions
+// come back translated by dberr.
+func NewStore(pool *pgxpool.Pool, opts ...StoreOption) Store {
+	return newSQLStore(New(dberr.Wrap(pool)), poolRunner{pool: pool}, opts...)
 }
 
-func NewStore(pool *pgxpool.Pool) *Store {
-	return &Store{
-		pool:    pool,
-		Queries: New(pool),
+func newSQLStore(q ledgerQuerier, db txRunner, opts ...StoreOption) *SQLStore {
+	store := &SQLStore{
+		storeQueries: storeQueries{ledgerQuerier: q},
+		db:           db,
+		txPolicy:     DefaultTxPolicy,
+		txStats:      &txStatsRecorder{},
 	}
+	for _, opt := range opts {
+		opt(store)
+	}
+	return store
+}
+
+// ledgerQuerier is a Querier that can also set an account's balance outright. Only a
+// Store's own transactions use it, since they journal every balance they write.
+type ledgerQuerier interface {
+	Querier
+	updateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (Account, error)
 }
 
-// TxFunc is a function that executes database operations within a transaction
-type TxFunc func(q *Queries) error
+// storeQueries is the Querier a Store runs its queries on, with the paginated lists
+// and transaction steps that are built from them.
+type storeQueries struct {
+	ledgerQuerier
+}
 
-var (
-	ErrInsufficientBalance = errors.New("insufficient balance for withdrawal")
-	ErrInvalidAmount       = errors.New("withdrawal amount must be positive")
-)
+// txFunc is a function that executes database operations within a transaction
+type txFunc func(q storeQueries) error
 
-func (store *Store) executeTransaction(ctx context.Context, fn TxFunc) error {
-	tx, err := store.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.ReadCommitted,
+// txRunner begins a transaction, runs fn on its queries, and commits it unless fn
+// fails.
+type txRunner interface {
+	runTx(ctx context.Context, isoLevel pgx.TxIsoLevel, fn func(q ledgerQuerier) error) error
+}
+
+// poolRunner runs transactions on a Postgres pool.
+type poolRunner struct {
+	pool *pgxpool.Pool
+}
+
+func (runner poolRunner) runTx(ctx context.Context, isoLevel pgx.TxIsoLevel, fn func(q ledgerQuerier) error) error {
+	tx, err := runner.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: isoLevel,
 	})
 	if err != nil {
-		return err
+		return dberr.Translate(err)
 	}
 	defer tx.Rollback(ctx)
-	q := New(tx)
-	err = fn(q)
+	err = fn(New(dberr.Wrap(tx)))
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit(ctx)
+	return dberr.Translate(tx.Commit(ctx))
+}
+
+var (
+	ErrInsufficientBalance = errors.New("insufficient balance for withdrawal")
+	ErrInvalidAmount       = errors.New("amount must be positive")
+)
+
+// executeTransaction runs fn in a transaction under op's TxPolicy. fn runs again on
+// every retry, so it must not carry state over from an earlier, rolled back attempt.
+func (store *SQLStore) executeTransaction(ctx context.Context, op TxOperation, fn txFunc) error {
+	policy := store.txPolicyFor(op)
+	return store.retryTransaction(ctx, op, policy, func() error {
+		return store.db.runTx(ctx, policy.IsoLevel, func(q ledgerQuerier) error {
+			return fn(storeQueries{ledgerQuerier: q})
+		})
+	})
+}
+
+// CreateAccount opens an account. An opening balance is funded from the cash_in account
+// with an opening_balance journal entry in the same database transaction, so that the
+// ledger accounts for it like for any later deposit.
+func (store *SQLStore) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	var account Account
+	err := store.executeTransaction(ctx, TxCreateAccount, func(q storeQueries) error {
+		var err error
+		account, err = q.CreateAccount(ctx, arg)
+		if err != nil || account.BalanceCents == 0 {
+			return err
+		}
+		_, err = q.postJournalEntry(ctx, JournalEntryTypeOpeningBalance, pgtype.UUID{},
+			ledgerPosting{accountID: account.ID, currency: account.Currency, amount: account.BalanceCents},
+			systemPosting(SystemAccountKindCashIn, account.Currency, -account.BalanceCents),
+		)
+		return err
+	})
+	return account, err
+}
+
+type TransferMoneyTxParams struct {
+	FromAccountID int64
+	ToAccountID   int64
+	// AmountCents is debited from the sender, in the sender's currency.
+	AmountCents int64
+	// IdempotencyKey is stored as the transfer's reference; see TransferMoneyTx.
+	IdempotencyKey pgtype.Text
+	// ConvertCurrency opts in to transfers between accounts of different currencies,
+	// converted at the latest exchange rate in effect. Without it they fail with ErrCurrencyMismatch.
+	ConvertCurrency bool
+	// Description is stored on both transaction legs.
+	Description pgtype.Text
 }
 
 // TransferMoneyTx performs a money transfer between two accounts within a database transaction.
-// It creates a transfer record, transaction entries for both accounts, and updates account balances.
+// It creates a transfer record, transaction entries for both accounts, a balanced journal entry
+// for the ledger, and updates account balances.
 // The function uses row-level locking (SELECT FOR UPDATE) to prevent race conditions and ensures
 // consistent lock ordering by ID to avoid deadlocks.
-func (store *Store) TransferMoneyTx(ctx context.Context, arg CreateTransferParams) (TransferMoneyResult, error) {
-	var transferMoneyResult TransferMoneyResult
-
+// When arg.IdempotencyKey is set, repeating the call returns the original transfer instead of moving money twice.
+//
+// A transfer refused by a business rule (insufficient balance, frozen or closed account, currency
+// mismatch) is not rolled back: it is committed with status failed, so the attempt stays on record,
+// and the rule's error is returned alongside the result.
+//
+// Either way a transfer.completed event for both owners, or a transfer.failed event for the
+// sender only, is written to the outbox in the same database transaction; see DispatchOutboxEvents.
+func (store *SQLStore) TransferMoneyTx(ctx context.Context, arg TransferMoneyTxParams) (TransferMoneyResult, error) {
 	if arg.FromAccountID == arg.ToAccountID {
-		return transferMoneyResult, errors.New("cannot transfer to the same account")
+		return TransferMoneyResult{}, errors.New("cannot transfer to the same account")
+	}
+	if arg.AmountCents <= 0 {
+		return TransferMoneyResult{}, ErrInvalidAmount
 	}
 
-	err := store.executeTransaction(ctx, func(q *Queries) error {
+	return store.idempotentTransfer(ctx, arg, func() (TransferMoneyResult, error) {
+		return store.transferMoney(ctx, arg)
+	})
+}
+
+func (store *SQLStore) transferMoney(ctx context.Context, arg TransferMoneyTxParams) (TransferMoneyResult, error) {
+	var transferMoneyResult TransferMoneyResult
+	var failure error
+
+	err := store.executeTransaction(ctx, TxTransferMoney, func(q storeQueries) error {
 		var err error
+		transferMoneyResult, failure, err = store.transfer(ctx, q, arg)
+		return err
+	})
+	if err != nil {
+		return transferMoneyResult, err
+	}
 
-		// Lock both accounts in ascending ID order to prevent deadlocks.
-		// When multiple concurrent transfers involve the same accounts in different directions,
-		// locking in a consistent order ensures no circular wait conditions occur.
-		if arg.FromAccountID < arg.ToAccountID {
-			transferMoneyResult.FromAccount, err = q.GetAccountForUpdate(ctx, arg.FromAccountID)
-			if err != nil {
-				return err
-			}
-			transferMoneyResult.ToAccount, err = q.GetAccountForUpdate(ctx, arg.ToAccountID)
-			if err != nil {
-				return err
-			}
-		} else {
-			transferMoneyResult.ToAccount, err = q.GetAccountForUpdate(ctx, arg.ToAccountID)
-			if err != nil {
-				return err
-			}
-			transferMoneyResult.FromAccount, err = q.GetAccountForUpdate(ctx, arg.FromAccountID)
-			if err != nil {
-				return err
-			}
-		}
+	return transferMoneyResult, failure
+}
 
-		// Validate sender has sufficient balance
-		if transferMoneyResult.FromAccount.BalanceCents < arg.AmountCents {
-			return ErrInsufficientBalance
-		}
+// transfer moves money inside the caller's database transaction. A transfer refused by a
+// business rule is recorded as failed and the rule's error comes back as failure with a nil
+// err, so the caller decides whether to commit the failed transfer or roll it back.
+func (store *SQLStore) transfer(ctx context.Context, q storeQueries, arg TransferMoneyTxParams) (transferMoneyResult TransferMoneyResult, failure error, err error) {
+	transferMoneyResult.FromAccount, transferMoneyResult.ToAccount, err = q.lockAccountPair(ctx, arg.FromAccountID, arg.ToAccountID)
+	if err != nil {
+		return
+	}
 
-		// Create transfer record
-		transferMoneyResult.Transfer, err = q.CreateTransfer(ctx, arg)
+	// Validate both account statuses and the sender's balance, then price the transfer
+	// in the recipient's currency
+	rejection := store.checkTransfer(transferMoneyResult.FromAccount, transferMoneyResult.ToAccount, arg.AmountCents)
+	var conversion transferConversion
+	if rejection == nil {
+		conversion, rejection, err = q.convertTransfer(ctx, transferMoneyResult.FromAccount, transferMoneyResult.ToAccount, arg)
 		if err != nil {
-			return err
+			return
 		}
+	}
 
-		// Create transaction entry for sender (debit)
-		transferMoneyResult.FromTx, err = q.CreateTransaction(ctx, CreateTransactionParams{
-			AccountID:         arg.FromAccountID,
-			Type:              TransactionTypeTransferOut,
-			AmountCents:       -arg.AmountCents,
-			BalanceAfterCents: transferMoneyResult.FromAccount.BalanceCents - arg.AmountCents,
-		})
-		if err != nil {
-			return err
-		}
+	// Create transfer record
+	createArg := CreateTransferParams{
+		FromAccountID:  arg.FromAccountID,
+		ToAccountID:    arg.ToAccountID,
+		AmountCents:    arg.AmountCents,
+		IdempotencyKey: arg.IdempotencyKey,
+	}
+	if conversion.rate.Valid {
+		createArg.ToAmountCents = pgtype.Int8{Int64: conversion.creditAmount, Valid: true}
+		createArg.ExchangeRate = conversion.rate
+	}
+	transferMoneyResult.Transfer, err = q.CreateTransfer(ctx, createArg)
+	if err != nil {
+		return
+	}
 
-		// Create transaction entry for receiver (credit)
-		transferMoneyResult.ToTx, err = q.CreateTransaction(ctx, CreateTransactionParams{
-			AccountID:         arg.ToAccountID,
-			Type:              TransactionTypeTransferIn,
-			AmountCents:       arg.AmountCents,
-			BalanceAfterCents: transferMoneyResult.ToAccount.BalanceCents + arg.AmountCents,
-		})
+	// A rejection settles the transfer as failed and commits it without touching any balance
+	if rejection != nil {
+		failure = rejection.err
+		transferMoneyResult.Transfer, err = q.transitionTransfer(ctx, transferMoneyResult.Transfer, TransferStatusFailed, rejection.reason)
 		if err != nil {
-			return err
+			return
 		}
+		// Only the sender hears of a refused transfer; it tells the recipient nothing they
+		// were party to, and would reveal the sender's balance or account status
+		err = q.recordEvent(ctx, EventTypeTransferFailed, newTransferEvent(transferMoneyResult), arg.FromAccountID)
+		return
+	}
 
-		// Update sender account balance
-		transferMoneyResult.FromAccount, err = q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
-			ID:           arg.FromAccountID,
-			BalanceCents: transferMoneyResult.FromAccount.BalanceCents - arg.AmountCents,
-		})
-		if err != nil {
-			return err
+	// Create transaction entry for sender (debit)
+	transferMoneyResult.FromTx, err = q.CreateTransaction(ctx, CreateTransactionParams{
+		AccountID:         arg.FromAccountID,
+		Type:              TransactionTypeTransferOut,
+		AmountCents:       -arg.AmountCents,
+		BalanceAfterCents: transferMoneyResult.FromAccount.BalanceCents - arg.AmountCents,
+		RelatedAccountID:  pgtype.Int8{Int64: arg.ToAccountID, Valid: true},
+		Description:       arg.Description,
+	})
+	if err != nil {
+		return
+	}
+
+	// Create transaction entry for receiver (credit), in the receiver's currency
+	transferMoneyResult.ToTx, err = q.CreateTransaction(ctx, CreateTransactionParams{
+		AccountID:         arg.ToAccountID,
+		Type:              TransactionTypeTransferIn,
+		AmountCents:       conversion.creditAmount,
+		BalanceAfterCents: transferMoneyResult.ToAccount.BalanceCents + conversion.creditAmount,
+		RelatedAccountID:  pgtype.Int8{Int64: arg.FromAccountID, Valid: true},
+		Description:       arg.Description,
+	})
+	if err != nil {
+		return
+	}
+
+	// Update sender account balance
+	transferMoneyResult.FromAccount, err = q.updateAccountBalance(ctx, UpdateAccountBalanceParams{
+		ID:           arg.FromAccountID,
+		BalanceCents: transferMoneyResult.FromAccount.BalanceCents - arg.AmountCents,
+	})
+	if err != nil {
+		return
+	}
+
+	// Update receiver account balance
+	transferMoneyResult.ToAccount, err = q.updateAccountBalance(ctx, UpdateAccountBalanceParams{
+		ID:           arg.ToAccountID,
+		BalanceCents: transferMoneyResult.ToAccount.BalanceCents + conversion.creditAmount,
+	})
+	if err != nil {
+		return
+	}
+
+	// Journal both legs, plus the FX clearing legs of a cross-currency transfer
+	if _, err = q.postJournalEntry(ctx, JournalEntryTypeTransfer, transferMoneyResult.Transfer.ID,
+		movementPostings(transferMoneyResult.FromAccount, transferMoneyResult.FromTx, transferMoneyResult.ToAccount, transferMoneyResult.ToTx)...,
+	); err != nil {
+		return
+	}
+
+	// Settle the transfer
+	transferMoneyResult.Transfer, err = q.transitionTransfer(ctx, transferMoneyResult.Transfer, TransferStatusCompleted, "")
+	if err != nil {
+		return
+	}
+	err = q.recordEvent(ctx, EventTypeTransferCompleted, newTransferEvent(transferMoneyResult), arg.FromAccountID, arg.ToAccountID)
+	return
+}
+
+// checkTransfer decides whether money can move between the two locked accounts,
+// returning nil or the reason it can't.
+func (store *SQLStore) checkTransfer(fromAccount, toAccount Account, amount int64) *transferRejection {
+	if err := store.checkDebit(fromAccount); err != nil {
+		if fromAccount.Status == AccountStatusClosed {
+			return &transferRejection{reason: TransferFailureFromAccountClosed, err: err}
+		}
+		return &transferRejection{reason: TransferFailureFromAccountFrozen, err: err}
+	}
+	if err := store.checkCredit(toAccount); err != nil {
+		if toAccount.Status == AccountStatusClosed {
+			return &transferRejection{reason: TransferFailureToAccountClosed, err: err}
 		}
+		return &transferRejection{reason: TransferFailureToAccountFrozen, err: err}
+	}
+	if fromAccount.AvailableCents() < amount {
+		return &transferRejection{reason: TransferFailureInsufficientBalance, err: ErrInsufficientBalance}
+	}
+	return nil
+}
 
-		// Update receiver account balance
-		transferMoneyResult.ToAccount, err = q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
-			ID:           arg.ToAccountID,
-			BalanceCents: transferMoneyResult.ToAccount.BalanceCents + arg.AmountCents,
-		})
+// lockAccountPair locks both accounts in ascending ID order to prevent deadlocks.
+// When multiple concurrent transfers involve the same accounts in different directions,
+// locking in a consistent order ensures no circular wait conditions occur.
+func (q storeQueries) lockAccountPair(ctx context.Context, fromAccountID, toAccountID int64) (fromAccount, toAccount Account, err error) {
+	if fromAccountID < toAccountID {
+		fromAccount, err = q.GetAccountForUpdate(ctx, fromAccountID)
 		if err != nil {
-			return err
+			return
 		}
+		toAccount, err = q.GetAccountForUpdate(ctx, toAccountID)
+		return
+	}
 
-		return nil
-	})
-
-	return transferMoneyResult, err
+	toAccount, err = q.GetAccountForUpdate(ctx, toAccountID)
+	if err != nil {
+		return
+	}
+	fromAccount, err = q.GetAccountForUpdate(ctx, fromAccountID)
+	return
 }
 
 type AccountTransactionParams struct {
 	AccountID int64
 	Amount    int64
+	// IdempotencyKey is stored as the transaction's reference. A repeated call with the
+	// same key returns the original result; with a different payload it fails with
+	// ErrIdempotencyKeyReused.
+	IdempotencyKey pgtype.Text
+	Description    pgtype.Text
 }
 
-func (store *Store) DepositMoneyTx(ctx context.Context, arg AccountTransactionParams) (AccountTransactionResult, error) {
+func (store *SQLStore) DepositMoneyTx(ctx context.Context, arg AccountTransactionParams) (AccountTransactionResult, error) {
+	if arg.Amount <= 0 {
+		return AccountTransactionResult{}, ErrInvalidAmount
+	}
+
+	return store.idempotentAccountTransaction(ctx, arg, TransactionTypeDeposit, arg.Amount, func() (AccountTransactionResult, error) {
+		return store.depositMoney(ctx, arg)
+	})
+}
+
+func (store *SQLStore) depositMoney(ctx context.Context, arg AccountTransactionParams) (AccountTransactionResult, error) {
 	var depositMoneyResult AccountTransactionResult
-	err := store.executeTransaction(ctx, func(q *Queries) error {
+	err := store.executeTransaction(ctx, TxDepositMoney, func(q storeQueries) error {
 		var err error
 		depositMoneyResult.Account, err = q.GetAccountForUpdate(ctx, arg.AccountID)
 		if err != nil {
 			return err
 		}
+		if err := store.checkCredit(depositMoneyResult.Account); err != nil {
+			return err
+		}
 		depositMoneyResult.Transaction, err = q.CreateTransaction(ctx, CreateTransactionParams{
 			AccountID:         arg.AccountID,
 			Type:              TransactionTypeDeposit,
 			AmountCents:       arg.Amount,
 			BalanceAfterCents: depositMoneyResult.Account.BalanceCents + arg.Amount,
+			Reference:         arg.IdempotencyKey,
+			Description:       arg.Description,
 		})
 		if err != nil {
 			return err
 		}
-		depositMoneyResult.Account, err = q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
+		depositMoneyResult.Account, err = q.updateAccountBalance(ctx, UpdateAccountBalanceParams{
 			ID:           arg.AccountID,
 			BalanceCents: depositMoneyResult.Account.BalanceCents + arg.Amount,
 		})
 		if err != nil {
 			return err
 		}
-		return nil
+		// Money enters the ledger from the cash_in account
+		_, err = q.postJournalEntry(ctx, JournalEntryTypeDeposit, pgtype.UUID{},
+			accountPosting(depositMoneyResult.Account, depositMoneyResult.Transaction),
+			systemPosting(SystemAccountKindCashIn, depositMoneyResult.Account.Currency, -arg.Amount),
+		)
+		if err != nil {
+			return err
+		}
+		return q.recordEvent(ctx, EventTypeDepositCompleted,
+			newTransactionEvent(depositMoneyResult.Account, depositMoneyResult.Transaction), arg.AccountID)
 	})
 
 	return depositMoneyResult, err
 }
 
-func (store *Store) WithdrawMoneyTx(ctx context.Context, arg AccountTransactionParams) (AccountTransactionResult, error) {
-	var withdrawMoneyResult AccountTransactionResult
+func (store *SQLStore) WithdrawMoneyTx(ctx context.Context, arg AccountTransactionParams) (AccountTransactionResult, error) {
 	if arg.Amount <= 0 {
 		return AccountTransactionResult{}, ErrInvalidAmount
 	}
 
-	err := store.executeTransaction(ctx, func(q *Queries) error {
-		var err error
-		withdrawMoneyResult.Account, err = q.GetAccountForUpdate(ctx, arg.AccountID)
-		if err != nil {
-			return err
-		}
+	return store.idempotentAccountTransaction(ctx, arg, TransactionTypeWithdrawal, -arg.Amount, func() (AccountTransactionResult, error) {
+		return store.withdrawMoney(ctx, arg)
+	})
+}
 
-		if withdrawMoneyResult.Account.BalanceCents < arg.Amount {
-			return ErrInsufficientBalance
-		}
+func (store *SQLStore) withdrawMoney(ctx context.Context, arg AccountTransactionParams) (AccountTransactionResult, error) {
+	var withdrawMoneyResult AccountTransactionResult
 
-		balanceAfterWithdrawal := withdrawMoneyResult.Account.BalanceCents - arg.Amount
-		withdrawMoneyResult.Transaction, err = q.CreateTransaction(ctx, CreateTransactionParams{
-			AccountID:         arg.AccountID,
-			Type:              TransactionTypeWithdrawal,
-			AmountCents:       -arg.Amount,
-			BalanceAfterCents: balanceAfterWithdrawal,
-		})
-		if err != nil {
-			return err
-		}
-		withdrawMoneyResult.Account, err = q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
-			ID:           arg.AccountID,
-			BalanceCents: balanceAfterWithdrawal,
-		})
-		if err != nil {
-			return err
-		}
-		return nil
+	err := store.executeTransaction(ctx, TxWithdrawMoney, func(q storeQueries) error {
+		var err error
+		withdrawMoneyResult, err = store.withdraw(ctx, q, arg)
+		return err
 	})
 
 	return withdrawMoneyResult, err
 }
+
+// withdraw debits an account inside the caller's database transaction.
+func (store *SQLStore) withdraw(ctx context.Context, q storeQueries, arg AccountTransactionParams) (withdrawMoneyResult AccountTransactionResult, err error) {
+	withdrawMoneyResult.Account, err = q.GetAccountForUpdate(ctx, arg.AccountID)
+	if err != nil {
+		return
+	}
+	if err := store.checkDebit(withdrawMoneyResult.Account); err != nil {
+		return withdrawMoneyResult, err
+	}
+
+	if withdrawMoneyResult.Account.AvailableCents() < arg.Amount {
+		return withdrawMoneyResult, ErrInsufficientBalance
+	}
+
+	balanceAfterWithdrawal := withdrawMoneyResult.Account.BalanceCents - arg.Amount
+	withdrawMoneyResult.Transaction, err = q.CreateTransaction(ctx, CreateTransactionParams{
+		AccountID:         arg.AccountID,
+		Type:              TransactionTypeWithdrawal,
+		AmountCents:       -arg.Amount,
+		BalanceAfterCents: balanceAfterWithdrawal,
+		Reference:         arg.IdempotencyKey,
+		Description:       arg.Description,
+	})
+	if err != nil {
+		return
+	}
+	withdrawMoneyResult.Account, err = q.updateAccountBalance(ctx, UpdateAccountBalanceParams{
+		ID:           arg.AccountID,
+		BalanceCents: balanceAfterWithdrawal,
+	})
+	if err != nil {
+		return
+	}
+	// Money leaves the ledger through the cash_out account
+	_, err = q.postJournalEntry(ctx, JournalEntryTypeWithdrawal, pgtype.UUID{},
+		accountPosting(withdrawMoneyResult.Account, withdrawMoneyResult.Transaction),
+		systemPosting(SystemAccountKindCashOut, withdrawMoneyResult.Account.Currency, arg.Amount),
+	)
+	if err != nil {
+		return
+	}
+	err = q.recordEvent(ctx, EventTypeWithdrawalCompleted,
+		newTransactionEvent(withdrawMoneyResult.Account, withdrawMoneyResult.Transaction), arg.AccountID)
+	return
+}
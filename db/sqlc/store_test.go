@@ -2,6 +2,7 @@ package sqlc
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -10,11 +11,11 @@ import (
 func TestTransferMoneyTx(t *testing.T) {
 	store := NewStore(testDB)
 
-	fromAccount := createRandomAccountWithQueries(t, store.Queries)
-	toAccount := createRandomAccountWithQueries(t, store.Queries)
+	fromAccount := createRandomAccountWithQueries(t, store)
+	toAccount := createRandomAccountWithQueries(t, store)
 	amount := int64(10)
 
-	result, err := store.TransferMoneyTx(context.Background(), CreateTransferParams{
+	result, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
 		FromAccountID: fromAccount.ID,
 		ToAccountID:   toAccount.ID,
 		AmountCents:   amount,
@@ -31,6 +32,8 @@ func TestTransferMoneyTx(t *testing.T) {
 	require.Equal(t, amount, transfer.AmountCents)
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
+	require.Equal(t, TransferStatusCompleted, transfer.Status)
+	require.True(t, transfer.ProcessedAt.Valid)
 
 	_, err = store.GetTransfer(context.Background(), transfer.ID)
 	require.NoError(t, err)
@@ -79,8 +82,8 @@ func TestTransferMoneyTx(t *testing.T) {
 func TestTransferMoneyTx_Bidirectional(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1 := createRandomAccountWithQueries(t, store.Queries)
-	account2 := createRandomAccountWithQueries(t, store.Queries)
+	account1 := createRandomAccountWithQueries(t, store)
+	account2 := createRandomAccountWithQueries(t, store)
 	amount := int64(5)
 
 	n := 10
@@ -91,7 +94,7 @@ func TestTransferMoneyTx_Bidirectional(t *testing.T) {
 	for i := 0; i < n; i++ {
 		// Account1 -> Account2
 		go func() {
-			result, err := store.TransferMoneyTx(context.Background(), CreateTransferParams{
+			result, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
 				FromAccountID: account1.ID,
 				ToAccountID:   account2.ID,
 				AmountCents:   amount,
@@ -102,7 +105,7 @@ func TestTransferMoneyTx_Bidirectional(t *testing.T) {
 
 		// Account2 -> Account1 (reverse direction)
 		go func() {
-			result, err := store.TransferMoneyTx(context.Background(), CreateTransferParams{
+			result, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
 				FromAccountID: account2.ID,
 				ToAccountID:   account1.ID,
 				AmountCents:   amount,
@@ -133,12 +136,12 @@ func TestTransferMoneyTx_Bidirectional(t *testing.T) {
 func TestTransferMoneyTxManyToOne(t *testing.T) {
 	store := NewStore(testDB)
 	var fromAccounts []Account
-	toAccount := createRandomAccountWithQueries(t, store.Queries)
+	toAccount := createRandomAccountWithQueries(t, store)
 	n := 10
 	amount := int64(5)
 
 	for range n {
-		fromAccounts = append(fromAccounts, createRandomAccountWithQueries(t, store.Queries))
+		fromAccounts = append(fromAccounts, createRandomAccountWithQueries(t, store))
 	}
 
 	errs := make(chan error)
@@ -146,7 +149,7 @@ func TestTransferMoneyTxManyToOne(t *testing.T) {
 
 	for i := range n {
 		go func(index int) {
-			result, err := store.TransferMoneyTx(context.Background(), CreateTransferParams{
+			result, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
 				FromAccountID: fromAccounts[index].ID,
 				ToAccountID:   toAccount.ID,
 				AmountCents:   amount,
@@ -212,7 +215,7 @@ func TestTransferMoneyTxManyToOne(t *testing.T) {
 
 func TestDepositMoneyTx(t *testing.T) {
 	store := NewStore(testDB)
-	account := createRandomAccountWithQueries(t, store.Queries)
+	account := createRandomAccountWithQueries(t, store)
 	amount := int64(10)
 
 	result, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{
@@ -235,48 +238,61 @@ func TestDepositMoneyTx(t *testing.T) {
 	require.Equal(t, result.Account.BalanceCents, updatedAccount.BalanceCents)
 }
 
-func TestConcurrentDepositMoneyTx(t *testing.T) {
-	store := NewStore(testDB)
-	account := createRandomAccountWithQueries(t, store.Queries)
-	amount := int64(40)
-	n := 10
-
-	errors := make(chan error, n) //  buffered channels -> call goroutine any order
-	results := make(chan AccountTransactionResult, n)
-
-	for range n {
-		go func() {
-			result, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{
-				AccountID: account.ID,
-				Amount:    amount,
-			})
-			results <- result
-			errors <- err
-		}()
-	}
+// testTxPolicies are the isolation levels the concurrency tests must hold under.
+var testTxPolicies = []struct {
+	name   string
+	policy TxPolicy
+}{
+	{name: "ReadCommitted", policy: DefaultTxPolicy},
+	{name: "Serializable", policy: SerializableTxPolicy},
+}
 
-	for range n {
-		err := <-errors
-		result := <-results
-		require.NoError(t, err)
-		require.NotEmpty(t, result)
+func TestConcurrentDepositMoneyTx(t *testing.T) {
+	for _, tc := range testTxPolicies {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore(testDB, WithTxPolicy(tc.policy))
+			account := createRandomAccountWithQueries(t, store)
+			amount := int64(40)
+			n := 10
+
+			errors := make(chan error, n) //  buffered channels -> call goroutine any order
+			results := make(chan AccountTransactionResult, n)
+
+			for range n {
+				go func() {
+					result, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{
+						AccountID: account.ID,
+						Amount:    amount,
+					})
+					results <- result
+					errors <- err
+				}()
+			}
+
+			for range n {
+				err := <-errors
+				result := <-results
+				require.NoError(t, err)
+				require.NotEmpty(t, result)
+
+				require.NotEmpty(t, result.Transaction)
+				require.NotEmpty(t, result.Account)
+				require.Equal(t, account.ID, result.Transaction.AccountID)
+				require.Equal(t, TransactionTypeDeposit, result.Transaction.Type)
+				require.Equal(t, amount, result.Transaction.AmountCents)
+			}
 
-		require.NotEmpty(t, result.Transaction)
-		require.NotEmpty(t, result.Account)
-		require.Equal(t, account.ID, result.Transaction.AccountID)
-		require.Equal(t, TransactionTypeDeposit, result.Transaction.Type)
-		require.Equal(t, amount, result.Transaction.AmountCents)
+			updatedAccount, err := store.GetAccount(context.Background(), account.ID)
+			require.NoError(t, err)
+			expectedBalance := account.BalanceCents + (int64(n) * amount)
+			require.Equal(t, expectedBalance, updatedAccount.BalanceCents)
+		})
 	}
-
-	updatedAccount, err := store.GetAccount(context.Background(), account.ID)
-	require.NoError(t, err)
-	expectedBalance := account.BalanceCents + (int64(n) * amount)
-	require.Equal(t, expectedBalance, updatedAccount.BalanceCents)
 }
 
 func TestWithdrawMoneyTx(t *testing.T) {
 	store := NewStore(testDB)
-	account := createRandomAccountWithQueries(t, store.Queries)
+	account := createRandomAccountWithQueries(t, store)
 	amount := int64(40)
 	result, err := store.WithdrawMoneyTx(context.Background(), AccountTransactionParams{
 		AccountID: account.ID,
@@ -301,63 +317,67 @@ func TestWithdrawMoneyTx(t *testing.T) {
 }
 
 func TestConcurrentWithdrawMoneyTx(t *testing.T) {
-	store := NewStore(testDB)
-	account := createRandomAccountWithQueries(t, store.Queries)
-	amount := int64(40)
-	n := 10
-
-	// Ensure account has enough balance for all withdrawals
-	// Deposit enough money first
-	initialDeposit := int64(n) * amount
-	_, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{
-		AccountID: account.ID,
-		Amount:    initialDeposit,
-	})
-	require.NoError(t, err)
-
-	// Get updated account balance
-	account, err = store.GetAccount(context.Background(), account.ID)
-	require.NoError(t, err)
-
-	errors := make(chan error, n) // buffered channels -> call goroutine any order
-	results := make(chan AccountTransactionResult, n)
-
-	// Run n concurrent withdrawals
-	for range n {
-		go func() {
-			result, err := store.WithdrawMoneyTx(context.Background(), AccountTransactionParams{
+	for _, tc := range testTxPolicies {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore(testDB, WithTxPolicy(tc.policy))
+			account := createRandomAccountWithQueries(t, store)
+			amount := int64(40)
+			n := 10
+
+			// Ensure account has enough balance for all withdrawals
+			// Deposit enough money first
+			initialDeposit := int64(n) * amount
+			_, err := store.DepositMoneyTx(context.Background(), AccountTransactionParams{
 				AccountID: account.ID,
-				Amount:    amount,
+				Amount:    initialDeposit,
 			})
-			results <- result
-			errors <- err
-		}()
-	}
+			require.NoError(t, err)
 
-	// Collect and validate results
-	for range n {
-		err := <-errors
-		result := <-results
-		require.NoError(t, err)
-		require.NotEmpty(t, result)
+			// Get updated account balance
+			account, err = store.GetAccount(context.Background(), account.ID)
+			require.NoError(t, err)
 
-		require.NotEmpty(t, result.Transaction)
-		require.NotEmpty(t, result.Account)
-		require.Equal(t, account.ID, result.Transaction.AccountID)
-		require.Equal(t, TransactionTypeWithdrawal, result.Transaction.Type)
-		require.Equal(t, -amount, result.Transaction.AmountCents)
+			errors := make(chan error, n) // buffered channels -> call goroutine any order
+			results := make(chan AccountTransactionResult, n)
+
+			// Run n concurrent withdrawals
+			for range n {
+				go func() {
+					result, err := store.WithdrawMoneyTx(context.Background(), AccountTransactionParams{
+						AccountID: account.ID,
+						Amount:    amount,
+					})
+					results <- result
+					errors <- err
+				}()
+			}
+
+			// Collect and validate results
+			for range n {
+				err := <-errors
+				result := <-results
+				require.NoError(t, err)
+				require.NotEmpty(t, result)
+
+				require.NotEmpty(t, result.Transaction)
+				require.NotEmpty(t, result.Account)
+				require.Equal(t, account.ID, result.Transaction.AccountID)
+				require.Equal(t, TransactionTypeWithdrawal, result.Transaction.Type)
+				require.Equal(t, -amount, result.Transaction.AmountCents)
+			}
+
+			// Verify final balance
+			updatedAccount, err := store.GetAccount(context.Background(), account.ID)
+			require.NoError(t, err)
+			expectedBalance := account.BalanceCents - (int64(n) * amount)
+			require.Equal(t, expectedBalance, updatedAccount.BalanceCents)
+		})
 	}
-
-	// Verify final balance
-	updatedAccount, err := store.GetAccount(context.Background(), account.ID)
-	require.NoError(t, err)
-	expectedBalance := account.BalanceCents - (int64(n) * amount)
-	require.Equal(t, expectedBalance, updatedAccount.BalanceCents)
 }
 
 func TestWithdrawMoneyTx_InsufficientBalance(t *testing.T) {
 	store := NewStore(testDB)
-	account := createRandomAccountWithQueries(t, store.Queries)
+	account := createRandomAccountWithQueries(t, store)
 
 	// Try to withdraw more than current balance
 	amount := account.BalanceCents + 100
@@ -379,7 +399,7 @@ func TestWithdrawMoneyTx_InsufficientBalance(t *testing.T) {
 
 func TestWithdrawMoneyTx_InvalidAmount(t *testing.T) {
 	store := NewStore(testDB)
-	account := createRandomAccountWithQueries(t, store.Queries)
+	account := createRandomAccountWithQueries(t, store)
 
 	testCases := []struct {
 		name   string
@@ -416,11 +436,11 @@ func TestWithdrawMoneyTx_InvalidAmount(t *testing.T) {
 
 func TestTransferMoneyTx_SameAccount(t *testing.T) {
 	store := NewStore(testDB)
-	account := createRandomAccountWithQueries(t, store.Queries)
+	account := createRandomAccountWithQueries(t, store)
 	amount := int64(100)
 
 	// Try to transfer to the same account
-	_, err := store.TransferMoneyTx(context.Background(), CreateTransferParams{
+	_, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
 		FromAccountID: account.ID,
 		ToAccountID:   account.ID,
 		AmountCents:   amount,
@@ -438,20 +458,28 @@ func TestTransferMoneyTx_SameAccount(t *testing.T) {
 
 func TestTransferMoneyTx_InsufficientBalance(t *testing.T) {
 	store := NewStore(testDB)
-	fromAccount := createRandomAccountWithQueries(t, store.Queries)
-	toAccount := createRandomAccountWithQueries(t, store.Queries)
+	fromAccount := createRandomAccountWithQueries(t, store)
+	toAccount := createRandomAccountWithQueries(t, store)
 
 	// Try to transfer more than available balance
 	amount := fromAccount.BalanceCents + 100
 
-	_, err := store.TransferMoneyTx(context.Background(), CreateTransferParams{
+	result, err := store.TransferMoneyTx(context.Background(), TransferMoneyTxParams{
 		FromAccountID: fromAccount.ID,
 		ToAccountID:   toAccount.ID,
 		AmountCents:   amount,
 	})
 
-	// Should fail (transaction will be rolled back)
-	require.Error(t, err)
+	// Should fail, but the attempt is kept as a failed transfer
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+	require.Empty(t, result.FromTx)
+	require.Empty(t, result.ToTx)
+
+	transfer, err := store.GetTransfer(context.Background(), result.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusFailed, transfer.Status)
+	require.Equal(t, TransferFailureInsufficientBalance, transfer.FailureReason.String)
+	require.True(t, transfer.ProcessedAt.Valid)
 
 	// Verify both balances unchanged
 	updatedFromAccount, err := store.GetAccount(context.Background(), fromAccount.ID)
@@ -462,3 +490,40 @@ func TestTransferMoneyTx_InsufficientBalance(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, toAccount.BalanceCents, updatedToAccount.BalanceCents)
 }
+
+func TestChangePasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUserWithQueries(t, store)
+	newHash := randomPasswordHash()
+
+	updated, err := store.ChangePasswordTx(context.Background(), ChangePasswordTxParams{
+		UserID:          user.ID,
+		NewPasswordHash: newHash,
+		VerifyCurrent: func(currentHash string) error {
+			require.Equal(t, user.PasswordHash, currentHash)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, newHash, updated.PasswordHash)
+}
+
+func TestChangePasswordTx_VerifyFails(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUserWithQueries(t, store)
+	errMismatch := errors.New("mismatch")
+
+	_, err := store.ChangePasswordTx(context.Background(), ChangePasswordTxParams{
+		UserID:          user.ID,
+		NewPasswordHash: randomPasswordHash(),
+		VerifyCurrent: func(string) error {
+			return errMismatch
+		},
+	})
+	require.ErrorIs(t, err, errMismatch)
+
+	// the hash is untouched
+	stored, err := store.GetUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, user.PasswordHash, stored.PasswordHash)
+}
@@ -0,0 +1,221 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrInvalidTransactionSort is returned for a TransactionSort outside the defined ones.
+var ErrInvalidTransactionSort = errors.New("invalid transaction sort")
+
+// TransactionSort orders transaction search results. Every order breaks ties on the id.
+type TransactionSort string
+
+const (
+	TransactionSortNewest   TransactionSort = "newest"
+	TransactionSortOldest   TransactionSort = "oldest"
+	TransactionSortLargest  TransactionSort = "largest"
+	TransactionSortSmallest TransactionSort = "smallest"
+)
+
+// SearchTransactionsPageParams filters one account's transactions. Each filter left at
+// its zero value matches everything.
+type SearchTransactionsPageParams struct {
+	AccountID int64
+	// CreatedFrom and CreatedTo bound created_at to [CreatedFrom, CreatedTo).
+	CreatedFrom pgtype.Timestamptz
+	CreatedTo   pgtype.Timestamptz
+	Types       []TransactionType
+	// MinAmountCents and MaxAmountCents bound the absolute amount, so a range finds
+	// debits and credits alike. A zero MaxAmountCents leaves the top open.
+	MinAmountCents int64
+	MaxAmountCents int64
+	// RelatedAccountID is the counterparty of a transfer or reversal leg.
+	RelatedAccountID pgtype.Int8
+	Reference        pgtype.Text
+	// DescriptionContains matches descriptions containing it, ignoring case.
+	DescriptionContains string
+	// Sort defaults to TransactionSortNewest.
+	Sort   TransactionSort
+	Cursor string
+	Limit  int32
+}
+
+// amountKey orders lists by absolute amount, with the id breaking ties.
+type amountKey struct {
+	AmountCents int64       `json:"a"`
+	ID          pgtype.UUID `json:"i"`
+}
+
+// SearchTransactionsPage pages through the account's transactions matching every
+// filter set in arg, in the order arg.Sort asks for. A cursor continues the search it
+// came from only under the same sort, and should be sent with the same filters.
+func (q storeQueries) SearchTransactionsPage(ctx context.Context, arg SearchTransactionsPageParams) (Page[Transaction], error) {
+	search := newTransactionSearch(q, arg)
+
+	switch arg.Sort {
+	case TransactionSortNewest, "":
+		return pager[Transaction, timeKey[pgtype.UUID]]{
+			list:   "transaction_search_newest",
+			start:  timeKey[pgtype.UUID]{CreatedAt: afterNewest, ID: noUUID},
+			key:    transactionTimeKey,
+			after:  search.newest,
+			before: search.oldest,
+		}.page(ctx, arg.Cursor, arg.Limit)
+	case TransactionSortOldest:
+		return pager[Transaction, timeKey[pgtype.UUID]]{
+			list:   "transaction_search_oldest",
+			start:  timeKey[pgtype.UUID]{CreatedAt: afterOldest, ID: noUUID},
+			key:    transactionTimeKey,
+			after:  search.oldest,
+			before: search.newest,
+		}.page(ctx, arg.Cursor, arg.Limit)
+	case TransactionSortLargest:
+		return pager[Transaction, amountKey]{
+			list:   "transaction_search_largest",
+			start:  amountKey{AmountCents: math.MaxInt64, ID: noUUID},
+			key:    transactionAmountKey,
+			after:  search.largest,
+			before: search.smallest,
+		}.page(ctx, arg.Cursor, arg.Limit)
+	case TransactionSortSmallest:
+		return pager[Transaction, amountKey]{
+			list:   "transaction_search_smallest",
+			start:  amountKey{AmountCents: -1, ID: noUUID},
+			key:    transactionAmountKey,
+			after:  search.smallest,
+			before: search.largest,
+		}.page(ctx, arg.Cursor, arg.Limit)
+	}
+	return Page[Transaction]{}, ErrInvalidTransactionSort
+}
+
+func transactionTimeKey(t Transaction) timeKey[pgtype.UUID] {
+	return timeKey[pgtype.UUID]{CreatedAt: t.CreatedAt, ID: t.ID}
+}
+
+func transactionAmountKey(t Transaction) amountKey {
+	amount := t.AmountCents
+	if amount < 0 {
+		amount = -amount
+	}
+	return amountKey{AmountCents: amount, ID: t.ID}
+}
+
+// likeEscaper makes a string match only itself in an ILIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// transactionSearch holds the filters of one search in the form the four search
+// queries take them, with open filters turned into bounds that match every row.
+type transactionSearch struct {
+	q                  Querier
+	accountID          int64
+	fromTime, toTime   pgtype.Timestamptz
+	types              []string
+	minAmount          int64
+	maxAmount          int64
+	relatedAccountID   pgtype.Int8
+	reference          pgtype.Text
+	descriptionPattern pgtype.Text
+}
+
+func newTransactionSearch(q Querier, arg SearchTransactionsPageParams) transactionSearch {
+	search := transactionSearch{
+		q:                q,
+		accountID:        arg.AccountID,
+		fromTime:         arg.CreatedFrom,
+		toTime:           arg.CreatedTo,
+		minAmount:        arg.MinAmountCents,
+		maxAmount:        arg.MaxAmountCents,
+		relatedAccountID: arg.RelatedAccountID,
+		reference:        arg.Reference,
+	}
+	if !search.fromTime.Valid {
+		search.fromTime = afterOldest
+	}
+	if !search.toTime.Valid {
+		search.toTime = afterNewest
+	}
+	if search.maxAmount == 0 {
+		search.maxAmount = math.MaxInt64
+	}
+	for _, t := range arg.Types {
+		search.types = append(search.types, string(t))
+	}
+	if arg.DescriptionContains != "" {
+		search.descriptionPattern = pgtype.Text{String: "%" + likeEscaper.Replace(arg.DescriptionContains) + "%", Valid: true}
+	}
+	return search
+}
+
+func (s transactionSearch) newest(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]Transaction, error) {
+	return s.q.SearchTransactionsNewest(ctx, SearchTransactionsNewestParams{
+		AccountID:          s.accountID,
+		FromTime:           s.fromTime,
+		ToTime:             s.toTime,
+		Types:              s.types,
+		MinAmountCents:     s.minAmount,
+		MaxAmountCents:     s.maxAmount,
+		RelatedAccountID:   s.relatedAccountID,
+		Reference:          s.reference,
+		DescriptionPattern: s.descriptionPattern,
+		AfterCreatedAt:     key.CreatedAt,
+		AfterID:            key.ID,
+		Limit:              limit,
+	})
+}
+
+func (s transactionSearch) oldest(ctx context.Context, key timeKey[pgtype.UUID], limit int32) ([]Transaction, error) {
+	return s.q.SearchTransactionsOldest(ctx, SearchTransactionsOldestParams{
+		AccountID:          s.accountID,
+		FromTime:           s.fromTime,
+		ToTime:             s.toTime,
+		Types:              s.types,
+		MinAmountCents:     s.minAmount,
+		MaxAmountCents:     s.maxAmount,
+		RelatedAccountID:   s.relatedAccountID,
+		Reference:          s.reference,
+		DescriptionPattern: s.descriptionPattern,
+		AfterCreatedAt:     key.CreatedAt,
+		AfterID:            key.ID,
+		Limit:              limit,
+	})
+}
+
+func (s transactionSearch) largest(ctx context.Context, key amountKey, limit int32) ([]Transaction, error) {
+	return s.q.SearchTransactionsLargest(ctx, SearchTransactionsLargestParams{
+		AccountID:          s.accountID,
+		FromTime:           s.fromTime,
+		ToTime:             s.toTime,
+		Types:              s.types,
+		MinAmountCents:     s.minAmount,
+		MaxAmountCents:     s.maxAmount,
+		RelatedAccountID:   s.relatedAccountID,
+		Reference:          s.reference,
+		DescriptionPattern: s.descriptionPattern,
+		AfterAmountCents:   key.AmountCents,
+		AfterID:            key.ID,
+		Limit:              limit,
+	})
+}
+
+func (s transactionSearch) smallest(ctx context.Context, key amountKey, limit int32) ([]Transaction, error) {
+	return s.q.SearchTransactionsSmallest(ctx, SearchTransactionsSmallestParams{
+		AccountID:          s.accountID,
+		FromTime:           s.fromTime,
+		ToTime:             s.toTime,
+		Types:              s.types,
+		MinAmountCents:     s.minAmount,
+		MaxAmountCents:     s.maxAmount,
+		RelatedAccountID:   s.relatedAccountID,
+		Reference:          s.reference,
+		DescriptionPattern: s.descriptionPattern,
+		AfterAmountCents:   key.AmountCents,
+		AfterID:            key.ID,
+		Limit:              limit,
+	})
+}
@@ -16,11 +16,14 @@ INSERT INTO transactions (
   account_id,
   type,
   amount_cents,
-  balance_after_cents
+  balance_after_cents,
+  related_account_id,
+  reference,
+  description
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at
+RETURNING id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description
 `
 
 type CreateTransactionParams struct {
@@ -28,6 +31,9 @@ type CreateTransactionParams struct {
 	Type              TransactionType
 	AmountCents       int64
 	BalanceAfterCents int64
+	RelatedAccountID  pgtype.Int8
+	Reference         pgtype.Text
+	Description       pgtype.Text
 }
 
 func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error) {
@@ -36,6 +42,9 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		arg.Type,
 		arg.AmountCents,
 		arg.BalanceAfterCents,
+		arg.RelatedAccountID,
+		arg.Reference,
+		arg.Description,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -47,12 +56,46 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		&i.RelatedAccountID,
 		&i.Reference,
 		&i.CreatedAt,
+		&i.Seq,
+		&i.Description,
+	)
+	return i, err
+}
+
+const getLastTransactionBefore = `-- name: GetLastTransactionBefore :one
+SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description FROM transactions
+WHERE account_id = $1 AND created_at < $2
+ORDER BY seq DESC
+LIMIT 1
+`
+
+type GetLastTransactionBeforeParams struct {
+	AccountID int64
+	Before    pgtype.Timestamptz
+}
+
+// The last transaction applied to the account before a point in time. Its
+// balance_after_cents is the account's balance at that point.
+func (q *Queries) GetLastTransactionBefore(ctx context.Context, arg GetLastTransactionBeforeParams) (Transaction, error) {
+	row := q.db.QueryRow(ctx, getLastTransactionBefore, arg.AccountID, arg.Before)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Type,
+		&i.AmountCents,
+		&i.BalanceAfterCents,
+		&i.RelatedAccountID,
+		&i.Reference,
+		&i.CreatedAt,
+		&i.Seq,
+		&i.Description,
 	)
 	return i, err
 }
 
 const getTransaction = `-- name: GetTransaction :one
-SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at FROM transactions
+SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description FROM transactions
 WHERE id = $1 LIMIT 1
 `
 
@@ -68,26 +111,531 @@ func (q *Queries) GetTransaction(ctx context.Context, id pgtype.UUID) (Transacti
 		&i.RelatedAccountID,
 		&i.Reference,
 		&i.CreatedAt,
+		&i.Seq,
+		&i.Description,
+	)
+	return i, err
+}
+
+const getTransactionByReference = `-- name: GetTransactionByReference :one
+SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description FROM transactions
+WHERE account_id = $1 AND reference = $2 LIMIT 1
+`
+
+type GetTransactionByReferenceParams struct {
+	AccountID int64
+	Reference pgtype.Text
+}
+
+// Idempotency keys are scoped to the account they were used on.
+func (q *Queries) GetTransactionByReference(ctx context.Context, arg GetTransactionByReferenceParams) (Transaction, error) {
+	row := q.db.QueryRow(ctx, getTransactionByReference, arg.AccountID, arg.Reference)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Type,
+		&i.AmountCents,
+		&i.BalanceAfterCents,
+		&i.RelatedAccountID,
+		&i.Reference,
+		&i.CreatedAt,
+		&i.Seq,
+		&i.Description,
 	)
 	return i, err
 }
 
+const listBalanceChainBreaks = `-- name: ListBalanceChainBreaks :many
+SELECT account_id, id, seq, amount_cents, balance_after_cents, previous_balance_cents
+FROM (
+  SELECT
+    account_id,
+    id,
+    seq,
+    amount_cents,
+    balance_after_cents,
+    COALESCE(lag(balance_after_cents) OVER (PARTITION BY account_id ORDER BY seq), 0)::bigint AS previous_balance_cents
+  FROM transactions
+  WHERE account_id = ANY($1::bigint[])
+) chain
+WHERE balance_after_cents <> previous_balance_cents + amount_cents
+ORDER BY account_id, seq
+`
+
+type ListBalanceChainBreaksRow struct {
+	AccountID            int64
+	ID                   pgtype.UUID
+	Seq                  int64
+	AmountCents          int64
+	BalanceAfterCents    int64
+	PreviousBalanceCents int64
+}
+
+// Finds transactions whose balance_after_cents doesn't follow from the row applied
+// before it on the same account. An account's first row follows from zero.
+func (q *Queries) ListBalanceChainBreaks(ctx context.Context, accountIds []int64) ([]ListBalanceChainBreaksRow, error) {
+	rows, err := q.db.Query(ctx, listBalanceChainBreaks, accountIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBalanceChainBreaksRow
+	for rows.Next() {
+		var i ListBalanceChainBreaksRow
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.ID,
+			&i.Seq,
+			&i.AmountCents,
+			&i.BalanceAfterCents,
+			&i.PreviousBalanceCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTransactions = `-- name: ListTransactions :many
-SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at FROM transactions
+SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description FROM transactions
 WHERE account_id = $1
-ORDER BY created_at DESC
-LIMIT $2
-OFFSET $3
+  AND (created_at, id) < ($2::timestamptz, $3::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT $4
 `
 
 type ListTransactionsParams struct {
+	AccountID      int64
+	AfterCreatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	Limit          int32
+}
+
+// Pages through the account's transactions newest first, continuing after the
+// (after_created_at, after_id) key of the previous page's last row.
+func (q *Queries) ListTransactions(ctx context.Context, arg ListTransactionsParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, listTransactions,
+		arg.AccountID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Type,
+			&i.AmountCents,
+			&i.BalanceAfterCents,
+			&i.RelatedAccountID,
+			&i.Reference,
+			&i.CreatedAt,
+			&i.Seq,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsBefore = `-- name: ListTransactionsBefore :many
+SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description FROM transactions
+WHERE account_id = $1
+  AND (created_at, id) > ($2::timestamptz, $3::uuid)
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListTransactionsBeforeParams struct {
+	AccountID       int64
+	BeforeCreatedAt pgtype.Timestamptz
+	BeforeID        pgtype.UUID
+	Limit           int32
+}
+
+// Pages back through the account's transactions from the (before_created_at, before_id)
+// key, returning the rows nearest to it first.
+func (q *Queries) ListTransactionsBefore(ctx context.Context, arg ListTransactionsBeforeParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, listTransactionsBefore,
+		arg.AccountID,
+		arg.BeforeCreatedAt,
+		arg.BeforeID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Type,
+			&i.AmountCents,
+			&i.BalanceAfterCents,
+			&i.RelatedAccountID,
+			&i.Reference,
+			&i.CreatedAt,
+			&i.Seq,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsInPeriod = `-- name: ListTransactionsInPeriod :many
+SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description FROM transactions
+WHERE account_id = $1
+  AND created_at >= $2
+  AND created_at < $3
+  AND seq > $4
+ORDER BY seq
+LIMIT $5
+`
+
+type ListTransactionsInPeriodParams struct {
 	AccountID int64
+	FromTime  pgtype.Timestamptz
+	ToTime    pgtype.Timestamptz
+	AfterSeq  int64
 	Limit     int32
-	Offset    int32
 }
 
-func (q *Queries) ListTransactions(ctx context.Context, arg ListTransactionsParams) ([]Transaction, error) {
-	rows, err := q.db.Query(ctx, listTransactions, arg.AccountID, arg.Limit, arg.Offset)
+// Pages through the account's transactions created in [from_time, to_time), in the
+// order they were applied, continuing after after_seq.
+func (q *Queries) ListTransactionsInPeriod(ctx context.Context, arg ListTransactionsInPeriodParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, listTransactionsInPeriod,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.AfterSeq,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Type,
+			&i.AmountCents,
+			&i.BalanceAfterCents,
+			&i.RelatedAccountID,
+			&i.Reference,
+			&i.CreatedAt,
+			&i.Seq,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTransactionsLargest = `-- name: SearchTransactionsLargest :many
+SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description FROM transactions
+WHERE account_id = $1
+  AND created_at >= $2 AND created_at < $3
+  AND (COALESCE(cardinality($4::text[]), 0) = 0 OR type = ANY($4::text[]::"TransactionType"[]))
+  AND abs(amount_cents) BETWEEN $5::bigint AND $6::bigint
+  AND ($7::bigint IS NULL OR related_account_id = $7)
+  AND ($8::text IS NULL OR reference = $8)
+  AND ($9::text IS NULL OR description ILIKE $9)
+  AND (abs(amount_cents), id) < ($10::bigint, $11::uuid)
+ORDER BY abs(amount_cents) DESC, id DESC
+LIMIT $12
+`
+
+type SearchTransactionsLargestParams struct {
+	AccountID          int64
+	FromTime           pgtype.Timestamptz
+	ToTime             pgtype.Timestamptz
+	Types              []string
+	MinAmountCents     int64
+	MaxAmountCents     int64
+	RelatedAccountID   pgtype.Int8
+	Reference          pgtype.Text
+	DescriptionPattern pgtype.Text
+	AfterAmountCents   int64
+	AfterID            pgtype.UUID
+	Limit              int32
+}
+
+// Searches the account's transactions largest absolute amount first, continuing after
+// the (after_amount_cents, after_id) key. Filters as in SearchTransactionsNewest.
+func (q *Queries) SearchTransactionsLargest(ctx context.Context, arg SearchTransactionsLargestParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, searchTransactionsLargest,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Types,
+		arg.MinAmountCents,
+		arg.MaxAmountCents,
+		arg.RelatedAccountID,
+		arg.Reference,
+		arg.DescriptionPattern,
+		arg.AfterAmountCents,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Type,
+			&i.AmountCents,
+			&i.BalanceAfterCents,
+			&i.RelatedAccountID,
+			&i.Reference,
+			&i.CreatedAt,
+			&i.Seq,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTransactionsNewest = `-- name: SearchTransactionsNewest :many
+SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description FROM transactions
+WHERE account_id = $1
+  AND created_at >= $2 AND created_at < $3
+  AND (COALESCE(cardinality($4::text[]), 0) = 0 OR type = ANY($4::text[]::"TransactionType"[]))
+  AND abs(amount_cents) BETWEEN $5::bigint AND $6::bigint
+  AND ($7::bigint IS NULL OR related_account_id = $7)
+  AND ($8::text IS NULL OR reference = $8)
+  AND ($9::text IS NULL OR description ILIKE $9)
+  AND (created_at, id) < ($10::timestamptz, $11::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT $12
+`
+
+type SearchTransactionsNewestParams struct {
+	AccountID          int64
+	FromTime           pgtype.Timestamptz
+	ToTime             pgtype.Timestamptz
+	Types              []string
+	MinAmountCents     int64
+	MaxAmountCents     int64
+	RelatedAccountID   pgtype.Int8
+	Reference          pgtype.Text
+	DescriptionPattern pgtype.Text
+	AfterCreatedAt     pgtype.Timestamptz
+	AfterID            pgtype.UUID
+	Limit              int32
+}
+
+// Searches the account's transactions newest first, continuing after the
+// (after_created_at, after_id) key. An empty types matches every type; amounts are
+// compared by absolute value.
+func (q *Queries) SearchTransactionsNewest(ctx context.Context, arg SearchTransactionsNewestParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, searchTransactionsNewest,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Types,
+		arg.MinAmountCents,
+		arg.MaxAmountCents,
+		arg.RelatedAccountID,
+		arg.Reference,
+		arg.DescriptionPattern,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Type,
+			&i.AmountCents,
+			&i.BalanceAfterCents,
+			&i.RelatedAccountID,
+			&i.Reference,
+			&i.CreatedAt,
+			&i.Seq,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTransactionsOldest = `-- name: SearchTransactionsOldest :many
+SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description FROM transactions
+WHERE account_id = $1
+  AND created_at >= $2 AND created_at < $3
+  AND (COALESCE(cardinality($4::text[]), 0) = 0 OR type = ANY($4::text[]::"TransactionType"[]))
+  AND abs(amount_cents) BETWEEN $5::bigint AND $6::bigint
+  AND ($7::bigint IS NULL OR related_account_id = $7)
+  AND ($8::text IS NULL OR reference = $8)
+  AND ($9::text IS NULL OR description ILIKE $9)
+  AND (created_at, id) > ($10::timestamptz, $11::uuid)
+ORDER BY created_at, id
+LIMIT $12
+`
+
+type SearchTransactionsOldestParams struct {
+	AccountID          int64
+	FromTime           pgtype.Timestamptz
+	ToTime             pgtype.Timestamptz
+	Types              []string
+	MinAmountCents     int64
+	MaxAmountCents     int64
+	RelatedAccountID   pgtype.Int8
+	Reference          pgtype.Text
+	DescriptionPattern pgtype.Text
+	AfterCreatedAt     pgtype.Timestamptz
+	AfterID            pgtype.UUID
+	Limit              int32
+}
+
+// Searches the account's transactions oldest first, continuing after the
+// (after_created_at, after_id) key. Filters as in SearchTransactionsNewest.
+func (q *Queries) SearchTransactionsOldest(ctx context.Context, arg SearchTransactionsOldestParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, searchTransactionsOldest,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Types,
+		arg.MinAmountCents,
+		arg.MaxAmountCents,
+		arg.RelatedAccountID,
+		arg.Reference,
+		arg.DescriptionPattern,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Type,
+			&i.AmountCents,
+			&i.BalanceAfterCents,
+			&i.RelatedAccountID,
+			&i.Reference,
+			&i.CreatedAt,
+			&i.Seq,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTransactionsSmallest = `-- name: SearchTransactionsSmallest :many
+SELECT id, account_id, type, amount_cents, balance_after_cents, related_account_id, reference, created_at, seq, description FROM transactions
+WHERE account_id = $1
+  AND created_at >= $2 AND created_at < $3
+  AND (COALESCE(cardinality($4::text[]), 0) = 0 OR type = ANY($4::text[]::"TransactionType"[]))
+  AND abs(amount_cents) BETWEEN $5::bigint AND $6::bigint
+  AND ($7::bigint IS NULL OR related_account_id = $7)
+  AND ($8::text IS NULL OR reference = $8)
+  AND ($9::text IS NULL OR description ILIKE $9)
+  AND (abs(amount_cents), id) > ($10::bigint, $11::uuid)
+ORDER BY abs(amount_cents), id
+LIMIT $12
+`
+
+type SearchTransactionsSmallestParams struct {
+	AccountID          int64
+	FromTime           pgtype.Timestamptz
+	ToTime             pgtype.Timestamptz
+	Types              []string
+	MinAmountCents     int64
+	MaxAmountCents     int64
+	RelatedAccountID   pgtype.Int8
+	Reference          pgtype.Text
+	DescriptionPattern pgtype.Text
+	AfterAmountCents   int64
+	AfterID            pgtype.UUID
+	Limit              int32
+}
+
+// Searches the account's transactions smallest absolute amount first, continuing after
+// the (after_amount_cents, after_id) key. Filters as in SearchTransactionsNewest.
+func (q *Queries) SearchTransactionsSmallest(ctx context.Context, arg SearchTransactionsSmallestParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, searchTransactionsSmallest,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Types,
+		arg.MinAmountCents,
+		arg.MaxAmountCents,
+		arg.RelatedAccountID,
+		arg.Reference,
+		arg.DescriptionPattern,
+		arg.AfterAmountCents,
+		arg.AfterID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +652,8 @@ func (q *Queries) ListTransactions(ctx context.Context, arg ListTransactionsPara
 			&i.RelatedAccountID,
 			&i.Reference,
 			&i.CreatedAt,
+			&i.Seq,
+			&i.Description,
 		); err != nil {
 			return nil, err
 		}
@@ -3,13 +3,16 @@ package sqlc
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/RakibRahman/fincore-api/db/dberr"
 	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
-func createRandomTransactionWithQueries(t *testing.T, q *Queries) Transaction {
+func createRandomTransactionWithQueries(t *testing.T, q Querier) Transaction {
 	account := createRandomAccountWithQueries(t, q)
 
 	amountCents := utils.RandomInt(100, 5000)
@@ -81,18 +84,16 @@ func TestListTransactions(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	params := ListTransactionsParams{
+	page, err := q.ListTransactionsPage(ctx, ListTransactionsPageParams{
 		AccountID: account.ID,
 		Limit:     transactionLimit,
-		Offset:    0,
-	}
-
-	transactions, err := q.ListTransactions(ctx, params)
+	})
 
 	require.NoError(t, err)
-	require.Len(t, transactions, transactionLimit) // Exact count since we're in isolated transaction
+	require.Len(t, page.Items, transactionLimit) // Exact count since we're in isolated transaction
+	require.Empty(t, page.NextCursor)
 
-	for _, transaction := range transactions {
+	for _, transaction := range page.Items {
 		require.NotEmpty(t, transaction.ID)
 		require.Equal(t, account.ID, transaction.AccountID)
 		require.NotEmpty(t, transaction.Type)
@@ -131,7 +132,7 @@ func TestCreateTransactionInvalidAccount(t *testing.T) {
 
 	transaction, err := q.CreateTransaction(ctx, arg)
 
-	require.Error(t, err) // Should fail due to foreign key constraint
+	require.ErrorIs(t, err, dberr.ErrAccountNotFound)
 	require.Empty(t, transaction.ID)
 }
 
@@ -141,14 +142,216 @@ func TestListTransactionsEmptyResult(t *testing.T) {
 	// Create an account with no transactions
 	account := createRandomAccountWithQueries(t, q)
 
-	params := ListTransactionsParams{
+	page, err := q.ListTransactionsPage(ctx, ListTransactionsPageParams{
 		AccountID: account.ID,
 		Limit:     10,
-		Offset:    0,
+	})
+
+	require.NoError(t, err)
+	require.Empty(t, page.Items) // Should return empty list, not error
+	require.Empty(t, page.NextCursor)
+	require.Empty(t, page.PrevCursor)
+}
+
+func TestListTransactionsPageCursors(t *testing.T) {
+	_, q := createTestTx(t)
+	ctx := context.Background()
+	account := createRandomAccountWithQueries(t, q)
+
+	// now() is fixed for the whole transaction, so every row has the same created_at
+	// and only the id keeps the pages apart
+	balance := account.BalanceCents
+	for i := 0; i < 5; i++ {
+		balance += 100
+		_, err := q.CreateTransaction(ctx, CreateTransactionParams{
+			AccountID:         account.ID,
+			Type:              TransactionTypeDeposit,
+			AmountCents:       100,
+			BalanceAfterCents: balance,
+		})
+		require.NoError(t, err)
+	}
+
+	var pages []Page[Transaction]
+	var seen []pgtype.UUID
+	cursor := ""
+	for {
+		page, err := q.ListTransactionsPage(ctx, ListTransactionsPageParams{AccountID: account.ID, Cursor: cursor, Limit: 2})
+		require.NoError(t, err)
+		pages = append(pages, page)
+		for _, transaction := range page.Items {
+			require.NotContains(t, seen, transaction.ID)
+			seen = append(seen, transaction.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	require.Len(t, pages, 3)
+	require.Len(t, seen, 5)
+	require.Empty(t, pages[0].PrevCursor)
+
+	// Going back from the last page gives the middle page again
+	back, err := q.ListTransactionsPage(ctx, ListTransactionsPageParams{AccountID: account.ID, Cursor: pages[2].PrevCursor, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, pages[1].Items, back.Items)
+	require.NotEmpty(t, back.PrevCursor)
+	require.NotEmpty(t, back.NextCursor)
+
+	_, err = q.ListTransfersByAccountPage(ctx, ListTransfersByAccountPageParams{AccountID: account.ID, Cursor: cursor, Limit: 2})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestListTransactionsInPeriod(t *testing.T) {
+	_, q := createTestTx(t)
+	ctx := context.Background()
+	account := createRandomAccountWithQueries(t, q)
+
+	balance := account.BalanceCents
+	for i := 0; i < 3; i++ {
+		balance += 100
+		_, err := q.CreateTransaction(ctx, CreateTransactionParams{
+			AccountID:         account.ID,
+			Type:              TransactionTypeDeposit,
+			AmountCents:       100,
+			BalanceAfterCents: balance,
+		})
+		require.NoError(t, err)
+	}
+
+	now := time.Now()
+	arg := ListTransactionsInPeriodParams{
+		AccountID: account.ID,
+		FromTime:  pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true},
+		ToTime:    pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true},
+		Limit:     2,
+	}
+	page1, err := q.ListTransactionsInPeriod(ctx, arg)
+	require.NoError(t, err)
+	require.Len(t, page1, 2)
+	require.Less(t, page1[0].Seq, page1[1].Seq)
+
+	arg.AfterSeq = page1[1].Seq
+	page2, err := q.ListTransactionsInPeriod(ctx, arg)
+	require.NoError(t, err)
+	require.Len(t, page2, 1)
+	require.Equal(t, balance, page2[0].BalanceAfterCents)
+
+	last, err := q.GetLastTransactionBefore(ctx, GetLastTransactionBeforeParams{AccountID: account.ID, Before: arg.ToTime})
+	require.NoError(t, err)
+	require.Equal(t, page2[0].ID, last.ID)
+
+	_, err = q.GetLastTransactionBefore(ctx, GetLastTransactionBeforeParams{AccountID: account.ID, Before: arg.FromTime})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestSearchTransactionsPage(t *testing.T) {
+	_, q := createTestTx(t)
+	ctx := context.Background()
+	account := createRandomAccountWithQueries(t, q)
+	other := createRandomAccountWithQueries(t, q)
+
+	create := func(txType TransactionType, amount int64, related pgtype.Int8, description string) Transaction {
+		transaction, err := q.CreateTransaction(ctx, CreateTransactionParams{
+			AccountID:         account.ID,
+			Type:              txType,
+			AmountCents:       amount,
+			BalanceAfterCents: account.BalanceCents,
+			RelatedAccountID:  related,
+			Description:       pgtype.Text{String: description, Valid: description != ""},
+		})
+		require.NoError(t, err)
+		return transaction
+	}
+	toOther := pgtype.Int8{Int64: other.ID, Valid: true}
+	rent := create(TransactionTypeTransferOut, -120000, toOther, "Rent for March")
+	refund := create(TransactionTypeTransferIn, 2500, toOther, "100% refund_")
+	salary := create(TransactionTypeDeposit, 300000, pgtype.Int8{}, "Salary")
+	cash := create(TransactionTypeWithdrawal, -2500, pgtype.Int8{}, "")
+
+	ids := func(transactions []Transaction) []pgtype.UUID {
+		var ids []pgtype.UUID
+		for _, transaction := range transactions {
+			ids = append(ids, transaction.ID)
+		}
+		return ids
 	}
 
-	transactions, err := q.ListTransactions(ctx, params)
+	testCases := []struct {
+		name string
+		arg  SearchTransactionsPageParams
+		want []Transaction
+	}{
+		{
+			name: "Largest",
+			arg:  SearchTransactionsPageParams{Sort: TransactionSortLargest},
+			want: []Transaction{salary, rent, refund, cash},
+		},
+		{
+			name: "Types",
+			arg:  SearchTransactionsPageParams{Types: []TransactionType{TransactionTypeDeposit, TransactionTypeWithdrawal}, Sort: TransactionSortLargest},
+			want: []Transaction{salary, cash},
+		},
+		{
+			name: "AmountRange",
+			arg:  SearchTransactionsPageParams{MinAmountCents: 2500, MaxAmountCents: 120000, Sort: TransactionSortLargest},
+			want: []Transaction{rent, refund, cash},
+		},
+		{
+			name: "Counterparty",
+			arg:  SearchTransactionsPageParams{RelatedAccountID: toOther, Sort: TransactionSortLargest},
+			want: []Transaction{rent, refund},
+		},
+		{
+			name: "DescriptionIgnoresCase",
+			arg:  SearchTransactionsPageParams{DescriptionContains: "RENT"},
+			want: []Transaction{rent},
+		},
+		{
+			name: "DescriptionWildcardsAreLiteral",
+			arg:  SearchTransactionsPageParams{DescriptionContains: "% refund_"},
+			want: []Transaction{refund},
+		},
+		{
+			name: "CreatedToExcludesNow",
+			arg:  SearchTransactionsPageParams{CreatedTo: rent.CreatedAt},
+		},
+	}
 
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			arg := tc.arg
+			arg.AccountID = account.ID
+			arg.Limit = 10
+			page, err := q.SearchTransactionsPage(ctx, arg)
+			require.NoError(t, err)
+			if tc.arg.Sort == TransactionSortLargest {
+				require.Equal(t, ids(tc.want), ids(page.Items))
+			} else {
+				require.ElementsMatch(t, ids(tc.want), ids(page.Items))
+			}
+		})
+	}
+
+	// Paging by amount walks both ways like the time-ordered lists
+	first, err := q.SearchTransactionsPage(ctx, SearchTransactionsPageParams{AccountID: account.ID, Sort: TransactionSortSmallest, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+	require.ElementsMatch(t, []pgtype.UUID{refund.ID, cash.ID}, ids(first.Items))
+
+	second, err := q.SearchTransactionsPage(ctx, SearchTransactionsPageParams{AccountID: account.ID, Sort: TransactionSortSmallest, Cursor: first.NextCursor, Limit: 2})
 	require.NoError(t, err)
-	require.Empty(t, transactions) // Should return empty list, not error
+	require.Equal(t, []pgtype.UUID{rent.ID, salary.ID}, ids(second.Items))
+
+	back, err := q.SearchTransactionsPage(ctx, SearchTransactionsPageParams{AccountID: account.ID, Sort: TransactionSortSmallest, Cursor: second.PrevCursor, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, ids(first.Items), ids(back.Items))
+
+	// A cursor only continues the sort it came from
+	_, err = q.SearchTransactionsPage(ctx, SearchTransactionsPageParams{AccountID: account.ID, Sort: TransactionSortLargest, Cursor: first.NextCursor, Limit: 2})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = q.SearchTransactionsPage(ctx, SearchTransactionsPageParams{AccountID: account.ID, Sort: "alphabetical", Limit: 2})
+	require.ErrorIs(t, err, ErrInvalidTransactionSort)
 }
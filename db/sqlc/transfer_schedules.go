@@ -0,0 +1,248 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInvalidSchedule     = errors.New("invalid transfer schedule")
+	ErrScheduleRunRecorded = errors.New("schedule run was already recorded")
+)
+
+const defaultScheduleMaxAttempts = 3
+
+type ScheduleTransferParams struct {
+	FromAccountID int64
+	ToAccountID   int64
+	AmountCents   int64
+	Frequency     ScheduleFrequency
+	// StartsAt is when the first transfer is due. Monthly schedules start on the first
+	// DayOfMonth at or after it, at StartsAt's time of day.
+	StartsAt time.Time
+	// DayOfMonth is the day monthly transfers run on, clamped to the last day of shorter
+	// months. Zero takes StartsAt's day. Only valid for monthly schedules.
+	DayOfMonth int32
+	// EndsAt stops the schedule after the last occurrence at or before it. Zero runs forever.
+	EndsAt time.Time
+	// InsufficientFundsPolicy decides what a run refused for insufficient funds does;
+	// empty means skip.
+	InsufficientFundsPolicy InsufficientFundsPolicy
+	// MaxAttempts bounds the attempts of one occurrence under the retry policy; zero means 3.
+	MaxAttempts int32
+}
+
+// ScheduleTransfer sets up a future-dated or recurring transfer. Both accounts must be able
+// to move money and share a currency when the schedule is created; the transfers themselves
+// are made later by the scheduler, through TransferMoneyTx, which checks everything again.
+func (store *SQLStore) ScheduleTransfer(ctx context.Context, arg ScheduleTransferParams) (TransferSchedule, error) {
+	if arg.AmountCents <= 0 {
+		return TransferSchedule{}, ErrInvalidAmount
+	}
+	if arg.FromAccountID == arg.ToAccountID {
+		return TransferSchedule{}, fmt.Errorf("%w: cannot transfer to the same account", ErrInvalidSchedule)
+	}
+
+	createArg := CreateTransferScheduleParams{
+		FromAccountID:           arg.FromAccountID,
+		ToAccountID:             arg.ToAccountID,
+		AmountCents:             arg.AmountCents,
+		Frequency:               arg.Frequency,
+		InsufficientFundsPolicy: arg.InsufficientFundsPolicy,
+		MaxAttempts:             arg.MaxAttempts,
+	}
+	if createArg.InsufficientFundsPolicy == "" {
+		createArg.InsufficientFundsPolicy = InsufficientFundsPolicySkip
+	}
+	if createArg.MaxAttempts == 0 {
+		createArg.MaxAttempts = defaultScheduleMaxAttempts
+	}
+
+	if err := validateSchedule(arg, createArg); err != nil {
+		return TransferSchedule{}, err
+	}
+
+	startsAt := arg.StartsAt.UTC()
+	if arg.Frequency == ScheduleFrequencyMonthly {
+		day := arg.DayOfMonth
+		if day == 0 {
+			day = int32(startsAt.Day())
+		}
+		createArg.DayOfMonth = pgtype.Int4{Int32: day, Valid: true}
+
+		first := onDayOfMonth(startsAt, startsAt.Year(), startsAt.Month(), int(day))
+		if first.Before(startsAt) {
+			first = onDayOfMonth(startsAt, startsAt.Year(), startsAt.Month()+1, int(day))
+		}
+		startsAt = first
+	}
+	if !arg.EndsAt.IsZero() {
+		if arg.EndsAt.Before(startsAt) {
+			return TransferSchedule{}, fmt.Errorf("%w: schedule ends before its first transfer", ErrInvalidSchedule)
+		}
+		createArg.EndsAt = pgtype.Timestamptz{Time: arg.EndsAt, Valid: true}
+	}
+	createArg.NextOccurrenceAt = pgtype.Timestamptz{Time: startsAt, Valid: true}
+
+	fromAccount, err := store.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return TransferSchedule{}, err
+	}
+	toAccount, err := store.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return TransferSchedule{}, err
+	}
+	if err := store.checkDebit(fromAccount); err != nil {
+		return TransferSchedule{}, err
+	}
+	if err := store.checkCredit(toAccount); err != nil {
+		return TransferSchedule{}, err
+	}
+	if fromAccount.Currency != toAccount.Currency {
+		return TransferSchedule{}, fmt.Errorf("%w: %s to %s", ErrCurrencyMismatch, fromAccount.Currency, toAccount.Currency)
+	}
+
+	return store.CreateTransferSchedule(ctx, createArg)
+}
+
+func validateSchedule(arg ScheduleTransferParams, createArg CreateTransferScheduleParams) error {
+	switch arg.Frequency {
+	case ScheduleFrequencyOnce, ScheduleFrequencyDaily, ScheduleFrequencyWeekly, ScheduleFrequencyMonthly:
+	default:
+		return fmt.Errorf("%w: unknown frequency %q", ErrInvalidSchedule, arg.Frequency)
+	}
+	switch createArg.InsufficientFundsPolicy {
+	case InsufficientFundsPolicyRetry, InsufficientFundsPolicySkip:
+	default:
+		return fmt.Errorf("%w: unknown insufficient funds policy %q", ErrInvalidSchedule, arg.InsufficientFundsPolicy)
+	}
+	if arg.DayOfMonth != 0 && arg.Frequency != ScheduleFrequencyMonthly {
+		return fmt.Errorf("%w: day of month is only valid for monthly schedules", ErrInvalidSchedule)
+	}
+	if arg.DayOfMonth < 0 || arg.DayOfMonth > 31 {
+		return fmt.Errorf("%w: day of month must be between 1 and 31", ErrInvalidSchedule)
+	}
+	if createArg.MaxAttempts < 1 {
+		return fmt.Errorf("%w: max attempts must be positive", ErrInvalidSchedule)
+	}
+	if arg.StartsAt.IsZero() {
+		return fmt.Errorf("%w: schedule needs a start time", ErrInvalidSchedule)
+	}
+	return nil
+}
+
+// NextOccurrence returns the occurrence that follows the one due at after, and false
+// when the schedule has no more occurrences.
+func (schedule TransferSchedule) NextOccurrence(after time.Time) (time.Time, bool) {
+	after = after.UTC()
+
+	var next time.Time
+	switch schedule.Frequency {
+	case ScheduleFrequencyDaily:
+		next = after.AddDate(0, 0, 1)
+	case ScheduleFrequencyWeekly:
+		next = after.AddDate(0, 0, 7)
+	case ScheduleFrequencyMonthly:
+		next = onDayOfMonth(after, after.Year(), after.Month()+1, int(schedule.DayOfMonth.Int32))
+	default:
+		return time.Time{}, false
+	}
+
+	if schedule.EndsAt.Valid && next.After(schedule.EndsAt.Time) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// onDayOfMonth returns day of the given month at clock's time of day, moved back to the
+// month's last day when the month is shorter. month may run past December.
+func onDayOfMonth(clock time.Time, year int, month time.Month, day int) time.Time {
+	if last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > last {
+		day = last
+	}
+	return time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), time.UTC)
+}
+
+// ScheduleRunOutcome is how a schedule moves on after one attempt.
+type ScheduleRunOutcome struct {
+	Status        ScheduleRunStatus
+	FailureReason pgtype.Text
+	// NextStatus, NextFailedAttempts, NextOccurrenceAt and NextRunAt are the
+	// schedule's state after the run.
+	NextStatus         ScheduleStatus
+	NextFailedAttempts int32
+	NextOccurrenceAt   time.Time
+	NextRunAt          time.Time
+}
+
+type RunTransferScheduleTxParams struct {
+	// Schedule is the schedule as it was claimed for the run.
+	Schedule TransferSchedule
+	// Outcome decides how the schedule moves on from the business rule its transfer was
+	// refused for, nil when the transfer completed.
+	Outcome func(transferErr error) ScheduleRunOutcome
+}
+
+type RunTransferScheduleTxResult struct {
+	Transfer TransferMoneyResult
+	Schedule TransferSchedule
+	Run      TransferScheduleRun
+}
+
+// RunTransferScheduleTx makes one attempt of a claimed schedule: it transfers the
+// schedule's amount, records the run and moves the schedule on, releasing the worker's
+// lease. A transfer refused by a business rule is committed as failed along with its run.
+//
+// The transfer and its record commit together, so a run is only ever made once per
+// occurrence and attempt. It fails with ErrScheduleRunRecorded, rolling the transfer back,
+// when another worker already ran that attempt after the lease ran out.
+func (store *SQLStore) RunTransferScheduleTx(ctx context.Context, arg RunTransferScheduleTxParams) (RunTransferScheduleTxResult, error) {
+	var result RunTransferScheduleTxResult
+	schedule := arg.Schedule
+
+	err := store.executeTransaction(ctx, TxRunSchedule, func(q storeQueries) error {
+		var failure, err error
+		result.Transfer, failure, err = store.transfer(ctx, q, TransferMoneyTxParams{
+			FromAccountID: schedule.FromAccountID,
+			ToAccountID:   schedule.ToAccountID,
+			AmountCents:   schedule.AmountCents,
+		})
+		if err != nil {
+			return err
+		}
+		outcome := arg.Outcome(failure)
+
+		result.Schedule, err = q.AdvanceTransferSchedule(ctx, AdvanceTransferScheduleParams{
+			ID:                schedule.ID,
+			Status:            outcome.NextStatus,
+			FailedAttempts:    outcome.NextFailedAttempts,
+			NextOccurrenceAt:  pgtype.Timestamptz{Time: outcome.NextOccurrenceAt, Valid: true},
+			NextRunAt:         pgtype.Timestamptz{Time: outcome.NextRunAt, Valid: true},
+			RanOccurrenceAt:   schedule.NextOccurrenceAt,
+			RanFailedAttempts: schedule.FailedAttempts,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrScheduleRunRecorded
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Run, err = q.CreateTransferScheduleRun(ctx, CreateTransferScheduleRunParams{
+			ScheduleID:    schedule.ID,
+			OccurrenceAt:  schedule.NextOccurrenceAt,
+			Attempt:       schedule.FailedAttempts + 1,
+			Status:        outcome.Status,
+			TransferID:    result.Transfer.Transfer.ID,
+			FailureReason: outcome.FailureReason,
+		})
+		return err
+	})
+
+	return result, err
+}
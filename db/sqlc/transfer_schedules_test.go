@@ -0,0 +1,307 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferScheduleNextOccurrence(t *testing.T) {
+	jan31 := time.Date(2026, 1, 31, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		schedule TransferSchedule
+		after    time.Time
+		want     time.Time
+		ok       bool
+	}{
+		{name: "Once", schedule: TransferSchedule{Frequency: ScheduleFrequencyOnce}, after: jan31},
+		{name: "Daily", schedule: TransferSchedule{Frequency: ScheduleFrequencyDaily}, after: jan31, want: jan31.AddDate(0, 0, 1), ok: true},
+		{name: "Weekly", schedule: TransferSchedule{Frequency: ScheduleFrequencyWeekly}, after: jan31, want: jan31.AddDate(0, 0, 7), ok: true},
+		{
+			name:     "MonthlyClampsToFebruary",
+			schedule: TransferSchedule{Frequency: ScheduleFrequencyMonthly, DayOfMonth: pgtype.Int4{Int32: 31, Valid: true}},
+			after:    jan31,
+			want:     time.Date(2026, 2, 28, 9, 30, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			// The day of month is kept after a short month
+			name:     "MonthlyAfterShortMonth",
+			schedule: TransferSchedule{Frequency: ScheduleFrequencyMonthly, DayOfMonth: pgtype.Int4{Int32: 31, Valid: true}},
+			after:    time.Date(2026, 2, 28, 9, 30, 0, 0, time.UTC),
+			want:     time.Date(2026, 3, 31, 9, 30, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "MonthlyAcrossYear",
+			schedule: TransferSchedule{Frequency: ScheduleFrequencyMonthly, DayOfMonth: pgtype.Int4{Int32: 15, Valid: true}},
+			after:    time.Date(2026, 12, 15, 9, 30, 0, 0, time.UTC),
+			want:     time.Date(2027, 1, 15, 9, 30, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name: "PastEnd",
+			schedule: TransferSchedule{
+				Frequency: ScheduleFrequencyDaily,
+				EndsAt:    pgtype.Timestamptz{Time: jan31.Add(time.Hour), Valid: true},
+			},
+			after: jan31,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, ok := tc.schedule.NextOccurrence(tc.after)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.want, next)
+		})
+	}
+}
+
+func TestScheduleTransfer(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	from := createAccountInCurrency(t, store, CurrencyUSD, 1000)
+	to := createAccountInCurrency(t, store, CurrencyUSD, 0)
+
+	startsAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	schedule, err := store.ScheduleTransfer(ctx, ScheduleTransferParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		AmountCents:   250,
+		Frequency:     ScheduleFrequencyMonthly,
+		StartsAt:      startsAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduleStatusActive, schedule.Status)
+	require.Equal(t, int32(startsAt.Day()), schedule.DayOfMonth.Int32)
+	require.Equal(t, InsufficientFundsPolicySkip, schedule.InsufficientFundsPolicy)
+	require.Equal(t, int32(defaultScheduleMaxAttempts), schedule.MaxAttempts)
+	require.True(t, startsAt.Equal(schedule.NextOccurrenceAt.Time))
+	require.True(t, startsAt.Equal(schedule.NextRunAt.Time))
+
+	testCases := []struct {
+		name    string
+		arg     ScheduleTransferParams
+		wantErr error
+	}{
+		{
+			name:    "DayOfMonthNotMonthly",
+			arg:     ScheduleTransferParams{FromAccountID: from.ID, ToAccountID: to.ID, AmountCents: 1, Frequency: ScheduleFrequencyDaily, StartsAt: startsAt, DayOfMonth: 3},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name:    "EndsBeforeStart",
+			arg:     ScheduleTransferParams{FromAccountID: from.ID, ToAccountID: to.ID, AmountCents: 1, Frequency: ScheduleFrequencyDaily, StartsAt: startsAt, EndsAt: startsAt.Add(-time.Hour)},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name:    "UnknownFrequency",
+			arg:     ScheduleTransferParams{FromAccountID: from.ID, ToAccountID: to.ID, AmountCents: 1, Frequency: "hourly", StartsAt: startsAt},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name:    "NonPositiveAmount",
+			arg:     ScheduleTransferParams{FromAccountID: from.ID, ToAccountID: to.ID, Frequency: ScheduleFrequencyOnce, StartsAt: startsAt},
+			wantErr: ErrInvalidAmount,
+		},
+		{
+			name:    "CurrencyMismatch",
+			arg:     ScheduleTransferParams{FromAccountID: from.ID, ToAccountID: createAccountInCurrency(t, store, CurrencyEUR, 0).ID, AmountCents: 1, Frequency: ScheduleFrequencyOnce, StartsAt: startsAt},
+			wantErr: ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.ScheduleTransfer(ctx, tc.arg)
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+// createDueSchedule creates a daily schedule between two fresh accounts whose first
+// occurrence is already due.
+func createDueSchedule(t *testing.T, store Store, balance int64) TransferSchedule {
+	from := createAccountInCurrency(t, store, CurrencyUSD, balance)
+	to := createAccountInCurrency(t, store, CurrencyUSD, 0)
+
+	schedule, err := store.ScheduleTransfer(context.Background(), ScheduleTransferParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		AmountCents:   100,
+		Frequency:     ScheduleFrequencyDaily,
+		StartsAt:      time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	return schedule
+}
+
+// claimSchedule claims due schedules until it gets the one with the given ID.
+func claimSchedule(t *testing.T, store Store, id pgtype.UUID, now time.Time) (TransferSchedule, bool) {
+	schedules, err := store.ClaimDueTransferSchedules(context.Background(), ClaimDueTransferSchedulesParams{
+		LockedUntil: pgtype.Timestamptz{Time: now.Add(time.Minute), Valid: true},
+		Now:         pgtype.Timestamptz{Time: now, Valid: true},
+		Limit:       1000,
+	})
+	require.NoError(t, err)
+	for _, schedule := range schedules {
+		if schedule.ID == id {
+			return schedule, true
+		}
+	}
+	return TransferSchedule{}, false
+}
+
+func TestClaimDueTransferSchedules(t *testing.T) {
+	store := NewStore(testDB)
+	now := time.Now()
+	schedule := createDueSchedule(t, store, 1000)
+
+	claimed, ok := claimSchedule(t, store, schedule.ID, now)
+	require.True(t, ok)
+	require.True(t, claimed.LockedUntil.Valid)
+
+	// Leased to the first worker until the lease runs out
+	_, ok = claimSchedule(t, store, schedule.ID, now)
+	require.False(t, ok)
+
+	_, ok = claimSchedule(t, store, schedule.ID, now.Add(2*time.Minute))
+	require.True(t, ok)
+}
+
+// succeeded moves a daily schedule on to its next occurrence.
+func succeeded(t *testing.T, schedule TransferSchedule) func(error) ScheduleRunOutcome {
+	return func(transferErr error) ScheduleRunOutcome {
+		require.NoError(t, transferErr)
+		next, ok := schedule.NextOccurrence(schedule.NextOccurrenceAt.Time)
+		require.True(t, ok)
+		return ScheduleRunOutcome{
+			Status:           ScheduleRunStatusSucceeded,
+			NextStatus:       ScheduleStatusActive,
+			NextOccurrenceAt: next,
+			NextRunAt:        next,
+		}
+	}
+}
+
+func TestRunTransferScheduleTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	now := time.Now()
+	schedule := createDueSchedule(t, store, 1000)
+
+	claimed, ok := claimSchedule(t, store, schedule.ID, now)
+	require.True(t, ok)
+
+	arg := RunTransferScheduleTxParams{Schedule: claimed, Outcome: succeeded(t, claimed)}
+	result, err := store.RunTransferScheduleTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCompleted, result.Transfer.Transfer.Status)
+	require.False(t, result.Transfer.Transfer.Reference.Valid)
+	require.Equal(t, int64(900), result.Transfer.FromAccount.BalanceCents)
+
+	next, _ := claimed.NextOccurrence(claimed.NextOccurrenceAt.Time)
+	require.True(t, next.Equal(result.Schedule.NextRunAt.Time))
+	require.False(t, result.Schedule.LockedUntil.Valid)
+	require.Equal(t, int32(1), result.Run.Attempt)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Run.TransferID)
+	require.True(t, claimed.NextOccurrenceAt.Time.Equal(result.Run.OccurrenceAt.Time))
+
+	// A second worker running the same attempt finds it already done, and its transfer
+	// is rolled back
+	_, err = store.RunTransferScheduleTx(ctx, arg)
+	require.ErrorIs(t, err, ErrScheduleRunRecorded)
+
+	from, err := store.GetAccount(ctx, claimed.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, int64(900), from.BalanceCents)
+
+	runs, err := store.ListTransferScheduleRunsPage(ctx, ListTransferScheduleRunsPageParams{ScheduleID: schedule.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, runs.Items, 1)
+}
+
+func TestRunTransferScheduleTx_Refused(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	now := time.Now()
+	schedule := createDueSchedule(t, store, 0)
+
+	claimed, ok := claimSchedule(t, store, schedule.ID, now)
+	require.True(t, ok)
+
+	result, err := store.RunTransferScheduleTx(ctx, RunTransferScheduleTxParams{
+		Schedule: claimed,
+		Outcome: func(transferErr error) ScheduleRunOutcome {
+			require.ErrorIs(t, transferErr, ErrInsufficientBalance)
+			return ScheduleRunOutcome{
+				Status:             ScheduleRunStatusRetrying,
+				FailureReason:      pgtype.Text{String: transferErr.Error(), Valid: true},
+				NextStatus:         ScheduleStatusActive,
+				NextFailedAttempts: 1,
+				NextOccurrenceAt:   claimed.NextOccurrenceAt.Time,
+				NextRunAt:          now.Add(time.Hour),
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	// The refused transfer is committed as failed along with the run
+	require.Equal(t, TransferStatusFailed, result.Transfer.Transfer.Status)
+	require.Equal(t, ScheduleRunStatusRetrying, result.Run.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Run.TransferID)
+	require.Equal(t, int32(1), result.Schedule.FailedAttempts)
+
+	transfer, err := store.GetTransfer(ctx, result.Transfer.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusFailed, transfer.Status)
+}
+
+func TestRunTransferScheduleTx_CallerKeyLikeOldRunKey(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	now := time.Now()
+	schedule := createDueSchedule(t, store, 1000)
+	other := createAccountInCurrency(t, store, CurrencyUSD, 0)
+
+	// runs used to be keyed on this, so a transfer claiming it first blocked the schedule
+	_, err := store.TransferMoneyTx(ctx, TransferMoneyTxParams{
+		FromAccountID: schedule.FromAccountID,
+		ToAccountID:   other.ID,
+		AmountCents:   1,
+		IdempotencyKey: pgtype.Text{
+			String: fmt.Sprintf("schedule:%s:%d:1", schedule.ID, schedule.NextOccurrenceAt.Time.Unix()),
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+
+	claimed, ok := claimSchedule(t, store, schedule.ID, now)
+	require.True(t, ok)
+
+	result, err := store.RunTransferScheduleTx(ctx, RunTransferScheduleTxParams{Schedule: claimed, Outcome: succeeded(t, claimed)})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCompleted, result.Transfer.Transfer.Status)
+	require.Equal(t, schedule.ToAccountID, result.Transfer.ToAccount.ID)
+	require.Equal(t, int64(899), result.Transfer.FromAccount.BalanceCents)
+}
+
+func TestCancelTransferSchedule(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+	schedule := createDueSchedule(t, store, 0)
+
+	cancelled, err := store.CancelTransferSchedule(ctx, schedule.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduleStatusCancelled, cancelled.Status)
+
+	_, ok := claimSchedule(t, store, schedule.ID, time.Now())
+	require.False(t, ok)
+}
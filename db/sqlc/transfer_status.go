@@ -0,0 +1,102 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrInvalidTransferTransition is returned when a transfer is asked to move to a
+// status its current status doesn't allow.
+var ErrInvalidTransferTransition = errors.New("transfer status transition not allowed")
+
+// Reasons recorded on failed transfers.
+const (
+	TransferFailureInsufficientBalance = "insufficient_balance"
+	TransferFailureFromAccountFrozen   = "from_account_frozen"
+	TransferFailureFromAccountClosed   = "from_account_closed"
+	TransferFailureToAccountFrozen     = "to_account_frozen"
+	TransferFailureToAccountClosed     = "to_account_closed"
+	TransferFailureCurrencyMismatch    = "currency_mismatch"
+	TransferFailureNoExchangeRate      = "exchange_rate_unavailable"
+	TransferFailureConvertedAmountZero = "converted_amount_zero"
+)
+
+// transferRejection is a business-rule refusal: rather than aborting the database
+// transaction, it settles the transfer as failed with reason.
+type transferRejection struct {
+	reason string
+	err    error
+}
+
+// transferTransitions is the transfer state machine: the statuses each status may
+// move to. A transfer starts pending and is settled as completed, failed or
+// cancelled; only a completed transfer can later be reversed. Statuses without an
+// entry are final.
+var transferTransitions = map[TransferStatus][]TransferStatus{
+	TransferStatusPending:   {TransferStatusCompleted, TransferStatusFailed, TransferStatusCancelled},
+	TransferStatusCompleted: {TransferStatusReversed},
+}
+
+// CanTransitionTo reports whether a transfer in status s may move to next.
+func (s TransferStatus) CanTransitionTo(next TransferStatus) bool {
+	for _, allowed := range transferTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal reports whether no further transitions are possible from s.
+func (s TransferStatus) IsFinal() bool {
+	return len(transferTransitions[s]) == 0
+}
+
+// transitionTransfer moves transfer to next, recording failureReason for failed
+// transfers. The update only applies if the row still has transfer's status, so a
+// transition racing another one fails instead of overwriting it.
+func (q storeQueries) transitionTransfer(ctx context.Context, transfer Transfer, next TransferStatus, failureReason string) (Transfer, error) {
+	if !transfer.Status.CanTransitionTo(next) {
+		return transfer, fmt.Errorf("%w: %s to %s", ErrInvalidTransferTransition, transfer.Status, next)
+	}
+
+	updated, err := q.UpdateTransferStatus(ctx, UpdateTransferStatusParams{
+		Status:        next,
+		FailureReason: pgtype.Text{String: failureReason, Valid: failureReason != ""},
+		ID:            transfer.ID,
+		FromStatus:    transfer.Status,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return transfer, fmt.Errorf("%w: transfer is no longer %s", ErrInvalidTransferTransition, transfer.Status)
+	}
+	return updated, err
+}
+
+// transferFailure rebuilds the error a failed transfer was rejected with, so replays
+// fail the same way.
+func transferFailure(transfer Transfer) error {
+	switch reason := transfer.FailureReason.String; reason {
+	case TransferFailureInsufficientBalance:
+		return ErrInsufficientBalance
+	case TransferFailureFromAccountFrozen:
+		return &AccountStatusError{AccountID: transfer.FromAccountID, Status: AccountStatusFrozen, Debit: true}
+	case TransferFailureFromAccountClosed:
+		return &AccountStatusError{AccountID: transfer.FromAccountID, Status: AccountStatusClosed, Debit: true}
+	case TransferFailureToAccountFrozen:
+		return &AccountStatusError{AccountID: transfer.ToAccountID, Status: AccountStatusFrozen}
+	case TransferFailureToAccountClosed:
+		return &AccountStatusError{AccountID: transfer.ToAccountID, Status: AccountStatusClosed}
+	case TransferFailureCurrencyMismatch:
+		return ErrCurrencyMismatch
+	case TransferFailureNoExchangeRate:
+		return ErrExchangeRateNotFound
+	case TransferFailureConvertedAmountZero:
+		return ErrConvertedAmountZero
+	default:
+		return fmt.Errorf("transfer failed: %s", reason)
+	}
+}
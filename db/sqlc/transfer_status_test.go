@@ -0,0 +1,82 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferStatusTransitions(t *testing.T) {
+	allowed := map[TransferStatus][]TransferStatus{
+		TransferStatusPending:   {TransferStatusCompleted, TransferStatusFailed, TransferStatusCancelled},
+		TransferStatusCompleted: {TransferStatusReversed},
+	}
+
+	statuses := []TransferStatus{
+		TransferStatusPending,
+		TransferStatusCompleted,
+		TransferStatusFailed,
+		TransferStatusCancelled,
+		TransferStatusReversed,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			require.Equal(t, contains(allowed[from], to), from.CanTransitionTo(to), "%s -> %s", from, to)
+		}
+		require.Equal(t, len(allowed[from]) == 0, from.IsFinal(), from)
+	}
+}
+
+func contains(statuses []TransferStatus, status TransferStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUpdateTransferStatus(t *testing.T) {
+	_, q := createTestTx(t)
+	transfer := createRandomTransferWithQueries(t, q)
+	require.False(t, transfer.ProcessedAt.Valid)
+	ctx := context.Background()
+
+	completed, err := q.transitionTransfer(ctx, transfer, TransferStatusCompleted, "")
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCompleted, completed.Status)
+	require.True(t, completed.ProcessedAt.Valid)
+	require.False(t, completed.FailureReason.Valid)
+
+	// a stale copy of the transfer can't be transitioned again
+	_, err = q.transitionTransfer(ctx, transfer, TransferStatusFailed, TransferFailureInsufficientBalance)
+	require.ErrorIs(t, err, ErrInvalidTransferTransition)
+
+	// processed_at keeps the time the transfer left pending
+	reversed, err := q.transitionTransfer(ctx, completed, TransferStatusReversed, "")
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusReversed, reversed.Status)
+	require.Equal(t, completed.ProcessedAt, reversed.ProcessedAt)
+
+	locked, err := q.GetTransferForUpdate(ctx, transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, reversed, locked)
+}
+
+func TestUpdateTransferStatusInvalidTransition(t *testing.T) {
+	_, q := createTestTx(t)
+	transfer := createRandomTransferWithQueries(t, q)
+	ctx := context.Background()
+
+	_, err := q.transitionTransfer(ctx, transfer, TransferStatusReversed, "")
+	require.ErrorIs(t, err, ErrInvalidTransferTransition)
+
+	failed, err := q.transitionTransfer(ctx, transfer, TransferStatusFailed, TransferFailureInsufficientBalance)
+	require.NoError(t, err)
+	require.Equal(t, TransferFailureInsufficientBalance, failed.FailureReason.String)
+
+	_, err = q.transitionTransfer(ctx, failed, TransferStatusCompleted, "")
+	require.ErrorIs(t, err, ErrInvalidTransferTransition)
+}
@@ -15,21 +15,35 @@ const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount_cents
+  amount_cents,
+  reference,
+  to_amount_cents,
+  exchange_rate
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4,
+  $5, $6
 )
-RETURNING id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at
+RETURNING id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at, reference, failure_reason, to_amount_cents, exchange_rate
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64
-	ToAccountID   int64
-	AmountCents   int64
+	FromAccountID  int64
+	ToAccountID    int64
+	AmountCents    int64
+	IdempotencyKey pgtype.Text
+	ToAmountCents  pgtype.Int8
+	ExchangeRate   pgtype.Numeric
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRow(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.AmountCents)
+	row := q.db.QueryRow(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.AmountCents,
+		arg.IdempotencyKey,
+		arg.ToAmountCents,
+		arg.ExchangeRate,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -39,12 +53,16 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.Status,
 		&i.CreatedAt,
 		&i.ProcessedAt,
+		&i.Reference,
+		&i.FailureReason,
+		&i.ToAmountCents,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at, reference, failure_reason, to_amount_cents, exchange_rate FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -59,24 +77,187 @@ func (q *Queries) GetTransfer(ctx context.Context, id pgtype.UUID) (Transfer, er
 		&i.Status,
 		&i.CreatedAt,
 		&i.ProcessedAt,
+		&i.Reference,
+		&i.FailureReason,
+		&i.ToAmountCents,
+		&i.ExchangeRate,
+	)
+	return i, err
+}
+
+const getTransferByReference = `-- name: GetTransferByReference :one
+SELECT id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at, reference, failure_reason, to_amount_cents, exchange_rate FROM transfers
+WHERE from_account_id = $1 AND reference = $2 LIMIT 1
+`
+
+type GetTransferByReferenceParams struct {
+	FromAccountID int64
+	Reference     pgtype.Text
+}
+
+// Idempotency keys are scoped to the account the transfer is sent from.
+func (q *Queries) GetTransferByReference(ctx context.Context, arg GetTransferByReferenceParams) (Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferByReference, arg.FromAccountID, arg.Reference)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.AmountCents,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ProcessedAt,
+		&i.Reference,
+		&i.FailureReason,
+		&i.ToAmountCents,
+		&i.ExchangeRate,
+	)
+	return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at, reference, failure_reason, to_amount_cents, exchange_rate FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id pgtype.UUID) (Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.AmountCents,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ProcessedAt,
+		&i.Reference,
+		&i.FailureReason,
+		&i.ToAmountCents,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at FROM transfers
-ORDER BY created_at DESC
-LIMIT $1
-OFFSET $2
+SELECT id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at, reference, failure_reason, to_amount_cents, exchange_rate FROM transfers
+WHERE (created_at, id) < ($1::timestamptz, $2::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT $3
 `
 
 type ListTransfersParams struct {
-	Limit  int32
-	Offset int32
+	AfterCreatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	Limit          int32
 }
 
+// Pages through every transfer newest first, continuing after the
+// (after_created_at, after_id) key of the previous page's last row.
 func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
-	rows, err := q.db.Query(ctx, listTransfers, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listTransfers, arg.AfterCreatedAt, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transfer
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.AmountCents,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ProcessedAt,
+			&i.Reference,
+			&i.FailureReason,
+			&i.ToAmountCents,
+			&i.ExchangeRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersBefore = `-- name: ListTransfersBefore :many
+SELECT id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at, reference, failure_reason, to_amount_cents, exchange_rate FROM transfers
+WHERE (created_at, id) > ($1::timestamptz, $2::uuid)
+ORDER BY created_at, id
+LIMIT $3
+`
+
+type ListTransfersBeforeParams struct {
+	BeforeCreatedAt pgtype.Timestamptz
+	BeforeID        pgtype.UUID
+	Limit           int32
+}
+
+// Pages back through every transfer from the (before_created_at, before_id)
+// key, returning the rows nearest to it first.
+func (q *Queries) ListTransfersBefore(ctx context.Context, arg ListTransfersBeforeParams) ([]Transfer, error) {
+	rows, err := q.db.Query(ctx, listTransfersBefore, arg.BeforeCreatedAt, arg.BeforeID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transfer
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.AmountCents,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ProcessedAt,
+			&i.Reference,
+			&i.FailureReason,
+			&i.ToAmountCents,
+			&i.ExchangeRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersByAccount = `-- name: ListTransfersByAccount :many
+SELECT id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at, reference, failure_reason, to_amount_cents, exchange_rate FROM transfers
+WHERE (from_account_id = $1 OR to_account_id = $1)
+  AND (created_at, id) < ($2::timestamptz, $3::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT $4
+`
+
+type ListTransfersByAccountParams struct {
+	AccountID      int64
+	AfterCreatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	Limit          int32
+}
+
+// Pages through the account's incoming and outgoing transfers newest first,
+// continuing after the (after_created_at, after_id) key of the previous page's last row.
+func (q *Queries) ListTransfersByAccount(ctx context.Context, arg ListTransfersByAccountParams) ([]Transfer, error) {
+	rows, err := q.db.Query(ctx, listTransfersByAccount,
+		arg.AccountID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -92,6 +273,10 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.Status,
 			&i.CreatedAt,
 			&i.ProcessedAt,
+			&i.Reference,
+			&i.FailureReason,
+			&i.ToAmountCents,
+			&i.ExchangeRate,
 		); err != nil {
 			return nil, err
 		}
@@ -102,3 +287,100 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	}
 	return items, nil
 }
+
+const listTransfersByAccountBefore = `-- name: ListTransfersByAccountBefore :many
+SELECT id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at, reference, failure_reason, to_amount_cents, exchange_rate FROM transfers
+WHERE (from_account_id = $1 OR to_account_id = $1)
+  AND (created_at, id) > ($2::timestamptz, $3::uuid)
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListTransfersByAccountBeforeParams struct {
+	AccountID       int64
+	BeforeCreatedAt pgtype.Timestamptz
+	BeforeID        pgtype.UUID
+	Limit           int32
+}
+
+// Pages back through the account's incoming and outgoing transfers from the
+// (before_created_at, before_id) key, returning the rows nearest to it first.
+func (q *Queries) ListTransfersByAccountBefore(ctx context.Context, arg ListTransfersByAccountBeforeParams) ([]Transfer, error) {
+	rows, err := q.db.Query(ctx, listTransfersByAccountBefore,
+		arg.AccountID,
+		arg.BeforeCreatedAt,
+		arg.BeforeID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transfer
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.AmountCents,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ProcessedAt,
+			&i.Reference,
+			&i.FailureReason,
+			&i.ToAmountCents,
+			&i.ExchangeRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTransferStatus = `-- name: UpdateTransferStatus :one
+UPDATE transfers
+SET status = $1,
+    failure_reason = $2,
+    processed_at = COALESCE(processed_at, now())
+WHERE id = $3 AND status = $4
+RETURNING id, from_account_id, to_account_id, amount_cents, status, created_at, processed_at, reference, failure_reason, to_amount_cents, exchange_rate
+`
+
+type UpdateTransferStatusParams struct {
+	Status        TransferStatus
+	FailureReason pgtype.Text
+	ID            pgtype.UUID
+	FromStatus    TransferStatus
+}
+
+// Compare-and-set on the current status, so two concurrent transitions of the same
+// transfer cannot both succeed. processed_at is stamped the first time a transfer
+// leaves pending and kept afterwards.
+func (q *Queries) UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) (Transfer, error) {
+	row := q.db.QueryRow(ctx, updateTransferStatus,
+		arg.Status,
+		arg.FailureReason,
+		arg.ID,
+		arg.FromStatus,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.AmountCents,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ProcessedAt,
+		&i.Reference,
+		&i.FailureReason,
+		&i.ToAmountCents,
+		&i.ExchangeRate,
+	)
+	return i, err
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"testing"
 
+	"github.com/RakibRahman/fincore-api/db/dberr"
 	"github.com/RakibRahman/fincore-api/utils"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
-func createRandomTransferWithQueries(t *testing.T, q *Queries) Transfer {
+func createRandomTransferWithQueries(t *testing.T, q Querier) Transfer {
 	fromAccount := createRandomAccountWithQueries(t, q)
 	toAccount := createRandomAccountWithQueries(t, q)
 
@@ -65,17 +66,12 @@ func TestListTransfers(t *testing.T) {
 		createRandomTransferWithQueries(t, q)
 	}
 
-	params := ListTransfersParams{
-		Limit:  transferLimit,
-		Offset: 0,
-	}
-
-	transfers, err := q.ListTransfers(ctx, params)
+	page, err := q.ListTransfersPage(ctx, PageParams{Limit: transferLimit})
 
 	require.NoError(t, err)
-	require.Len(t, transfers, transferLimit) // Exact count since we're in isolated transaction
+	require.Len(t, page.Items, transferLimit)
 
-	for _, transfer := range transfers {
+	for _, transfer := range page.Items {
 		require.NotEmpty(t, transfer.ID)
 		require.NotZero(t, transfer.FromAccountID)
 		require.NotZero(t, transfer.ToAccountID)
@@ -84,6 +80,34 @@ func TestListTransfers(t *testing.T) {
 	}
 }
 
+func TestListTransfersByAccount(t *testing.T) {
+	_, q := createTestTx(t)
+	ctx := context.Background()
+	account := createRandomAccountWithQueries(t, q)
+	other := createRandomAccountWithQueries(t, q)
+
+	for _, arg := range []CreateTransferParams{
+		{FromAccountID: account.ID, ToAccountID: other.ID, AmountCents: 100},
+		{FromAccountID: other.ID, ToAccountID: account.ID, AmountCents: 200},
+	} {
+		_, err := q.CreateTransfer(ctx, arg)
+		require.NoError(t, err)
+	}
+	// unrelated transfer
+	createRandomTransferWithQueries(t, q)
+
+	page, err := q.ListTransfersByAccountPage(ctx, ListTransfersByAccountPageParams{
+		AccountID: account.ID,
+		Limit:     10,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	for _, transfer := range page.Items {
+		require.True(t, transfer.FromAccountID == account.ID || transfer.ToAccountID == account.ID)
+	}
+}
+
 // Negative test cases
 
 func TestGetTransferNotFound(t *testing.T) {
@@ -113,7 +137,7 @@ func TestCreateTransferInvalidFromAccount(t *testing.T) {
 
 	transfer, err := q.CreateTransfer(ctx, arg)
 
-	require.Error(t, err) // Should fail due to foreign key constraint
+	require.ErrorIs(t, err, dberr.ErrAccountNotFound)
 	require.Empty(t, transfer.ID)
 }
 
@@ -131,7 +155,7 @@ func TestCreateTransferInvalidToAccount(t *testing.T) {
 
 	transfer, err := q.CreateTransfer(ctx, arg)
 
-	require.Error(t, err) // Should fail due to foreign key constraint
+	require.ErrorIs(t, err, dberr.ErrAccountNotFound)
 	require.Empty(t, transfer.ID)
 }
 
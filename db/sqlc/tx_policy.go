@@ -0,0 +1,168 @@
+package sqlc
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/db/dberr"
+	"github.com/jackc/pgx/v5"
+)
+
+// TxOperation names a kind of Store transaction, so that it can be given its own
+// TxPolicy and have its retries counted apart.
+type TxOperation string
+
+const (
+	TxCreateAccount       TxOperation = "create_account"
+	TxTransferMoney       TxOperation = "transfer_money"
+	TxDepositMoney        TxOperation = "deposit_money"
+	TxWithdrawMoney       TxOperation = "withdraw_money"
+	TxReverseTransfer     TxOperation = "reverse_transfer"
+	TxChangeAccountStatus TxOperation = "change_account_status"
+	TxChangePassword      TxOperation = "change_password"
+	TxPlaceHold           TxOperation = "place_hold"
+	TxCaptureHold         TxOperation = "capture_hold"
+	TxVoidHold            TxOperation = "void_hold"
+	TxExpireHold          TxOperation = "expire_hold"
+	TxRunSchedule         TxOperation = "run_schedule"
+	TxSetOverdraftLimit   TxOperation = "set_overdraft_limit"
+	TxAccrueOverdraft     TxOperation = "accrue_overdraft"
+	TxDispatchOutbox      TxOperation = "dispatch_outbox"
+)
+
+// TxPolicy sets the isolation level of a transaction and how it is retried after a
+// serialization failure or deadlock. Any other error ends it at once.
+type TxPolicy struct {
+	IsoLevel pgx.TxIsoLevel
+	// MaxAttempts bounds how many times the transaction runs, the first run included.
+	// Values below 1 run it once.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, doubled for every later one up to
+	// MaxDelay. Each wait is drawn at random between zero and that, so that transactions
+	// that collided once don't collide again in lockstep.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+var (
+	// DefaultTxPolicy is what Store transactions run with unless configured otherwise.
+	// Row locks keep them correct under READ COMMITTED; only deadlocks are retried.
+	DefaultTxPolicy = TxPolicy{
+		IsoLevel:    pgx.ReadCommitted,
+		MaxAttempts: 3,
+		BaseDelay:   10 * time.Millisecond,
+		MaxDelay:    100 * time.Millisecond,
+	}
+	// SerializableTxPolicy runs transactions under SERIALIZABLE, which fails the losers
+	// of every conflict, so it retries more often.
+	SerializableTxPolicy = TxPolicy{
+		IsoLevel:    pgx.Serializable,
+		MaxAttempts: 10,
+		BaseDelay:   5 * time.Millisecond,
+		MaxDelay:    250 * time.Millisecond,
+	}
+)
+
+// WithTxPolicy sets the policy of the given operations. Without any, it sets the
+// policy of every operation that has none of its own.
+func WithTxPolicy(policy TxPolicy, ops ...TxOperation) StoreOption {
+	return func(store *SQLStore) {
+		if len(ops) == 0 {
+			store.txPolicy = policy
+			return
+		}
+		if store.txPolicies == nil {
+			store.txPolicies = make(map[TxOperation]TxPolicy)
+		}
+		for _, op := range ops {
+			store.txPolicies[op] = policy
+		}
+	}
+}
+
+func (store *SQLStore) txPolicyFor(op TxOperation) TxPolicy {
+	if policy, ok := store.txPolicies[op]; ok {
+		return policy
+	}
+	return store.txPolicy
+}
+
+// backoff returns how long to wait before retrying after the given failed attempt.
+func (policy TxPolicy) backoff(attempt int) time.Duration {
+	delay := policy.BaseDelay
+	for i := 1; i < attempt && delay < policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, policy.MaxDelay)
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay + 1)
+}
+
+// TxStats counts the runs of one kind of transaction since the Store was created.
+type TxStats struct {
+	// Attempts counts every run, retries included.
+	Attempts uint64
+	// Retries counts the runs made again after a serialization failure or deadlock.
+	Retries uint64
+	// Exhausted counts transactions that still failed that way on their last attempt.
+	Exhausted uint64
+}
+
+type txStatsRecorder struct {
+	mu   sync.Mutex
+	byOp map[TxOperation]TxStats
+}
+
+func (recorder *txStatsRecorder) record(op TxOperation, update func(*TxStats)) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if recorder.byOp == nil {
+		recorder.byOp = make(map[TxOperation]TxStats)
+	}
+	stats := recorder.byOp[op]
+	update(&stats)
+	recorder.byOp[op] = stats
+}
+
+// TxStats returns the attempt and retry counts of every operation that has run.
+func (store *SQLStore) TxStats() map[TxOperation]TxStats {
+	store.txStats.mu.Lock()
+	defer store.txStats.mu.Unlock()
+	stats := make(map[TxOperation]TxStats, len(store.txStats.byOp))
+	for op, opStats := range store.txStats.byOp {
+		stats[op] = opStats
+	}
+	return stats
+}
+
+// retryTransaction calls run until it succeeds, fails for a reason a retry can't fix,
+// or the policy runs out of attempts. A cancelled context stops the wait between attempts.
+func (store *SQLStore) retryTransaction(ctx context.Context, op TxOperation, policy TxPolicy, run func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := run()
+		retry := dberr.Retryable(err) && attempt < policy.MaxAttempts
+		store.txStats.record(op, func(stats *TxStats) {
+			stats.Attempts++
+			if retry {
+				stats.Retries++
+			} else if dberr.Retryable(err) {
+				stats.Exhausted++
+			}
+		})
+		if !retry {
+			return err
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
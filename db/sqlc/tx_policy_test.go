@@ -0,0 +1,120 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxPolicyBackoff(t *testing.T) {
+	policy := TxPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	for attempt, limit := range map[int]time.Duration{
+		1: 10 * time.Millisecond,
+		2: 20 * time.Millisecond,
+		3: 40 * time.Millisecond,
+		4: 50 * time.Millisecond,
+		9: 50 * time.Millisecond,
+	} {
+		for range 100 {
+			delay := policy.backoff(attempt)
+			require.GreaterOrEqual(t, delay, time.Duration(0))
+			require.LessOrEqual(t, delay, limit, attempt)
+		}
+	}
+
+	require.Zero(t, TxPolicy{}.backoff(3))
+}
+
+func TestRetryTransaction(t *testing.T) {
+	serializationFailure := &pgconn.PgError{Code: "40001"}
+	policy := TxPolicy{IsoLevel: pgx.Serializable, MaxAttempts: 3}
+
+	testCases := []struct {
+		name     string
+		failures []error
+		wantErr  error
+		wantRuns int
+		want     TxStats
+	}{
+		{
+			name:     "FirstAttempt",
+			wantRuns: 1,
+			want:     TxStats{Attempts: 1},
+		},
+		{
+			name:     "RetriedUntilSuccess",
+			failures: []error{serializationFailure, &pgconn.PgError{Code: "40P01"}},
+			wantRuns: 3,
+			want:     TxStats{Attempts: 3, Retries: 2},
+		},
+		{
+			name:     "Exhausted",
+			failures: []error{serializationFailure, serializationFailure, serializationFailure, serializationFailure},
+			wantErr:  serializationFailure,
+			wantRuns: 3,
+			want:     TxStats{Attempts: 3, Retries: 2, Exhausted: 1},
+		},
+		{
+			name:     "NotRetryable",
+			failures: []error{ErrInsufficientBalance},
+			wantErr:  ErrInsufficientBalance,
+			wantRuns: 1,
+			want:     TxStats{Attempts: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &SQLStore{txStats: &txStatsRecorder{}}
+			runs := 0
+			err := store.retryTransaction(context.Background(), TxWithdrawMoney, policy, func() error {
+				runs++
+				if runs <= len(tc.failures) {
+					return tc.failures[runs-1]
+				}
+				return nil
+			})
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+			require.Equal(t, tc.wantRuns, runs)
+			require.Equal(t, map[TxOperation]TxStats{TxWithdrawMoney: tc.want}, store.TxStats())
+		})
+	}
+}
+
+func TestRetryTransactionCancelled(t *testing.T) {
+	store := &SQLStore{txStats: &txStatsRecorder{}}
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := TxPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	runs := 0
+	err := store.retryTransaction(ctx, TxTransferMoney, policy, func() error {
+		runs++
+		cancel()
+		return &pgconn.PgError{Code: "40001"}
+	})
+	var pgErr *pgconn.PgError
+	require.True(t, errors.As(err, &pgErr))
+	require.Equal(t, 1, runs)
+}
+
+func TestWithTxPolicy(t *testing.T) {
+	serializable := newSQLStore(nil, nil, WithTxPolicy(SerializableTxPolicy, TxTransferMoney, TxReverseTransfer))
+	require.Equal(t, SerializableTxPolicy, serializable.txPolicyFor(TxTransferMoney))
+	require.Equal(t, SerializableTxPolicy, serializable.txPolicyFor(TxReverseTransfer))
+	require.Equal(t, DefaultTxPolicy, serializable.txPolicyFor(TxDepositMoney))
+
+	fallback := TxPolicy{IsoLevel: pgx.RepeatableRead, MaxAttempts: 2}
+	store := newSQLStore(nil, nil, WithTxPolicy(fallback), WithTxPolicy(SerializableTxPolicy, TxTransferMoney))
+	require.Equal(t, fallback, store.txPolicyFor(TxDepositMoney))
+	require.Equal(t, SerializableTxPolicy, store.txPolicyFor(TxTransferMoney))
+}
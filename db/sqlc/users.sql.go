@@ -65,17 +65,56 @@ func (q *Queries) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
 	return i, err
 }
 
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, first_name, last_name, email, password_hash, created_at FROM users
+WHERE email = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PasswordHash,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT id, first_name, last_name, email, password_hash, created_at FROM users
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUserForUpdate, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PasswordHash,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const listUsers = `-- name: ListUsers :many
 SELECT id, first_name, last_name, email
 FROM users
+WHERE id > $1
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
 `
 
 type ListUsersParams struct {
-	Limit  int32
-	Offset int32
+	AfterID pgtype.UUID
+	Limit   int32
 }
 
 type ListUsersRow struct {
@@ -85,8 +124,9 @@ type ListUsersRow struct {
 	Email     string
 }
 
+// Pages through users by id, continuing after after_id.
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error) {
-	rows, err := q.db.Query(ctx, listUsers, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listUsers, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +150,53 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUse
 	return items, nil
 }
 
+const listUsersBefore = `-- name: ListUsersBefore :many
+SELECT id, first_name, last_name, email
+FROM users
+WHERE id < $1
+ORDER BY id DESC
+LIMIT $2
+`
+
+type ListUsersBeforeParams struct {
+	BeforeID pgtype.UUID
+	Limit    int32
+}
+
+type ListUsersBeforeRow struct {
+	ID        pgtype.UUID
+	FirstName string
+	LastName  string
+	Email     string
+}
+
+// Pages back through users from before_id, returning the rows
+// nearest to it first.
+func (q *Queries) ListUsersBefore(ctx context.Context, arg ListUsersBeforeParams) ([]ListUsersBeforeRow, error) {
+	rows, err := q.db.Query(ctx, listUsersBefore, arg.BeforeID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersBeforeRow
+	for rows.Next() {
+		var i ListUsersBeforeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET 
@@ -150,3 +237,29 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 	)
 	return i, err
 }
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE users
+SET password_hash = $2
+WHERE id = $1
+RETURNING id, first_name, last_name, email, password_hash, created_at
+`
+
+type UpdateUserPasswordParams struct {
+	ID           pgtype.UUID
+	PasswordHash string
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserPassword, arg.ID, arg.PasswordHash)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PasswordHash,
+		&i.CreatedAt,
+	)
+	return i, err
+}
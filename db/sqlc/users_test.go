@@ -4,17 +4,24 @@ import (
 	"context"
 	"testing"
 
+	"github.com/RakibRahman/fincore-api/db/dberr"
 	"github.com/RakibRahman/fincore-api/utils"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
-func createRandomUserWithQueries(t *testing.T, q *Queries) User {
+// randomPasswordHash returns a string in bcrypt's encoded format, which is all the
+// users_password_hash_format check constraint looks at.
+func randomPasswordHash() string {
+	return "$2a$10$" + utils.RandomString(53)
+}
+
+func createRandomUserWithQueries(t *testing.T, q Querier) User {
 	arg := CreateUserParams{
 		FirstName:    utils.RandomString(6),
 		LastName:     utils.RandomString(4),
 		Email:        utils.RandomEmail(),
-		PasswordHash: utils.RandomString(12),
+		PasswordHash: randomPasswordHash(),
 	}
 	ctx := context.Background()
 	user, err := q.CreateUser(ctx, arg)
@@ -46,6 +53,17 @@ func TestGetUser(t *testing.T) {
 	require.Equal(t, user1.FirstName, user2.FirstName)
 }
 
+func TestGetUserByEmail(t *testing.T) {
+	_, q := createTestTx(t)
+	user1 := createRandomUserWithQueries(t, q)
+	ctx := context.Background()
+	user2, err := q.GetUserByEmail(ctx, user1.Email)
+
+	require.NoError(t, err)
+	require.Equal(t, user1.ID, user2.ID)
+	require.Equal(t, user1.PasswordHash, user2.PasswordHash)
+}
+
 func TestUpdateUser(t *testing.T) {
 	_, q := createTestTx(t)
 	user1 := createRandomUserWithQueries(t, q)
@@ -78,16 +96,11 @@ func TestListUsers(t *testing.T) {
 		createRandomUserWithQueries(t, q)
 	}
 
-	params := ListUsersParams{
-		Limit:  userLimit,
-		Offset: 0,
-	}
-
-	users, err := q.ListUsers(ctx, params)
+	page, err := q.ListUsersPage(ctx, PageParams{Limit: userLimit})
 
 	require.NoError(t, err)
-	require.Len(t, users, userLimit)
-	for _, user := range users {
+	require.Len(t, page.Items, userLimit)
+	for _, user := range page.Items {
 		require.NotEmpty(t, user.ID)
 		require.NotEmpty(t, user.Email)
 		require.NotEmpty(t, user.FirstName)
@@ -119,12 +132,13 @@ func TestCreateUserDuplicateEmail(t *testing.T) {
 		FirstName:    utils.RandomString(6),
 		LastName:     utils.RandomString(4),
 		Email:        user1.Email, // Duplicate email
-		PasswordHash: utils.RandomString(12),
+		PasswordHash: randomPasswordHash(),
 	}
 
 	user2, err := q.CreateUser(ctx, arg)
 
-	require.Error(t, err)
+	require.ErrorIs(t, err, dberr.ErrEmailTaken)
+	require.EqualError(t, err, "email already registered")
 	require.Empty(t, user2.ID)
 }
 
@@ -167,3 +181,31 @@ func TestUpdateUserDuplicateEmail(t *testing.T) {
 	require.Error(t, err)
 	require.Empty(t, updatedUser.ID)
 }
+
+func TestUpdateUserPassword(t *testing.T) {
+	_, q := createTestTx(t)
+	user1 := createRandomUserWithQueries(t, q)
+	ctx := context.Background()
+
+	locked, err := q.GetUserForUpdate(ctx, user1.ID)
+	require.NoError(t, err)
+	require.Equal(t, user1.PasswordHash, locked.PasswordHash)
+
+	newHash := randomPasswordHash()
+	user2, err := q.UpdateUserPassword(ctx, UpdateUserPasswordParams{ID: user1.ID, PasswordHash: newHash})
+	require.NoError(t, err)
+	require.Equal(t, user1.ID, user2.ID)
+	require.Equal(t, user1.Email, user2.Email)
+	require.Equal(t, newHash, user2.PasswordHash)
+}
+
+func TestUpdateUserPasswordInvalidFormat(t *testing.T) {
+	_, q := createTestTx(t)
+	user := createRandomUserWithQueries(t, q)
+
+	_, err := q.UpdateUserPassword(context.Background(), UpdateUserPasswordParams{
+		ID:           user.ID,
+		PasswordHash: utils.RandomString(60),
+	})
+	require.Error(t, err)
+}
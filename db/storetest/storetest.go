@@ -0,0 +1,412 @@
+// Package storetest is a conformance suite for implementations of db.Store. Every
+// implementation runs it, so that the in-memory store behaves like the Postgres one for
+// the users, accounts, transactions and transfers the service is built on.
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RakibRahman/fincore-api/db/dberr"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// Run runs the suite against the stores newStore returns. A store may be shared with
+// other tests: the suite only looks at rows it created itself.
+func Run(t *testing.T, newStore func(t *testing.T) db.Store) {
+	for _, tc := range []struct {
+		name string
+		test func(t *testing.T, store db.Store)
+	}{
+		{"Users", testUsers},
+		{"Accounts", testAccounts},
+		{"DeleteAccount", testDeleteAccount},
+		{"DepositAndWithdraw", testDepositAndWithdraw},
+		{"WithdrawInsufficientBalance", testWithdrawInsufficientBalance},
+		{"IdempotentDeposit", testIdempotentDeposit},
+		{"Transfer", testTransfer},
+		{"TransferInsufficientBalance", testTransferInsufficientBalance},
+		{"TransferAccountNotFound", testTransferAccountNotFound},
+		{"ConcurrentWithdrawals", testConcurrentWithdrawals},
+		{"ConcurrentTransfers", testConcurrentTransfers},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test(t, newStore(t))
+		})
+	}
+}
+
+func createUser(t *testing.T, store db.Store) db.User {
+	user, err := store.CreateUser(context.Background(), db.CreateUserParams{
+		FirstName:    utils.RandomString(6),
+		LastName:     utils.RandomString(6),
+		Email:        utils.RandomEmail(),
+		PasswordHash: "$2a$10$" + utils.RandomString(53),
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func createAccount(t *testing.T, store db.Store, owner db.User, balance int64) db.Account {
+	account, err := store.CreateAccount(context.Background(), db.CreateAccountParams{
+		OwnerID:  owner.ID,
+		Currency: db.CurrencyUSD,
+	})
+	require.NoError(t, err)
+	if balance > 0 {
+		result, err := store.DepositMoneyTx(context.Background(), db.AccountTransactionParams{
+			AccountID: account.ID,
+			Amount:    balance,
+		})
+		require.NoError(t, err)
+		account = result.Account
+	}
+	return account
+}
+
+func requireBalance(t *testing.T, store db.Store, accountID, want int64) {
+	account, err := store.GetAccount(context.Background(), accountID)
+	require.NoError(t, err)
+	require.Equal(t, want, account.BalanceCents)
+
+	ledger, err := store.GetAccountLedgerBalance(context.Background(), accountID)
+	require.NoError(t, err)
+	require.Equal(t, want, ledger)
+}
+
+func testUsers(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	user := createUser(t, store)
+	require.True(t, user.ID.Valid)
+	require.True(t, user.CreatedAt.Valid)
+
+	byID, err := store.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, user.Email, byID.Email)
+	byEmail, err := store.GetUserByEmail(ctx, user.Email)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, byEmail.ID)
+
+	_, err = store.CreateUser(ctx, db.CreateUserParams{
+		FirstName:    "Second",
+		LastName:     "User",
+		Email:        user.Email,
+		PasswordHash: user.PasswordHash,
+	})
+	require.ErrorIs(t, err, dberr.ErrEmailTaken)
+	require.ErrorIs(t, err, dberr.ErrAlreadyExists)
+
+	other := createUser(t, store)
+	_, err = store.UpdateUser(ctx, db.UpdateUserParams{
+		ID:        other.ID,
+		FirstName: other.FirstName,
+		LastName:  other.LastName,
+		Email:     user.Email,
+	})
+	require.ErrorIs(t, err, dberr.ErrEmailTaken)
+
+	// Keeping one's own email is no conflict
+	updated, err := store.UpdateUser(ctx, db.UpdateUserParams{
+		ID:        user.ID,
+		FirstName: "Renamed",
+		LastName:  user.LastName,
+		Email:     user.Email,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Renamed", updated.FirstName)
+
+	_, err = store.GetUserByEmail(ctx, utils.RandomEmail())
+	require.ErrorIs(t, err, dberr.ErrNotFound)
+}
+
+func testAccounts(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	owner := createUser(t, store)
+
+	_, err := store.CreateAccount(ctx, db.CreateAccountParams{
+		OwnerID:  pgtype.UUID{Bytes: [16]byte{1}, Valid: true},
+		Currency: db.CurrencyUSD,
+	})
+	require.ErrorIs(t, err, dberr.ErrOwnerNotFound)
+
+	var created []db.Account
+	for range 3 {
+		account := createAccount(t, store, owner, 0)
+		require.Equal(t, db.AccountStatusActive, account.Status)
+		require.Zero(t, account.BalanceCents)
+		created = append(created, account)
+	}
+
+	got, err := store.GetAccount(ctx, created[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, created[0].ID, got.ID)
+	require.Equal(t, owner.ID, got.OwnerID)
+
+	// The owner's accounts come back by id, two at a time
+	first, err := store.ListAccountsByOwnerPage(ctx, db.ListAccountsByOwnerPageParams{OwnerID: owner.ID, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+	require.NotEmpty(t, first.NextCursor)
+	second, err := store.ListAccountsByOwnerPage(ctx, db.ListAccountsByOwnerPageParams{OwnerID: owner.ID, Cursor: first.NextCursor, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, second.Items, 1)
+	require.Empty(t, second.NextCursor)
+
+	var listed []int64
+	for _, account := range append(first.Items, second.Items...) {
+		listed = append(listed, account.ID)
+	}
+	require.Equal(t, []int64{created[0].ID, created[1].ID, created[2].ID}, listed)
+}
+
+func testDeleteAccount(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	owner := createUser(t, store)
+
+	unused := createAccount(t, store, owner, 0)
+	require.NoError(t, store.DeleteAccount(ctx, unused.ID))
+	_, err := store.GetAccount(ctx, unused.ID)
+	require.ErrorIs(t, err, dberr.ErrNotFound)
+
+	// An account with transactions can't be deleted
+	used := createAccount(t, store, owner, 100)
+	err = store.DeleteAccount(ctx, used.ID)
+	require.ErrorIs(t, err, dberr.ErrStillReferenced)
+	requireBalance(t, store, used.ID, 100)
+}
+
+func testDepositAndWithdraw(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	account := createAccount(t, store, createUser(t, store), 0)
+
+	deposit, err := store.DepositMoneyTx(ctx, db.AccountTransactionParams{
+		AccountID:   account.ID,
+		Amount:      500,
+		Description: pgtype.Text{String: "salary", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.TransactionTypeDeposit, deposit.Transaction.Type)
+	require.Equal(t, int64(500), deposit.Transaction.AmountCents)
+	require.Equal(t, int64(500), deposit.Transaction.BalanceAfterCents)
+	require.Equal(t, "salary", deposit.Transaction.Description.String)
+	require.Equal(t, int64(500), deposit.Account.BalanceCents)
+
+	withdrawal, err := store.WithdrawMoneyTx(ctx, db.AccountTransactionParams{
+		AccountID: account.ID,
+		Amount:    200,
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.TransactionTypeWithdrawal, withdrawal.Transaction.Type)
+	require.Equal(t, int64(-200), withdrawal.Transaction.AmountCents)
+	require.Equal(t, int64(300), withdrawal.Transaction.BalanceAfterCents)
+	require.Greater(t, withdrawal.Transaction.Seq, deposit.Transaction.Seq)
+	requireBalance(t, store, account.ID, 300)
+
+	got, err := store.GetTransaction(ctx, withdrawal.Transaction.ID)
+	require.NoError(t, err)
+	require.Equal(t, withdrawal.Transaction.ID, got.ID)
+
+	page, err := store.ListTransactionsPage(ctx, db.ListTransactionsPageParams{AccountID: account.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+
+	_, err = store.WithdrawMoneyTx(ctx, db.AccountTransactionParams{AccountID: account.ID, Amount: 0})
+	require.ErrorIs(t, err, db.ErrInvalidAmount)
+	_, err = store.DepositMoneyTx(ctx, db.AccountTransactionParams{AccountID: account.ID, Amount: 0})
+	require.ErrorIs(t, err, db.ErrInvalidAmount)
+	_, err = store.DepositMoneyTx(ctx, db.AccountTransactionParams{AccountID: account.ID, Amount: -100})
+	require.ErrorIs(t, err, db.ErrInvalidAmount)
+	requireBalance(t, store, account.ID, 300)
+}
+
+func testWithdrawInsufficientBalance(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	account := createAccount(t, store, createUser(t, store), 100)
+
+	_, err := store.WithdrawMoneyTx(ctx, db.AccountTransactionParams{
+		AccountID: account.ID,
+		Amount:    101,
+	})
+	require.ErrorIs(t, err, db.ErrInsufficientBalance)
+
+	// The refused withdrawal left nothing behind
+	requireBalance(t, store, account.ID, 100)
+	page, err := store.ListTransactionsPage(ctx, db.ListTransactionsPageParams{AccountID: account.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+}
+
+func testIdempotentDeposit(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	account := createAccount(t, store, createUser(t, store), 0)
+	arg := db.AccountTransactionParams{
+		AccountID:      account.ID,
+		Amount:         250,
+		IdempotencyKey: pgtype.Text{String: utils.RandomString(24), Valid: true},
+	}
+
+	first, err := store.DepositMoneyTx(ctx, arg)
+	require.NoError(t, err)
+	require.False(t, first.Replayed)
+
+	replay, err := store.DepositMoneyTx(ctx, arg)
+	require.NoError(t, err)
+	require.True(t, replay.Replayed)
+	require.Equal(t, first.Transaction.ID, replay.Transaction.ID)
+	requireBalance(t, store, account.ID, 250)
+
+	arg.Amount = 300
+	_, err = store.DepositMoneyTx(ctx, arg)
+	require.ErrorIs(t, err, db.ErrIdempotencyKeyReused)
+	requireBalance(t, store, account.ID, 250)
+}
+
+func testTransfer(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	from := createAccount(t, store, createUser(t, store), 1000)
+	to := createAccount(t, store, createUser(t, store), 0)
+	key := pgtype.Text{String: utils.RandomString(24), Valid: true}
+
+	result, err := store.TransferMoneyTx(ctx, db.TransferMoneyTxParams{
+		FromAccountID:  from.ID,
+		ToAccountID:    to.ID,
+		AmountCents:    400,
+		IdempotencyKey: key,
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.TransferStatusCompleted, result.Transfer.Status)
+	require.True(t, result.Transfer.ProcessedAt.Valid)
+	require.Equal(t, int64(-400), result.FromTx.AmountCents)
+	require.Equal(t, int64(400), result.ToTx.AmountCents)
+	require.Equal(t, int64(600), result.FromAccount.BalanceCents)
+	require.Equal(t, int64(400), result.ToAccount.BalanceCents)
+	requireBalance(t, store, from.ID, 600)
+	requireBalance(t, store, to.ID, 400)
+
+	got, err := store.GetTransferByReference(ctx, db.GetTransferByReferenceParams{FromAccountID: from.ID, Reference: key})
+	require.NoError(t, err)
+	require.Equal(t, result.Transfer.ID, got.ID)
+
+	replay, err := store.TransferMoneyTx(ctx, db.TransferMoneyTxParams{
+		FromAccountID:  from.ID,
+		ToAccountID:    to.ID,
+		AmountCents:    400,
+		IdempotencyKey: key,
+	})
+	require.NoError(t, err)
+	require.True(t, replay.Replayed)
+	require.Equal(t, result.Transfer.ID, replay.Transfer.ID)
+	requireBalance(t, store, from.ID, 600)
+
+	for _, accountID := range []int64{from.ID, to.ID} {
+		page, err := store.ListTransfersByAccountPage(ctx, db.ListTransfersByAccountPageParams{AccountID: accountID, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		require.Equal(t, result.Transfer.ID, page.Items[0].ID)
+	}
+
+	_, err = store.TransferMoneyTx(ctx, db.TransferMoneyTxParams{FromAccountID: from.ID, ToAccountID: from.ID, AmountCents: 1})
+	require.Error(t, err)
+}
+
+func testTransferInsufficientBalance(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	from := createAccount(t, store, createUser(t, store), 100)
+	to := createAccount(t, store, createUser(t, store), 0)
+
+	result, err := store.TransferMoneyTx(ctx, db.TransferMoneyTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		AmountCents:   101,
+	})
+	require.ErrorIs(t, err, db.ErrInsufficientBalance)
+
+	// The attempt is on record as failed, without moving any money
+	require.Equal(t, db.TransferStatusFailed, result.Transfer.Status)
+	require.Equal(t, db.TransferFailureInsufficientBalance, result.Transfer.FailureReason.String)
+	got, err := store.GetTransfer(ctx, result.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, db.TransferStatusFailed, got.Status)
+	requireBalance(t, store, from.ID, 100)
+	requireBalance(t, store, to.ID, 0)
+}
+
+func testTransferAccountNotFound(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	from := createAccount(t, store, createUser(t, store), 100)
+
+	// Deleted ids are never handed out again, so this one is free
+	missing := createAccount(t, store, createUser(t, store), 0)
+	require.NoError(t, store.DeleteAccount(ctx, missing.ID))
+
+	_, err := store.TransferMoneyTx(ctx, db.TransferMoneyTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   missing.ID,
+		AmountCents:   10,
+	})
+	require.ErrorIs(t, err, dberr.ErrNotFound)
+	requireBalance(t, store, from.ID, 100)
+}
+
+// testConcurrentWithdrawals races more withdrawals than the balance covers: the row
+// lock lets exactly as many through as there is money for.
+func testConcurrentWithdrawals(t *testing.T, store db.Store) {
+	account := createAccount(t, store, createUser(t, store), 100)
+	n := 8
+
+	errs := make(chan error, n)
+	for range n {
+		go func() {
+			_, err := store.WithdrawMoneyTx(context.Background(), db.AccountTransactionParams{
+				AccountID: account.ID,
+				Amount:    30,
+			})
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	for range n {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, db.ErrInsufficientBalance)
+	}
+	require.Equal(t, 3, succeeded)
+	requireBalance(t, store, account.ID, 10)
+}
+
+// testConcurrentTransfers moves money both ways between two accounts at once, which
+// deadlocks unless the pair is locked in a consistent order.
+func testConcurrentTransfers(t *testing.T, store db.Store) {
+	a := createAccount(t, store, createUser(t, store), 1000)
+	b := createAccount(t, store, createUser(t, store), 1000)
+	n := 10
+
+	errs := make(chan error, n)
+	for i := range n {
+		from, to := a, b
+		if i%2 == 1 {
+			from, to = b, a
+		}
+		go func() {
+			_, err := store.TransferMoneyTx(context.Background(), db.TransferMoneyTxParams{
+				FromAccountID: from.ID,
+				ToAccountID:   to.ID,
+				AmountCents:   10,
+			})
+			errs <- err
+		}()
+	}
+	for range n {
+		require.NoError(t, <-errs)
+	}
+
+	requireBalance(t, store, a.ID, 1000)
+	requireBalance(t, store, b.ID, 1000)
+}
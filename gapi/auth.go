@@ -0,0 +1,107 @@
+package gapi
+
+import (
+	"context"
+	"strings"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/RakibRahman/fincore-api/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeaderKey  = "authorization"
+	authorizationTypeBearer = "bearer"
+)
+
+// publicMethods can be called without an access token.
+var publicMethods = map[string]bool{
+	pb.Fincore_CreateUser_FullMethodName: true,
+	pb.Fincore_LoginUser_FullMethodName:  true,
+}
+
+var errAccountNotOwned = status.Error(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+
+type authPayloadKey struct{}
+
+// authenticate verifies the bearer token in the call's metadata and returns a context
+// carrying its payload.
+func (server *Server) authenticate(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get(authorizationHeaderKey)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization header is not provided")
+	}
+
+	fields := strings.Fields(values[0])
+	if len(fields) < 2 {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization header format")
+	}
+
+	authorizationType := strings.ToLower(fields[0])
+	if authorizationType != authorizationTypeBearer {
+		return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization type %s", authorizationType)
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(fields[1])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return context.WithValue(ctx, authPayloadKey{}, payload), nil
+}
+
+func (server *Server) unaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	ctx, err := server.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (server *Server) streamAuthInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := server.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticatedStream hands the authenticated context to streaming handlers.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *authenticatedStream) Context() context.Context {
+	return stream.ctx
+}
+
+// authPayload returns the token payload stored by the auth interceptors.
+func authPayload(ctx context.Context) *token.Payload {
+	return ctx.Value(authPayloadKey{}).(*token.Payload)
+}
+
+// authorizedAccount loads the account and verifies that the authenticated caller owns it.
+func (server *Server) authorizedAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		return account, storeError(err)
+	}
+
+	if account.OwnerID.Bytes != authPayload(ctx).UserID {
+		return account, errAccountNotOwned
+	}
+	return account, nil
+}
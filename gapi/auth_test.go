@@ -0,0 +1,83 @@
+package gapi
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuthInterceptors(t *testing.T) {
+	account := randomAccount(t)
+	store := newMockStore(t)
+	expectAccounts(store, account)
+	store.EXPECT().ListTransactionsInPeriod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+	client, server := newTestClient(t, store)
+
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{name: "OK", ctx: contextAs(t, server, account.OwnerID), want: codes.OK},
+		{name: "NoAuthorization", ctx: context.Background(), want: codes.Unauthenticated},
+		{
+			name: "UnsupportedType",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), authorizationHeaderKey, "basic abc"),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "InvalidToken",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), authorizationHeaderKey, "bearer abc"),
+			want: codes.Unauthenticated,
+		},
+		{name: "NotOwner", ctx: contextAs(t, server, randomUUID(t)), want: codes.PermissionDenied},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.GetAccount(tc.ctx, &pb.GetAccountRequest{Id: account.ID})
+			requireCode(t, tc.want, err)
+
+			// Streams go through the same checks
+			stream, err := client.StreamTransactions(tc.ctx, &pb.StreamTransactionsRequest{AccountId: account.ID})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			if tc.want == codes.OK {
+				// The account has no transactions
+				require.Equal(t, io.EOF, err)
+			} else {
+				requireCode(t, tc.want, err)
+			}
+		})
+	}
+}
+
+func TestPublicMethods(t *testing.T) {
+	store := newMockStore(t)
+	store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(_ context.Context, arg db.CreateUserParams) (db.User, error) {
+			require.Equal(t, "ada@example.com", arg.Email)
+			require.NotEqual(t, "Secret-pass-123", arg.PasswordHash)
+			return db.User{ID: randomUUID(t), FirstName: arg.FirstName, LastName: arg.LastName, Email: arg.Email}, nil
+		})
+	client, _ := newTestClient(t, store)
+
+	rsp, err := client.CreateUser(context.Background(), &pb.CreateUserRequest{
+		FirstName: "Ada",
+		LastName:  "Lovelace",
+		Email:     "ada@example.com",
+		Password:  "Secret-pass-123",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ada@example.com", rsp.GetUser().GetEmail())
+
+	_, err = client.CreateUser(context.Background(), &pb.CreateUserRequest{FirstName: "Ada", Email: "ada"})
+	requireCode(t, codes.InvalidArgument, err)
+}
@@ -0,0 +1,87 @@
+package gapi
+
+import (
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func convertTimestamp(t pgtype.Timestamptz) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+func convertUser(user db.User) *pb.User {
+	return &pb.User{
+		Id:        user.ID.String(),
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		CreatedAt: convertTimestamp(user.CreatedAt),
+	}
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:                  account.ID,
+		OwnerId:             account.OwnerID.String(),
+		BalanceCents:        account.BalanceCents,
+		Currency:            string(account.Currency),
+		Status:              string(account.Status),
+		CreatedAt:           convertTimestamp(account.CreatedAt),
+		OverdraftLimitCents: account.OverdraftLimitCents,
+		HeldCents:           account.HeldCents,
+		AvailableCents:      account.AvailableCents(),
+	}
+}
+
+func convertTransaction(transaction db.Transaction) *pb.Transaction {
+	rsp := &pb.Transaction{
+		Id:                transaction.ID.String(),
+		AccountId:         transaction.AccountID,
+		Type:              string(transaction.Type),
+		AmountCents:       transaction.AmountCents,
+		BalanceAfterCents: transaction.BalanceAfterCents,
+		CreatedAt:         convertTimestamp(transaction.CreatedAt),
+		Seq:               transaction.Seq,
+	}
+	if transaction.RelatedAccountID.Valid {
+		rsp.RelatedAccountId = &transaction.RelatedAccountID.Int64
+	}
+	if transaction.Reference.Valid {
+		rsp.Reference = &transaction.Reference.String
+	}
+	if transaction.Description.Valid {
+		rsp.Description = &transaction.Description.String
+	}
+	return rsp
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	rsp := &pb.Transfer{
+		Id:            transfer.ID.String(),
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		AmountCents:   transfer.AmountCents,
+		Status:        string(transfer.Status),
+		CreatedAt:     convertTimestamp(transfer.CreatedAt),
+		ProcessedAt:   convertTimestamp(transfer.ProcessedAt),
+	}
+	if transfer.Reference.Valid {
+		rsp.Reference = &transfer.Reference.String
+	}
+	if transfer.FailureReason.Valid {
+		rsp.FailureReason = &transfer.FailureReason.String
+	}
+	if transfer.ToAmountCents.Valid {
+		rsp.ToAmountCents = &transfer.ToAmountCents.Int64
+	}
+	if rate, err := transfer.ExchangeRate.Value(); err == nil && rate != nil {
+		s := rate.(string)
+		rsp.ExchangeRate = &s
+	}
+	return rsp
+}
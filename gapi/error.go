@@ -0,0 +1,111 @@
+package gapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/RakibRahman/fincore-api/db/dberr"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errInternal = status.Error(codes.Internal, "internal server error")
+
+// storeError translates store and database errors into a gRPC status, the way
+// api.handleStoreError translates them into HTTP statuses. Unknown errors become
+// Internal without leaking their message.
+func storeError(err error) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows), errors.Is(err, dberr.ErrNotFound):
+		return status.Error(codes.NotFound, dberr.Missing(err).Error())
+	case errors.Is(err, db.ErrInvalidAmount), errors.Is(err, db.ErrInvalidSchedule), errors.Is(err, db.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, db.ErrInsufficientBalance),
+		errors.Is(err, db.ErrIdempotencyKeyReused),
+		errors.Is(err, db.ErrInvalidTransferTransition), errors.Is(err, db.ErrInvalidAccountTransition),
+		errors.Is(err, db.ErrAccountFrozen), errors.Is(err, db.ErrAccountClosed), errors.Is(err, db.ErrAccountBalanceNotZero),
+		errors.Is(err, db.ErrAccountHasActiveHolds),
+		errors.Is(err, db.ErrCurrencyMismatch), errors.Is(err, db.ErrExchangeRateNotFound), errors.Is(err, db.ErrConvertedAmountZero):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, db.ErrFreezeNotOwned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, dberr.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, dberr.ErrReferenceNotFound), errors.Is(err, dberr.ErrConstraintViolated):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, dberr.ErrStillReferenced):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case dberr.Retryable(err):
+		return status.Error(codes.Aborted, err.Error())
+	}
+
+	return errInternal
+}
+
+// errorDomain is the ErrorInfo domain of the details this service attaches to errors.
+const errorDomain = "fincore.v1"
+
+// failedTransferError is storeError for a transfer refused by a business rule and
+// committed as failed. Its ErrorInfo detail names the transfer, with its ID and status in
+// the metadata and its failure reason as the reason.
+func failedTransferError(err error, transfer db.Transfer) error {
+	st := status.Convert(storeError(err))
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: strings.ToUpper(transfer.FailureReason.String),
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"transfer_id": transfer.ID.String(),
+			"status":      string(transfer.Status),
+		},
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+var validate = validator.New()
+
+// fieldViolations collects the invalid fields of a request.
+type fieldViolations []*errdetails.BadRequest_FieldViolation
+
+// check validates value against validator tags, the same rules the REST API binds with.
+func (violations *fieldViolations) check(field string, value any, tag string) {
+	err := validate.Var(value, tag)
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		rule := validationErrs[0].Tag()
+		if param := validationErrs[0].Param(); param != "" {
+			rule += "=" + param
+		}
+		violations.add(field, fmt.Errorf("failed on the '%s' rule", rule))
+	} else if err != nil {
+		violations.add(field, err)
+	}
+}
+
+func (violations *fieldViolations) add(field string, err error) {
+	*violations = append(*violations, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: err.Error(),
+	})
+}
+
+// err returns an InvalidArgument status listing the violations, or nil if there are none.
+func (violations fieldViolations) err() error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	st, err := status.New(codes.InvalidArgument, "invalid parameters").
+		WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid parameters")
+	}
+	return st.Err()
+}
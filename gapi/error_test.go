@@ -0,0 +1,72 @@
+package gapi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/RakibRahman/fincore-api/db/dberr"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStoreError(t *testing.T) {
+	testCases := []struct {
+		name    string
+		err     error
+		want    codes.Code
+		message string
+	}{
+		{name: "NoRows", err: pgx.ErrNoRows, want: codes.NotFound},
+		{name: "WrappedNoRows", err: fmt.Errorf("get account: %w", pgx.ErrNoRows), want: codes.NotFound, message: dberr.ErrNotFound.Error()},
+		{name: "AccountLookupNotFound", err: fmt.Errorf("get account: %w", dberr.TranslateQuery("GetAccount", pgx.ErrNoRows)), want: codes.NotFound, message: dberr.ErrAccountNotFound.Error()},
+		{name: "TransferLookupNotFound", err: dberr.TranslateQuery("GetTransfer", pgx.ErrNoRows), want: codes.NotFound, message: dberr.ErrTransferNotFound.Error()},
+		{name: "InsufficientBalance", err: db.ErrInsufficientBalance, want: codes.FailedPrecondition},
+		{name: "InvalidAmount", err: db.ErrInvalidAmount, want: codes.InvalidArgument},
+		{name: "AccountFrozen", err: db.ErrAccountFrozen, want: codes.FailedPrecondition},
+		{name: "CurrencyMismatch", err: db.ErrCurrencyMismatch, want: codes.FailedPrecondition},
+		{name: "EmailTaken", err: dberr.Translate(&pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}), want: codes.AlreadyExists},
+		{name: "AccountNotFound", err: dberr.Translate(&pgconn.PgError{Code: "23503", ConstraintName: "transfers_to_account_id_fkey"}), want: codes.InvalidArgument},
+		{name: "SerializationFailure", err: dberr.Translate(&pgconn.PgError{Code: "40001"}), want: codes.Aborted},
+		{name: "Unknown", err: errors.New("connection reset"), want: codes.Internal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := storeError(tc.err)
+			require.Equal(t, tc.want, status.Code(err))
+			if tc.message != "" {
+				require.Equal(t, tc.message, status.Convert(err).Message())
+			}
+			if tc.want == codes.Internal {
+				require.NotContains(t, err.Error(), "connection reset")
+			}
+		})
+	}
+}
+
+func TestFieldViolations(t *testing.T) {
+	var violations fieldViolations
+	require.NoError(t, violations.err())
+
+	violations.check("email", "not-an-email", "required,email")
+	violations.check("page_size", int32(500), "required,min=1,max=100")
+	violations.check("page_id", int32(1), "required,min=1")
+
+	st, ok := status.FromError(violations.err())
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	badRequest := st.Details()[0].(*errdetails.BadRequest)
+	require.Len(t, badRequest.FieldViolations, 2)
+	require.Equal(t, "email", badRequest.FieldViolations[0].Field)
+	require.Equal(t, "failed on the 'email' rule", badRequest.FieldViolations[0].Description)
+	require.Equal(t, "page_size", badRequest.FieldViolations[1].Field)
+	require.Equal(t, "failed on the 'max=100' rule", badRequest.FieldViolations[1].Description)
+}
@@ -0,0 +1,108 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	mockdb "github.com/RakibRahman/fincore-api/db/mock"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/password"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/RakibRahman/fincore-api/token"
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves a Server over an in-memory connection and returns a client of it.
+func newTestClient(t *testing.T, store Store) (pb.FincoreClient, *Server) {
+	tokenMaker, err := token.NewPasetoMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	hasher, err := password.NewBcryptHasher(bcrypt.MinCost)
+	require.NoError(t, err)
+
+	server := NewServer(store, tokenMaker, hasher, time.Minute)
+	server.activityPollInterval = 10 * time.Millisecond
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := server.GRPCServer()
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewFincoreClient(conn), server
+}
+
+// contextAs returns a context carrying a valid bearer token for userID.
+func contextAs(t *testing.T, server *Server, userID pgtype.UUID) context.Context {
+	accessToken, _, err := server.tokenMaker.CreateToken(userID.Bytes, time.Minute)
+	require.NoError(t, err)
+
+	md := metadata.Pairs(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, accessToken))
+	return metadata.NewOutgoingContext(context.Background(), md)
+}
+
+func requireCode(t *testing.T, want codes.Code, err error) {
+	t.Helper()
+	require.Equal(t, want, status.Code(err), "error: %v", err)
+}
+
+func randomUUID(t *testing.T) pgtype.UUID {
+	var id pgtype.UUID
+	id.Valid = true
+	for i := range id.Bytes {
+		id.Bytes[i] = byte(utils.RandomInt(0, 255))
+	}
+	id.Bytes[6] = (id.Bytes[6] & 0x0f) | 0x40
+	id.Bytes[8] = (id.Bytes[8] & 0x3f) | 0x80
+	return id
+}
+
+func randomAccount(t *testing.T) db.Account {
+	return db.Account{
+		ID:           utils.RandomInt(1, 1000),
+		OwnerID:      randomUUID(t),
+		BalanceCents: utils.RandomInt(1000, 10000),
+		Currency:     db.CurrencyUSD,
+		Status:       db.AccountStatusActive,
+	}
+}
+
+// newMockStore returns a mock Store that fails the test on any call not expected of it.
+func newMockStore(t *testing.T) *mockdb.MockStore {
+	return mockdb.NewMockStore(gomock.NewController(t))
+}
+
+// expectAccounts stubs GetAccount over a fixed set of accounts, however often it is called.
+func expectAccounts(store *mockdb.MockStore, accounts ...db.Account) {
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, id int64) (db.Account, error) {
+			for _, account := range accounts {
+				if account.ID == id {
+					return account, nil
+				}
+			}
+			return db.Account{}, pgx.ErrNoRows
+		})
+}
@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// pageTag bounds page_size like the REST API's page_size query parameter.
+const pageTag = "required,min=1,max=100"
+
+func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	var violations fieldViolations
+	violations.check("currency", req.GetCurrency(), "required,oneof=USD EUR GBP BDT INR")
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	// Accounts are always opened for the caller and start empty
+	account, err := server.store.CreateAccount(ctx, db.CreateAccountParams{
+		OwnerID:      pgtype.UUID{Bytes: authPayload(ctx).UserID, Valid: true},
+		BalanceCents: 0,
+		Currency:     db.Currency(req.GetCurrency()),
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &pb.CreateAccountResponse{Account: convertAccount(account)}, nil
+}
+
+func (server *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	var violations fieldViolations
+	violations.check("id", req.GetId(), "required,min=1")
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	account, err := server.authorizedAccount(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetAccountResponse{Account: convertAccount(account)}, nil
+}
+
+func (server *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	var violations fieldViolations
+	violations.check("page_size", req.GetPageSize(), pageTag)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	accounts, err := server.store.ListAccountsByOwnerPage(ctx, db.ListAccountsByOwnerPageParams{
+		OwnerID: pgtype.UUID{Bytes: authPayload(ctx).UserID, Valid: true},
+		Cursor:  req.GetCursor(),
+		Limit:   req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	rsp := &pb.ListAccountsResponse{
+		Accounts:   make([]*pb.Account, 0, len(accounts.Items)),
+		NextCursor: accounts.NextCursor,
+		PrevCursor: accounts.PrevCursor,
+	}
+	for _, account := range accounts.Items {
+		rsp.Accounts = append(rsp.Accounts, convertAccount(account))
+	}
+	return rsp, nil
+}
@@ -0,0 +1,259 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// idempotencyKeyTag matches the length limit of the REST API's Idempotency-Key header.
+	idempotencyKeyTag = "omitempty,max=255"
+	// descriptionTag matches the REST API's limit on transaction descriptions.
+	descriptionTag = "max=140"
+	// streamPageSize is how many transactions the streaming RPCs read per query.
+	streamPageSize = 100
+)
+
+var errPeriodReversed = errors.New("must be after from")
+
+// checkIdempotencyKey validates a key under the same rules as the REST API's
+// Idempotency-Key header.
+func (violations *fieldViolations) checkIdempotencyKey(key string) {
+	violations.check("idempotency_key", key, idempotencyKeyTag)
+	if err := db.CheckIdempotencyKey(key); err != nil {
+		violations.add("idempotency_key", err)
+	}
+}
+
+func idempotencyKey(key string) pgtype.Text {
+	return optionalText(key)
+}
+
+// optionalText stores an empty string as NULL.
+func optionalText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func (server *Server) DepositMoney(ctx context.Context, req *pb.DepositMoneyRequest) (*pb.DepositMoneyResponse, error) {
+	var violations fieldViolations
+	violations.check("account_id", req.GetAccountId(), "required,min=1")
+	violations.check("amount_cents", req.GetAmountCents(), "required,gt=0")
+	violations.checkIdempotencyKey(req.GetIdempotencyKey())
+	violations.check("description", req.GetDescription(), descriptionTag)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := server.authorizedAccount(ctx, req.GetAccountId()); err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.DepositMoneyTx(ctx, db.AccountTransactionParams{
+		AccountID:      req.GetAccountId(),
+		Amount:         req.GetAmountCents(),
+		IdempotencyKey: idempotencyKey(req.GetIdempotencyKey()),
+		Description:    optionalText(req.GetDescription()),
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &pb.DepositMoneyResponse{
+		Transaction: convertTransaction(result.Transaction),
+		Account:     convertAccount(result.Account),
+		Replayed:    result.Replayed,
+	}, nil
+}
+
+func (server *Server) WithdrawMoney(ctx context.Context, req *pb.WithdrawMoneyRequest) (*pb.WithdrawMoneyResponse, error) {
+	var violations fieldViolations
+	violations.check("account_id", req.GetAccountId(), "required,min=1")
+	violations.check("amount_cents", req.GetAmountCents(), "required,gt=0")
+	violations.checkIdempotencyKey(req.GetIdempotencyKey())
+	violations.check("description", req.GetDescription(), descriptionTag)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := server.authorizedAccount(ctx, req.GetAccountId()); err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.WithdrawMoneyTx(ctx, db.AccountTransactionParams{
+		AccountID:      req.GetAccountId(),
+		Amount:         req.GetAmountCents(),
+		IdempotencyKey: idempotencyKey(req.GetIdempotencyKey()),
+		Description:    optionalText(req.GetDescription()),
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &pb.WithdrawMoneyResponse{
+		Transaction: convertTransaction(result.Transaction),
+		Account:     convertAccount(result.Account),
+		Replayed:    result.Replayed,
+	}, nil
+}
+
+func (server *Server) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.GetTransactionResponse, error) {
+	var violations fieldViolations
+	violations.check("id", req.GetId(), "required,uuid")
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	var id pgtype.UUID
+	_ = id.Scan(req.GetId())
+
+	transaction, err := server.store.GetTransaction(ctx, id)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	if _, err := server.authorizedAccount(ctx, transaction.AccountID); err != nil {
+		return nil, err
+	}
+
+	return &pb.GetTransactionResponse{Transaction: convertTransaction(transaction)}, nil
+}
+
+func (server *Server) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	var violations fieldViolations
+	violations.check("account_id", req.GetAccountId(), "required,min=1")
+	violations.check("page_size", req.GetPageSize(), pageTag)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := server.authorizedAccount(ctx, req.GetAccountId()); err != nil {
+		return nil, err
+	}
+
+	transactions, err := server.store.ListTransactionsPage(ctx, db.ListTransactionsPageParams{
+		AccountID: req.GetAccountId(),
+		Cursor:    req.GetCursor(),
+		Limit:     req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	rsp := &pb.ListTransactionsResponse{
+		Transactions: make([]*pb.Transaction, 0, len(transactions.Items)),
+		NextCursor:   transactions.NextCursor,
+		PrevCursor:   transactions.PrevCursor,
+	}
+	for _, transaction := range transactions.Items {
+		rsp.Transactions = append(rsp.Transactions, convertTransaction(transaction))
+	}
+	return rsp, nil
+}
+
+func (server *Server) StreamTransactions(req *pb.StreamTransactionsRequest, stream pb.Fincore_StreamTransactionsServer) error {
+	ctx := stream.Context()
+
+	var violations fieldViolations
+	violations.check("account_id", req.GetAccountId(), "required,min=1")
+	if req.GetFrom() != nil && req.GetTo() != nil && !req.GetFrom().AsTime().Before(req.GetTo().AsTime()) {
+		violations.add("to", errPeriodReversed)
+	}
+	if err := violations.err(); err != nil {
+		return err
+	}
+
+	if _, err := server.authorizedAccount(ctx, req.GetAccountId()); err != nil {
+		return err
+	}
+
+	arg := db.ListTransactionsInPeriodParams{
+		AccountID: req.GetAccountId(),
+		FromTime:  periodBound(req.GetFrom(), pgtype.NegativeInfinity),
+		ToTime:    periodBound(req.GetTo(), pgtype.Infinity),
+		Limit:     streamPageSize,
+	}
+	for {
+		transactions, err := server.store.ListTransactionsInPeriod(ctx, arg)
+		if err != nil {
+			return storeError(err)
+		}
+		for _, transaction := range transactions {
+			if err := stream.Send(&pb.StreamTransactionsResponse{Transaction: convertTransaction(transaction)}); err != nil {
+				return err
+			}
+			arg.AfterSeq = transaction.Seq
+		}
+		if len(transactions) < streamPageSize {
+			return nil
+		}
+	}
+}
+
+// WatchAccountActivity polls for transactions applied after the last one sent. Rows are
+// read in seq order, which only grows on an account, so a transaction committed between two
+// polls is picked up by the next one.
+func (server *Server) WatchAccountActivity(req *pb.WatchAccountActivityRequest, stream pb.Fincore_WatchAccountActivityServer) error {
+	ctx := stream.Context()
+
+	var violations fieldViolations
+	violations.check("account_id", req.GetAccountId(), "required,min=1")
+	violations.check("after_seq", req.GetAfterSeq(), "min=0")
+	if err := violations.err(); err != nil {
+		return err
+	}
+
+	if _, err := server.authorizedAccount(ctx, req.GetAccountId()); err != nil {
+		return err
+	}
+
+	arg := db.ListTransactionsInPeriodParams{
+		AccountID: req.GetAccountId(),
+		FromTime:  pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+		ToTime:    pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+		AfterSeq:  req.GetAfterSeq(),
+		Limit:     streamPageSize,
+	}
+	ticker := time.NewTicker(server.activityPollInterval)
+	defer ticker.Stop()
+
+	for {
+		transactions, err := server.store.ListTransactionsInPeriod(ctx, arg)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return storeError(err)
+		}
+		for _, transaction := range transactions {
+			if err := stream.Send(&pb.WatchAccountActivityResponse{Transaction: convertTransaction(transaction)}); err != nil {
+				return err
+			}
+			arg.AfterSeq = transaction.Seq
+		}
+		if len(transactions) == streamPageSize {
+			// More are waiting; don't sleep
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// periodBound turns an optional timestamp into a bound of ListTransactionsInPeriod,
+// open (infinite) when unset.
+func periodBound(t *timestamppb.Timestamp, open pgtype.InfinityModifier) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{InfinityModifier: open, Valid: true}
+	}
+	return pgtype.Timestamptz{Time: t.AsTime(), Valid: true}
+}
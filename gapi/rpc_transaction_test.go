@@ -0,0 +1,294 @@
+package gapi
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	mockdb "github.com/RakibRahman/fincore-api/db/mock"
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDepositMoneyRPC(t *testing.T) {
+	account := randomAccount(t)
+
+	testCases := []struct {
+		name    string
+		req     *pb.DepositMoneyRequest
+		caller  pgtype.UUID
+		deposit func(arg db.AccountTransactionParams) (db.AccountTransactionResult, error)
+		want    codes.Code
+	}{
+		{
+			name:   "OK",
+			req:    &pb.DepositMoneyRequest{AccountId: account.ID, AmountCents: 500, IdempotencyKey: "dep-1"},
+			caller: account.OwnerID,
+			deposit: func(arg db.AccountTransactionParams) (db.AccountTransactionResult, error) {
+				require.Equal(t, account.ID, arg.AccountID)
+				require.Equal(t, int64(500), arg.Amount)
+				require.Equal(t, pgtype.Text{String: "dep-1", Valid: true}, arg.IdempotencyKey)
+				updated := account
+				updated.BalanceCents += arg.Amount
+				return db.AccountTransactionResult{
+					Account: updated,
+					Transaction: db.Transaction{
+						ID:                randomUUID(t),
+						AccountID:         account.ID,
+						Type:              db.TransactionTypeDeposit,
+						AmountCents:       arg.Amount,
+						BalanceAfterCents: updated.BalanceCents,
+						Reference:         arg.IdempotencyKey,
+					},
+					Replayed: true,
+				}, nil
+			},
+			want: codes.OK,
+		},
+		{
+			name:   "ZeroAmount",
+			req:    &pb.DepositMoneyRequest{AccountId: account.ID},
+			caller: account.OwnerID,
+			want:   codes.InvalidArgument,
+		},
+		{
+			name:   "ReservedKey",
+			req:    &pb.DepositMoneyRequest{AccountId: account.ID, AmountCents: 500, IdempotencyKey: "hold:dep-1"},
+			caller: account.OwnerID,
+			want:   codes.InvalidArgument,
+		},
+		{
+			name:   "NotOwner",
+			req:    &pb.DepositMoneyRequest{AccountId: account.ID, AmountCents: 500},
+			caller: randomUUID(t),
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "AccountNotFound",
+			req:    &pb.DepositMoneyRequest{AccountId: account.ID + 1, AmountCents: 500},
+			caller: account.OwnerID,
+			want:   codes.NotFound,
+		},
+		{
+			name:   "AccountClosed",
+			req:    &pb.DepositMoneyRequest{AccountId: account.ID, AmountCents: 500},
+			caller: account.OwnerID,
+			deposit: func(arg db.AccountTransactionParams) (db.AccountTransactionResult, error) {
+				return db.AccountTransactionResult{}, db.ErrAccountClosed
+			},
+			want: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newMockStore(t)
+			expectAccounts(store, account)
+			if tc.deposit != nil {
+				store.EXPECT().DepositMoneyTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ context.Context, arg db.AccountTransactionParams) (db.AccountTransactionResult, error) {
+						return tc.deposit(arg)
+					})
+			}
+
+			client, server := newTestClient(t, store)
+			rsp, err := client.DepositMoney(contextAs(t, server, tc.caller), tc.req)
+			requireCode(t, tc.want, err)
+			if tc.want == codes.OK {
+				require.Equal(t, account.BalanceCents+500, rsp.GetAccount().GetBalanceCents())
+				require.Equal(t, "deposit", rsp.GetTransaction().GetType())
+				require.Equal(t, "dep-1", rsp.GetTransaction().GetReference())
+				require.True(t, rsp.GetReplayed())
+			}
+		})
+	}
+}
+
+func TestWithdrawMoneyRPCInsufficientBalance(t *testing.T) {
+	account := randomAccount(t)
+	store := newMockStore(t)
+	expectAccounts(store, account)
+	store.EXPECT().WithdrawMoneyTx(gomock.Any(), gomock.Any()).Times(1).
+		Return(db.AccountTransactionResult{}, db.ErrInsufficientBalance)
+	client, server := newTestClient(t, store)
+
+	_, err := client.WithdrawMoney(contextAs(t, server, account.OwnerID), &pb.WithdrawMoneyRequest{
+		AccountId:   account.ID,
+		AmountCents: account.BalanceCents + 1,
+	})
+	requireCode(t, codes.FailedPrecondition, err)
+}
+
+func TestListTransactionsRPC(t *testing.T) {
+	account := randomAccount(t)
+	store := newMockStore(t)
+	expectAccounts(store, account)
+	store.EXPECT().ListTransactionsPage(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, arg db.ListTransactionsPageParams) (db.Page[db.Transaction], error) {
+			require.Equal(t, account.ID, arg.AccountID)
+			require.Equal(t, int32(2), arg.Limit)
+			if arg.Cursor != "next" {
+				return db.Page[db.Transaction]{}, db.ErrInvalidCursor
+			}
+			return db.Page[db.Transaction]{
+				Items:      []db.Transaction{{ID: randomUUID(t), AccountID: account.ID, Type: db.TransactionTypeDeposit}},
+				PrevCursor: "prev",
+			}, nil
+		})
+	client, server := newTestClient(t, store)
+	ctx := contextAs(t, server, account.OwnerID)
+
+	rsp, err := client.ListTransactions(ctx, &pb.ListTransactionsRequest{AccountId: account.ID, PageSize: 2, Cursor: "next"})
+	require.NoError(t, err)
+	require.Len(t, rsp.GetTransactions(), 1)
+	require.Empty(t, rsp.GetNextCursor())
+	require.Equal(t, "prev", rsp.GetPrevCursor())
+
+	_, err = client.ListTransactions(ctx, &pb.ListTransactionsRequest{AccountId: account.ID, PageSize: 2, Cursor: "stale"})
+	requireCode(t, codes.InvalidArgument, err)
+
+	_, err = client.ListTransactions(ctx, &pb.ListTransactionsRequest{AccountId: account.ID, Cursor: "next"})
+	requireCode(t, codes.InvalidArgument, err)
+}
+
+// ledger is an account's transactions for the streaming RPCs, safe to append to while
+// a stream reads it.
+type ledger struct {
+	mu           sync.Mutex
+	account      db.Account
+	transactions []db.Transaction
+}
+
+func (l *ledger) append(amount int64, createdAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.account.BalanceCents += amount
+	l.transactions = append(l.transactions, db.Transaction{
+		AccountID:         l.account.ID,
+		Type:              db.TransactionTypeDeposit,
+		AmountCents:       amount,
+		BalanceAfterCents: l.account.BalanceCents,
+		CreatedAt:         pgtype.Timestamptz{Time: createdAt, Valid: true},
+		Seq:               int64(len(l.transactions) + 1),
+	})
+}
+
+// expectListing serves ListTransactionsInPeriod from the ledger.
+func (l *ledger) expectListing(store *mockdb.MockStore) {
+	store.EXPECT().ListTransactionsInPeriod(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error) {
+			return l.listTransactionsInPeriod(arg)
+		})
+}
+
+func (l *ledger) listTransactionsInPeriod(arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var page []db.Transaction
+	for _, transaction := range l.transactions {
+		if transaction.Seq <= arg.AfterSeq || len(page) == int(arg.Limit) {
+			continue
+		}
+		if arg.FromTime.InfinityModifier == pgtype.Finite && transaction.CreatedAt.Time.Before(arg.FromTime.Time) {
+			continue
+		}
+		if arg.ToTime.InfinityModifier == pgtype.Finite && !transaction.CreatedAt.Time.Before(arg.ToTime.Time) {
+			continue
+		}
+		page = append(page, transaction)
+	}
+	return page, nil
+}
+
+func TestStreamTransactionsRPC(t *testing.T) {
+	l := &ledger{account: randomAccount(t)}
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	// More than one page
+	for i := range 250 {
+		l.append(100, start.Add(time.Duration(i)*time.Hour))
+	}
+
+	store := newMockStore(t)
+	expectAccounts(store, l.account)
+	l.expectListing(store)
+	client, server := newTestClient(t, store)
+	ctx := contextAs(t, server, l.account.OwnerID)
+
+	receive := func(req *pb.StreamTransactionsRequest) []int64 {
+		stream, err := client.StreamTransactions(ctx, req)
+		require.NoError(t, err)
+		var seqs []int64
+		for {
+			rsp, err := stream.Recv()
+			if err == io.EOF {
+				return seqs
+			}
+			require.NoError(t, err)
+			seqs = append(seqs, rsp.GetTransaction().GetSeq())
+		}
+	}
+
+	all := receive(&pb.StreamTransactionsRequest{AccountId: l.account.ID})
+	require.Len(t, all, 250)
+	require.Equal(t, int64(1), all[0])
+	require.Equal(t, int64(250), all[249])
+
+	window := receive(&pb.StreamTransactionsRequest{
+		AccountId: l.account.ID,
+		From:      timestamppb.New(start.Add(10 * time.Hour)),
+		To:        timestamppb.New(start.Add(20 * time.Hour)),
+	})
+	require.Equal(t, []int64{11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, window)
+
+	stream, err := client.StreamTransactions(ctx, &pb.StreamTransactionsRequest{
+		AccountId: l.account.ID,
+		From:      timestamppb.New(start),
+		To:        timestamppb.New(start),
+	})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	requireCode(t, codes.InvalidArgument, err)
+}
+
+func TestWatchAccountActivityRPC(t *testing.T) {
+	l := &ledger{account: randomAccount(t)}
+	l.append(100, time.Now())
+	l.append(200, time.Now())
+	l.append(300, time.Now())
+
+	store := newMockStore(t)
+	expectAccounts(store, l.account)
+	l.expectListing(store)
+	client, server := newTestClient(t, store)
+	ctx, cancel := context.WithCancel(contextAs(t, server, l.account.OwnerID))
+	defer cancel()
+
+	stream, err := client.WatchAccountActivity(ctx, &pb.WatchAccountActivityRequest{AccountId: l.account.ID, AfterSeq: 1})
+	require.NoError(t, err)
+
+	// History after the given seq comes first
+	for _, want := range []int64{2, 3} {
+		rsp, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, want, rsp.GetTransaction().GetSeq())
+	}
+
+	// Then transactions as they are applied
+	l.append(400, time.Now())
+	rsp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, int64(4), rsp.GetTransaction().GetSeq())
+	require.Equal(t, int64(400), rsp.GetTransaction().GetAmountCents())
+
+	cancel()
+	_, err = stream.Recv()
+	requireCode(t, codes.Canceled, err)
+}
@@ -0,0 +1,119 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	var violations fieldViolations
+	violations.check("from_account_id", req.GetFromAccountId(), "required,min=1")
+	violations.check("to_account_id", req.GetToAccountId(), "required,min=1,ne="+fmt.Sprint(req.GetFromAccountId()))
+	violations.check("amount_cents", req.GetAmountCents(), "required,gt=0")
+	violations.checkIdempotencyKey(req.GetIdempotencyKey())
+	violations.check("description", req.GetDescription(), descriptionTag)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	// Only the owner of the source account may move money out of it
+	if _, err := server.authorizedAccount(ctx, req.GetFromAccountId()); err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.TransferMoneyTx(ctx, db.TransferMoneyTxParams{
+		FromAccountID:   req.GetFromAccountId(),
+		ToAccountID:     req.GetToAccountId(),
+		AmountCents:     req.GetAmountCents(),
+		IdempotencyKey:  idempotencyKey(req.GetIdempotencyKey()),
+		ConvertCurrency: req.GetConvertCurrency(),
+		Description:     optionalText(req.GetDescription()),
+	})
+	if err != nil {
+		// A refused transfer is committed as failed; hand back which one, so the client
+		// can find it again
+		if result.Transfer.Status == db.TransferStatusFailed {
+			return nil, failedTransferError(err, result.Transfer)
+		}
+		return nil, storeError(err)
+	}
+
+	return &pb.CreateTransferResponse{
+		Transfer:        convertTransfer(result.Transfer),
+		FromAccount:     convertAccount(result.FromAccount),
+		ToAccount:       convertAccount(result.ToAccount),
+		FromTransaction: convertTransaction(result.FromTx),
+		ToTransaction:   convertTransaction(result.ToTx),
+		Replayed:        result.Replayed,
+	}, nil
+}
+
+func (server *Server) GetTransfer(ctx context.Context, req *pb.GetTransferRequest) (*pb.GetTransferResponse, error) {
+	var violations fieldViolations
+	violations.check("id", req.GetId(), "required,uuid")
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	var id pgtype.UUID
+	_ = id.Scan(req.GetId())
+
+	transfer, err := server.store.GetTransfer(ctx, id)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	if !server.ownsTransferSide(ctx, transfer) {
+		return nil, errAccountNotOwned
+	}
+
+	return &pb.GetTransferResponse{Transfer: convertTransfer(transfer)}, nil
+}
+
+// ownsTransferSide reports whether the caller owns either account of the transfer.
+func (server *Server) ownsTransferSide(ctx context.Context, transfer db.Transfer) bool {
+	userID := authPayload(ctx).UserID
+	for _, accountID := range []int64{transfer.FromAccountID, transfer.ToAccountID} {
+		account, err := server.store.GetAccount(ctx, accountID)
+		if err == nil && account.OwnerID.Bytes == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (server *Server) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	var violations fieldViolations
+	violations.check("account_id", req.GetAccountId(), "required,min=1")
+	violations.check("page_size", req.GetPageSize(), pageTag)
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := server.authorizedAccount(ctx, req.GetAccountId()); err != nil {
+		return nil, err
+	}
+
+	transfers, err := server.store.ListTransfersByAccountPage(ctx, db.ListTransfersByAccountPageParams{
+		AccountID: req.GetAccountId(),
+		Cursor:    req.GetCursor(),
+		Limit:     req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	rsp := &pb.ListTransfersResponse{
+		Transfers:  make([]*pb.Transfer, 0, len(transfers.Items)),
+		NextCursor: transfers.NextCursor,
+		PrevCursor: transfers.PrevCursor,
+	}
+	for _, transfer := range transfers.Items {
+		rsp.Transfers = append(rsp.Transfers, convertTransfer(transfer))
+	}
+	return rsp, nil
+}
@@ -0,0 +1,131 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateTransferRPC(t *testing.T) {
+	fromAccount := randomAccount(t)
+	toAccount := randomAccount(t)
+	toAccount.ID = fromAccount.ID + 1
+
+	testCases := []struct {
+		name     string
+		req      *pb.CreateTransferRequest
+		caller   pgtype.UUID
+		transfer func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error)
+		want     codes.Code
+	}{
+		{
+			name:   "OK",
+			req:    &pb.CreateTransferRequest{FromAccountId: fromAccount.ID, ToAccountId: toAccount.ID, AmountCents: 250},
+			caller: fromAccount.OwnerID,
+			transfer: func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+				require.Equal(t, fromAccount.ID, arg.FromAccountID)
+				require.Equal(t, toAccount.ID, arg.ToAccountID)
+				require.Equal(t, int64(250), arg.AmountCents)
+				require.False(t, arg.IdempotencyKey.Valid)
+				return db.TransferMoneyResult{
+					Transfer: db.Transfer{
+						ID:            randomUUID(t),
+						FromAccountID: arg.FromAccountID,
+						ToAccountID:   arg.ToAccountID,
+						AmountCents:   arg.AmountCents,
+						Status:        db.TransferStatusCompleted,
+					},
+					FromAccount: fromAccount,
+					ToAccount:   toAccount,
+				}, nil
+			},
+			want: codes.OK,
+		},
+		{
+			name:   "SameAccount",
+			req:    &pb.CreateTransferRequest{FromAccountId: fromAccount.ID, ToAccountId: fromAccount.ID, AmountCents: 250},
+			caller: fromAccount.OwnerID,
+			want:   codes.InvalidArgument,
+		},
+		{
+			name:   "NotSourceOwner",
+			req:    &pb.CreateTransferRequest{FromAccountId: fromAccount.ID, ToAccountId: toAccount.ID, AmountCents: 250},
+			caller: toAccount.OwnerID,
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "InsufficientBalance",
+			req:    &pb.CreateTransferRequest{FromAccountId: fromAccount.ID, ToAccountId: toAccount.ID, AmountCents: 250},
+			caller: fromAccount.OwnerID,
+			transfer: func(arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+				return db.TransferMoneyResult{}, db.ErrInsufficientBalance
+			},
+			want: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newMockStore(t)
+			expectAccounts(store, fromAccount, toAccount)
+			if tc.transfer != nil {
+				store.EXPECT().TransferMoneyTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ context.Context, arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error) {
+						return tc.transfer(arg)
+					})
+			}
+
+			client, server := newTestClient(t, store)
+			rsp, err := client.CreateTransfer(contextAs(t, server, tc.caller), tc.req)
+			requireCode(t, tc.want, err)
+			if tc.want == codes.OK {
+				require.Equal(t, "completed", rsp.GetTransfer().GetStatus())
+				require.Equal(t, fromAccount.ID, rsp.GetFromAccount().GetId())
+			}
+		})
+	}
+}
+
+func TestCreateTransferRPCFailedTransfer(t *testing.T) {
+	fromAccount := randomAccount(t)
+	toAccount := randomAccount(t)
+	toAccount.ID = fromAccount.ID + 1
+	transferID := randomUUID(t)
+
+	store := newMockStore(t)
+	expectAccounts(store, fromAccount, toAccount)
+	// A refused transfer is committed as failed and returned with the rule's error
+	store.EXPECT().TransferMoneyTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferMoneyResult{
+		Transfer: db.Transfer{
+			ID:            transferID,
+			FromAccountID: fromAccount.ID,
+			ToAccountID:   toAccount.ID,
+			AmountCents:   250,
+			Status:        db.TransferStatusFailed,
+			FailureReason: pgtype.Text{String: db.TransferFailureInsufficientBalance, Valid: true},
+		},
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+	}, db.ErrInsufficientBalance)
+
+	client, server := newTestClient(t, store)
+	req := &pb.CreateTransferRequest{FromAccountId: fromAccount.ID, ToAccountId: toAccount.ID, AmountCents: 250}
+	_, err := client.CreateTransfer(contextAs(t, server, fromAccount.OwnerID), req)
+	requireCode(t, codes.FailedPrecondition, err)
+
+	st := status.Convert(err)
+	require.Equal(t, db.ErrInsufficientBalance.Error(), st.Message())
+	require.Len(t, st.Details(), 1)
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	require.Equal(t, "INSUFFICIENT_BALANCE", info.GetReason())
+	require.Equal(t, transferID.String(), info.GetMetadata()["transfer_id"])
+	require.Equal(t, string(db.TransferStatusFailed), info.GetMetadata()["status"])
+}
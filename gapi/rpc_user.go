@@ -0,0 +1,130 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	var violations fieldViolations
+	violations.check("first_name", req.GetFirstName(), "required")
+	violations.check("last_name", req.GetLastName(), "required")
+	violations.check("email", req.GetEmail(), "required,email")
+	if err := server.passwordPolicy.Validate(req.GetPassword()); err != nil {
+		violations.add("password", err)
+	}
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := server.hasher.Hash(req.GetPassword())
+	if err != nil {
+		return nil, errInternal
+	}
+
+	user, err := server.store.CreateUser(ctx, db.CreateUserParams{
+		FirstName:    req.GetFirstName(),
+		LastName:     req.GetLastName(),
+		Email:        req.GetEmail(),
+		PasswordHash: hashedPassword,
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &pb.CreateUserResponse{User: convertUser(user)}, nil
+}
+
+var errInvalidCredentials = status.Error(codes.Unauthenticated, "invalid email or password")
+
+func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
+	var violations fieldViolations
+	violations.check("email", req.GetEmail(), "required,email")
+	violations.check("password", req.GetPassword(), "required")
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	// Unknown email and wrong password fail the same way, as in the REST API
+	user, err := server.store.GetUserByEmail(ctx, req.GetEmail())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errInvalidCredentials
+		}
+		return nil, storeError(err)
+	}
+
+	needsRehash, err := server.hasher.Verify(req.GetPassword(), user.PasswordHash)
+	if err != nil {
+		return nil, errInvalidCredentials
+	}
+
+	if needsRehash {
+		server.upgradePasswordHash(ctx, user, req.GetPassword())
+	}
+
+	accessToken, payload, err := server.tokenMaker.CreateToken(user.ID.Bytes, server.accessTokenDuration)
+	if err != nil {
+		return nil, errInternal
+	}
+
+	return &pb.LoginUserResponse{
+		User:                 convertUser(user),
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: timestamppb.New(payload.ExpiredAt),
+	}, nil
+}
+
+// upgradePasswordHash re-hashes a verified password with the current hasher configuration.
+// Like its REST counterpart it is best effort and never fails the login.
+func (server *Server) upgradePasswordHash(ctx context.Context, user db.User, plainPassword string) {
+	newHash, err := server.hasher.Hash(plainPassword)
+	if err != nil {
+		log.Printf("cannot rehash password for user %s: %v", user.ID.String(), err)
+		return
+	}
+
+	_, err = server.store.ChangePasswordTx(ctx, db.ChangePasswordTxParams{
+		UserID:          user.ID,
+		NewPasswordHash: newHash,
+		VerifyCurrent: func(currentHash string) error {
+			if currentHash != user.PasswordHash {
+				return errors.New("password changed concurrently")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		log.Printf("cannot store upgraded password hash for user %s: %v", user.ID.String(), err)
+	}
+}
+
+func (server *Server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	var violations fieldViolations
+	violations.check("id", req.GetId(), "required,uuid")
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	var id pgtype.UUID
+	_ = id.Scan(req.GetId())
+	if id.Bytes != authPayload(ctx).UserID {
+		return nil, status.Error(codes.PermissionDenied, "cannot access another user's profile")
+	}
+
+	user, err := server.store.GetUser(ctx, id)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return &pb.GetUserResponse{User: convertUser(user)}, nil
+}
@@ -0,0 +1,60 @@
+// Package gapi serves the core banking operations over gRPC, next to the REST API in package api.
+package gapi
+
+import (
+	"net"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/password"
+	"github.com/RakibRahman/fincore-api/pb"
+	"github.com/RakibRahman/fincore-api/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+const defaultActivityPollInterval = time.Second
+
+// Server serves gRPC requests for the banking service.
+type Server struct {
+	pb.UnimplementedFincoreServer
+	store               Store
+	tokenMaker          token.Maker
+	hasher              password.Hasher
+	passwordPolicy      password.Policy
+	accessTokenDuration time.Duration
+	// activityPollInterval is how often WatchAccountActivity looks for new transactions.
+	activityPollInterval time.Duration
+}
+
+// NewServer creates a new gRPC server.
+func NewServer(store Store, tokenMaker token.Maker, hasher password.Hasher, accessTokenDuration time.Duration) *Server {
+	return &Server{
+		store:                store,
+		tokenMaker:           tokenMaker,
+		hasher:               hasher,
+		passwordPolicy:       password.DefaultPolicy,
+		accessTokenDuration:  accessTokenDuration,
+		activityPollInterval: defaultActivityPollInterval,
+	}
+}
+
+// GRPCServer returns a grpc.Server with the Fincore service and server reflection registered,
+// and every call but CreateUser and LoginUser behind token authentication.
+func (server *Server) GRPCServer() *grpc.Server {
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(server.unaryAuthInterceptor),
+		grpc.StreamInterceptor(server.streamAuthInterceptor),
+	)
+	pb.RegisterFincoreServer(grpcServer, server)
+	reflection.Register(grpcServer)
+	return grpcServer
+}
+
+// Start runs the gRPC server on a specific address.
+func (server *Server) Start(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return server.GRPCServer().Serve(listener)
+}
@@ -0,0 +1,34 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Store is the subset of db.Store the gRPC service depends on.
+type Store interface {
+	CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error)
+	GetUser(ctx context.Context, id pgtype.UUID) (db.User, error)
+	GetUserByEmail(ctx context.Context, email string) (db.User, error)
+	ChangePasswordTx(ctx context.Context, arg db.ChangePasswordTxParams) (db.User, error)
+
+	CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error)
+	GetAccount(ctx context.Context, id int64) (db.Account, error)
+	ListAccountsByOwnerPage(ctx context.Context, arg db.ListAccountsByOwnerPageParams) (db.Page[db.Account], error)
+
+	GetTransaction(ctx context.Context, id pgtype.UUID) (db.Transaction, error)
+	ListTransactionsPage(ctx context.Context, arg db.ListTransactionsPageParams) (db.Page[db.Transaction], error)
+	ListTransactionsInPeriod(ctx context.Context, arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error)
+
+	GetTransfer(ctx context.Context, id pgtype.UUID) (db.Transfer, error)
+	ListTransfersByAccountPage(ctx context.Context, arg db.ListTransfersByAccountPageParams) (db.Page[db.Transfer], error)
+
+	TransferMoneyTx(ctx context.Context, arg db.TransferMoneyTxParams) (db.TransferMoneyResult, error)
+	DepositMoneyTx(ctx context.Context, arg db.AccountTransactionParams) (db.AccountTransactionResult, error)
+	WithdrawMoneyTx(ctx context.Context, arg db.AccountTransactionParams) (db.AccountTransactionResult, error)
+}
+
+// compile-time check that the Postgres-backed store satisfies Store
+var _ Store = (db.Store)(nil)
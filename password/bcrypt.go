@@ -0,0 +1,55 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher hashes passwords with bcrypt at a configurable cost
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher creates a new BcryptHasher
+func NewBcryptHasher(cost int) (Hasher, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("invalid bcrypt cost %d: must be between %d and %d", cost, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	return &BcryptHasher{cost: cost}, nil
+}
+
+// Hash returns the bcrypt hash of the password
+func (hasher *BcryptHasher) Hash(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), hasher.cost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hashedPassword), nil
+}
+
+// Verify checks the password against an encoded hash of any supported algorithm
+func (hasher *BcryptHasher) Verify(password, encodedHash string) (bool, error) {
+	if err := verifyAny(password, encodedHash); err != nil {
+		return false, err
+	}
+
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	if err != nil {
+		// a valid hash from another algorithm
+		return true, nil
+	}
+	return cost != hasher.cost, nil
+}
+
+func compareBcrypt(password, encodedHash string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrMismatchedPassword
+	}
+	if err != nil {
+		return ErrUnknownHashFormat
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package password
+
+import (
+	"errors"
+	"strings"
+)
+
+// Different types of error returned by Hasher.Verify
+var (
+	ErrMismatchedPassword = errors.New("password does not match")
+	ErrUnknownHashFormat  = errors.New("unrecognised password hash format")
+)
+
+// Hasher hashes and verifies passwords.
+// Implementations are interchangeable; every implementation can verify hashes produced by the
+// others, so the algorithm or its cost can be changed without invalidating existing credentials.
+type Hasher interface {
+	// Hash returns an encoded, salted hash of the password
+	Hash(password string) (string, error)
+
+	// Verify checks the password against an encoded hash. It returns ErrMismatchedPassword when
+	// the password is wrong. needsRehash is true when the hash was produced by a different
+	// algorithm or with different parameters than the hasher is currently configured with.
+	Verify(password, encodedHash string) (needsRehash bool, err error)
+}
+
+const (
+	argon2idPrefix = "$argon2id$"
+	bcryptPrefix   = "$2"
+)
+
+// verifyAny verifies a hash produced by any supported algorithm.
+func verifyAny(password, encodedHash string) error {
+	switch {
+	case strings.HasPrefix(encodedHash, argon2idPrefix):
+		params, salt, key, err := decodeArgon2id(encodedHash)
+		if err != nil {
+			return err
+		}
+		return compareArgon2id(password, params, salt, key)
+	case strings.HasPrefix(encodedHash, bcryptPrefix):
+		return compareBcrypt(password, encodedHash)
+	default:
+		return ErrUnknownHashFormat
+	}
+}
@@ -0,0 +1,97 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// cheapArgon2idParams keeps tests fast; production code uses DefaultArgon2idParams.
+var cheapArgon2idParams = Argon2idParams{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestArgon2idHasher(t *testing.T) {
+	hasher, err := NewArgon2idHasher(cheapArgon2idParams)
+	require.NoError(t, err)
+
+	password := utils.RandomString(12)
+	hash1, err := hasher.Hash(password)
+	require.NoError(t, err)
+	require.Contains(t, hash1, argon2idPrefix)
+
+	needsRehash, err := hasher.Verify(password, hash1)
+	require.NoError(t, err)
+	require.False(t, needsRehash)
+
+	_, err = hasher.Verify(utils.RandomString(12), hash1)
+	require.ErrorIs(t, err, ErrMismatchedPassword)
+
+	// salts are random, so the same password never hashes to the same string
+	hash2, err := hasher.Hash(password)
+	require.NoError(t, err)
+	require.NotEqual(t, hash1, hash2)
+}
+
+func TestBcryptHasher(t *testing.T) {
+	hasher, err := NewBcryptHasher(bcrypt.MinCost)
+	require.NoError(t, err)
+
+	password := utils.RandomString(12)
+	hash, err := hasher.Hash(password)
+	require.NoError(t, err)
+
+	needsRehash, err := hasher.Verify(password, hash)
+	require.NoError(t, err)
+	require.False(t, needsRehash)
+
+	_, err = hasher.Verify(utils.RandomString(12), hash)
+	require.ErrorIs(t, err, ErrMismatchedPassword)
+
+	_, err = NewBcryptHasher(bcrypt.MaxCost + 1)
+	require.Error(t, err)
+}
+
+func TestNeedsRehashWhenParametersChange(t *testing.T) {
+	password := utils.RandomString(12)
+
+	oldHasher, err := NewArgon2idHasher(cheapArgon2idParams)
+	require.NoError(t, err)
+	hash, err := oldHasher.Hash(password)
+	require.NoError(t, err)
+
+	stronger := cheapArgon2idParams
+	stronger.Iterations++
+	newHasher, err := NewArgon2idHasher(stronger)
+	require.NoError(t, err)
+
+	needsRehash, err := newHasher.Verify(password, hash)
+	require.NoError(t, err)
+	require.True(t, needsRehash)
+}
+
+func TestNeedsRehashAcrossAlgorithms(t *testing.T) {
+	password := utils.RandomString(12)
+
+	bcryptHasher, err := NewBcryptHasher(bcrypt.MinCost)
+	require.NoError(t, err)
+	bcryptHash, err := bcryptHasher.Hash(password)
+	require.NoError(t, err)
+
+	argonHasher, err := NewArgon2idHasher(cheapArgon2idParams)
+	require.NoError(t, err)
+
+	// a legacy bcrypt hash still verifies, but is flagged for upgrade
+	needsRehash, err := argonHasher.Verify(password, bcryptHash)
+	require.NoError(t, err)
+	require.True(t, needsRehash)
+
+	_, err = argonHasher.Verify(password, "plaintext")
+	require.ErrorIs(t, err, ErrUnknownHashFormat)
+}
@@ -0,0 +1,80 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// Different types of error returned by Policy.Validate
+var (
+	ErrTooShort       = errors.New("password is too short")
+	ErrTooLong        = errors.New("password is too long")
+	ErrMissingUpper   = errors.New("password must contain an upper-case letter")
+	ErrMissingLower   = errors.New("password must contain a lower-case letter")
+	ErrMissingDigit   = errors.New("password must contain a digit")
+	ErrMissingSymbol  = errors.New("password must contain a symbol")
+	ErrSameAsPrevious = errors.New("new password must differ from the current one")
+)
+
+// Policy describes the rules a new password must satisfy
+type Policy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// DefaultPolicy is applied to every password set through the API.
+// MaxLength stays below bcrypt's 72 byte input limit so either hasher can be used.
+var DefaultPolicy = Policy{
+	MinLength:    10,
+	MaxLength:    72,
+	RequireUpper: true,
+	RequireLower: true,
+	RequireDigit: true,
+}
+
+// Validate returns nil when the password satisfies the policy, otherwise an error
+// joining every violated rule so callers can report them all at once.
+func (policy Policy) Validate(password string) error {
+	var errs []error
+
+	if len(password) < policy.MinLength {
+		errs = append(errs, fmt.Errorf("%w: minimum %d characters", ErrTooShort, policy.MinLength))
+	}
+	if policy.MaxLength > 0 && len(password) > policy.MaxLength {
+		errs = append(errs, fmt.Errorf("%w: maximum %d bytes", ErrTooLong, policy.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	if policy.RequireUpper && !hasUpper {
+		errs = append(errs, ErrMissingUpper)
+	}
+	if policy.RequireLower && !hasLower {
+		errs = append(errs, ErrMissingLower)
+	}
+	if policy.RequireDigit && !hasDigit {
+		errs = append(errs, ErrMissingDigit)
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		errs = append(errs, ErrMissingSymbol)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,40 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		password string
+		wantErrs []error
+	}{
+		{name: "OK", password: "Correct-Horse1"},
+		{name: "TooShort", password: "Ab1", wantErrs: []error{ErrTooShort}},
+		{name: "MissingUpper", password: "lowercase123", wantErrs: []error{ErrMissingUpper}},
+		{name: "MissingLower", password: "UPPERCASE123", wantErrs: []error{ErrMissingLower}},
+		{name: "MissingDigit", password: "NoDigitsHere", wantErrs: []error{ErrMissingDigit}},
+		{name: "MultipleViolations", password: "short", wantErrs: []error{ErrTooShort, ErrMissingUpper, ErrMissingDigit}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DefaultPolicy.Validate(tc.password)
+			if len(tc.wantErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, wantErr := range tc.wantErrs {
+				require.ErrorIs(t, err, wantErr)
+			}
+		})
+	}
+
+	policy := DefaultPolicy
+	policy.RequireSymbol = true
+	require.ErrorIs(t, policy.Validate("NoSymbols123"), ErrMissingSymbol)
+	require.ErrorIs(t, DefaultPolicy.Validate("Aa1"+string(make([]byte, 80))), ErrTooLong)
+}
@@ -0,0 +1,824 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: rpc_transaction.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DepositMoneyRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AccountId   int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AmountCents int64                  `protobuf:"varint,2,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	// Repeating a request with the same key returns the original result instead of
+	// moving money twice.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Up to 140 characters, stored on the transaction.
+	Description   string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepositMoneyRequest) Reset() {
+	*x = DepositMoneyRequest{}
+	mi := &file_rpc_transaction_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositMoneyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositMoneyRequest) ProtoMessage() {}
+
+func (x *DepositMoneyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositMoneyRequest.ProtoReflect.Descriptor instead.
+func (*DepositMoneyRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DepositMoneyRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *DepositMoneyRequest) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *DepositMoneyRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *DepositMoneyRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type DepositMoneyResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Transaction *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Account     *Account               `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	// Set when the response describes an earlier request with the same idempotency key.
+	Replayed      bool `protobuf:"varint,3,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepositMoneyResponse) Reset() {
+	*x = DepositMoneyResponse{}
+	mi := &file_rpc_transaction_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositMoneyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositMoneyResponse) ProtoMessage() {}
+
+func (x *DepositMoneyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositMoneyResponse.ProtoReflect.Descriptor instead.
+func (*DepositMoneyResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DepositMoneyResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *DepositMoneyResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *DepositMoneyResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+type WithdrawMoneyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AmountCents    int64                  `protobuf:"varint,2,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WithdrawMoneyRequest) Reset() {
+	*x = WithdrawMoneyRequest{}
+	mi := &file_rpc_transaction_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawMoneyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawMoneyRequest) ProtoMessage() {}
+
+func (x *WithdrawMoneyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawMoneyRequest.ProtoReflect.Descriptor instead.
+func (*WithdrawMoneyRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WithdrawMoneyRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *WithdrawMoneyRequest) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *WithdrawMoneyRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *WithdrawMoneyRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type WithdrawMoneyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Account       *Account               `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	Replayed      bool                   `protobuf:"varint,3,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawMoneyResponse) Reset() {
+	*x = WithdrawMoneyResponse{}
+	mi := &file_rpc_transaction_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawMoneyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawMoneyResponse) ProtoMessage() {}
+
+func (x *WithdrawMoneyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawMoneyResponse.ProtoReflect.Descriptor instead.
+func (*WithdrawMoneyResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WithdrawMoneyResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *WithdrawMoneyResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *WithdrawMoneyResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_rpc_transaction_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTransactionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionResponse) Reset() {
+	*x = GetTransactionResponse{}
+	mi := &file_rpc_transaction_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionResponse) ProtoMessage() {}
+
+func (x *GetTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTransactionResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type ListTransactionsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	PageSize  int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Empty for the first page, otherwise the next_cursor or prev_cursor of a page already read.
+	Cursor        string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_rpc_transaction_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTransactionsRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListTransactionsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Transactions []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// Empty when there is nothing further in that direction.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string `protobuf:"bytes,3,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_rpc_transaction_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListTransactionsResponse) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type StreamTransactionsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Transactions created in [from, to); either end may be left open.
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTransactionsRequest) Reset() {
+	*x = StreamTransactionsRequest{}
+	mi := &file_rpc_transaction_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTransactionsRequest) ProtoMessage() {}
+
+func (x *StreamTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*StreamTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamTransactionsRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *StreamTransactionsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *StreamTransactionsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type StreamTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTransactionsResponse) Reset() {
+	*x = StreamTransactionsResponse{}
+	mi := &file_rpc_transaction_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTransactionsResponse) ProtoMessage() {}
+
+func (x *StreamTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*StreamTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StreamTransactionsResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type WatchAccountActivityRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Only transactions applied after this seq are sent; 0 starts from the first one.
+	AfterSeq      int64 `protobuf:"varint,2,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAccountActivityRequest) Reset() {
+	*x = WatchAccountActivityRequest{}
+	mi := &file_rpc_transaction_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAccountActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAccountActivityRequest) ProtoMessage() {}
+
+func (x *WatchAccountActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAccountActivityRequest.ProtoReflect.Descriptor instead.
+func (*WatchAccountActivityRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchAccountActivityRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *WatchAccountActivityRequest) GetAfterSeq() int64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+type WatchAccountActivityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAccountActivityResponse) Reset() {
+	*x = WatchAccountActivityResponse{}
+	mi := &file_rpc_transaction_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAccountActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAccountActivityResponse) ProtoMessage() {}
+
+func (x *WatchAccountActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_transaction_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAccountActivityResponse.ProtoReflect.Descriptor instead.
+func (*WatchAccountActivityResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_transaction_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchAccountActivityResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+var File_rpc_transaction_proto protoreflect.FileDescriptor
+
+const file_rpc_transaction_proto_rawDesc = "" +
+	"\n" +
+	"\x15rpc_transaction.proto\x12\n" +
+	"fincore.v1\x1a\raccount.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x11transaction.proto\"\xa2\x01\n" +
+	"\x13DepositMoneyRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12!\n" +
+	"\famount_cents\x18\x02 \x01(\x03R\vamountCents\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\x9c\x01\n" +
+	"\x14DepositMoneyResponse\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.fincore.v1.TransactionR\vtransaction\x12-\n" +
+	"\aaccount\x18\x02 \x01(\v2\x13.fincore.v1.AccountR\aaccount\x12\x1a\n" +
+	"\breplayed\x18\x03 \x01(\bR\breplayed\"\xa3\x01\n" +
+	"\x14WithdrawMoneyRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12!\n" +
+	"\famount_cents\x18\x02 \x01(\x03R\vamountCents\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\x9d\x01\n" +
+	"\x15WithdrawMoneyResponse\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.fincore.v1.TransactionR\vtransaction\x12-\n" +
+	"\aaccount\x18\x02 \x01(\v2\x13.fincore.v1.AccountR\aaccount\x12\x1a\n" +
+	"\breplayed\x18\x03 \x01(\bR\breplayed\"'\n" +
+	"\x15GetTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"S\n" +
+	"\x16GetTransactionResponse\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.fincore.v1.TransactionR\vtransaction\"|\n" +
+	"\x17ListTransactionsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursorJ\x04\b\x02\x10\x03R\apage_id\"\x99\x01\n" +
+	"\x18ListTransactionsResponse\x12;\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x17.fincore.v1.TransactionR\ftransactions\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x03 \x01(\tR\n" +
+	"prevCursor\"\x96\x01\n" +
+	"\x19StreamTransactionsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"W\n" +
+	"\x1aStreamTransactionsResponse\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.fincore.v1.TransactionR\vtransaction\"Y\n" +
+	"\x1bWatchAccountActivityRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12\x1b\n" +
+	"\tafter_seq\x18\x02 \x01(\x03R\bafterSeq\"Y\n" +
+	"\x1cWatchAccountActivityResponse\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.fincore.v1.TransactionR\vtransactionB'Z%github.com/RakibRahman/fincore-api/pbb\x06proto3"
+
+var (
+	file_rpc_transaction_proto_rawDescOnce sync.Once
+	file_rpc_transaction_proto_rawDescData []byte
+)
+
+func file_rpc_transaction_proto_rawDescGZIP() []byte {
+	file_rpc_transaction_proto_rawDescOnce.Do(func() {
+		file_rpc_transaction_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_transaction_proto_rawDesc), len(file_rpc_transaction_proto_rawDesc)))
+	})
+	return file_rpc_transaction_proto_rawDescData
+}
+
+var file_rpc_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_rpc_transaction_proto_goTypes = []any{
+	(*DepositMoneyRequest)(nil),          // 0: fincore.v1.DepositMoneyRequest
+	(*DepositMoneyResponse)(nil),         // 1: fincore.v1.DepositMoneyResponse
+	(*WithdrawMoneyRequest)(nil),         // 2: fincore.v1.WithdrawMoneyRequest
+	(*WithdrawMoneyResponse)(nil),        // 3: fincore.v1.WithdrawMoneyResponse
+	(*GetTransactionRequest)(nil),        // 4: fincore.v1.GetTransactionRequest
+	(*GetTransactionResponse)(nil),       // 5: fincore.v1.GetTransactionResponse
+	(*ListTransactionsRequest)(nil),      // 6: fincore.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),     // 7: fincore.v1.ListTransactionsResponse
+	(*StreamTransactionsRequest)(nil),    // 8: fincore.v1.StreamTransactionsRequest
+	(*StreamTransactionsResponse)(nil),   // 9: fincore.v1.StreamTransactionsResponse
+	(*WatchAccountActivityRequest)(nil),  // 10: fincore.v1.WatchAccountActivityRequest
+	(*WatchAccountActivityResponse)(nil), // 11: fincore.v1.WatchAccountActivityResponse
+	(*Transaction)(nil),                  // 12: fincore.v1.Transaction
+	(*Account)(nil),                      // 13: fincore.v1.Account
+	(*timestamppb.Timestamp)(nil),        // 14: google.protobuf.Timestamp
+}
+var file_rpc_transaction_proto_depIdxs = []int32{
+	12, // 0: fincore.v1.DepositMoneyResponse.transaction:type_name -> fincore.v1.Transaction
+	13, // 1: fincore.v1.DepositMoneyResponse.account:type_name -> fincore.v1.Account
+	12, // 2: fincore.v1.WithdrawMoneyResponse.transaction:type_name -> fincore.v1.Transaction
+	13, // 3: fincore.v1.WithdrawMoneyResponse.account:type_name -> fincore.v1.Account
+	12, // 4: fincore.v1.GetTransactionResponse.transaction:type_name -> fincore.v1.Transaction
+	12, // 5: fincore.v1.ListTransactionsResponse.transactions:type_name -> fincore.v1.Transaction
+	14, // 6: fincore.v1.StreamTransactionsRequest.from:type_name -> google.protobuf.Timestamp
+	14, // 7: fincore.v1.StreamTransactionsRequest.to:type_name -> google.protobuf.Timestamp
+	12, // 8: fincore.v1.StreamTransactionsResponse.transaction:type_name -> fincore.v1.Transaction
+	12, // 9: fincore.v1.WatchAccountActivityResponse.transaction:type_name -> fincore.v1.Transaction
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_rpc_transaction_proto_init() }
+func file_rpc_transaction_proto_init() {
+	if File_rpc_transaction_proto != nil {
+		return
+	}
+	file_account_proto_init()
+	file_transaction_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_transaction_proto_rawDesc), len(file_rpc_transaction_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_transaction_proto_goTypes,
+		DependencyIndexes: file_rpc_transaction_proto_depIdxs,
+		MessageInfos:      file_rpc_transaction_proto_msgTypes,
+	}.Build()
+	File_rpc_transaction_proto = out.File
+	file_rpc_transaction_proto_goTypes = nil
+	file_rpc_transaction_proto_depIdxs = nil
+}
@@ -0,0 +1,144 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: service_fincore.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_service_fincore_proto protoreflect.FileDescriptor
+
+const file_service_fincore_proto_rawDesc = "" +
+	"\n" +
+	"\x15service_fincore.proto\x12\n" +
+	"fincore.v1\x1a\x11rpc_account.proto\x1a\x15rpc_transaction.proto\x1a\x12rpc_transfer.proto\x1a\x0erpc_user.proto2\x8e\n" +
+	"\n" +
+	"\aFincore\x12K\n" +
+	"\n" +
+	"CreateUser\x12\x1d.fincore.v1.CreateUserRequest\x1a\x1e.fincore.v1.CreateUserResponse\x12H\n" +
+	"\tLoginUser\x12\x1c.fincore.v1.LoginUserRequest\x1a\x1d.fincore.v1.LoginUserResponse\x12B\n" +
+	"\aGetUser\x12\x1a.fincore.v1.GetUserRequest\x1a\x1b.fincore.v1.GetUserResponse\x12T\n" +
+	"\rCreateAccount\x12 .fincore.v1.CreateAccountRequest\x1a!.fincore.v1.CreateAccountResponse\x12K\n" +
+	"\n" +
+	"GetAccount\x12\x1d.fincore.v1.GetAccountRequest\x1a\x1e.fincore.v1.GetAccountResponse\x12Q\n" +
+	"\fListAccounts\x12\x1f.fincore.v1.ListAccountsRequest\x1a .fincore.v1.ListAccountsResponse\x12Q\n" +
+	"\fDepositMoney\x12\x1f.fincore.v1.DepositMoneyRequest\x1a .fincore.v1.DepositMoneyResponse\x12T\n" +
+	"\rWithdrawMoney\x12 .fincore.v1.WithdrawMoneyRequest\x1a!.fincore.v1.WithdrawMoneyResponse\x12W\n" +
+	"\x0eGetTransaction\x12!.fincore.v1.GetTransactionRequest\x1a\".fincore.v1.GetTransactionResponse\x12]\n" +
+	"\x10ListTransactions\x12#.fincore.v1.ListTransactionsRequest\x1a$.fincore.v1.ListTransactionsResponse\x12e\n" +
+	"\x12StreamTransactions\x12%.fincore.v1.StreamTransactionsRequest\x1a&.fincore.v1.StreamTransactionsResponse0\x01\x12k\n" +
+	"\x14WatchAccountActivity\x12'.fincore.v1.WatchAccountActivityRequest\x1a(.fincore.v1.WatchAccountActivityResponse0\x01\x12W\n" +
+	"\x0eCreateTransfer\x12!.fincore.v1.CreateTransferRequest\x1a\".fincore.v1.CreateTransferResponse\x12N\n" +
+	"\vGetTransfer\x12\x1e.fincore.v1.GetTransferRequest\x1a\x1f.fincore.v1.GetTransferResponse\x12T\n" +
+	"\rListTransfers\x12 .fincore.v1.ListTransfersRequest\x1a!.fincore.v1.ListTransfersResponseB'Z%github.com/RakibRahman/fincore-api/pbb\x06proto3"
+
+var file_service_fincore_proto_goTypes = []any{
+	(*CreateUserRequest)(nil),            // 0: fincore.v1.CreateUserRequest
+	(*LoginUserRequest)(nil),             // 1: fincore.v1.LoginUserRequest
+	(*GetUserRequest)(nil),               // 2: fincore.v1.GetUserRequest
+	(*CreateAccountRequest)(nil),         // 3: fincore.v1.CreateAccountRequest
+	(*GetAccountRequest)(nil),            // 4: fincore.v1.GetAccountRequest
+	(*ListAccountsRequest)(nil),          // 5: fincore.v1.ListAccountsRequest
+	(*DepositMoneyRequest)(nil),          // 6: fincore.v1.DepositMoneyRequest
+	(*WithdrawMoneyRequest)(nil),         // 7: fincore.v1.WithdrawMoneyRequest
+	(*GetTransactionRequest)(nil),        // 8: fincore.v1.GetTransactionRequest
+	(*ListTransactionsRequest)(nil),      // 9: fincore.v1.ListTransactionsRequest
+	(*StreamTransactionsRequest)(nil),    // 10: fincore.v1.StreamTransactionsRequest
+	(*WatchAccountActivityRequest)(nil),  // 11: fincore.v1.WatchAccountActivityRequest
+	(*CreateTransferRequest)(nil),        // 12: fincore.v1.CreateTransferRequest
+	(*GetTransferRequest)(nil),           // 13: fincore.v1.GetTransferRequest
+	(*ListTransfersRequest)(nil),         // 14: fincore.v1.ListTransfersRequest
+	(*CreateUserResponse)(nil),           // 15: fincore.v1.CreateUserResponse
+	(*LoginUserResponse)(nil),            // 16: fincore.v1.LoginUserResponse
+	(*GetUserResponse)(nil),              // 17: fincore.v1.GetUserResponse
+	(*CreateAccountResponse)(nil),        // 18: fincore.v1.CreateAccountResponse
+	(*GetAccountResponse)(nil),           // 19: fincore.v1.GetAccountResponse
+	(*ListAccountsResponse)(nil),         // 20: fincore.v1.ListAccountsResponse
+	(*DepositMoneyResponse)(nil),         // 21: fincore.v1.DepositMoneyResponse
+	(*WithdrawMoneyResponse)(nil),        // 22: fincore.v1.WithdrawMoneyResponse
+	(*GetTransactionResponse)(nil),       // 23: fincore.v1.GetTransactionResponse
+	(*ListTransactionsResponse)(nil),     // 24: fincore.v1.ListTransactionsResponse
+	(*StreamTransactionsResponse)(nil),   // 25: fincore.v1.StreamTransactionsResponse
+	(*WatchAccountActivityResponse)(nil), // 26: fincore.v1.WatchAccountActivityResponse
+	(*CreateTransferResponse)(nil),       // 27: fincore.v1.CreateTransferResponse
+	(*GetTransferResponse)(nil),          // 28: fincore.v1.GetTransferResponse
+	(*ListTransfersResponse)(nil),        // 29: fincore.v1.ListTransfersResponse
+}
+var file_service_fincore_proto_depIdxs = []int32{
+	0,  // 0: fincore.v1.Fincore.CreateUser:input_type -> fincore.v1.CreateUserRequest
+	1,  // 1: fincore.v1.Fincore.LoginUser:input_type -> fincore.v1.LoginUserRequest
+	2,  // 2: fincore.v1.Fincore.GetUser:input_type -> fincore.v1.GetUserRequest
+	3,  // 3: fincore.v1.Fincore.CreateAccount:input_type -> fincore.v1.CreateAccountRequest
+	4,  // 4: fincore.v1.Fincore.GetAccount:input_type -> fincore.v1.GetAccountRequest
+	5,  // 5: fincore.v1.Fincore.ListAccounts:input_type -> fincore.v1.ListAccountsRequest
+	6,  // 6: fincore.v1.Fincore.DepositMoney:input_type -> fincore.v1.DepositMoneyRequest
+	7,  // 7: fincore.v1.Fincore.WithdrawMoney:input_type -> fincore.v1.WithdrawMoneyRequest
+	8,  // 8: fincore.v1.Fincore.GetTransaction:input_type -> fincore.v1.GetTransactionRequest
+	9,  // 9: fincore.v1.Fincore.ListTransactions:input_type -> fincore.v1.ListTransactionsRequest
+	10, // 10: fincore.v1.Fincore.StreamTransactions:input_type -> fincore.v1.StreamTransactionsRequest
+	11, // 11: fincore.v1.Fincore.WatchAccountActivity:input_type -> fincore.v1.WatchAccountActivityRequest
+	12, // 12: fincore.v1.Fincore.CreateTransfer:input_type -> fincore.v1.CreateTransferRequest
+	13, // 13: fincore.v1.Fincore.GetTransfer:input_type -> fincore.v1.GetTransferRequest
+	14, // 14: fincore.v1.Fincore.ListTransfers:input_type -> fincore.v1.ListTransfersRequest
+	15, // 15: fincore.v1.Fincore.CreateUser:output_type -> fincore.v1.CreateUserResponse
+	16, // 16: fincore.v1.Fincore.LoginUser:output_type -> fincore.v1.LoginUserResponse
+	17, // 17: fincore.v1.Fincore.GetUser:output_type -> fincore.v1.GetUserResponse
+	18, // 18: fincore.v1.Fincore.CreateAccount:output_type -> fincore.v1.CreateAccountResponse
+	19, // 19: fincore.v1.Fincore.GetAccount:output_type -> fincore.v1.GetAccountResponse
+	20, // 20: fincore.v1.Fincore.ListAccounts:output_type -> fincore.v1.ListAccountsResponse
+	21, // 21: fincore.v1.Fincore.DepositMoney:output_type -> fincore.v1.DepositMoneyResponse
+	22, // 22: fincore.v1.Fincore.WithdrawMoney:output_type -> fincore.v1.WithdrawMoneyResponse
+	23, // 23: fincore.v1.Fincore.GetTransaction:output_type -> fincore.v1.GetTransactionResponse
+	24, // 24: fincore.v1.Fincore.ListTransactions:output_type -> fincore.v1.ListTransactionsResponse
+	25, // 25: fincore.v1.Fincore.StreamTransactions:output_type -> fincore.v1.StreamTransactionsResponse
+	26, // 26: fincore.v1.Fincore.WatchAccountActivity:output_type -> fincore.v1.WatchAccountActivityResponse
+	27, // 27: fincore.v1.Fincore.CreateTransfer:output_type -> fincore.v1.CreateTransferResponse
+	28, // 28: fincore.v1.Fincore.GetTransfer:output_type -> fincore.v1.GetTransferResponse
+	29, // 29: fincore.v1.Fincore.ListTransfers:output_type -> fincore.v1.ListTransfersResponse
+	15, // [15:30] is the sub-list for method output_type
+	0,  // [0:15] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_service_fincore_proto_init() }
+func file_service_fincore_proto_init() {
+	if File_service_fincore_proto != nil {
+		return
+	}
+	file_rpc_account_proto_init()
+	file_rpc_transaction_proto_init()
+	file_rpc_transfer_proto_init()
+	file_rpc_user_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_service_fincore_proto_rawDesc), len(file_service_fincore_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_service_fincore_proto_goTypes,
+		DependencyIndexes: file_service_fincore_proto_depIdxs,
+	}.Build()
+	File_service_fincore_proto = out.File
+	file_service_fincore_proto_goTypes = nil
+	file_service_fincore_proto_depIdxs = nil
+}
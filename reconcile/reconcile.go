@@ -0,0 +1,263 @@
+// Package reconcile checks stored account balances against the history that produced them.
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultActor is recorded on accounts frozen by reconciliation when Options.Actor is empty.
+const DefaultActor = "system:reconcile"
+
+const defaultBatchSize = 500
+
+// Store is the subset of db.Store reconciliation needs.
+type Store interface {
+	ListAccountBalanceTotals(ctx context.Context, arg db.ListAccountBalanceTotalsParams) ([]db.ListAccountBalanceTotalsRow, error)
+	ListBalanceChainBreaks(ctx context.Context, accountIds []int64) ([]db.ListBalanceChainBreaksRow, error)
+	OpenReconciliationIncident(ctx context.Context, arg db.OpenReconciliationIncidentParams) (db.ReconciliationIncident, error)
+	FreezeAccountTx(ctx context.Context, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error)
+}
+
+// Options selects what a run scans and what it does about drift.
+type Options struct {
+	// AccountIDs limits the run to these accounts. Empty scans every account.
+	AccountIDs []int64
+	// BatchSize is how many accounts are read per query; zero means 500.
+	BatchSize int
+	// OpenIncidents records each drift as a reconciliation incident.
+	OpenIncidents bool
+	// FreezeAccounts freezes every account with drift so no more money leaves it.
+	FreezeAccounts bool
+	// Actor is recorded on the freeze; defaults to DefaultActor.
+	Actor string
+}
+
+// Drift is one disagreement between an account's balance and its history.
+type Drift struct {
+	AccountID int64        `json:"account_id"`
+	Kind      db.DriftKind `json:"kind"`
+	// ExpectedCents is what the history says; ActualCents is what is stored.
+	ExpectedCents int64 `json:"expected_cents"`
+	ActualCents   int64 `json:"actual_cents"`
+	// TransactionID is the first transaction breaking the balance chain.
+	TransactionID string `json:"transaction_id,omitempty"`
+	// IncidentID is set when the run opened an incident for this drift.
+	IncidentID int64 `json:"incident_id,omitempty"`
+}
+
+// Report is the machine-readable outcome of a run.
+type Report struct {
+	StartedAt       time.Time `json:"started_at"`
+	FinishedAt      time.Time `json:"finished_at"`
+	AccountsScanned int       `json:"accounts_scanned"`
+	Drifts          []Drift   `json:"drifts"`
+	// MissingAccounts lists requested accounts that don't exist.
+	MissingAccounts []int64 `json:"missing_accounts,omitempty"`
+	FrozenAccounts  []int64 `json:"frozen_accounts,omitempty"`
+}
+
+// Clean reports whether every scanned account reconciled.
+func (report Report) Clean() bool {
+	return len(report.Drifts) == 0 && len(report.MissingAccounts) == 0
+}
+
+// Run scans accounts in batches and checks, for each, that
+//   - balance_cents equals the sum of its transactions,
+//   - balance_cents equals the sum of its ledger postings, and
+//   - every transaction's balance_after_cents follows from the one before it.
+//
+// Only the first break in a balance chain is reported: every row after a bad one
+// disagrees with it as well. Incidents and freezes are applied as drift is found, so a
+// run that fails part way keeps what it already recorded.
+func Run(ctx context.Context, store Store, opts Options) (Report, error) {
+	report := Report{StartedAt: time.Now(), Drifts: []Drift{}}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	actor := opts.Actor
+	if actor == "" {
+		actor = DefaultActor
+	}
+
+	seen := make(map[int64]bool)
+	var afterID int64
+	for {
+		totals, err := store.ListAccountBalanceTotals(ctx, db.ListAccountBalanceTotalsParams{
+			AfterID:    afterID,
+			AccountIds: opts.AccountIDs,
+			Limit:      int32(batchSize),
+		})
+		if err != nil {
+			return report, fmt.Errorf("list account balance totals: %w", err)
+		}
+		if len(totals) == 0 {
+			break
+		}
+
+		drifts, err := checkBatch(ctx, store, totals)
+		if err != nil {
+			return report, err
+		}
+		for _, row := range totals {
+			seen[row.ID] = true
+		}
+		report.AccountsScanned += len(totals)
+
+		for _, accountDrifts := range groupByAccount(drifts) {
+			if opts.OpenIncidents {
+				if err := openIncidents(ctx, store, accountDrifts); err != nil {
+					return report, err
+				}
+			}
+			if opts.FreezeAccounts {
+				frozen, err := freeze(ctx, store, accountDrifts, actor)
+				if err != nil {
+					return report, err
+				}
+				if frozen {
+					report.FrozenAccounts = append(report.FrozenAccounts, accountDrifts[0].AccountID)
+				}
+			}
+			report.Drifts = append(report.Drifts, accountDrifts...)
+		}
+
+		afterID = totals[len(totals)-1].ID
+		if len(totals) < batchSize {
+			break
+		}
+	}
+
+	for _, id := range opts.AccountIDs {
+		if !seen[id] && !slices.Contains(report.MissingAccounts, id) {
+			report.MissingAccounts = append(report.MissingAccounts, id)
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+// checkBatch returns the drift in one batch of accounts, ordered by account.
+func checkBatch(ctx context.Context, store Store, totals []db.ListAccountBalanceTotalsRow) ([]Drift, error) {
+	ids := make([]int64, len(totals))
+	for i, row := range totals {
+		ids[i] = row.ID
+	}
+
+	breaks, err := store.ListBalanceChainBreaks(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("list balance chain breaks: %w", err)
+	}
+	firstBreak := make(map[int64]db.ListBalanceChainBreaksRow)
+	for _, row := range breaks {
+		if _, ok := firstBreak[row.AccountID]; !ok {
+			firstBreak[row.AccountID] = row
+		}
+	}
+
+	var drifts []Drift
+	for _, row := range totals {
+		if row.TransactionsTotalCents != row.BalanceCents {
+			drifts = append(drifts, Drift{
+				AccountID:     row.ID,
+				Kind:          db.DriftKindTransactionsTotal,
+				ExpectedCents: row.TransactionsTotalCents,
+				ActualCents:   row.BalanceCents,
+			})
+		}
+		if row.LedgerTotalCents != row.BalanceCents {
+			drifts = append(drifts, Drift{
+				AccountID:     row.ID,
+				Kind:          db.DriftKindLedgerTotal,
+				ExpectedCents: row.LedgerTotalCents,
+				ActualCents:   row.BalanceCents,
+			})
+		}
+		if chainBreak, ok := firstBreak[row.ID]; ok {
+			drifts = append(drifts, Drift{
+				AccountID:     row.ID,
+				Kind:          db.DriftKindBalanceChain,
+				ExpectedCents: chainBreak.PreviousBalanceCents + chainBreak.AmountCents,
+				ActualCents:   chainBreak.BalanceAfterCents,
+				TransactionID: chainBreak.ID.String(),
+			})
+		}
+	}
+	return drifts, nil
+}
+
+// groupByAccount splits drifts, already ordered by account, into one slice per account.
+func groupByAccount(drifts []Drift) [][]Drift {
+	var groups [][]Drift
+	for start := 0; start < len(drifts); {
+		end := start + 1
+		for end < len(drifts) && drifts[end].AccountID == drifts[start].AccountID {
+			end++
+		}
+		groups = append(groups, drifts[start:end])
+		start = end
+	}
+	return groups
+}
+
+// openIncidents records each drift, leaving IncidentID unset where an open incident of
+// the same kind already covers the account.
+func openIncidents(ctx context.Context, store Store, drifts []Drift) error {
+	for i, drift := range drifts {
+		arg := db.OpenReconciliationIncidentParams{
+			AccountID:     drift.AccountID,
+			Kind:          drift.Kind,
+			ExpectedCents: drift.ExpectedCents,
+			ActualCents:   drift.ActualCents,
+		}
+		if drift.TransactionID != "" {
+			if err := arg.TransactionID.Scan(drift.TransactionID); err != nil {
+				return err
+			}
+		}
+
+		incident, err := store.OpenReconciliationIncident(ctx, arg)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("open incident for account %d: %w", drift.AccountID, err)
+		}
+		drifts[i].IncidentID = incident.ID
+	}
+	return nil
+}
+
+// freeze freezes the account the drifts belong to. An account that is already frozen or
+// closed is left alone and reported as not frozen by this run.
+func freeze(ctx context.Context, store Store, drifts []Drift, actor string) (bool, error) {
+	kinds := make([]string, len(drifts))
+	for i, drift := range drifts {
+		kinds[i] = string(drift.Kind)
+	}
+
+	accountID := drifts[0].AccountID
+	_, err := store.FreezeAccountTx(ctx, db.ChangeAccountStatusParams{
+		AccountID: accountID,
+		Reason:    "reconciliation drift: " + strings.Join(kinds, ", "),
+		Actor:     actor,
+	})
+	// Frozen accounts refuse the transition and closed ones refuse any change
+	if errors.Is(err, db.ErrInvalidAccountTransition) || errors.Is(err, db.ErrAccountClosed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("freeze account %d: %w", accountID, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,195 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore serves balance totals and chain breaks from memory and records side effects.
+type fakeStore struct {
+	totals []db.ListAccountBalanceTotalsRow
+	breaks []db.ListBalanceChainBreaksRow
+	// frozen and closed accounts refuse a freeze like the real store does
+	statuses map[int64]db.AccountStatus
+
+	pages     int
+	incidents []db.OpenReconciliationIncidentParams
+	freezes   []db.ChangeAccountStatusParams
+}
+
+func (s *fakeStore) ListAccountBalanceTotals(_ context.Context, arg db.ListAccountBalanceTotalsParams) ([]db.ListAccountBalanceTotalsRow, error) {
+	s.pages++
+	var rows []db.ListAccountBalanceTotalsRow
+	for _, row := range s.totals {
+		if row.ID <= arg.AfterID || (len(arg.AccountIds) > 0 && !slices.Contains(arg.AccountIds, row.ID)) {
+			continue
+		}
+		if len(rows) == int(arg.Limit) {
+			break
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (s *fakeStore) ListBalanceChainBreaks(_ context.Context, accountIds []int64) ([]db.ListBalanceChainBreaksRow, error) {
+	var rows []db.ListBalanceChainBreaksRow
+	for _, row := range s.breaks {
+		if slices.Contains(accountIds, row.AccountID) {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (s *fakeStore) OpenReconciliationIncident(_ context.Context, arg db.OpenReconciliationIncidentParams) (db.ReconciliationIncident, error) {
+	for _, open := range s.incidents {
+		if open.AccountID == arg.AccountID && open.Kind == arg.Kind {
+			return db.ReconciliationIncident{}, pgx.ErrNoRows
+		}
+	}
+	s.incidents = append(s.incidents, arg)
+	return db.ReconciliationIncident{ID: int64(len(s.incidents)), AccountID: arg.AccountID, Kind: arg.Kind}, nil
+}
+
+func (s *fakeStore) FreezeAccountTx(_ context.Context, arg db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error) {
+	switch status := s.statuses[arg.AccountID]; status {
+	case db.AccountStatusFrozen:
+		return db.ChangeAccountStatusResult{}, fmt.Errorf("%w: frozen to frozen", db.ErrInvalidAccountTransition)
+	case db.AccountStatusClosed:
+		return db.ChangeAccountStatusResult{}, &db.AccountStatusError{AccountID: arg.AccountID, Status: status}
+	}
+	s.freezes = append(s.freezes, arg)
+	return db.ChangeAccountStatusResult{}, nil
+}
+
+func balanced(id, balance int64) db.ListAccountBalanceTotalsRow {
+	return db.ListAccountBalanceTotalsRow{ID: id, BalanceCents: balance, TransactionsTotalCents: balance, LedgerTotalCents: balance}
+}
+
+func chainBreak(t *testing.T, accountID, previous, amount, after int64) db.ListBalanceChainBreaksRow {
+	var id pgtype.UUID
+	require.NoError(t, id.Scan(fmt.Sprintf("00000000-0000-0000-0000-%012d", accountID*1000+after)))
+	return db.ListBalanceChainBreaksRow{AccountID: accountID, ID: id, PreviousBalanceCents: previous, AmountCents: amount, BalanceAfterCents: after}
+}
+
+func TestRunClean(t *testing.T) {
+	store := &fakeStore{totals: []db.ListAccountBalanceTotalsRow{balanced(1, 100), balanced(2, 0), balanced(3, 5000)}}
+
+	report, err := Run(context.Background(), store, Options{OpenIncidents: true, FreezeAccounts: true})
+	require.NoError(t, err)
+	require.True(t, report.Clean())
+	require.Equal(t, 3, report.AccountsScanned)
+	require.NotNil(t, report.Drifts)
+	require.Empty(t, store.incidents)
+	require.Empty(t, store.freezes)
+	require.False(t, report.FinishedAt.Before(report.StartedAt))
+}
+
+func TestRunFindsDrift(t *testing.T) {
+	drifted := db.ListAccountBalanceTotalsRow{ID: 2, BalanceCents: 900, TransactionsTotalCents: 1000, LedgerTotalCents: 900}
+	store := &fakeStore{
+		totals: []db.ListAccountBalanceTotalsRow{balanced(1, 100), drifted, balanced(3, 300)},
+		breaks: []db.ListBalanceChainBreaksRow{
+			chainBreak(t, 3, 100, 50, 140),
+			// follows from the bad row above
+			chainBreak(t, 3, 140, 160, 310),
+		},
+	}
+
+	report, err := Run(context.Background(), store, Options{})
+	require.NoError(t, err)
+	require.False(t, report.Clean())
+	require.Equal(t, []Drift{
+		{AccountID: 2, Kind: db.DriftKindTransactionsTotal, ExpectedCents: 1000, ActualCents: 900},
+		{AccountID: 3, Kind: db.DriftKindBalanceChain, ExpectedCents: 150, ActualCents: 140, TransactionID: store.breaks[0].ID.String()},
+	}, report.Drifts)
+	require.Empty(t, store.incidents)
+	require.Empty(t, store.freezes)
+}
+
+func TestRunBatches(t *testing.T) {
+	store := &fakeStore{}
+	for id := int64(1); id <= 5; id++ {
+		store.totals = append(store.totals, balanced(id, id*10))
+	}
+	store.totals[4].LedgerTotalCents = 0
+
+	report, err := Run(context.Background(), store, Options{BatchSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, 5, report.AccountsScanned)
+	require.Equal(t, 3, store.pages)
+	require.Len(t, report.Drifts, 1)
+	require.Equal(t, int64(5), report.Drifts[0].AccountID)
+	require.Equal(t, db.DriftKindLedgerTotal, report.Drifts[0].Kind)
+}
+
+func TestRunSubset(t *testing.T) {
+	store := &fakeStore{totals: []db.ListAccountBalanceTotalsRow{
+		balanced(1, 100),
+		{ID: 2, BalanceCents: 1, TransactionsTotalCents: 2, LedgerTotalCents: 1},
+		balanced(3, 300),
+	}}
+
+	report, err := Run(context.Background(), store, Options{AccountIDs: []int64{3, 7}})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.AccountsScanned)
+	require.Empty(t, report.Drifts)
+	require.Equal(t, []int64{7}, report.MissingAccounts)
+	require.False(t, report.Clean())
+}
+
+func TestRunOpensIncidentsAndFreezes(t *testing.T) {
+	store := &fakeStore{
+		totals: []db.ListAccountBalanceTotalsRow{
+			{ID: 1, BalanceCents: 500, TransactionsTotalCents: 400, LedgerTotalCents: 400},
+			{ID: 2, BalanceCents: 10, TransactionsTotalCents: 20, LedgerTotalCents: 10},
+			balanced(3, 0),
+			{ID: 4, BalanceCents: 0, TransactionsTotalCents: 30, LedgerTotalCents: 0},
+			{ID: 5, BalanceCents: 70, TransactionsTotalCents: 70, LedgerTotalCents: 60},
+		},
+		statuses: map[int64]db.AccountStatus{2: db.AccountStatusFrozen, 4: db.AccountStatusClosed},
+	}
+	// account 2 already has an open incident from an earlier run
+	store.incidents = []db.OpenReconciliationIncidentParams{{AccountID: 2, Kind: db.DriftKindTransactionsTotal}}
+
+	report, err := Run(context.Background(), store, Options{OpenIncidents: true, FreezeAccounts: true})
+	require.NoError(t, err)
+	require.Len(t, report.Drifts, 5)
+
+	require.Len(t, store.incidents, 5)
+	require.NotZero(t, report.Drifts[0].IncidentID)
+	require.NotZero(t, report.Drifts[1].IncidentID)
+	require.Zero(t, report.Drifts[2].IncidentID)
+
+	// The closed account doesn't stop the scan from freezing the accounts after it
+	require.Equal(t, []int64{1, 5}, report.FrozenAccounts)
+	require.Len(t, store.freezes, 2)
+	require.Equal(t, DefaultActor, store.freezes[0].Actor)
+	require.Equal(t, "reconciliation drift: transactions_total, ledger_total", store.freezes[0].Reason)
+}
+
+type failingStore struct {
+	fakeStore
+}
+
+func (s *failingStore) FreezeAccountTx(context.Context, db.ChangeAccountStatusParams) (db.ChangeAccountStatusResult, error) {
+	return db.ChangeAccountStatusResult{}, errors.New("connection reset")
+}
+
+func TestRunStopsOnStoreError(t *testing.T) {
+	store := &failingStore{fakeStore{totals: []db.ListAccountBalanceTotalsRow{
+		{ID: 1, BalanceCents: 1, TransactionsTotalCents: 0, LedgerTotalCents: 1},
+	}}}
+
+	_, err := Run(context.Background(), store, Options{FreezeAccounts: true})
+	require.ErrorContains(t, err, "freeze account 1")
+}
@@ -0,0 +1,196 @@
+// Package scheduler runs due transfer schedules (standing orders).
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultBatchSize  = 100
+	defaultLease      = 5 * time.Minute
+	defaultRetryDelay = time.Hour
+)
+
+// Store is the subset of db.Store the scheduler needs.
+type Store interface {
+	ClaimDueTransferSchedules(ctx context.Context, arg db.ClaimDueTransferSchedulesParams) ([]db.TransferSchedule, error)
+	RunTransferScheduleTx(ctx context.Context, arg db.RunTransferScheduleTxParams) (db.RunTransferScheduleTxResult, error)
+	ExpireHolds(ctx context.Context, now time.Time) (int, error)
+}
+
+// Options tunes a pass over the due schedules.
+type Options struct {
+	// BatchSize is how many schedules are claimed per query; zero means 100.
+	BatchSize int
+	// Lease is how long a claimed schedule stays with this worker before another may
+	// take it over; zero means five minutes. It must outlast a transfer.
+	Lease time.Duration
+	// RetryDelay is how long a schedule under the retry policy waits after a run
+	// refused for insufficient funds; zero means an hour.
+	RetryDelay time.Duration
+}
+
+// Report counts what a pass did, by run outcome.
+type Report struct {
+	Succeeded int `json:"succeeded"`
+	Retrying  int `json:"retrying"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
+	// Errors counts schedules left to a later pass because the transfer or its
+	// record could not be written.
+	Errors int `json:"errors"`
+	// ExpiredHolds counts holds past their expiry whose amounts were released.
+	ExpiredHolds int `json:"expired_holds"`
+}
+
+// RunDue expires the holds due at now, so that their amounts are available again, then
+// claims every schedule due at now and makes its transfer through RunTransferScheduleTx.
+// Each run is recorded and the schedule moved on as follows:
+//   - a completed transfer moves to the next occurrence;
+//   - a transfer refused for insufficient funds is retried after RetryDelay under the
+//     retry policy, until the schedule's attempts run out, and otherwise skipped;
+//   - a transfer involving a closed account cancels the schedule;
+//   - any other refusal fails the occurrence and moves to the next one.
+//
+// Each transfer commits together with its run record, so a worker that dies mid-run
+// leaves nothing behind and the next worker to claim the schedule makes the run afresh.
+func RunDue(ctx context.Context, store Store, now time.Time, opts Options) (Report, error) {
+	var report Report
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	lease := opts.Lease
+	if lease <= 0 {
+		lease = defaultLease
+	}
+	retryDelay := opts.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = defaultRetryDelay
+	}
+
+	expired, err := store.ExpireHolds(ctx, now)
+	report.ExpiredHolds = expired
+	if err != nil {
+		return report, fmt.Errorf("expire holds: %w", err)
+	}
+
+	for {
+		schedules, err := store.ClaimDueTransferSchedules(ctx, db.ClaimDueTransferSchedulesParams{
+			LockedUntil: pgtype.Timestamptz{Time: now.Add(lease), Valid: true},
+			Now:         pgtype.Timestamptz{Time: now, Valid: true},
+			Limit:       int32(batchSize),
+		})
+		if err != nil {
+			return report, fmt.Errorf("claim due transfer schedules: %w", err)
+		}
+
+		for _, schedule := range schedules {
+			status, err := run(ctx, store, schedule, now, retryDelay)
+			if err != nil {
+				report.Errors++
+				continue
+			}
+			switch status {
+			case db.ScheduleRunStatusSucceeded:
+				report.Succeeded++
+			case db.ScheduleRunStatusRetrying:
+				report.Retrying++
+			case db.ScheduleRunStatusSkipped:
+				report.Skipped++
+			case db.ScheduleRunStatusFailed:
+				report.Failed++
+			}
+		}
+
+		if len(schedules) < batchSize {
+			return report, nil
+		}
+	}
+}
+
+// Run calls RunDue every interval until ctx is done. A failed pass is reported
+// through onPass and retried on the next tick.
+func Run(ctx context.Context, store Store, interval time.Duration, opts Options, onPass func(Report, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := RunDue(ctx, store, time.Now(), opts)
+		if onPass != nil {
+			onPass(report, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// run makes one attempt of a claimed schedule and records it.
+func run(ctx context.Context, store Store, schedule db.TransferSchedule, now time.Time, retryDelay time.Duration) (db.ScheduleRunStatus, error) {
+	result, err := store.RunTransferScheduleTx(ctx, db.RunTransferScheduleTxParams{
+		Schedule: schedule,
+		Outcome: func(transferErr error) db.ScheduleRunOutcome {
+			return outcome(schedule, transferErr, now, retryDelay)
+		},
+	})
+	if err != nil {
+		// Nothing was written: either the transfer never reached the accounts and is left
+		// for the lease to run out, or another worker took over the lease and made this
+		// attempt already (ErrScheduleRunRecorded)
+		return "", err
+	}
+	return result.Run.Status, nil
+}
+
+// outcome decides how a schedule moves on after an attempt that ended with transferErr.
+func outcome(schedule db.TransferSchedule, transferErr error, now time.Time, retryDelay time.Duration) db.ScheduleRunOutcome {
+	record := db.ScheduleRunOutcome{
+		NextStatus: db.ScheduleStatusActive,
+	}
+	if transferErr != nil {
+		record.FailureReason = pgtype.Text{String: transferErr.Error(), Valid: true}
+	}
+
+	attempt := schedule.FailedAttempts + 1
+	switch {
+	case transferErr == nil:
+		record.Status = db.ScheduleRunStatusSucceeded
+	case errors.Is(transferErr, db.ErrInsufficientBalance):
+		if schedule.InsufficientFundsPolicy == db.InsufficientFundsPolicyRetry && attempt < schedule.MaxAttempts {
+			record.Status = db.ScheduleRunStatusRetrying
+			record.NextFailedAttempts = attempt
+			record.NextOccurrenceAt = schedule.NextOccurrenceAt.Time
+			record.NextRunAt = now.Add(retryDelay)
+			return record
+		}
+		record.Status = db.ScheduleRunStatusSkipped
+	case errors.Is(transferErr, db.ErrAccountClosed):
+		record.Status = db.ScheduleRunStatusFailed
+		record.NextStatus = db.ScheduleStatusCancelled
+		record.NextOccurrenceAt = schedule.NextOccurrenceAt.Time
+		record.NextRunAt = schedule.NextRunAt.Time
+		return record
+	default:
+		record.Status = db.ScheduleRunStatusFailed
+	}
+
+	next, ok := schedule.NextOccurrence(schedule.NextOccurrenceAt.Time)
+	if !ok {
+		record.NextStatus = db.ScheduleStatusCompleted
+		next = schedule.NextOccurrenceAt.Time
+	}
+	record.NextOccurrenceAt = next
+	record.NextRunAt = next
+	return record
+}
@@ -0,0 +1,283 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore hands out due schedules once and answers runs from a per-account table.
+type fakeStore struct {
+	due []db.TransferSchedule
+	// transferErrs is the error a transfer from the account fails with
+	transferErrs map[int64]error
+	// broken accounts fail transfers before anything is written
+	broken map[int64]bool
+
+	// expirable is how many holds the next expiry pass releases
+	expirable int
+
+	expiries []time.Time
+	claims   []db.ClaimDueTransferSchedulesParams
+	runs     []db.TransferSchedule
+	records  []db.TransferScheduleRun
+}
+
+func (s *fakeStore) ClaimDueTransferSchedules(_ context.Context, arg db.ClaimDueTransferSchedulesParams) ([]db.TransferSchedule, error) {
+	s.claims = append(s.claims, arg)
+	n := min(int(arg.Limit), len(s.due))
+	claimed := s.due[:n]
+	s.due = s.due[n:]
+	return claimed, nil
+}
+
+func (s *fakeStore) ExpireHolds(_ context.Context, now time.Time) (int, error) {
+	s.expiries = append(s.expiries, now)
+	expired := s.expirable
+	s.expirable = 0
+	return expired, nil
+}
+
+func (s *fakeStore) RunTransferScheduleTx(_ context.Context, arg db.RunTransferScheduleTxParams) (db.RunTransferScheduleTxResult, error) {
+	s.runs = append(s.runs, arg.Schedule)
+	if s.broken[arg.Schedule.FromAccountID] {
+		return db.RunTransferScheduleTxResult{}, errors.New("connection reset")
+	}
+	result := db.RunTransferScheduleTxResult{
+		Transfer: db.TransferMoneyResult{Transfer: db.Transfer{ID: uuid(len(s.runs)), Status: db.TransferStatusCompleted}},
+	}
+	transferErr := s.transferErrs[arg.Schedule.FromAccountID]
+	if transferErr != nil {
+		result.Transfer.Transfer.Status = db.TransferStatusFailed
+	}
+	outcome := arg.Outcome(transferErr)
+	result.Run = db.TransferScheduleRun{
+		ScheduleID:    arg.Schedule.ID,
+		Status:        outcome.Status,
+		TransferID:    result.Transfer.Transfer.ID,
+		FailureReason: outcome.FailureReason,
+	}
+	s.records = append(s.records, result.Run)
+	return result, nil
+}
+
+func uuid(n int) pgtype.UUID {
+	var id pgtype.UUID
+	_ = id.Scan(fmt.Sprintf("00000000-0000-0000-0000-%012d", n))
+	return id
+}
+
+var occurrence = time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC)
+
+func schedule(fromAccountID int64, frequency db.ScheduleFrequency) db.TransferSchedule {
+	return db.TransferSchedule{
+		ID:                      uuid(int(fromAccountID)),
+		FromAccountID:           fromAccountID,
+		ToAccountID:             99,
+		AmountCents:             500,
+		Frequency:               frequency,
+		Status:                  db.ScheduleStatusActive,
+		InsufficientFundsPolicy: db.InsufficientFundsPolicySkip,
+		MaxAttempts:             3,
+		NextOccurrenceAt:        pgtype.Timestamptz{Time: occurrence, Valid: true},
+		NextRunAt:               pgtype.Timestamptz{Time: occurrence, Valid: true},
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	now := occurrence.Add(time.Minute)
+	retryDelay := 2 * time.Hour
+	nextDay := occurrence.AddDate(0, 0, 1)
+
+	retrying := schedule(1, db.ScheduleFrequencyDaily)
+	retrying.InsufficientFundsPolicy = db.InsufficientFundsPolicyRetry
+
+	lastAttempt := retrying
+	lastAttempt.FailedAttempts = 2
+
+	monthly := schedule(1, db.ScheduleFrequencyMonthly)
+	monthly.DayOfMonth = pgtype.Int4{Int32: 31, Valid: true}
+
+	ending := schedule(1, db.ScheduleFrequencyWeekly)
+	ending.EndsAt = pgtype.Timestamptz{Time: occurrence.AddDate(0, 0, 6), Valid: true}
+
+	testCases := []struct {
+		name         string
+		schedule     db.TransferSchedule
+		err          error
+		status       db.ScheduleRunStatus
+		nextStatus   db.ScheduleStatus
+		nextAttempts int32
+		nextOccurs   time.Time
+		nextRun      time.Time
+	}{
+		{
+			name:       "Succeeded",
+			schedule:   schedule(1, db.ScheduleFrequencyDaily),
+			status:     db.ScheduleRunStatusSucceeded,
+			nextStatus: db.ScheduleStatusActive,
+			nextOccurs: nextDay,
+			nextRun:    nextDay,
+		},
+		{
+			name:       "MonthlyClampsToShortMonth",
+			schedule:   monthly,
+			status:     db.ScheduleRunStatusSucceeded,
+			nextStatus: db.ScheduleStatusActive,
+			nextOccurs: time.Date(2026, 4, 30, 9, 0, 0, 0, time.UTC),
+			nextRun:    time.Date(2026, 4, 30, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "OnceCompletes",
+			schedule:   schedule(1, db.ScheduleFrequencyOnce),
+			status:     db.ScheduleRunStatusSucceeded,
+			nextStatus: db.ScheduleStatusCompleted,
+			nextOccurs: occurrence,
+			nextRun:    occurrence,
+		},
+		{
+			name:       "PastEndCompletes",
+			schedule:   ending,
+			status:     db.ScheduleRunStatusSucceeded,
+			nextStatus: db.ScheduleStatusCompleted,
+			nextOccurs: occurrence,
+			nextRun:    occurrence,
+		},
+		{
+			name:       "InsufficientFundsSkipped",
+			schedule:   schedule(1, db.ScheduleFrequencyDaily),
+			err:        db.ErrInsufficientBalance,
+			status:     db.ScheduleRunStatusSkipped,
+			nextStatus: db.ScheduleStatusActive,
+			nextOccurs: nextDay,
+			nextRun:    nextDay,
+		},
+		{
+			name:         "InsufficientFundsRetried",
+			schedule:     retrying,
+			err:          db.ErrInsufficientBalance,
+			status:       db.ScheduleRunStatusRetrying,
+			nextStatus:   db.ScheduleStatusActive,
+			nextAttempts: 1,
+			nextOccurs:   occurrence,
+			nextRun:      now.Add(retryDelay),
+		},
+		{
+			name:       "RetriesExhausted",
+			schedule:   lastAttempt,
+			err:        db.ErrInsufficientBalance,
+			status:     db.ScheduleRunStatusSkipped,
+			nextStatus: db.ScheduleStatusActive,
+			nextOccurs: nextDay,
+			nextRun:    nextDay,
+		},
+		{
+			name:       "AccountClosed",
+			schedule:   schedule(1, db.ScheduleFrequencyDaily),
+			err:        &db.AccountStatusError{AccountID: 99, Status: db.AccountStatusClosed},
+			status:     db.ScheduleRunStatusFailed,
+			nextStatus: db.ScheduleStatusCancelled,
+			nextOccurs: occurrence,
+			nextRun:    occurrence,
+		},
+		{
+			name:       "AccountFrozen",
+			schedule:   schedule(1, db.ScheduleFrequencyDaily),
+			err:        &db.AccountStatusError{AccountID: 1, Status: db.AccountStatusFrozen},
+			status:     db.ScheduleRunStatusFailed,
+			nextStatus: db.ScheduleStatusActive,
+			nextOccurs: nextDay,
+			nextRun:    nextDay,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			record := outcome(tc.schedule, tc.err, now, retryDelay)
+			require.Equal(t, tc.status, record.Status)
+			require.Equal(t, tc.nextStatus, record.NextStatus)
+			require.Equal(t, tc.nextAttempts, record.NextFailedAttempts)
+			require.Equal(t, tc.nextOccurs, record.NextOccurrenceAt)
+			require.Equal(t, tc.nextRun, record.NextRunAt)
+			require.Equal(t, tc.err != nil, record.FailureReason.Valid)
+		})
+	}
+}
+
+func TestRunDue(t *testing.T) {
+	store := &fakeStore{
+		due: []db.TransferSchedule{
+			schedule(1, db.ScheduleFrequencyDaily),
+			schedule(2, db.ScheduleFrequencyDaily),
+			schedule(3, db.ScheduleFrequencyDaily),
+		},
+		transferErrs: map[int64]error{2: db.ErrInsufficientBalance},
+		broken:       map[int64]bool{3: true},
+		expirable:    2,
+	}
+	now := occurrence.Add(time.Minute)
+
+	report, err := RunDue(context.Background(), store, now, Options{BatchSize: 2, Lease: time.Minute})
+	require.NoError(t, err)
+	require.Equal(t, Report{Succeeded: 1, Skipped: 1, Errors: 1, ExpiredHolds: 2}, report)
+	require.Equal(t, []time.Time{now}, store.expiries)
+
+	// A full batch, then a short one that ends the pass
+	require.Len(t, store.claims, 2)
+	require.Equal(t, now.Add(time.Minute), store.claims[0].LockedUntil.Time)
+	require.Equal(t, now, store.claims[0].Now.Time)
+
+	require.Len(t, store.runs, 3)
+
+	// The broken schedule is left leased for a later pass
+	require.Len(t, store.records, 2)
+	require.Equal(t, db.ScheduleRunStatusSucceeded, store.records[0].Status)
+	require.Equal(t, uuid(1), store.records[0].TransferID)
+	require.Equal(t, db.ScheduleRunStatusSkipped, store.records[1].Status)
+	require.Equal(t, uuid(2), store.records[1].TransferID)
+}
+
+func TestRunDueReleasesExpiredHolds(t *testing.T) {
+	store := db.NewMemoryStore()
+	ctx := context.Background()
+
+	user, err := store.CreateUser(ctx, db.CreateUserParams{
+		FirstName:    "Ada",
+		LastName:     "Lovelace",
+		Email:        "ada@example.com",
+		PasswordHash: "$2a$10$" + strings.Repeat("x", 53),
+	})
+	require.NoError(t, err)
+	account, err := store.CreateAccount(ctx, db.CreateAccountParams{OwnerID: user.ID, Currency: db.CurrencyUSD})
+	require.NoError(t, err)
+	_, err = store.DepositMoneyTx(ctx, db.AccountTransactionParams{AccountID: account.ID, Amount: 1000})
+	require.NoError(t, err)
+
+	now := time.Now()
+	_, err = store.PlaceHoldTx(ctx, db.PlaceHoldTxParams{AccountID: account.ID, AmountCents: 400, ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = store.PlaceHoldTx(ctx, db.PlaceHoldTxParams{AccountID: account.ID, AmountCents: 100, ExpiresAt: now.Add(3 * time.Hour)})
+	require.NoError(t, err)
+
+	// Before its expiry the hold keeps its amount out of the available balance
+	report, err := RunDue(ctx, store, now, Options{})
+	require.NoError(t, err)
+	require.Equal(t, Report{}, report)
+
+	report, err = RunDue(ctx, store, now.Add(2*time.Hour), Options{})
+	require.NoError(t, err)
+	require.Equal(t, Report{ExpiredHolds: 1}, report)
+
+	account, err = store.GetAccount(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), account.BalanceCents)
+	require.Equal(t, int64(100), account.HeldCents)
+	require.Equal(t, int64(900), account.AvailableCents())
+}
@@ -0,0 +1,171 @@
+package statement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+)
+
+// camtNamespace is camt.053.001.02, the version ERPs most widely import.
+const camtNamespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+type camtDocument struct {
+	XMLName   xml.Name      `xml:"Document"`
+	Namespace string        `xml:"xmlns,attr"`
+	Statement camtBkToCstmr `xml:"BkToCstmrStmt"`
+}
+
+type camtBkToCstmr struct {
+	GroupHeader camtGroupHeader `xml:"GrpHdr"`
+	Statement   camtStatement   `xml:"Stmt"`
+}
+
+type camtGroupHeader struct {
+	MessageID string `xml:"MsgId"`
+	CreatedAt string `xml:"CreDtTm"`
+}
+
+type camtStatement struct {
+	ID        string        `xml:"Id"`
+	CreatedAt string        `xml:"CreDtTm"`
+	Period    camtPeriod    `xml:"FrToDt"`
+	Account   camtAccount   `xml:"Acct"`
+	Balances  []camtBalance `xml:"Bal"`
+	Summary   camtSummary   `xml:"TxsSummry"`
+	Entries   []camtEntry   `xml:"Ntry"`
+}
+
+type camtPeriod struct {
+	From string `xml:"FrDtTm"`
+	To   string `xml:"ToDtTm"`
+}
+
+type camtAccount struct {
+	ID       string `xml:"Id>Othr>Id"`
+	Currency string `xml:"Ccy"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtBalance struct {
+	Code   string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount camtAmount `xml:"Amt"`
+	Mark   string     `xml:"CdtDbtInd"`
+	Date   string     `xml:"Dt>Dt"`
+}
+
+type camtSummary struct {
+	Credits camtSum `xml:"TtlCdtNtries"`
+	Debits  camtSum `xml:"TtlDbtNtries"`
+}
+
+type camtSum struct {
+	Count int    `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type camtEntry struct {
+	Reference   string     `xml:"NtryRef"`
+	Amount      camtAmount `xml:"Amt"`
+	Mark        string     `xml:"CdtDbtInd"`
+	Reversal    bool       `xml:"RvslInd,omitempty"`
+	Status      string     `xml:"Sts"`
+	BookedAt    string     `xml:"BookgDt>DtTm"`
+	ValueDate   string     `xml:"ValDt>Dt"`
+	BankTxCode  string     `xml:"BkTxCd>Prtry>Cd"`
+	EndToEndID  string     `xml:"NtryDtls>TxDtls>Refs>EndToEndId"`
+	Information string     `xml:"NtryDtls>TxDtls>AddtlTxInf"`
+}
+
+// WriteCAMT053 renders the statement as an ISO 20022 camt.053 bank-to-customer statement.
+// Amounts are unsigned with a CRDT or DBIT mark, and the creation time is the end of the
+// period so the document of a past period is always the same.
+func WriteCAMT053(w io.Writer, statement Statement) error {
+	if err := checkCurrency(statement.Currency); err != nil {
+		return err
+	}
+
+	currency := string(statement.Currency)
+	id := fmt.Sprintf("%d-%s-%s", statement.AccountID, statement.Period.From.UTC().Format("20060102"), lastDay(statement.Period.To).UTC().Format("20060102"))
+	createdAt := formatTime(statement.Period.To)
+
+	balance := func(code string, cents int64, date time.Time) camtBalance {
+		mark := "CRDT"
+		if cents < 0 {
+			mark = "DBIT"
+		}
+		return camtBalance{
+			Code:   code,
+			Amount: camtAmount{Currency: currency, Value: formatUnsigned(cents, ".")},
+			Mark:   mark,
+			Date:   date.UTC().Format(time.DateOnly),
+		}
+	}
+
+	doc := camtDocument{
+		Namespace: camtNamespace,
+		Statement: camtBkToCstmr{
+			GroupHeader: camtGroupHeader{MessageID: "STMT-" + id, CreatedAt: createdAt},
+			Statement: camtStatement{
+				ID:        id,
+				CreatedAt: createdAt,
+				Period:    camtPeriod{From: formatTime(statement.Period.From), To: formatTime(statement.Period.To)},
+				Account:   camtAccount{ID: fmt.Sprint(statement.AccountID), Currency: currency},
+				Balances: []camtBalance{
+					balance("OPBD", statement.OpeningBalanceCents, statement.Period.From),
+					balance("CLBD", statement.ClosingBalanceCents, lastDay(statement.Period.To)),
+				},
+			},
+		},
+	}
+
+	var creditSum, debitSum int64
+	stmt := &doc.Statement.Statement
+	for _, line := range statement.Lines {
+		mark := "DBIT"
+		if isCredit(line) {
+			mark = "CRDT"
+			stmt.Summary.Credits.Count++
+			creditSum += abs(line.AmountCents)
+		} else {
+			stmt.Summary.Debits.Count++
+			debitSum += abs(line.AmountCents)
+		}
+
+		endToEndID := line.Reference
+		if endToEndID == "" {
+			endToEndID = "NOTPROVIDED"
+		}
+		stmt.Entries = append(stmt.Entries, camtEntry{
+			Reference:   line.TransactionID,
+			Amount:      camtAmount{Currency: currency, Value: formatUnsigned(line.AmountCents, ".")},
+			Mark:        mark,
+			Reversal:    line.Type == db.TransactionTypeReversal,
+			Status:      "BOOK",
+			BookedAt:    formatTime(line.PostedAt),
+			ValueDate:   line.PostedAt.UTC().Format(time.DateOnly),
+			BankTxCode:  string(line.Type),
+			EndToEndID:  endToEndID,
+			Information: line.Description,
+		})
+	}
+	stmt.Summary.Credits.Sum = formatUnsigned(creditSum, ".")
+	stmt.Summary.Debits.Sum = formatUnsigned(debitSum, ".")
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
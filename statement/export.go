@@ -0,0 +1,61 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+)
+
+var ErrUnsupportedCurrency = errors.New("currency not supported by bank statement formats")
+
+// minorUnits is the number of decimals each currency is written with in bank formats.
+var minorUnits = map[db.Currency]int{
+	db.CurrencyUSD: 2,
+	db.CurrencyEUR: 2,
+	db.CurrencyGBP: 2,
+	db.CurrencyBDT: 2,
+	db.CurrencyINR: 2,
+}
+
+// checkCurrency fails for currencies the exporters don't know how to write amounts in,
+// so a statement never goes out with a wrong or missing ISO 4217 code.
+func checkCurrency(currency db.Currency) error {
+	if minorUnits[currency] != 2 {
+		return fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+	}
+	return nil
+}
+
+// credits is the side of the account each transaction type books on: true for money in.
+// Reversals are left out because they undo a movement in either direction.
+var credits = map[db.TransactionType]bool{
+	db.TransactionTypeDeposit:           true,
+	db.TransactionTypeWithdrawal:        false,
+	db.TransactionTypeTransferIn:        true,
+	db.TransactionTypeTransferOut:       false,
+	db.TransactionTypeOverdraftInterest: false,
+	db.TransactionTypeOverdraftFee:      false,
+}
+
+// isCredit reports whether a line paid money into the account.
+func isCredit(line Line) bool {
+	if credit, ok := credits[line.Type]; ok {
+		return credit
+	}
+	return line.AmountCents > 0
+}
+
+// formatUnsigned writes the size of an amount with decimalMark, for formats that carry
+// the direction in a separate credit/debit mark.
+func formatUnsigned(cents int64, decimalMark string) string {
+	return strings.Replace(formatAmount(abs(cents)), ".", decimalMark, 1)
+}
+
+func abs(cents int64) int64 {
+	if cents < 0 {
+		return -cents
+	}
+	return cents
+}
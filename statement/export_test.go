@@ -0,0 +1,95 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// exportStatement is a March statement of a EUR account that goes overdrawn, with
+// every direction of movement, a reversal of each direction and a reference that
+// needs cleaning up for MT940.
+func exportStatement(t *testing.T) Statement {
+	store := &fakeStore{account: db.Account{ID: 42, Currency: db.CurrencyEUR}}
+	store.record(db.TransactionTypeDeposit, 20000, day(time.February, 25))
+	store.record(db.TransactionTypeTransferIn, 7550, day(time.March, 2))
+	withdrawal := store.record(db.TransactionTypeWithdrawal, -30000, day(time.March, 9))
+	store.record(db.TransactionTypeReversal, -1200, day(time.March, 12))
+	store.record(db.TransactionTypeReversal, 800, day(time.March, 20))
+	store.record(db.TransactionTypeOverdraftFee, -2500, day(time.March, 31))
+	store.record(db.TransactionTypeTransferOut, -1000, day(time.April, 1))
+
+	store.transactions[withdrawal.Seq-1].Reference = pgtype.Text{String: "atm_7//cash#1", Valid: true}
+
+	statement, err := Generate(context.Background(), store, 42, Month(2026, time.March, time.UTC))
+	require.NoError(t, err)
+	return statement
+}
+
+func TestExportGolden(t *testing.T) {
+	statement := exportStatement(t)
+
+	for _, format := range []Format{FormatCAMT053, FormatMT940, FormatOFX} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, statement, format))
+
+			golden := filepath.Join("testdata", "march."+string(format)+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(want), buf.String())
+		})
+	}
+}
+
+func TestExportUnsupportedCurrency(t *testing.T) {
+	statement := exportStatement(t)
+	statement.Currency = "XAU"
+
+	for _, format := range []Format{FormatCAMT053, FormatMT940, FormatOFX} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.ErrorIs(t, Write(&buf, statement, format), ErrUnsupportedCurrency)
+			require.Zero(t, buf.Len())
+		})
+	}
+}
+
+func TestIsCredit(t *testing.T) {
+	testCases := []struct {
+		name string
+		line Line
+		want bool
+	}{
+		{name: "Deposit", line: Line{Type: db.TransactionTypeDeposit, AmountCents: 100}, want: true},
+		{name: "TransferOut", line: Line{Type: db.TransactionTypeTransferOut, AmountCents: -100}, want: false},
+		{name: "OverdraftFee", line: Line{Type: db.TransactionTypeOverdraftFee, AmountCents: -100}, want: false},
+		{name: "ReversalToSender", line: Line{Type: db.TransactionTypeReversal, AmountCents: 100}, want: true},
+		{name: "ReversalFromRecipient", line: Line{Type: db.TransactionTypeReversal, AmountCents: -100}, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, isCredit(tc.line))
+		})
+	}
+}
+
+func TestSwiftText(t *testing.T) {
+	require.Equal(t, "atm.7/.cash.1", swiftText("atm_7//cash#1", 16))
+	require.Equal(t, "abcd", swiftText("abcdef", 4))
+	require.Equal(t, "caf.", swiftText("café", 16))
+}
@@ -0,0 +1,98 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+)
+
+// mtTypeCodes is the SWIFT transaction type identification code of each transaction type.
+var mtTypeCodes = map[db.TransactionType]string{
+	db.TransactionTypeDeposit:           "NMSC",
+	db.TransactionTypeWithdrawal:        "NMSC",
+	db.TransactionTypeTransferIn:        "NTRF",
+	db.TransactionTypeTransferOut:       "NTRF",
+	db.TransactionTypeReversal:          "NTRF",
+	db.TransactionTypeOverdraftInterest: "NINT",
+	db.TransactionTypeOverdraftFee:      "NCHG",
+}
+
+// WriteMT940 renders the statement as a SWIFT MT940 customer statement message, one :61:
+// line per transaction followed by a :86: line with its description and ID. Lines end
+// in CRLF and amounts use a decimal comma, as the format requires.
+func WriteMT940(w io.Writer, statement Statement) error {
+	if err := checkCurrency(statement.Currency); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	field := func(tag, value string) {
+		fmt.Fprintf(&b, ":%s:%s\r\n", tag, value)
+	}
+	balance := func(cents int64, date time.Time) string {
+		mark := "C"
+		if cents < 0 {
+			mark = "D"
+		}
+		return mark + date.UTC().Format("060102") + string(statement.Currency) + formatUnsigned(cents, ",")
+	}
+
+	field("20", swiftText(fmt.Sprintf("STMT%d-%s", statement.AccountID, statement.Period.From.UTC().Format("060102")), 16))
+	field("25", swiftText(fmt.Sprint(statement.AccountID), 35))
+	field("28C", statement.Period.From.UTC().Format("0601"))
+	field("60F", balance(statement.OpeningBalanceCents, statement.Period.From))
+
+	for _, line := range statement.Lines {
+		// A reversal is marked with the direction it undoes: RC takes a credit back out,
+		// RD puts a debit back in
+		mark := "D"
+		switch {
+		case line.Type == db.TransactionTypeReversal && isCredit(line):
+			mark = "RD"
+		case line.Type == db.TransactionTypeReversal:
+			mark = "RC"
+		case isCredit(line):
+			mark = "C"
+		}
+
+		typeCode, ok := mtTypeCodes[line.Type]
+		if !ok {
+			typeCode = "NMSC"
+		}
+		reference := swiftText(line.Reference, 16)
+		if reference == "" {
+			reference = "NONREF"
+		}
+
+		posted := line.PostedAt.UTC()
+		field("61", posted.Format("060102")+posted.Format("0102")+mark+formatUnsigned(line.AmountCents, ",")+typeCode+reference)
+		field("86", swiftText(line.Description+" "+line.TransactionID, 65))
+	}
+
+	field("62F", balance(statement.ClosingBalanceCents, lastDay(statement.Period.To)))
+	b.WriteString("-\r\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// swiftText fits s to the SWIFT x character set and to limit characters. Characters
+// outside the set become '.', and a reference may not contain "//".
+func swiftText(s string, limit int) string {
+	var b strings.Builder
+	for _, r := range s {
+		if b.Len() == limit {
+			break
+		}
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', strings.ContainsRune("/-?:().,'+ ", r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('.')
+		}
+	}
+	return strings.ReplaceAll(b.String(), "//", "/.")
+}
@@ -0,0 +1,157 @@
+package statement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+)
+
+// ofxHeader declares OFX 2.2, the XML flavour of the format.
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+// ofxBankID identifies fincore as the institution in BANKACCTFROM.
+const ofxBankID = "FINCORE"
+
+// ofxTypes is the OFX TRNTYPE of each transaction type. Types missing here are
+// written as a plain CREDIT or DEBIT.
+var ofxTypes = map[db.TransactionType]string{
+	db.TransactionTypeDeposit:           "DEP",
+	db.TransactionTypeWithdrawal:        "DEBIT",
+	db.TransactionTypeTransferIn:        "XFER",
+	db.TransactionTypeTransferOut:       "XFER",
+	db.TransactionTypeOverdraftInterest: "INT",
+	db.TransactionTypeOverdraftFee:      "FEE",
+}
+
+type ofxDocument struct {
+	XMLName xml.Name  `xml:"OFX"`
+	Signon  ofxSignon `xml:"SIGNONMSGSRSV1>SONRS"`
+	Bank    ofxBank   `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxSignon struct {
+	Status   ofxStatus `xml:"STATUS"`
+	Server   string    `xml:"DTSERVER"`
+	Language string    `xml:"LANGUAGE"`
+}
+
+type ofxBank struct {
+	TransactionUID string       `xml:"TRNUID"`
+	Status         ofxStatus    `xml:"STATUS"`
+	Statement      ofxStatement `xml:"STMTRS"`
+}
+
+type ofxStatement struct {
+	Currency     string           `xml:"CURDEF"`
+	Account      ofxAccount       `xml:"BANKACCTFROM"`
+	Transactions ofxTransactions  `xml:"BANKTRANLIST"`
+	Ledger       ofxLedgerBalance `xml:"LEDGERBAL"`
+}
+
+type ofxAccount struct {
+	BankID      string `xml:"BANKID"`
+	AccountID   string `xml:"ACCTID"`
+	AccountType string `xml:"ACCTTYPE"`
+}
+
+type ofxTransactions struct {
+	Start        string           `xml:"DTSTART"`
+	End          string           `xml:"DTEND"`
+	Transactions []ofxTransaction `xml:"STMTTRN"`
+}
+
+type ofxTransaction struct {
+	Type   string `xml:"TRNTYPE"`
+	Posted string `xml:"DTPOSTED"`
+	Amount string `xml:"TRNAMT"`
+	FITID  string `xml:"FITID"`
+	Name   string `xml:"NAME"`
+	Memo   string `xml:"MEMO,omitempty"`
+}
+
+type ofxLedgerBalance struct {
+	Amount string `xml:"BALAMT"`
+	AsOf   string `xml:"DTASOF"`
+}
+
+// WriteOFX renders the statement as an OFX 2.2 bank statement response. Amounts are
+// signed, the transaction ID is the FITID importers de-duplicate on, and the server
+// time is the end of the period so the file of a past period is always the same.
+func WriteOFX(w io.Writer, statement Statement) error {
+	if err := checkCurrency(statement.Currency); err != nil {
+		return err
+	}
+
+	doc := ofxDocument{
+		Signon: ofxSignon{
+			Status:   ofxStatus{Code: 0, Severity: "INFO"},
+			Server:   ofxTime(statement.Period.To),
+			Language: "ENG",
+		},
+		Bank: ofxBank{
+			TransactionUID: "0",
+			Status:         ofxStatus{Code: 0, Severity: "INFO"},
+			Statement: ofxStatement{
+				Currency: string(statement.Currency),
+				Account:  ofxAccount{BankID: ofxBankID, AccountID: fmt.Sprint(statement.AccountID), AccountType: "CHECKING"},
+				Transactions: ofxTransactions{
+					Start: ofxTime(statement.Period.From),
+					End:   ofxTime(statement.Period.To),
+				},
+				Ledger: ofxLedgerBalance{
+					Amount: formatAmount(statement.ClosingBalanceCents),
+					AsOf:   ofxTime(statement.Period.To),
+				},
+			},
+		},
+	}
+
+	transactions := &doc.Bank.Statement.Transactions
+	for _, line := range statement.Lines {
+		trnType, ok := ofxTypes[line.Type]
+		if !ok {
+			trnType = "DEBIT"
+			if isCredit(line) {
+				trnType = "CREDIT"
+			}
+		}
+
+		amount := abs(line.AmountCents)
+		if !isCredit(line) {
+			amount = -amount
+		}
+		transactions.Transactions = append(transactions.Transactions, ofxTransaction{
+			Type:   trnType,
+			Posted: ofxTime(line.PostedAt),
+			Amount: formatAmount(amount),
+			FITID:  line.TransactionID,
+			Name:   line.Description,
+			Memo:   line.Reference,
+		})
+	}
+
+	if _, err := io.WriteString(w, ofxHeader); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405") + "[0:GMT]"
+}
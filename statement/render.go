@@ -0,0 +1,188 @@
+package statement
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Format names an output format for statements.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	// FormatHTML is a self-contained page laid out for printing or saving as PDF.
+	FormatHTML Format = "html"
+	// FormatCAMT053, FormatMT940 and FormatOFX are the bank formats ERP and personal
+	// finance tools import.
+	FormatCAMT053 Format = "camt053"
+	FormatMT940   Format = "mt940"
+	FormatOFX     Format = "ofx"
+)
+
+var ErrUnknownFormat = errors.New("unknown statement format")
+
+var writers = map[Format]func(io.Writer, Statement) error{
+	FormatJSON:    WriteJSON,
+	FormatCSV:     WriteCSV,
+	FormatHTML:    WriteHTML,
+	FormatCAMT053: WriteCAMT053,
+	FormatMT940:   WriteMT940,
+	FormatOFX:     WriteOFX,
+}
+
+var contentTypes = map[Format]string{
+	FormatJSON:    "application/json",
+	FormatCSV:     "text/csv; charset=utf-8",
+	FormatHTML:    "text/html; charset=utf-8",
+	FormatCAMT053: "application/xml",
+	FormatMT940:   "text/plain; charset=utf-8",
+	FormatOFX:     "application/x-ofx",
+}
+
+// fileExtensions holds the formats that are downloaded as a file rather than displayed.
+var fileExtensions = map[Format]string{
+	FormatCSV:     "csv",
+	FormatCAMT053: "xml",
+	FormatMT940:   "sta",
+	FormatOFX:     "ofx",
+}
+
+// ContentType is the MIME type of the format.
+func (format Format) ContentType() string {
+	return contentTypes[format]
+}
+
+// FileExtension is the extension of a downloaded statement, or empty for formats meant
+// to be displayed.
+func (format Format) FileExtension() string {
+	return fileExtensions[format]
+}
+
+// Write renders statement to w in format.
+func Write(w io.Writer, statement Statement, format Format) error {
+	write, ok := writers[format]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownFormat, format)
+	}
+	return write(w, statement)
+}
+
+// WriteJSON renders the statement as indented JSON, amounts in cents.
+func WriteJSON(w io.Writer, statement Statement) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(statement)
+}
+
+// WriteCSV renders one row per line, between an opening and a closing balance row.
+// Amounts are in major units of the account's currency.
+func WriteCSV(w io.Writer, statement Statement) error {
+	writer := csv.NewWriter(w)
+	currency := string(statement.Currency)
+
+	rows := [][]string{
+		{"date", "transaction_id", "type", "description", "reference", "amount", "balance", "currency"},
+		{formatTime(statement.Period.From), "", "", "Opening balance", "", "", formatAmount(statement.OpeningBalanceCents), currency},
+	}
+	for _, line := range statement.Lines {
+		rows = append(rows, []string{
+			formatTime(line.PostedAt),
+			line.TransactionID,
+			string(line.Type),
+			line.Description,
+			line.Reference,
+			formatAmount(line.AmountCents),
+			formatAmount(line.BalanceCents),
+			currency,
+		})
+	}
+	rows = append(rows, []string{formatTime(statement.Period.To), "", "", "Closing balance", "", "", formatAmount(statement.ClosingBalanceCents), currency})
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// WriteHTML renders a printable page with the balances, the totals by type and every line.
+func WriteHTML(w io.Writer, statement Statement) error {
+	return htmlTemplate.Execute(w, statement)
+}
+
+// formatAmount writes cents in major units with two decimals.
+func formatAmount(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%s.%02d", sign, strconv.FormatInt(cents/100, 10), cents%100)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// lastDay is the last calendar day inside a period ending at the exclusive bound to.
+func lastDay(to time.Time) time.Time {
+	return to.Add(-time.Nanosecond)
+}
+
+var htmlTemplate = template.Must(template.New("statement").Funcs(template.FuncMap{
+	"amount":  formatAmount,
+	"lastDay": lastDay,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Statement for account {{.AccountID}}</title>
+<style>
+  @page { size: A4; margin: 15mm; }
+  body { font-family: Helvetica, Arial, sans-serif; font-size: 11px; color: #222; }
+  h1 { font-size: 18px; margin-bottom: 4px; }
+  table { width: 100%; border-collapse: collapse; margin-top: 12px; }
+  th, td { padding: 4px 6px; border-bottom: 1px solid #ddd; text-align: left; }
+  td.amount, th.amount { text-align: right; font-variant-numeric: tabular-nums; }
+  thead { display: table-header-group; }
+  tr { page-break-inside: avoid; }
+</style>
+</head>
+<body>
+<h1>Account statement</h1>
+<p>Account {{.AccountID}} &middot; {{.Currency}} &middot; {{.Period.From.Format "2 Jan 2006"}} to {{(lastDay .Period.To).Format "2 Jan 2006"}}</p>
+<table>
+  <tr><th>Opening balance</th><td class="amount">{{amount .OpeningBalanceCents}}</td></tr>
+  <tr><th>Money in</th><td class="amount">{{amount .CreditsCents}}</td></tr>
+  <tr><th>Money out</th><td class="amount">{{amount .DebitsCents}}</td></tr>
+  <tr><th>Closing balance</th><td class="amount">{{amount .ClosingBalanceCents}}</td></tr>
+</table>
+{{- if .Totals}}
+<table>
+  <thead><tr><th>Type</th><th class="amount">Count</th><th class="amount">Total</th></tr></thead>
+  <tbody>
+  {{- range .Totals}}
+    <tr><td>{{.Type}}</td><td class="amount">{{.Count}}</td><td class="amount">{{amount .AmountCents}}</td></tr>
+  {{- end}}
+  </tbody>
+</table>
+{{- end}}
+<table>
+  <thead><tr><th>Date</th><th>Description</th><th>Reference</th><th class="amount">Amount</th><th class="amount">Balance</th></tr></thead>
+  <tbody>
+  {{- range .Lines}}
+    <tr><td>{{.PostedAt.Format "2006-01-02 15:04"}}</td><td>{{.Description}}</td><td>{{.Reference}}</td><td class="amount">{{amount .AmountCents}}</td><td class="amount">{{amount .BalanceCents}}</td></tr>
+  {{- else}}
+    <tr><td colspan="5">No transactions in this period.</td></tr>
+  {{- end}}
+  </tbody>
+</table>
+</body>
+</html>
+`))
@@ -0,0 +1,80 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatAmount(t *testing.T) {
+	testCases := []struct {
+		cents int64
+		want  string
+	}{
+		{cents: 0, want: "0.00"},
+		{cents: 5, want: "0.05"},
+		{cents: -5, want: "-0.05"},
+		{cents: 123456, want: "1234.56"},
+		{cents: -100, want: "-1.00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			require.Equal(t, tc.want, formatAmount(tc.cents))
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	statement, err := Generate(context.Background(), newFakeStore(), 7, Month(2026, time.February, time.UTC))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, statement, FormatCSV))
+	require.Equal(t, `date,transaction_id,type,description,reference,amount,balance,currency
+2026-02-01T00:00:00Z,,,Opening balance,,,75.00,USD
+2026-02-02T12:00:00Z,00000000-0000-0000-0000-000000000003,transfer_in,Transfer in,,40.00,115.00,USD
+2026-02-14T12:00:00Z,00000000-0000-0000-0000-000000000004,withdrawal,Withdrawal,,-10.00,105.00,USD
+2026-02-27T12:00:00Z,00000000-0000-0000-0000-000000000005,transfer_out,Transfer out,,-30.00,75.00,USD
+2026-03-01T00:00:00Z,,,Closing balance,,,75.00,USD
+`, buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	statement, err := Generate(context.Background(), newFakeStore(), 7, Month(2026, time.February, time.UTC))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, statement, FormatJSON))
+
+	var decoded Statement
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, statement, decoded)
+
+	// The same period renders to the same bytes every time
+	var again bytes.Buffer
+	require.NoError(t, Write(&again, statement, FormatJSON))
+	require.Equal(t, buf.String(), again.String())
+}
+
+func TestWriteHTML(t *testing.T) {
+	statement, err := Generate(context.Background(), newFakeStore(), 7, Month(2026, time.February, time.UTC))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, statement, FormatHTML))
+	html := buf.String()
+	require.Contains(t, html, "1 Feb 2026 to 28 Feb 2026")
+	require.Contains(t, html, "@page")
+	require.Contains(t, html, `<td class="amount">-30.00</td>`)
+	require.NotContains(t, html, "No transactions in this period.")
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	require.ErrorIs(t, Write(&buf, Statement{}, "pdf"), ErrUnknownFormat)
+}
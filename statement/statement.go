@@ -0,0 +1,195 @@
+// Package statement builds account statements for a period from the stored transaction history.
+package statement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// pageSize is how many transactions are read per query.
+const pageSize = 500
+
+var ErrInvalidPeriod = errors.New("statement period must end after it starts")
+
+// Store is the subset of db.Store statements need.
+type Store interface {
+	GetAccount(ctx context.Context, id int64) (db.Account, error)
+	GetLastTransactionBefore(ctx context.Context, arg db.GetLastTransactionBeforeParams) (db.Transaction, error)
+	ListTransactionsInPeriod(ctx context.Context, arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error)
+}
+
+// Period is the half-open interval [From, To).
+type Period struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Month returns the calendar month in loc.
+func Month(year int, month time.Month, loc *time.Location) Period {
+	from := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	return Period{From: from, To: from.AddDate(0, 1, 0)}
+}
+
+// Line is one transaction on a statement.
+type Line struct {
+	TransactionID string             `json:"transaction_id"`
+	PostedAt      time.Time          `json:"posted_at"`
+	Type          db.TransactionType `json:"type"`
+	Description   string             `json:"description"`
+	Reference     string             `json:"reference,omitempty"`
+	AmountCents   int64              `json:"amount_cents"`
+	// BalanceCents is the running balance after the line, as stored with the transaction.
+	BalanceCents int64 `json:"balance_cents"`
+}
+
+// TypeTotal sums the lines of one transaction type.
+type TypeTotal struct {
+	Type        db.TransactionType `json:"type"`
+	Count       int                `json:"count"`
+	AmountCents int64              `json:"amount_cents"`
+}
+
+// Statement is an account's activity over a period. It carries no generation time,
+// so the statement of a past period comes out the same every time it is generated.
+type Statement struct {
+	AccountID           int64       `json:"account_id"`
+	Currency            db.Currency `json:"currency"`
+	Period              Period      `json:"period"`
+	OpeningBalanceCents int64       `json:"opening_balance_cents"`
+	ClosingBalanceCents int64       `json:"closing_balance_cents"`
+	// CreditsCents sums the money in; DebitsCents sums the money out, as a negative amount.
+	CreditsCents int64       `json:"credits_cents"`
+	DebitsCents  int64       `json:"debits_cents"`
+	Totals       []TypeTotal `json:"totals"`
+	Lines        []Line      `json:"lines"`
+}
+
+// transactionTypes fixes the order totals are listed in.
+var transactionTypes = []db.TransactionType{
+	db.TransactionTypeDeposit,
+	db.TransactionTypeWithdrawal,
+	db.TransactionTypeTransferIn,
+	db.TransactionTypeTransferOut,
+	db.TransactionTypeReversal,
+	db.TransactionTypeOverdraftInterest,
+	db.TransactionTypeOverdraftFee,
+}
+
+var descriptions = map[db.TransactionType]string{
+	db.TransactionTypeDeposit:           "Deposit",
+	db.TransactionTypeWithdrawal:        "Withdrawal",
+	db.TransactionTypeTransferIn:        "Transfer in",
+	db.TransactionTypeTransferOut:       "Transfer out",
+	db.TransactionTypeReversal:          "Transfer reversal",
+	db.TransactionTypeOverdraftInterest: "Overdraft interest",
+	db.TransactionTypeOverdraftFee:      "Overdraft fee",
+}
+
+// Generate builds the statement of an account for period. Lines are the transactions
+// created in the period, in the order they were applied to the account, and every balance
+// comes from balance_after_cents: the opening balance is the one the first line started
+// from, or the balance left by the last earlier transaction when the period is empty.
+func Generate(ctx context.Context, store Store, accountID int64, period Period) (Statement, error) {
+	if !period.To.After(period.From) {
+		return Statement{}, ErrInvalidPeriod
+	}
+
+	account, err := store.GetAccount(ctx, accountID)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	statement := Statement{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		Period:    period,
+		Lines:     []Line{},
+		Totals:    []TypeTotal{},
+	}
+
+	var afterSeq int64
+	totals := make(map[db.TransactionType]*TypeTotal)
+	for {
+		transactions, err := store.ListTransactionsInPeriod(ctx, db.ListTransactionsInPeriodParams{
+			AccountID: accountID,
+			FromTime:  pgtype.Timestamptz{Time: period.From, Valid: true},
+			ToTime:    pgtype.Timestamptz{Time: period.To, Valid: true},
+			AfterSeq:  afterSeq,
+			Limit:     pageSize,
+		})
+		if err != nil {
+			return Statement{}, fmt.Errorf("list transactions: %w", err)
+		}
+
+		for _, transaction := range transactions {
+			statement.Lines = append(statement.Lines, newLine(transaction))
+
+			total, ok := totals[transaction.Type]
+			if !ok {
+				total = &TypeTotal{Type: transaction.Type}
+				totals[transaction.Type] = total
+			}
+			total.Count++
+			total.AmountCents += transaction.AmountCents
+
+			if transaction.AmountCents > 0 {
+				statement.CreditsCents += transaction.AmountCents
+			} else {
+				statement.DebitsCents += transaction.AmountCents
+			}
+		}
+
+		if len(transactions) < pageSize {
+			break
+		}
+		afterSeq = transactions[len(transactions)-1].Seq
+	}
+
+	for _, txType := range transactionTypes {
+		if total, ok := totals[txType]; ok {
+			statement.Totals = append(statement.Totals, *total)
+		}
+	}
+
+	if len(statement.Lines) > 0 {
+		first, last := statement.Lines[0], statement.Lines[len(statement.Lines)-1]
+		statement.OpeningBalanceCents = first.BalanceCents - first.AmountCents
+		statement.ClosingBalanceCents = last.BalanceCents
+		return statement, nil
+	}
+
+	previous, err := store.GetLastTransactionBefore(ctx, db.GetLastTransactionBeforeParams{
+		AccountID: accountID,
+		Before:    pgtype.Timestamptz{Time: period.From, Valid: true},
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return Statement{}, fmt.Errorf("get opening balance: %w", err)
+	}
+	statement.OpeningBalanceCents = previous.BalanceAfterCents
+	statement.ClosingBalanceCents = previous.BalanceAfterCents
+	return statement, nil
+}
+
+func newLine(transaction db.Transaction) Line {
+	line := Line{
+		TransactionID: transaction.ID.String(),
+		PostedAt:      transaction.CreatedAt.Time.UTC(),
+		Type:          transaction.Type,
+		Description:   descriptions[transaction.Type],
+		AmountCents:   transaction.AmountCents,
+		BalanceCents:  transaction.BalanceAfterCents,
+	}
+	if line.Description == "" {
+		line.Description = string(transaction.Type)
+	}
+	if transaction.Reference.Valid {
+		line.Reference = transaction.Reference.String
+	}
+	return line
+}
@@ -0,0 +1,160 @@
+package statement
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore serves one account's history from memory, in the order it was applied.
+type fakeStore struct {
+	account      db.Account
+	transactions []db.Transaction
+	pages        int
+}
+
+func (s *fakeStore) GetAccount(_ context.Context, id int64) (db.Account, error) {
+	if id != s.account.ID {
+		return db.Account{}, pgx.ErrNoRows
+	}
+	return s.account, nil
+}
+
+func (s *fakeStore) GetLastTransactionBefore(_ context.Context, arg db.GetLastTransactionBeforeParams) (db.Transaction, error) {
+	for i := len(s.transactions) - 1; i >= 0; i-- {
+		if s.transactions[i].CreatedAt.Time.Before(arg.Before.Time) {
+			return s.transactions[i], nil
+		}
+	}
+	return db.Transaction{}, pgx.ErrNoRows
+}
+
+func (s *fakeStore) ListTransactionsInPeriod(_ context.Context, arg db.ListTransactionsInPeriodParams) ([]db.Transaction, error) {
+	s.pages++
+	var items []db.Transaction
+	for _, transaction := range s.transactions {
+		createdAt := transaction.CreatedAt.Time
+		if transaction.Seq <= arg.AfterSeq || createdAt.Before(arg.FromTime.Time) || !createdAt.Before(arg.ToTime.Time) {
+			continue
+		}
+		if len(items) == int(arg.Limit) {
+			break
+		}
+		items = append(items, transaction)
+	}
+	return items, nil
+}
+
+// record appends a transaction applied at createdAt, chaining its balance from the previous one.
+func (s *fakeStore) record(txType db.TransactionType, amount int64, createdAt time.Time) db.Transaction {
+	var balance int64
+	if n := len(s.transactions); n > 0 {
+		balance = s.transactions[n-1].BalanceAfterCents
+	}
+
+	seq := int64(len(s.transactions) + 1)
+	var id pgtype.UUID
+	_ = id.Scan(fmt.Sprintf("00000000-0000-0000-0000-%012d", seq))
+
+	transaction := db.Transaction{
+		ID:                id,
+		AccountID:         s.account.ID,
+		Type:              txType,
+		AmountCents:       amount,
+		BalanceAfterCents: balance + amount,
+		CreatedAt:         pgtype.Timestamptz{Time: createdAt, Valid: true},
+		Seq:               seq,
+	}
+	s.transactions = append(s.transactions, transaction)
+	return transaction
+}
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2026, month, d, 12, 0, 0, 0, time.UTC)
+}
+
+func newFakeStore() *fakeStore {
+	store := &fakeStore{account: db.Account{ID: 7, Currency: db.CurrencyUSD}}
+	store.record(db.TransactionTypeDeposit, 10000, day(time.January, 10))
+	store.record(db.TransactionTypeWithdrawal, -2500, day(time.January, 20))
+	store.record(db.TransactionTypeTransferIn, 4000, day(time.February, 2))
+	store.record(db.TransactionTypeWithdrawal, -1000, day(time.February, 14))
+	store.record(db.TransactionTypeTransferOut, -3000, day(time.February, 27))
+	store.record(db.TransactionTypeDeposit, 500, day(time.March, 1))
+	return store
+}
+
+func TestGenerate(t *testing.T) {
+	store := newFakeStore()
+
+	statement, err := Generate(context.Background(), store, 7, Month(2026, time.February, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, db.CurrencyUSD, statement.Currency)
+	require.Equal(t, int64(7500), statement.OpeningBalanceCents)
+	require.Equal(t, int64(7500), statement.ClosingBalanceCents)
+	require.Equal(t, int64(4000), statement.CreditsCents)
+	require.Equal(t, int64(-4000), statement.DebitsCents)
+
+	require.Len(t, statement.Lines, 3)
+	require.Equal(t, "Transfer in", statement.Lines[0].Description)
+	require.Equal(t, int64(11500), statement.Lines[0].BalanceCents)
+	require.Equal(t, int64(10500), statement.Lines[1].BalanceCents)
+
+	require.Equal(t, []TypeTotal{
+		{Type: db.TransactionTypeWithdrawal, Count: 1, AmountCents: -1000},
+		{Type: db.TransactionTypeTransferIn, Count: 1, AmountCents: 4000},
+		{Type: db.TransactionTypeTransferOut, Count: 1, AmountCents: -3000},
+	}, statement.Totals)
+}
+
+func TestGenerateEmptyPeriod(t *testing.T) {
+	testCases := []struct {
+		name    string
+		period  Period
+		balance int64
+	}{
+		{name: "AfterHistory", period: Month(2026, time.April, time.UTC), balance: 8000},
+		{name: "BeforeHistory", period: Month(2025, time.December, time.UTC), balance: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			statement, err := Generate(context.Background(), newFakeStore(), 7, tc.period)
+			require.NoError(t, err)
+			require.Empty(t, statement.Lines)
+			require.Empty(t, statement.Totals)
+			require.Equal(t, tc.balance, statement.OpeningBalanceCents)
+			require.Equal(t, tc.balance, statement.ClosingBalanceCents)
+		})
+	}
+}
+
+func TestGeneratePages(t *testing.T) {
+	store := &fakeStore{account: db.Account{ID: 7, Currency: db.CurrencyUSD}}
+	start := day(time.May, 1)
+	for i := range pageSize + 1 {
+		store.record(db.TransactionTypeDeposit, 1, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	statement, err := Generate(context.Background(), store, 7, Month(2026, time.May, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, 2, store.pages)
+	require.Len(t, statement.Lines, pageSize+1)
+	require.Equal(t, int64(pageSize+1), statement.ClosingBalanceCents)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	store := newFakeStore()
+
+	_, err := Generate(context.Background(), store, 7, Period{From: day(time.March, 1), To: day(time.March, 1)})
+	require.ErrorIs(t, err, ErrInvalidPeriod)
+
+	_, err = Generate(context.Background(), store, 8, Month(2026, time.March, time.UTC))
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
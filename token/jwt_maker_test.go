@@ -0,0 +1,82 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTMaker(t *testing.T) {
+	maker, err := NewJWTMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(userID, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	verified, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, verified)
+
+	require.Equal(t, payload.ID, verified.ID)
+	require.Equal(t, userID, verified.UserID)
+	require.WithinDuration(t, issuedAt, verified.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, verified.ExpiredAt, time.Second)
+}
+
+func TestExpiredJWTToken(t *testing.T) {
+	maker, err := NewJWTMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateToken(uuid.New(), -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	verified, err := maker.VerifyToken(token)
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrExpiredToken)
+	require.Nil(t, verified)
+}
+
+func TestInvalidJWTTokenAlgNone(t *testing.T) {
+	payload, err := NewPayload(uuid.New(), time.Minute)
+	require.NoError(t, err)
+
+	claims := jwtClaims{
+		UserID: payload.UserID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        payload.ID.String(),
+			IssuedAt:  jwt.NewNumericDate(payload.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(payload.ExpiredAt),
+		},
+	}
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	token, err := jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	maker, err := NewJWTMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	verified, err := maker.VerifyToken(token)
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, verified)
+}
+
+func TestJWTMakerInvalidKeySize(t *testing.T) {
+	maker, err := NewJWTMaker(utils.RandomString(minSecretKeySize - 1))
+	require.Error(t, err)
+	require.Nil(t, maker)
+}
@@ -0,0 +1,17 @@
+package token
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Maker is an interface for managing access tokens.
+// Implementations are interchangeable so the signing algorithm can be switched by configuration.
+type Maker interface {
+	// CreateToken creates a new token for a specific user and duration
+	CreateToken(userID uuid.UUID, duration time.Duration) (string, *Payload, error)
+
+	// VerifyToken checks if the token is valid or not
+	VerifyToken(token string) (*Payload, error)
+}
@@ -0,0 +1,71 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RakibRahman/fincore-api/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasetoMaker(t *testing.T) {
+	maker, err := NewPasetoMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(userID, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	verified, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, verified)
+
+	require.Equal(t, payload.ID, verified.ID)
+	require.Equal(t, userID, verified.UserID)
+	require.WithinDuration(t, issuedAt, verified.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, verified.ExpiredAt, time.Second)
+}
+
+func TestExpiredPasetoToken(t *testing.T) {
+	maker, err := NewPasetoMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateToken(uuid.New(), -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	verified, err := maker.VerifyToken(token)
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrExpiredToken)
+	require.Nil(t, verified)
+}
+
+func TestPasetoTokenWrongKey(t *testing.T) {
+	maker, err := NewPasetoMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(uuid.New(), time.Minute)
+	require.NoError(t, err)
+
+	otherMaker, err := NewPasetoMaker(utils.RandomString(32))
+	require.NoError(t, err)
+
+	verified, err := otherMaker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, verified)
+}
+
+func TestPasetoMakerInvalidKeySize(t *testing.T) {
+	maker, err := NewPasetoMaker(utils.RandomString(31))
+	require.Error(t, err)
+	require.Nil(t, maker)
+}
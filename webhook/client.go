@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrInsecureURL      = errors.New("webhook url must use https")
+	ErrForbiddenAddress = errors.New("webhook url must reach a public address")
+	ErrUnresolvableHost = errors.New("webhook url host cannot be resolved")
+)
+
+// Errors recorded for failed requests in place of the transport's own, which would tell
+// the endpoint's owner about the network the dispatcher runs in.
+var (
+	errRequestTimedOut = errors.New("request timed out")
+	errRequestFailed   = errors.New("request failed")
+)
+
+// Resolver looks up the addresses of a host name; *net.Resolver is one.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// CheckURL returns an error unless rawURL is an https URL whose host resolves only to
+// public addresses. Endpoints are checked when they are registered; the client made by
+// NewClient checks the address it connects to again, since DNS may have changed since.
+func CheckURL(ctx context.Context, rawURL string, resolver Resolver) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return fmt.Errorf("invalid webhook url %q", rawURL)
+	}
+	if u.Scheme != "https" {
+		return ErrInsecureURL
+	}
+
+	addrs := make([]netip.Addr, 1)
+	if addrs[0], err = netip.ParseAddr(u.Hostname()); err != nil {
+		addrs, err = resolver.LookupNetIP(ctx, "ip", u.Hostname())
+		if err != nil || len(addrs) == 0 {
+			return ErrUnresolvableHost
+		}
+	}
+	for _, addr := range addrs {
+		if !publicAddr(addr) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// nonPublicPrefixes are ranges that netip doesn't classify as private or local but that
+// aren't reachable on the internet either.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// publicAddr reports whether addr is a unicast address outside the loopback, private,
+// link-local and unspecified ranges.
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewClient returns the client that sends deliveries when Options.Client is nil. It
+// connects only to public addresses, checked after DNS resolution, ignores proxy settings
+// and doesn't follow redirects, so that an endpoint can't point it at internal services.
+func NewClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !publicAddr(addrPort.Addr()) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// requestError replaces an error from sending a request with one that is safe to show
+// the endpoint's owner.
+func requestError(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrForbiddenAddress):
+		return ErrForbiddenAddress
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return errRequestTimedOut
+	}
+	return errRequestFailed
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the signature of a delivery in the form t=<unix seconds>,v1=<hex>.
+const SignatureHeader = "Fincore-Signature"
+
+var (
+	ErrInvalidSignature = errors.New("webhook signature does not match")
+	ErrSignatureExpired = errors.New("webhook signature timestamp outside tolerance")
+)
+
+// NewSecret returns a random signing secret for a new endpoint.
+func NewSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(key), nil
+}
+
+// Sign returns the SignatureHeader value for body sent at timestamp: an HMAC-SHA256 of
+// "<unix seconds>.<body>" keyed with the endpoint's secret. Covering the timestamp lets
+// receivers refuse replays of old deliveries.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac(secret, unix, body))
+}
+
+// Verify checks a SignatureHeader value against body and refuses signatures made more
+// than tolerance away from now. Receivers written in Go can use it as is.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var unix, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			signature = value
+		}
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing timestamp", ErrInvalidSignature)
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(got, mac(secret, unix, body)) {
+		return ErrInvalidSignature
+	}
+
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+func mac(secret, unix string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(unix))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
@@ -0,0 +1,288 @@
+// Package webhook delivers ledger events from the outbox to registered webhook endpoints.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultBatchSize   = 20
+	defaultTimeout     = 10 * time.Second
+	defaultMaxAttempts = 8
+	defaultBaseBackoff = 30 * time.Second
+	defaultMaxBackoff  = 6 * time.Hour
+)
+
+// Headers sent with every delivery besides SignatureHeader.
+const (
+	EventIDHeader   = "Fincore-Event-Id"
+	EventTypeHeader = "Fincore-Event-Type"
+	AttemptHeader   = "Fincore-Delivery-Attempt"
+)
+
+// Store is the subset of db.Store the dispatcher needs.
+type Store interface {
+	DispatchOutboxEvents(ctx context.Context, limit int32) (int, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, arg db.ClaimDueWebhookDeliveriesParams) ([]db.WebhookDelivery, error)
+	GetOutboxEvent(ctx context.Context, id int64) (db.OutboxEvent, error)
+	GetWebhookEndpoint(ctx context.Context, id pgtype.UUID) (db.WebhookEndpoint, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error)
+}
+
+// Options tunes a dispatcher pass.
+type Options struct {
+	// BatchSize is how many events or deliveries are claimed per query; zero means 20.
+	BatchSize int
+	// Timeout bounds each HTTP request; zero means ten seconds.
+	Timeout time.Duration
+	// Lease is how long claimed deliveries stay with this dispatcher; zero means long
+	// enough for every request of a batch to time out.
+	Lease time.Duration
+	// MaxAttempts is how many failed attempts move a delivery to dead; zero means 8.
+	MaxAttempts int
+	// BaseBackoff is the wait after the first failed attempt, doubled after every
+	// further one up to MaxBackoff; zero means 30 seconds and six hours.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Client sends the requests; nil means a client from NewClient, which reaches only
+	// public addresses.
+	Client *http.Client
+}
+
+func (opts Options) withDefaults() Options {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = time.Duration(opts.BatchSize)*opts.Timeout + time.Minute
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.BaseBackoff <= 0 {
+		opts.BaseBackoff = defaultBaseBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+	if opts.Client == nil {
+		opts.Client = NewClient()
+	}
+	return opts
+}
+
+// Report counts what a pass did.
+type Report struct {
+	// Dispatched counts outbox events whose deliveries were queued.
+	Dispatched int `json:"dispatched"`
+	Delivered  int `json:"delivered"`
+	Retrying   int `json:"retrying"`
+	Dead       int `json:"dead"`
+	// Errors counts deliveries left to a later pass because their event, endpoint or
+	// outcome could not be read or written.
+	Errors int `json:"errors"`
+}
+
+// Envelope is the JSON body of every delivery.
+type Envelope struct {
+	ID        int64           `json:"id"`
+	Type      db.EventType    `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// RunDue queues deliveries for the events written since the last pass, then sends every
+// delivery due at now. A delivery succeeds on any 2xx response. A failed one is retried
+// with exponential backoff until MaxAttempts, then left dead until it is redelivered by hand.
+// Deliveries are at least once: receivers should de-duplicate on the event ID.
+func RunDue(ctx context.Context, store Store, now time.Time, opts Options) (Report, error) {
+	var report Report
+	opts = opts.withDefaults()
+
+	for {
+		dispatched, err := store.DispatchOutboxEvents(ctx, int32(opts.BatchSize))
+		if err != nil {
+			return report, fmt.Errorf("dispatch outbox events: %w", err)
+		}
+		report.Dispatched += dispatched
+		if dispatched < opts.BatchSize {
+			break
+		}
+	}
+
+	for {
+		deliveries, err := store.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+			LockedUntil: pgtype.Timestamptz{Time: now.Add(opts.Lease), Valid: true},
+			Now:         pgtype.Timestamptz{Time: now, Valid: true},
+			Limit:       int32(opts.BatchSize),
+		})
+		if err != nil {
+			return report, fmt.Errorf("claim due webhook deliveries: %w", err)
+		}
+
+		for _, delivery := range deliveries {
+			status, err := deliver(ctx, store, delivery, now, opts)
+			if err != nil {
+				report.Errors++
+				continue
+			}
+			switch status {
+			case db.WebhookDeliveryStatusDelivered:
+				report.Delivered++
+			case db.WebhookDeliveryStatusDead:
+				report.Dead++
+			case db.WebhookDeliveryStatusPending:
+				report.Retrying++
+			}
+		}
+
+		if len(deliveries) < opts.BatchSize {
+			return report, nil
+		}
+	}
+}
+
+// Run calls RunDue every interval until ctx is done. A failed pass is reported
+// through onPass and retried on the next tick.
+func Run(ctx context.Context, store Store, interval time.Duration, opts Options, onPass func(Report, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := RunDue(ctx, store, time.Now(), opts)
+		if onPass != nil {
+			onPass(report, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliver makes one attempt of a claimed delivery and records its outcome.
+func deliver(ctx context.Context, store Store, delivery db.WebhookDelivery, now time.Time, opts Options) (db.WebhookDeliveryStatus, error) {
+	endpoint, err := store.GetWebhookEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		return "", err
+	}
+
+	record := db.RecordWebhookDeliveryAttemptParams{
+		ID:            delivery.ID,
+		RanAttempts:   delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+	}
+	attempt := int(delivery.Attempts) + 1
+
+	if !endpoint.Active {
+		record.Status = db.WebhookDeliveryStatusDead
+		record.LastError = pgtype.Text{String: "endpoint deactivated", Valid: true}
+	} else {
+		event, err := store.GetOutboxEvent(ctx, delivery.EventID)
+		if err != nil {
+			return "", err
+		}
+
+		statusCode, sendErr := send(ctx, endpoint, event, attempt, opts)
+		if statusCode != 0 {
+			record.LastStatusCode = pgtype.Int4{Int32: int32(statusCode), Valid: true}
+		}
+		switch {
+		case sendErr == nil:
+			record.Status = db.WebhookDeliveryStatusDelivered
+			record.DeliveredAt = pgtype.Timestamptz{Time: now, Valid: true}
+		case attempt >= opts.MaxAttempts:
+			record.Status = db.WebhookDeliveryStatusDead
+			record.LastError = pgtype.Text{String: sendErr.Error(), Valid: true}
+		default:
+			record.Status = db.WebhookDeliveryStatusPending
+			record.LastError = pgtype.Text{String: sendErr.Error(), Valid: true}
+			record.NextAttemptAt = pgtype.Timestamptz{Time: now.Add(backoff(attempt, opts)), Valid: true}
+		}
+	}
+
+	_, err = store.RecordWebhookDeliveryAttempt(ctx, record)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Redelivered or recorded by another dispatcher after the lease ran out
+		return "", fmt.Errorf("delivery %d moved on while it was being sent: %w", delivery.ID, err)
+	}
+	return record.Status, err
+}
+
+// send posts the event to the endpoint and returns the response status, if any. Any
+// status outside 2xx is an error, redirects included. The signature is made at sending
+// time rather than at the start of the pass, so receivers can hold it to a tight
+// tolerance. The errors are shown to the endpoint's owner, so they never carry the
+// transport's own message.
+func send(ctx context.Context, endpoint db.WebhookEndpoint, event db.OutboxEvent, attempt int, opts Options) (int, error) {
+	body, err := json.Marshal(Envelope{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt.Time.UTC(),
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Endpoints registered before https was required are not sent to
+	if u, err := url.Parse(endpoint.Url); err != nil || u.Scheme != "https" {
+		return 0, ErrInsecureURL
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, errRequestFailed
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, strconv.FormatInt(event.ID, 10))
+	req.Header.Set(EventTypeHeader, string(event.Type))
+	req.Header.Set(AttemptHeader, strconv.Itoa(attempt))
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, time.Now(), body))
+
+	rsp, err := opts.Client.Do(req)
+	if err != nil {
+		return 0, requestError(err)
+	}
+	defer rsp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(rsp.Body, 64<<10))
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return rsp.StatusCode, fmt.Errorf("unexpected response status %d", rsp.StatusCode)
+	}
+	return rsp.StatusCode, nil
+}
+
+// backoff is the wait after the given failed attempt: BaseBackoff doubled for every
+// attempt after the first, capped at MaxBackoff.
+func backoff(attempt int, opts Options) time.Duration {
+	wait := opts.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if wait >= opts.MaxBackoff {
+			return opts.MaxBackoff
+		}
+	}
+	return wait
+}
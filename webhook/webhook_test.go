@@ -0,0 +1,370 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	db "github.com/RakibRahman/fincore-api/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore keeps the outbox in memory. Every event goes to every endpoint, and a
+// delivery is due when its next attempt time has come.
+type fakeStore struct {
+	events     []db.OutboxEvent
+	endpoints  []db.WebhookEndpoint
+	deliveries []db.WebhookDelivery
+}
+
+func (s *fakeStore) DispatchOutboxEvents(_ context.Context, limit int32) (int, error) {
+	dispatched := 0
+	for i := range s.events {
+		if s.events[i].DispatchedAt.Valid || dispatched == int(limit) {
+			continue
+		}
+		for _, endpoint := range s.endpoints {
+			s.deliveries = append(s.deliveries, db.WebhookDelivery{
+				ID:         int64(len(s.deliveries) + 1),
+				EventID:    s.events[i].ID,
+				EndpointID: endpoint.ID,
+				Status:     db.WebhookDeliveryStatusPending,
+			})
+		}
+		s.events[i].DispatchedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		dispatched++
+	}
+	return dispatched, nil
+}
+
+func (s *fakeStore) ClaimDueWebhookDeliveries(_ context.Context, arg db.ClaimDueWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	var claimed []db.WebhookDelivery
+	for i, delivery := range s.deliveries {
+		if len(claimed) == int(arg.Limit) {
+			break
+		}
+		if delivery.Status != db.WebhookDeliveryStatusPending || delivery.NextAttemptAt.Time.After(arg.Now.Time) ||
+			delivery.LockedUntil.Time.After(arg.Now.Time) {
+			continue
+		}
+		s.deliveries[i].LockedUntil = arg.LockedUntil
+		claimed = append(claimed, s.deliveries[i])
+	}
+	return claimed, nil
+}
+
+func (s *fakeStore) GetOutboxEvent(_ context.Context, id int64) (db.OutboxEvent, error) {
+	for _, event := range s.events {
+		if event.ID == id {
+			return event, nil
+		}
+	}
+	return db.OutboxEvent{}, pgx.ErrNoRows
+}
+
+func (s *fakeStore) GetWebhookEndpoint(_ context.Context, id pgtype.UUID) (db.WebhookEndpoint, error) {
+	for _, endpoint := range s.endpoints {
+		if endpoint.ID == id {
+			return endpoint, nil
+		}
+	}
+	return db.WebhookEndpoint{}, pgx.ErrNoRows
+}
+
+func (s *fakeStore) RecordWebhookDeliveryAttempt(_ context.Context, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+	for i, delivery := range s.deliveries {
+		if delivery.ID != arg.ID || delivery.Status != db.WebhookDeliveryStatusPending || delivery.Attempts != arg.RanAttempts {
+			continue
+		}
+		s.deliveries[i].Status = arg.Status
+		s.deliveries[i].Attempts++
+		s.deliveries[i].NextAttemptAt = arg.NextAttemptAt
+		s.deliveries[i].LastStatusCode = arg.LastStatusCode
+		s.deliveries[i].LastError = arg.LastError
+		s.deliveries[i].DeliveredAt = arg.DeliveredAt
+		s.deliveries[i].LockedUntil = pgtype.Timestamptz{}
+		return s.deliveries[i], nil
+	}
+	return db.WebhookDelivery{}, pgx.ErrNoRows
+}
+
+func uuid(n int) pgtype.UUID {
+	var id pgtype.UUID
+	_ = id.Scan(fmt.Sprintf("00000000-0000-0000-0000-%012d", n))
+	return id
+}
+
+// receiver is a webhook endpoint that answers with the next of its statuses and
+// keeps every request it verified.
+type receiver struct {
+	t        *testing.T
+	secret   string
+	mu       sync.Mutex
+	statuses []int
+	received []Envelope
+	attempts []string
+	// client trusts the receiver's certificate and, unlike the default client, may
+	// reach it on the loopback address
+	client *http.Client
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// The handler runs off the test goroutine, so failures are reported with assert
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(r.t, err)
+	assert.NoError(r.t, Verify(r.secret, req.Header.Get(SignatureHeader), body, time.Now(), time.Minute))
+
+	var envelope Envelope
+	assert.NoError(r.t, json.Unmarshal(body, &envelope))
+	assert.Equal(r.t, fmt.Sprint(envelope.ID), req.Header.Get(EventIDHeader))
+	assert.Equal(r.t, string(envelope.Type), req.Header.Get(EventTypeHeader))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, envelope)
+	r.attempts = append(r.attempts, req.Header.Get(AttemptHeader))
+
+	status := http.StatusNoContent
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newFixture(t *testing.T, statuses ...int) (*fakeStore, *receiver) {
+	r := &receiver{t: t, secret: "whsec_test", statuses: statuses}
+	server := httptest.NewTLSServer(r)
+	t.Cleanup(server.Close)
+	r.client = server.Client()
+
+	store := &fakeStore{
+		events: []db.OutboxEvent{{
+			ID:         1,
+			Type:       db.EventTypeDepositCompleted,
+			AccountIds: []int64{7},
+			Payload:    []byte(`{"account_id":7,"amount_cents":500}`),
+			CreatedAt:  pgtype.Timestamptz{Time: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), Valid: true},
+		}},
+		endpoints: []db.WebhookEndpoint{{ID: uuid(1), Url: server.URL, Secret: r.secret, Active: true}},
+	}
+	return store, r
+}
+
+func TestRunDueDelivers(t *testing.T) {
+	store, r := newFixture(t)
+	now := time.Now()
+
+	report, err := RunDue(context.Background(), store, now, Options{Client: r.client})
+	require.NoError(t, err)
+	require.Equal(t, Report{Dispatched: 1, Delivered: 1}, report)
+
+	require.Len(t, r.received, 1)
+	require.Equal(t, db.EventTypeDepositCompleted, r.received[0].Type)
+	require.JSONEq(t, `{"account_id":7,"amount_cents":500}`, string(r.received[0].Data))
+
+	delivery := store.deliveries[0]
+	require.Equal(t, db.WebhookDeliveryStatusDelivered, delivery.Status)
+	require.Equal(t, int32(http.StatusNoContent), delivery.LastStatusCode.Int32)
+	require.True(t, now.Equal(delivery.DeliveredAt.Time))
+
+	// Nothing is sent twice
+	report, err = RunDue(context.Background(), store, now.Add(time.Hour), Options{Client: r.client})
+	require.NoError(t, err)
+	require.Equal(t, Report{}, report)
+}
+
+func TestRunDueBacksOffThenDeadLetters(t *testing.T) {
+	store, r := newFixture(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable)
+	opts := Options{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour, Client: r.client}
+	now := time.Now()
+
+	report, err := RunDue(context.Background(), store, now, opts)
+	require.NoError(t, err)
+	require.Equal(t, Report{Dispatched: 1, Retrying: 1}, report)
+	require.True(t, now.Add(time.Minute).Equal(store.deliveries[0].NextAttemptAt.Time))
+	require.Equal(t, "unexpected response status 500", store.deliveries[0].LastError.String)
+
+	// Not due until the backoff has passed
+	report, err = RunDue(context.Background(), store, now.Add(30*time.Second), opts)
+	require.NoError(t, err)
+	require.Equal(t, Report{}, report)
+
+	now = now.Add(time.Minute)
+	report, err = RunDue(context.Background(), store, now, opts)
+	require.NoError(t, err)
+	require.Equal(t, Report{Retrying: 1}, report)
+	require.True(t, now.Add(2*time.Minute).Equal(store.deliveries[0].NextAttemptAt.Time))
+
+	now = now.Add(2 * time.Minute)
+	report, err = RunDue(context.Background(), store, now, opts)
+	require.NoError(t, err)
+	require.Equal(t, Report{Dead: 1}, report)
+
+	delivery := store.deliveries[0]
+	require.Equal(t, db.WebhookDeliveryStatusDead, delivery.Status)
+	require.Equal(t, int32(3), delivery.Attempts)
+	require.Equal(t, int32(http.StatusServiceUnavailable), delivery.LastStatusCode.Int32)
+	require.Equal(t, []string{"1", "2", "3"}, r.attempts)
+
+	// A dead delivery is not retried
+	report, err = RunDue(context.Background(), store, now.Add(24*time.Hour), opts)
+	require.NoError(t, err)
+	require.Equal(t, Report{}, report)
+}
+
+func TestRunDueUnreachableEndpoint(t *testing.T) {
+	store, r := newFixture(t)
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	server.Close()
+	store.endpoints[0].Url = server.URL
+
+	report, err := RunDue(context.Background(), store, time.Now(), Options{Client: r.client})
+	require.NoError(t, err)
+	require.Equal(t, Report{Dispatched: 1, Retrying: 1}, report)
+	require.False(t, store.deliveries[0].LastStatusCode.Valid)
+	// The transport's error would give away the dispatcher's network
+	require.Equal(t, "request failed", store.deliveries[0].LastError.String)
+}
+
+func TestRunDueRefusesUnsafeEndpoints(t *testing.T) {
+	testCases := []struct {
+		name    string
+		url     func(fixtureURL string) string
+		wantErr error
+	}{
+		{
+			// The default client won't connect to the receiver on the loopback address
+			name:    "PrivateAddress",
+			url:     func(fixtureURL string) string { return fixtureURL },
+			wantErr: ErrForbiddenAddress,
+		},
+		{
+			name:    "PlainHTTP",
+			url:     func(fixtureURL string) string { return strings.Replace(fixtureURL, "https:", "http:", 1) },
+			wantErr: ErrInsecureURL,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store, r := newFixture(t)
+			store.endpoints[0].Url = tc.url(store.endpoints[0].Url)
+
+			report, err := RunDue(context.Background(), store, time.Now(), Options{})
+			require.NoError(t, err)
+			require.Equal(t, Report{Dispatched: 1, Retrying: 1}, report)
+			require.Equal(t, tc.wantErr.Error(), store.deliveries[0].LastError.String)
+			require.Empty(t, r.received)
+		})
+	}
+}
+
+func TestNewClientDoesNotFollowRedirects(t *testing.T) {
+	require.ErrorIs(t, NewClient().CheckRedirect(nil, nil), http.ErrUseLastResponse)
+}
+
+// fakeResolver resolves host names from a fixed table.
+type fakeResolver map[string][]netip.Addr
+
+func (r fakeResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	if addrs, ok := r[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestCheckURL(t *testing.T) {
+	resolver := fakeResolver{
+		"hooks.example.com": {netip.MustParseAddr("93.184.216.34")},
+		"localhost":         {netip.MustParseAddr("127.0.0.1"), netip.MustParseAddr("::1")},
+		"internal.example":  {netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("10.0.0.7")},
+	}
+
+	testCases := []struct {
+		url     string
+		wantErr error
+	}{
+		{url: "https://hooks.example.com/fincore"},
+		{url: "https://93.184.216.34:8443/fincore"},
+		{url: "https://[2606:2800:220:1:248:1893:25c8:1946]/fincore"},
+		{url: "http://hooks.example.com/fincore", wantErr: ErrInsecureURL},
+		{url: "https://localhost:5433", wantErr: ErrForbiddenAddress},
+		{url: "https://internal.example/hooks", wantErr: ErrForbiddenAddress},
+		{url: "https://169.254.169.254/latest/meta-data", wantErr: ErrForbiddenAddress},
+		{url: "https://10.1.2.3/hooks", wantErr: ErrForbiddenAddress},
+		{url: "https://192.168.1.1/hooks", wantErr: ErrForbiddenAddress},
+		{url: "https://100.64.0.1/hooks", wantErr: ErrForbiddenAddress},
+		{url: "https://0.0.0.0/hooks", wantErr: ErrForbiddenAddress},
+		{url: "https://[::1]/hooks", wantErr: ErrForbiddenAddress},
+		{url: "https://[::ffff:127.0.0.1]/hooks", wantErr: ErrForbiddenAddress},
+		{url: "https://[fe80::1]/hooks", wantErr: ErrForbiddenAddress},
+		{url: "https://[fd00::1]/hooks", wantErr: ErrForbiddenAddress},
+		{url: "https://unknown.example/hooks", wantErr: ErrUnresolvableHost},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			err := CheckURL(context.Background(), tc.url, resolver)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunDueDeactivatedEndpoint(t *testing.T) {
+	store, r := newFixture(t)
+	store.endpoints[0].Active = false
+
+	report, err := RunDue(context.Background(), store, time.Now(), Options{Client: r.client})
+	require.NoError(t, err)
+	require.Equal(t, Report{Dispatched: 1, Dead: 1}, report)
+	require.Empty(t, r.received)
+}
+
+func TestBackoff(t *testing.T) {
+	opts := Options{BaseBackoff: 30 * time.Second, MaxBackoff: 10 * time.Minute}
+
+	testCases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 5, want: 8 * time.Minute},
+		{attempt: 6, want: 10 * time.Minute},
+		{attempt: 40, want: 10 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.attempt), func(t *testing.T) {
+			require.Equal(t, tc.want, backoff(tc.attempt, opts))
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	signedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	header := Sign("secret", signedAt, body)
+
+	require.NoError(t, Verify("secret", header, body, signedAt.Add(time.Minute), 5*time.Minute))
+	require.ErrorIs(t, Verify("other", header, body, signedAt, 5*time.Minute), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("secret", header, []byte(`{"id":2}`), signedAt, 5*time.Minute), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("secret", header, body, signedAt.Add(time.Hour), 5*time.Minute), ErrSignatureExpired)
+	require.ErrorIs(t, Verify("secret", "v1=00", body, signedAt, 5*time.Minute), ErrInvalidSignature)
+}